@@ -6,7 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -20,7 +19,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
-	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
@@ -34,6 +32,7 @@ type createClusterOptions struct {
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	installPackages       string
+	preflightOnly         bool
 }
 
 var cc = &createClusterOptions{}
@@ -63,6 +62,7 @@ func init() {
 	createClusterCmd.Flags().StringVar(&cc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	createClusterCmd.Flags().StringVar(&cc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	createClusterCmd.Flags().BoolVar(&cc.preflightOnly, "preflight-only", false, preflightOnlyFlagDescription)
 
 	if err := createClusterCmd.MarkFlagRequired("filename"); err != nil {
 		log.Fatalf("Error marking flag as required: %v", err)
@@ -202,6 +202,14 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	}
 	createValidations := createvalidations.New(validationOpts)
 
+	if cc.preflightOnly {
+		report, err := createCluster.RunPreflights(ctx, clusterSpec, createValidations)
+		if printErr := printPreflightReport(report); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+
 	err = createCluster.Run(ctx, clusterSpec, createValidations, cc.forceClean)
 
 	cleanup(deps, &err)
@@ -209,27 +217,16 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 }
 
 func (cc *createClusterOptions) directoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig) ([]string, error) {
-	dirs := cc.mountDirs()
+	dirs, err := cc.clusterOptions.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	fluxConfig := clusterSpec.FluxConfig
 	if fluxConfig != nil && fluxConfig.Spec.Git != nil {
-		dirs = append(dirs, filepath.Dir(cliConfig.GitPrivateKeyFile))
-		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
 		dirs = append(dirs, filepath.Dir(cc.installPackages))
 	}
 
-	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.CloudStackDatacenterKind {
-		env, found := os.LookupEnv(decoder.EksaCloudStackHostPathToMount)
-		if found && len(env) > 0 {
-			mountDirs := strings.Split(env, ",")
-			for _, dir := range mountDirs {
-				if _, err := os.Stat(dir); err != nil {
-					return nil, fmt.Errorf("invalid host path to mount: %v", err)
-				}
-				dirs = append(dirs, dir)
-			}
-		}
-	}
-
 	return dirs, nil
 }
 
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type generateAwsIamKubeconfigOptions struct {
+	clusterOptions
+}
+
+var gaiko = &generateAwsIamKubeconfigOptions{}
+
+var generateAwsIamKubeconfigCmd = &cobra.Command{
+	Use:          "aws-iam-kubeconfig",
+	Short:        "Generate the aws-iam-authenticator kubeconfig of a cluster",
+	Long:         "This command regenerates the kubeconfig used to authenticate to a cluster with aws-iam-authenticator",
+	PreRunE:      preRunGenerateAwsIamKubeconfigCmd,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gaiko.generateAwsIamKubeconfig(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to generate aws-iam-authenticator kubeconfig: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateAwsIamKubeconfigCmd)
+	generateAwsIamKubeconfigCmd.Flags().StringVarP(&gaiko.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	generateAwsIamKubeconfigCmd.Flags().StringVar(&gaiko.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	generateAwsIamKubeconfigCmd.Flags().StringVar(&gaiko.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	if err := generateAwsIamKubeconfigCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func preRunGenerateAwsIamKubeconfigCmd(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func (gaiko *generateAwsIamKubeconfigOptions) generateAwsIamKubeconfig(ctx context.Context) error {
+	if !validations.FileExists(gaiko.fileName) {
+		return fmt.Errorf("the cluster config file %s does not exist", gaiko.fileName)
+	}
+
+	clusterSpec, err := newClusterSpec(gaiko.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.AWSIamConfig == nil {
+		return fmt.Errorf("cluster %s doesn't reference an AWSIamConfig", clusterSpec.Cluster.Name)
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
+	}
+	if !validations.FileExists(workloadCluster.KubeconfigFile) {
+		return fmt.Errorf("kubeconfig file %s for cluster %s does not exist", workloadCluster.KubeconfigFile, workloadCluster.Name)
+	}
+
+	managementCluster := workloadCluster
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).
+		WithExecutableMountDirs(gaiko.mountDirs()...).
+		WithClusterManager(clusterSpec.Cluster).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	if err := deps.ClusterManager.RefreshAwsIamAuthKubeconfig(ctx, managementCluster, workloadCluster, clusterSpec); err != nil {
+		return err
+	}
+
+	logger.Info("Generated aws-iam-authenticator kubeconfig", "cluster", workloadCluster.Name)
+	return nil
+}
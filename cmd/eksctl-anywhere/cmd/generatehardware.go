@@ -2,17 +2,29 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
+// bmcPasswordEnvVar is the environment variable holding the BMC password used for discovery.
+const bmcPasswordEnvVar = "EKSA_BMC_PASSWORD"
+
 type hardwareOptions struct {
-	csvPath    string
-	outputPath string
+	csvPath         string
+	outputPath      string
+	discoverRedfish string
+	ipPlanPath      string
+	bmcUsername     string
+	bmcPasswordFile string
+	csvOutputPath   string
 }
 
 var hOpts = &hardwareOptions{}
@@ -22,6 +34,13 @@ var generateHardwareCmd = &cobra.Command{
 	Short: "Generate hardware files",
 	Long: `
 Generate Kubernetes hardware YAML manifests for each Hardware entry in the source.
+
+The source is either a hardware CSV file or the BMCs in an IP range discovered through their
+Redfish API. Discovered MAC addresses and disks are merged with an IP plan CSV that provides
+the hostname, network configuration and labels for each BMC IP.
+
+The BMC password used for discovery is read from --bmc-password-file or, if not set, from the
+EKSA_BMC_PASSWORD environment variable.
 `,
 	RunE: hOpts.generateHardware,
 }
@@ -39,20 +58,27 @@ func init() {
 		TinkerbellHardwareCSVFlagDescription,
 	)
 
-	if err := generateHardwareCmd.MarkFlagRequired(TinkerbellHardwareCSVFlagName); err != nil {
-		panic(err)
-	}
+	flags.StringVar(
+		&hOpts.discoverRedfish,
+		"discover-redfish",
+		"",
+		"Discover hardware through the Redfish API of the BMCs in an IP range (<start ip>-<end ip> or CIDR).",
+	)
+	flags.StringVar(&hOpts.ipPlanPath, "ip-plan", "", "Path to a CSV with the hostname and network configuration for each discovered BMC IP.")
+	flags.StringVar(&hOpts.bmcUsername, "bmc-username", "", "BMC username used for discovery when the IP plan doesn't specify one.")
+	flags.StringVar(
+		&hOpts.bmcPasswordFile,
+		"bmc-password-file",
+		"",
+		"Path to a file with the BMC password used for discovery when the IP plan doesn't specify one. Defaults to the EKSA_BMC_PASSWORD environment variable.",
+	)
+	flags.StringVar(&hOpts.csvOutputPath, "csv-output", "", "Path to output a hardware CSV usable with --hardware-csv.")
 }
 
 func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string) error {
-	csvFile, err := os.Open(hOpts.csvPath)
-	if err != nil {
-		return fmt.Errorf("csv: %v", err)
-	}
-
-	reader, err := hardware.NewCSVReader(csvFile)
+	reader, err := hOpts.machineReader(cmd)
 	if err != nil {
-		return fmt.Errorf("csv: %v", err)
+		return err
 	}
 
 	fh, err := hardware.CreateOrStdout(hOpts.outputPath)
@@ -61,9 +87,91 @@ func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string
 	}
 	bufferedWriter := bufio.NewWriter(fh)
 	defer bufferedWriter.Flush()
-	writer := hardware.NewTinkerbellManifestYAML(bufferedWriter)
+	var writer hardware.MachineWriter = hardware.NewTinkerbellManifestYAML(bufferedWriter)
+
+	if hOpts.csvOutputPath != "" {
+		csvFile, err := os.Create(hOpts.csvOutputPath)
+		if err != nil {
+			return fmt.Errorf("csv output: %v", err)
+		}
+		defer csvFile.Close()
+		writer = hardware.MultiMachineWriter(writer, hardware.NewCSVWriter(csvFile))
+	}
 
 	validator := hardware.NewDefaultMachineValidator()
 
 	return hardware.TranslateAll(reader, writer, validator)
 }
+
+func (hOpts *hardwareOptions) machineReader(cmd *cobra.Command) (hardware.MachineReader, error) {
+	switch {
+	case hOpts.csvPath != "" && hOpts.discoverRedfish != "":
+		return nil, fmt.Errorf("only one of --%s and --discover-redfish can be specified", TinkerbellHardwareCSVFlagName)
+	case hOpts.csvPath != "":
+		csvFile, err := os.Open(hOpts.csvPath)
+		if err != nil {
+			return nil, fmt.Errorf("csv: %v", err)
+		}
+
+		reader, err := hardware.NewCSVReader(csvFile)
+		if err != nil {
+			return nil, fmt.Errorf("csv: %v", err)
+		}
+		return reader, nil
+	case hOpts.discoverRedfish != "":
+		return hOpts.redfishMachineReader(cmd)
+	default:
+		return nil, fmt.Errorf("one of --%s or --discover-redfish is required", TinkerbellHardwareCSVFlagName)
+	}
+}
+
+func (hOpts *hardwareOptions) redfishMachineReader(cmd *cobra.Command) (hardware.MachineReader, error) {
+	if hOpts.ipPlanPath == "" {
+		return nil, errors.New("--ip-plan is required with --discover-redfish")
+	}
+
+	var bmcRange *networkutils.IPRange
+	var err error
+	if strings.Contains(hOpts.discoverRedfish, "/") {
+		bmcRange, err = networkutils.ParseCIDRRange(hOpts.discoverRedfish)
+	} else {
+		bmcRange, err = networkutils.ParseIPRange(hOpts.discoverRedfish)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("discover-redfish: %v", err)
+	}
+
+	plan, err := hardware.ParseIPPlanFromFile(hOpts.ipPlanPath)
+	if err != nil {
+		return nil, fmt.Errorf("ip plan: %v", err)
+	}
+
+	password, err := hOpts.bmcPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := hardware.NewRedfishMachineReader(
+		cmd.Context(),
+		hardware.NewRedfishDiscoverer(),
+		bmcRange,
+		plan,
+		hOpts.bmcUsername,
+		password,
+	)
+
+	return hardware.NewNormalizer(reader), nil
+}
+
+// bmcPassword returns the BMC password from the password file, if provided, or from the environment.
+func (hOpts *hardwareOptions) bmcPassword() (string, error) {
+	if hOpts.bmcPasswordFile == "" {
+		return os.Getenv(bmcPasswordEnvVar), nil
+	}
+
+	content, err := ioutil.ReadFile(hOpts.bmcPasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("bmc password file: %v", err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
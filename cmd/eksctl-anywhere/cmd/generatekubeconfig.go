@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig/issuer"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	kubeconfigAuthCertificate = "certificate"
+	kubeconfigAuthOIDC        = "oidc"
+	kubeconfigAuthAWSIam      = "aws-iam"
+
+	defaultKubeconfigTTL = 8 * time.Hour
+)
+
+type generateKubeconfigOptions struct {
+	clusterName          string
+	user                 string
+	groups               []string
+	ttl                  time.Duration
+	auth                 string
+	managementKubeconfig string
+}
+
+var gko = &generateKubeconfigOptions{}
+
+var generateKubeconfigCmd = &cobra.Command{
+	Use:          "kubeconfig",
+	Short:        "Generate a kubeconfig for a cluster user",
+	Long:         "This command generates a kubeconfig with short-lived client certificate, OIDC or aws-iam-authenticator credentials for a cluster user",
+	PreRunE:      preRunGenerateKubeconfigCmd,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gko.validate(); err != nil {
+			return err
+		}
+		if err := gko.generateKubeconfig(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to generate kubeconfig: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateKubeconfigCmd)
+	generateKubeconfigCmd.Flags().StringVar(&gko.clusterName, "cluster", "", "Name of the cluster to generate the kubeconfig for")
+	generateKubeconfigCmd.Flags().StringVar(&gko.user, "user", "", "Kubernetes user name")
+	generateKubeconfigCmd.Flags().StringSliceVar(&gko.groups, "groups", nil, "Kubernetes groups of the user. Only used with certificate auth")
+	generateKubeconfigCmd.Flags().DurationVar(&gko.ttl, "ttl", defaultKubeconfigTTL, "Validity of the client certificate. Only used with certificate auth")
+	generateKubeconfigCmd.Flags().StringVar(&gko.auth, "auth", kubeconfigAuthCertificate, "Authentication method: certificate|oidc|aws-iam")
+	generateKubeconfigCmd.Flags().StringVar(&gko.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	for _, flag := range []string{"cluster", "user"} {
+		if err := generateKubeconfigCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func preRunGenerateKubeconfigCmd(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func (gko *generateKubeconfigOptions) validate() error {
+	switch gko.auth {
+	case kubeconfigAuthCertificate:
+		if err := issuer.ValidateCertificateRequest(gko.certificateRequest()); err != nil {
+			return err
+		}
+	case kubeconfigAuthOIDC, kubeconfigAuthAWSIam:
+		if len(gko.groups) > 0 {
+			return fmt.Errorf("groups can only be set with %s auth, %s groups are assigned by the identity provider", kubeconfigAuthCertificate, gko.auth)
+		}
+	default:
+		return fmt.Errorf("invalid auth %s, must be one of %s, %s or %s", gko.auth, kubeconfigAuthCertificate, kubeconfigAuthOIDC, kubeconfigAuthAWSIam)
+	}
+
+	managementKubeconfig := getKubeconfigPath(gko.clusterName, gko.managementKubeconfig)
+	if !validations.FileExists(managementKubeconfig) {
+		return kubeconfig.NewMissingFileError(managementKubeconfig)
+	}
+
+	// The aws-iam-authenticator cluster ID is read from the cluster itself.
+	if gko.auth == kubeconfigAuthAWSIam {
+		workloadKubeconfig := kubeconfig.FromClusterName(gko.clusterName)
+		if !validations.FileExists(workloadKubeconfig) {
+			return kubeconfig.NewMissingFileError(workloadKubeconfig)
+		}
+	}
+
+	return nil
+}
+
+func (gko *generateKubeconfigOptions) certificateRequest() issuer.CertificateRequest {
+	return issuer.CertificateRequest{
+		User:   gko.user,
+		Groups: gko.groups,
+		TTL:    gko.ttl,
+	}
+}
+
+func (gko *generateKubeconfigOptions) generateKubeconfig(ctx context.Context) error {
+	managementCluster := &types.Cluster{
+		Name:           gko.clusterName,
+		KubeconfigFile: getKubeconfigPath(gko.clusterName, gko.managementKubeconfig),
+	}
+	workloadCluster := &types.Cluster{
+		Name:           gko.clusterName,
+		KubeconfigFile: kubeconfig.FromClusterName(gko.clusterName),
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(managementCluster.KubeconfigFile), filepath.Dir(workloadCluster.KubeconfigFile)).
+		WithWriterFolder(gko.clusterName).
+		WithKubeconfigIssuer().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	var path string
+	switch gko.auth {
+	case kubeconfigAuthCertificate:
+		path, err = deps.KubeconfigIssuer.IssueCertificate(ctx, managementCluster, gko.clusterName, gko.certificateRequest())
+	case kubeconfigAuthOIDC:
+		path, err = deps.KubeconfigIssuer.IssueOIDC(ctx, managementCluster, gko.clusterName, gko.user)
+	case kubeconfigAuthAWSIam:
+		path, err = deps.KubeconfigIssuer.IssueAWSIam(ctx, managementCluster, workloadCluster, gko.user)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Generated kubeconfig", "cluster", gko.clusterName, "user", gko.user, "kubeconfig", path)
+	return nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+type generateSchemaOptions struct {
+	kind string
+}
+
+var gso = &generateSchemaOptions{}
+
+var generateSchemaCmd = &cobra.Command{
+	Use:          "schema",
+	Short:        "Generate the JSON Schema of the cluster config",
+	Long:         "This command outputs a JSON Schema for all the kinds accepted in a cluster config file, to validate it from editors and linters",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := gso.generateSchema(); err != nil {
+			return fmt.Errorf("failed to generate schema: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateSchemaCmd)
+	generateSchemaCmd.Flags().StringVar(&gso.kind, "kind", "", "Only output the schema for this kind")
+}
+
+func (gso *generateSchemaOptions) generateSchema() error {
+	manager, err := cluster.NewDefaultConfigManager()
+	if err != nil {
+		return err
+	}
+
+	objs := map[string]runtime.Object{}
+	for kind, obj := range manager.APIObjects() {
+		objs[kind] = obj
+	}
+
+	var s *schema.Schema
+	if gso.kind == "" {
+		s, err = schema.ForKinds(objs)
+	} else {
+		obj, ok := objs[gso.kind]
+		if !ok {
+			kinds := make([]string, 0, len(objs))
+			for kind := range objs {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			return fmt.Errorf("unknown kind %s, must be one of: %s", gso.kind, strings.Join(kinds, ", "))
+		}
+		s, err = schema.ForKind(gso.kind, obj)
+	}
+	if err != nil {
+		return fmt.Errorf("generating schema: %v", err)
+	}
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing schema to json: %v", err)
+	}
+
+	fmt.Println(string(content))
+	return nil
+}
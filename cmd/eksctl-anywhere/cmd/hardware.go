@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+var hardwareCmd = &cobra.Command{
+	Use:   "hardware",
+	Short: "Manage Tinkerbell hardware",
+	Long:  "Use eksctl anywhere hardware to manage the bare metal hardware registered in a management cluster",
+}
+
+func init() {
+	rootCmd.AddCommand(hardwareCmd)
+}
+
+// withHardwareInventory builds a hardware.Inventory for the cluster accessible with kubeconfig and
+// passes it to fn, closing any dependencies when fn returns.
+func withHardwareInventory(ctx context.Context, kubeconfig string, fn func(*hardware.Inventory) error) error {
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeconfig).
+		WithExecutableBuilder().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+	defer close(ctx, deps)
+
+	cluster := &types.Cluster{
+		Name:           "management",
+		KubeconfigFile: kubeconfig,
+	}
+
+	return fn(hardware.NewInventory(deps.Kubectl, cluster))
+}
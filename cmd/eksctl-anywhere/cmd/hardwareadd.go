@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type hardwareAddOptions struct {
+	csvPath    string
+	kubeconfig string
+}
+
+var hao = &hardwareAddOptions{}
+
+var hardwareAddCmd = &cobra.Command{
+	Use:          "add",
+	Short:        "Add hardware to a management cluster",
+	Long:         "This command validates the hardware in a CSV file against the hardware already registered in the management cluster and adds it to the cluster",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := hao.addHardware(cmd); err != nil {
+			return fmt.Errorf("failed to add hardware: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	hardwareCmd.AddCommand(hardwareAddCmd)
+	hardwareAddCmd.Flags().StringVarP(
+		&hao.csvPath,
+		TinkerbellHardwareCSVFlagName,
+		TinkerbellHardwareCSVFlagAlias,
+		"",
+		TinkerbellHardwareCSVFlagDescription,
+	)
+	hardwareAddCmd.Flags().StringVar(&hao.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	for _, flag := range []string{TinkerbellHardwareCSVFlagName, "kubeconfig"} {
+		if err := hardwareAddCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag as required: %v", err)
+		}
+	}
+}
+
+func (h *hardwareAddOptions) addHardware(cmd *cobra.Command) error {
+	if !validations.FileExistsAndIsNotEmpty(h.kubeconfig) {
+		return kubeconfig.NewMissingFileError(h.kubeconfig)
+	}
+
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(h.csvPath)
+	if err != nil {
+		return fmt.Errorf("reading csv: %v", err)
+	}
+
+	return withHardwareInventory(cmd.Context(), h.kubeconfig, func(inventory *hardware.Inventory) error {
+		added, err := inventory.Add(cmd.Context(), reader)
+		if err != nil {
+			return err
+		}
+		logger.Info("Hardware added", "count", added)
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type hardwareLabelOptions struct {
+	kubeconfig string
+}
+
+var hlo = &hardwareLabelOptions{}
+
+var hardwareLabelCmd = &cobra.Command{
+	Use:          "label HOSTNAME KEY=VALUE...",
+	Short:        "Label hardware in a management cluster",
+	Long:         "This command sets labels on hardware registered in the management cluster, overwriting existing values for the same keys. Labels are used by hardware selectors to assign hardware to node groups",
+	SilenceUsage: true,
+	Args:         cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := hlo.labelHardware(cmd, args[0], args[1:]); err != nil {
+			return fmt.Errorf("failed to label hardware: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	hardwareCmd.AddCommand(hardwareLabelCmd)
+	hardwareLabelCmd.Flags().StringVar(&hlo.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	if err := hardwareLabelCmd.MarkFlagRequired("kubeconfig"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (h *hardwareLabelOptions) labelHardware(cmd *cobra.Command, hostname string, args []string) error {
+	labels := make(map[string]string, len(args))
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid label %s, expected KEY=VALUE", arg)
+		}
+		labels[kv[0]] = kv[1]
+	}
+
+	if !validations.FileExistsAndIsNotEmpty(h.kubeconfig) {
+		return kubeconfig.NewMissingFileError(h.kubeconfig)
+	}
+
+	return withHardwareInventory(cmd.Context(), h.kubeconfig, func(inventory *hardware.Inventory) error {
+		if err := inventory.Label(cmd.Context(), hostname, labels); err != nil {
+			return err
+		}
+		logger.Info("Hardware labeled", "hostname", hostname)
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type hardwareListOptions struct {
+	kubeconfig string
+}
+
+var hlso = &hardwareListOptions{}
+
+var hardwareListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List hardware in a management cluster",
+	Long:         "This command lists the hardware registered in the management cluster and whether each machine is provisioned, free or in error",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := hlso.listHardware(cmd); err != nil {
+			return fmt.Errorf("failed to list hardware: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	hardwareCmd.AddCommand(hardwareListCmd)
+	hardwareListCmd.Flags().StringVar(&hlso.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	if err := hardwareListCmd.MarkFlagRequired("kubeconfig"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (h *hardwareListOptions) listHardware(cmd *cobra.Command) error {
+	if !validations.FileExistsAndIsNotEmpty(h.kubeconfig) {
+		return kubeconfig.NewMissingFileError(h.kubeconfig)
+	}
+
+	return withHardwareInventory(cmd.Context(), h.kubeconfig, func(inventory *hardware.Inventory) error {
+		entries, err := inventory.List(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		table, err := serializeHardwareInventory(entries)
+		if err != nil {
+			return err
+		}
+		fmt.Print(table)
+		return nil
+	})
+}
+
+func serializeHardwareInventory(entries []hardware.InventoryEntry) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tIP\tMAC\tBMC\tLABELS\tSTATUS")
+	for _, entry := range entries {
+		m := entry.Machine()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", m.Hostname, m.IPAddress, m.MACAddress, m.BMCIPAddress, m.Labels.String(), entry.Status)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
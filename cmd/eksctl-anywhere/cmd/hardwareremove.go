@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type hardwareRemoveOptions struct {
+	kubeconfig string
+}
+
+var hro = &hardwareRemoveOptions{}
+
+var hardwareRemoveCmd = &cobra.Command{
+	Use:          "remove HOSTNAME...",
+	Short:        "Remove hardware from a management cluster",
+	Long:         "This command removes hardware and its BMC configuration from the management cluster. Provisioned hardware can't be removed",
+	SilenceUsage: true,
+	Args:         cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := hro.removeHardware(cmd, args); err != nil {
+			return fmt.Errorf("failed to remove hardware: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	hardwareCmd.AddCommand(hardwareRemoveCmd)
+	hardwareRemoveCmd.Flags().StringVar(&hro.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	if err := hardwareRemoveCmd.MarkFlagRequired("kubeconfig"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (h *hardwareRemoveOptions) removeHardware(cmd *cobra.Command, hostnames []string) error {
+	if !validations.FileExistsAndIsNotEmpty(h.kubeconfig) {
+		return kubeconfig.NewMissingFileError(h.kubeconfig)
+	}
+
+	return withHardwareInventory(cmd.Context(), h.kubeconfig, func(inventory *hardware.Inventory) error {
+		for _, hostname := range hostnames {
+			if err := inventory.Remove(cmd.Context(), hostname); err != nil {
+				return err
+			}
+			logger.Info("Hardware removed", "hostname", hostname)
+		}
+		return nil
+	})
+}
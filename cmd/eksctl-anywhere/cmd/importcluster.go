@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type importClusterOptions struct {
+	managementKubeconfig string
+	namespace            string
+	dryRun               bool
+	output               string
+}
+
+var ic = &importClusterOptions{}
+
+var importClusterCmd = &cobra.Command{
+	Use:   "cluster <cluster-name>",
+	Short: "Import a CAPI cluster into EKS-A",
+	Long: "This command reads the CAPI objects of a cluster created with clusterctl and creates the equivalent EKS-A Cluster, " +
+		"datacenter and machine configs in the management cluster. The EKS-A objects are created paused, " +
+		"review the generated cluster config and the fields that couldn't be imported before resuming them with eksctl anywhere resume cluster",
+	PreRunE:      preRunImportCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return err
+		}
+		if err := ic.importCluster(cmd.Context(), clusterName); err != nil {
+			return fmt.Errorf("failed to import cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importClusterCmd)
+	importClusterCmd.Flags().StringVar(&ic.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to the management cluster running the CAPI cluster")
+	importClusterCmd.Flags().StringVarP(&ic.namespace, "namespace", "n", "default", "Namespace to create the EKS-A objects in")
+	importClusterCmd.Flags().BoolVar(&ic.dryRun, "dry-run", false, "Only write the imported cluster config and report the fields that can't be imported, without creating the EKS-A objects")
+	importClusterCmd.Flags().StringVarP(&ic.output, outputFlagName, "o", outputDefault, "Output format of the unsupported fields report: text|json")
+
+	if err := importClusterCmd.MarkFlagRequired("kubeconfig"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func preRunImportCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+
+	if ic.output != outputText && ic.output != outputJson {
+		return fmt.Errorf("invalid output format %s, must be one of: %s, %s", ic.output, outputText, outputJson)
+	}
+
+	return nil
+}
+
+func (ic *importClusterOptions) importCluster(ctx context.Context, clusterName string) error {
+	if !validations.FileExistsAndIsNotEmpty(ic.managementKubeconfig) {
+		return kubeconfig.NewMissingFileError(ic.managementKubeconfig)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(ic.managementKubeconfig)).
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{KubeconfigFile: ic.managementKubeconfig}
+	clusters, err := deps.Kubectl.GetEksaClusters(ctx, managementCluster)
+	if err != nil {
+		return err
+	}
+
+	var eksaManagementCluster *v1alpha1.Cluster
+	for i := range clusters {
+		if clusters[i].Name == clusterName {
+			return fmt.Errorf("cluster %s is already managed by EKS-A", clusterName)
+		}
+		if clusters[i].IsSelfManaged() {
+			eksaManagementCluster = &clusters[i]
+		}
+	}
+	if eksaManagementCluster == nil {
+		return fmt.Errorf("no self-managed EKS-A cluster found with kubeconfig %s, the EKS-A controller must run in the management cluster", ic.managementKubeconfig)
+	}
+	managementCluster.Name = eksaManagementCluster.Name
+
+	logger.Info("Reading CAPI objects", "cluster", clusterName, "namespace", constants.EksaSystemNamespace)
+	objs, err := clusterimport.NewReader(deps.Kubectl).Read(ctx, managementCluster, clusterName, constants.EksaSystemNamespace)
+	if err != nil {
+		return err
+	}
+
+	result, err := clusterimport.Import(objs, eksaManagementCluster, ic.namespace)
+	if err != nil {
+		return err
+	}
+
+	content, err := clustermarshaller.MarshalClusterConfig(result.Config)
+	if err != nil {
+		return err
+	}
+
+	writer, err := filewriter.NewWriter(clusterName)
+	if err != nil {
+		return err
+	}
+	configFile, err := writer.Write(fmt.Sprintf("%s-eks-a-cluster.yaml", clusterName), content, filewriter.PersistentFile)
+	if err != nil {
+		return fmt.Errorf("writing imported cluster config: %v", err)
+	}
+	logger.Info("Imported cluster config written", "file", configFile)
+
+	if err := ic.printReport(result.Unsupported); err != nil {
+		return err
+	}
+
+	if ic.dryRun {
+		logger.Info("Dry run, the EKS-A objects were not created")
+		return nil
+	}
+
+	if err := deps.Kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, content); err != nil {
+		return fmt.Errorf("creating EKS-A objects: %v", err)
+	}
+
+	logger.MarkSuccess("EKS-A objects created with reconciliation paused", "cluster", clusterName)
+	logger.Info(fmt.Sprintf("Review the imported cluster config and run eksctl anywhere resume cluster %s --kubeconfig %s to let EKS-A manage the cluster", clusterName, ic.managementKubeconfig))
+	return nil
+}
+
+func (ic *importClusterOptions) printReport(unsupported []clusterimport.UnsupportedField) error {
+	if ic.output == outputJson {
+		if unsupported == nil {
+			unsupported = []clusterimport.UnsupportedField{}
+		}
+		content, err := json.Marshal(unsupported)
+		if err != nil {
+			return fmt.Errorf("failed serializing the unsupported fields report to json: %v", err)
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+
+	if len(unsupported) == 0 {
+		fmt.Println("All the fields of the CAPI objects were imported")
+		return nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tFIELD\tREASON")
+	for _, f := range unsupported {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Kind, f.Name, f.Field, f.Reason)
+	}
+	fmt.Fprintf(w, "\n%d fields can't be imported, EKS-A will overwrite or drop them once the cluster is resumed\n", len(unsupported))
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	fmt.Print(buffer.String())
+	return nil
+}
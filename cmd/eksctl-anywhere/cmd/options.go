@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -23,6 +28,38 @@ func (c clusterOptions) mountDirs() []string {
 	return dirs
 }
 
+// directoriesToMount returns the directories the executables need access to for the cluster: the management
+// cluster kubeconfig, the Git credentials, the CloudStack host paths and any non empty extra path in kubeconfigs.
+func (c clusterOptions) directoriesToMount(clusterSpec *cluster.Spec, cliConfig *config.CliConfig, kubeconfigs ...string) ([]string, error) {
+	dirs := c.mountDirs()
+	for _, k := range kubeconfigs {
+		if k != "" {
+			dirs = append(dirs, filepath.Dir(k))
+		}
+	}
+
+	fluxConfig := clusterSpec.FluxConfig
+	if fluxConfig != nil && fluxConfig.Spec.Git != nil {
+		dirs = append(dirs, filepath.Dir(cliConfig.GitPrivateKeyFile))
+		dirs = append(dirs, filepath.Dir(cliConfig.GitKnownHostsFile))
+	}
+
+	if clusterSpec.Config.Cluster.Spec.DatacenterRef.Kind == v1alpha1.CloudStackDatacenterKind {
+		env, found := os.LookupEnv(decoder.EksaCloudStackHostPathToMount)
+		if found && len(env) > 0 {
+			mountDirs := strings.Split(env, ",")
+			for _, dir := range mountDirs {
+				if _, err := os.Stat(dir); err != nil {
+					return nil, fmt.Errorf("invalid host path to mount: %v", err)
+				}
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	return dirs, nil
+}
+
 func newClusterSpec(options clusterOptions) (*cluster.Spec, error) {
 	var specOpts []cluster.SpecOpt
 	if options.bundlesOverride != "" {
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+type pauseClusterOptions struct {
+	clusterOptions
+	wConfig string
+}
+
+var pc = &pauseClusterOptions{}
+
+var pauseClusterCmd = &cobra.Command{
+	Use:          "cluster (<cluster-name>|-f <config-file>)",
+	Short:        "Pause the reconciliation of a cluster",
+	Long:         "This command pauses the reconciliation of a cluster by the Flux, EKS-A and CAPI controllers, until it's resumed with eksctl anywhere resume cluster",
+	PreRunE:      preRunPauseCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := pc.validate(cmd.Context(), args); err != nil {
+			return err
+		}
+		if err := pc.pauseCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to pause cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	pauseCmd.AddCommand(pauseClusterCmd)
+	pc.addFlags(pauseClusterCmd)
+}
+
+func preRunPauseCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func (pc *pauseClusterOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&pc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration, required if <cluster-name> is not provided")
+	cmd.Flags().StringVarP(&pc.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster, if it's not a workload cluster managed by --kubeconfig")
+	cmd.Flags().StringVar(&pc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
+	cmd.Flags().StringVar(&pc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+}
+
+func (pc *pauseClusterOptions) validate(ctx context.Context, args []string) error {
+	if pc.fileName == "" {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return fmt.Errorf("please provide either a valid <cluster-name> or -f <config-file>")
+		}
+		filename := fmt.Sprintf("%[1]s/%[1]s-eks-a-cluster.yaml", clusterName)
+		if !validations.FileExists(filename) {
+			return fmt.Errorf("clusterconfig file %s for cluster: %s not found, please provide the clusterconfig path manually using -f <config-file>", filename, clusterName)
+		}
+		pc.fileName = filename
+	}
+	clusterConfig, err := commonValidation(ctx, pc.fileName)
+	if err != nil {
+		return err
+	}
+
+	if pc.managementKubeconfig == "" {
+		kubeconfigPath := getKubeconfigPath(clusterConfig.Name, pc.wConfig)
+		if !validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+			return kubeconfig.NewMissingFileError(kubeconfigPath)
+		}
+	}
+
+	return nil
+}
+
+func (pc *pauseClusterOptions) pauseCluster(ctx context.Context) error {
+	return pc.run(ctx, func(ctx context.Context, pauseCluster *workflows.PauseCluster, managementCluster *types.Cluster, currentSpec *cluster.Spec) error {
+		if currentSpec.Cluster.IsReconcilePaused() {
+			logger.Info("Cluster reconciliation is already paused, making sure all the controllers are paused", "cluster", currentSpec.Cluster.Name)
+		}
+		return pauseCluster.Pause(ctx, managementCluster, currentSpec)
+	})
+}
+
+func (pc *pauseClusterOptions) resumeCluster(ctx context.Context) error {
+	return pc.run(ctx, func(ctx context.Context, pauseCluster *workflows.PauseCluster, managementCluster *types.Cluster, currentSpec *cluster.Spec) error {
+		if !currentSpec.Cluster.IsReconcilePaused() {
+			logger.Info("Cluster reconciliation is not paused, making sure all the controllers are resumed", "cluster", currentSpec.Cluster.Name)
+		}
+		return pauseCluster.Resume(ctx, managementCluster, currentSpec)
+	})
+}
+
+type pauseClusterAction func(ctx context.Context, pauseCluster *workflows.PauseCluster, managementCluster *types.Cluster, currentSpec *cluster.Spec) error
+
+// run builds the dependencies and runs the action with the cluster spec currently in the management cluster,
+// so the objects paused or resumed are the ones the controllers reconcile even if the config file is out of date.
+func (pc *pauseClusterOptions) run(ctx context.Context, action pauseClusterAction) error {
+	clusterSpec, err := newClusterSpec(pc.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(pc.fileName, clusterSpec.Cluster, cc.skipIpCheck, "", false, "").
+		WithFluxAddonClient(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	var managementCluster *types.Cluster
+	if clusterSpec.ManagementCluster == nil {
+		managementCluster = &types.Cluster{
+			Name:           clusterSpec.Cluster.Name,
+			KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, pc.wConfig),
+		}
+	} else {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	pauseCluster := workflows.NewPauseCluster(deps.Provider, deps.ClusterManager, deps.FluxAddonClient)
+	return action(ctx, pauseCluster, managementCluster, currentSpec)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var rc = &pauseClusterOptions{}
+
+var resumeClusterCmd = &cobra.Command{
+	Use:          "cluster (<cluster-name>|-f <config-file>)",
+	Short:        "Resume the reconciliation of a cluster",
+	Long:         "This command resumes the reconciliation of a cluster paused with eksctl anywhere pause cluster",
+	PreRunE:      preRunResumeCluster,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := rc.validate(cmd.Context(), args); err != nil {
+			return err
+		}
+		if err := rc.resumeCluster(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to resume cluster: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	resumeCmd.AddCommand(resumeClusterCmd)
+	rc.addFlags(resumeClusterCmd)
+}
+
+func preRunResumeCluster(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var scaleCmd = &cobra.Command{
+	Use:   "scale",
+	Short: "Scale resources",
+	Long:  "Use eksctl anywhere scale to scale resources, such as node groups",
+}
+
+func init() {
+	rootCmd.AddCommand(scaleCmd)
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+type scaleNodeGroupOptions struct {
+	clusterOptions
+	wConfig  string
+	replicas int
+}
+
+var sng = &scaleNodeGroupOptions{}
+
+var scaleNodeGroupCmd = &cobra.Command{
+	Use:          "nodegroup CLUSTER_NAME NODE_GROUP_NAME",
+	Short:        "Scale a worker node group",
+	Long:         "This command is used to change the number of nodes of a worker node group without editing the cluster config",
+	PreRunE:      preRunScaleNodeGroup,
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := sng.scaleNodeGroup(cmd, args[0], args[1]); err != nil {
+			return fmt.Errorf("failed to scale node group: %v", err)
+		}
+		return nil
+	},
+}
+
+func preRunScaleNodeGroup(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+	return nil
+}
+
+func init() {
+	scaleCmd.AddCommand(scaleNodeGroupCmd)
+	scaleNodeGroupCmd.Flags().IntVar(&sng.replicas, "replicas", 0, "Number of nodes for the worker node group")
+	scaleNodeGroupCmd.Flags().StringVarP(&sng.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration, defaults to the config file written by the last create or upgrade")
+	scaleNodeGroupCmd.Flags().StringVarP(&sng.wConfig, "w-config", "w", "", "Kubeconfig file to use when scaling a workload cluster")
+	scaleNodeGroupCmd.Flags().StringVar(&sng.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	scaleNodeGroupCmd.Flags().StringVar(&sng.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+
+	if err := scaleNodeGroupCmd.MarkFlagRequired("replicas"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (s *scaleNodeGroupOptions) scaleNodeGroup(cmd *cobra.Command, clusterName, nodeGroupName string) error {
+	ctx := cmd.Context()
+
+	if s.replicas < 1 {
+		return fmt.Errorf("replicas must be greater than 0, got %d", s.replicas)
+	}
+
+	defaultFileName := fmt.Sprintf("%[1]s/%[1]s-eks-a-cluster.yaml", clusterName)
+	if s.fileName == "" {
+		s.fileName = defaultFileName
+	}
+
+	if !validations.FileExists(s.fileName) {
+		return fmt.Errorf("the cluster config file %s does not exist", s.fileName)
+	}
+
+	kubeconfigPath := getKubeconfigPath(clusterName, s.wConfig)
+	if !validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+		return kubeconfig.NewMissingFileError(kubeconfigPath)
+	}
+
+	clusterSpec, err := newClusterSpec(s.clusterOptions)
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Name != clusterName {
+		return fmt.Errorf("cluster config file %s is for cluster %s, not %s", s.fileName, clusterSpec.Cluster.Name, clusterName)
+	}
+
+	nodeGroup := workerNodeGroup(clusterSpec, nodeGroupName)
+	if nodeGroup == nil {
+		return fmt.Errorf("worker node group %s not found in cluster %s", nodeGroupName, clusterName)
+	}
+
+	if nodeGroup.Count == s.replicas {
+		logger.Info("Worker node group already has the requested replicas", "nodegroup", nodeGroupName, "replicas", s.replicas)
+		return nil
+	}
+	nodeGroup.Count = s.replicas
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig)
+	if err != nil {
+		return err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithBootstrapper().
+		WithCliConfig(cliConfig).
+		WithClusterManager(clusterSpec.Cluster).
+		WithProvider(s.fileName, clusterSpec.Cluster, cc.skipIpCheck, "", false, "").
+		WithFluxAddonClient(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithWriter().
+		WithCAPIManager().
+		WithEksdUpgrader().
+		WithEksdInstaller().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	if deps.Provider.Name() == "tinkerbell" {
+		return fmt.Errorf("scale operation is not supported for provider tinkerbell")
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: kubeconfigPath,
+	}
+
+	var managementCluster *types.Cluster
+	if clusterSpec.ManagementCluster == nil {
+		managementCluster = workloadCluster
+	} else {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	currentSpec, err := deps.ClusterManager.GetCurrentClusterSpec(ctx, managementCluster, clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	scaleOnly, err := deps.ClusterManager.OnlyWorkerNodeGroupCountsChanged(ctx, managementCluster, currentSpec, clusterSpec, deps.Provider)
+	if err != nil {
+		return err
+	}
+	if !scaleOnly {
+		return fmt.Errorf("cluster config file %s has changes other than the worker node group count, use upgrade cluster instead", s.fileName)
+	}
+
+	scaleCluster := workflows.NewUpgrade(
+		deps.Bootstrapper,
+		deps.Provider,
+		deps.CAPIManager,
+		deps.ClusterManager,
+		deps.FluxAddonClient,
+		deps.Writer,
+		deps.EksdUpgrader,
+		deps.EksdInstaller,
+	)
+
+	validationOpts := &validations.Opts{
+		Kubectl:           deps.Kubectl,
+		Spec:              clusterSpec,
+		WorkloadCluster:   workloadCluster,
+		ManagementCluster: managementCluster,
+		Provider:          deps.Provider,
+	}
+	upgradeValidations := upgradevalidations.New(validationOpts)
+
+	err = scaleCluster.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, false)
+	if err == nil && filepath.Clean(s.fileName) != filepath.Clean(defaultFileName) {
+		// The workflow only writes the default cluster config file, so the one passed with -f is updated here.
+		err = s.updateClusterConfigFile(clusterSpec, deps.Provider)
+	}
+	cleanup(deps, &err)
+	return err
+}
+
+func (s *scaleNodeGroupOptions) updateClusterConfigFile(clusterSpec *cluster.Spec, provider providers.Provider) error {
+	content, err := clustermarshaller.MarshalClusterSpec(clusterSpec, provider.DatacenterConfig(clusterSpec), provider.MachineConfigs(clusterSpec))
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(s.fileName)
+	if err != nil {
+		return fmt.Errorf("updating cluster config file %s: %v", s.fileName, err)
+	}
+	if err := ioutil.WriteFile(s.fileName, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("updating cluster config file %s: %v", s.fileName, err)
+	}
+	logger.V(3).Info("Cluster config file updated", "file", s.fileName)
+	return nil
+}
+
+func workerNodeGroup(clusterSpec *cluster.Spec, name string) *v1alpha1.WorkerNodeGroupConfiguration {
+	for i := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i].Name == name {
+			return &clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[i]
+		}
+	}
+	return nil
+}
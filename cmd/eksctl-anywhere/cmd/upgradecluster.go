@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -12,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
@@ -24,6 +28,9 @@ type upgradeClusterOptions struct {
 	forceClean            bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
+	preflightOnly         bool
+	dryRun                bool
+	upgradeScope          task.UpgradeScope
 }
 
 var uc = &upgradeClusterOptions{}
@@ -49,6 +56,9 @@ func preRunUpgradeCluster(cmd *cobra.Command, args []string) error {
 			log.Fatalf("Error initializing flags: %v", err)
 		}
 	})
+	if uc.preflightOnly && uc.dryRun {
+		return fmt.Errorf("--preflight-only and --dry-run can't be used together")
+	}
 	return nil
 }
 
@@ -59,6 +69,8 @@ func init() {
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	upgradeClusterCmd.Flags().StringVar(&uc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
 	upgradeClusterCmd.Flags().StringVar(&uc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	upgradeClusterCmd.Flags().BoolVar(&uc.preflightOnly, "preflight-only", false, preflightOnlyFlagDescription)
+	upgradeClusterCmd.Flags().BoolVar(&uc.dryRun, "dry-run", false, "Show the changes the upgrade would make to the cluster objects and GitOps repository without applying them")
 	upgradeClusterCmd.Flags().StringVarP(
 		&cc.hardwareCSVPath,
 		TinkerbellHardwareCSVFlagName,
@@ -99,6 +111,11 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		}
 	}
 
+	return uc.upgrade(ctx)
+}
+
+// upgrade upgrades the cluster in the config file once the command flags have been validated.
+func (uc *upgradeClusterOptions) upgrade(ctx context.Context) error {
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
@@ -143,7 +160,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 		deps.Writer,
 		deps.EksdUpgrader,
 		deps.EksdInstaller,
-	)
+	).WithScope(uc.upgradeScope)
 
 	workloadCluster := &types.Cluster{
 		Name:           clusterSpec.Cluster.Name,
@@ -166,6 +183,27 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command) error {
 	}
 	upgradeValidations := upgradevalidations.New(validationOpts)
 
+	if uc.preflightOnly {
+		report, err := upgradeCluster.RunPreflights(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations)
+		if printErr := printPreflightReport(report); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+
+	if uc.dryRun {
+		diff, err := upgradeCluster.DryRun(ctx, clusterSpec, managementCluster, workloadCluster)
+		if err != nil {
+			return err
+		}
+		serializedDiff, err := serializeUpgradeDiff(diff)
+		if err != nil {
+			return err
+		}
+		fmt.Print(serializedDiff)
+		return nil
+	}
+
 	err = upgradeCluster.Run(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations, uc.forceClean)
 	cleanup(deps, &err)
 	return err
@@ -184,3 +222,50 @@ func (uc *upgradeClusterOptions) commonValidations(ctx context.Context) (cluster
 
 	return clusterConfig, nil
 }
+
+func serializeUpgradeDiff(diff *types.UpgradeDiff) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+
+	fmt.Fprintln(w, "CAPI OBJECTS")
+	writeObjectDiffs(w, diff.CAPIObjects, true)
+
+	fmt.Fprintln(w, "\nEKS-A OBJECTS")
+	writeObjectDiffs(w, diff.EKSAObjects, false)
+
+	if len(diff.GitOpsFiles) > 0 {
+		fmt.Fprintln(w, "\nGITOPS FILES")
+		for _, f := range diff.GitOpsFiles {
+			fmt.Fprintln(w, f)
+		}
+	}
+
+	if diff.Rollout() {
+		fmt.Fprintln(w, "\nThe upgrade would trigger a rollout of the cluster machines")
+	} else {
+		fmt.Fprintln(w, "\nThe upgrade would not trigger a rollout of the cluster machines")
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func writeObjectDiffs(w *tabwriter.Writer, diffs []types.ObjectDiff, withRollout bool) {
+	if withRollout {
+		fmt.Fprintln(w, "KIND\tNAME\tCHANGE\tROLLOUT\tCHANGED FIELDS")
+	} else {
+		fmt.Fprintln(w, "KIND\tNAME\tCHANGE\tCHANGED FIELDS")
+	}
+
+	for _, d := range diffs {
+		fields := strings.Join(d.ChangedFields, ",")
+		if withRollout {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\n", d.Kind, d.Name, d.Change, d.Rollout, fields)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Kind, d.Name, d.Change, fields)
+		}
+	}
+}
@@ -104,7 +104,9 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 	componentChangeDiffs := eksaupgrader.EksaChangeDiff(currentSpec, newClusterSpec)
 	componentChangeDiffs.Append(fluxupgrader.FluxChangeDiff(currentSpec, newClusterSpec))
 	componentChangeDiffs.Append(capiupgrader.CapiChangeDiff(currentSpec, newClusterSpec, deps.Provider))
-	componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
+	if !newClusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.IsUserManaged() {
+		componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
+	}
 
 	serializedDiff, err := serialize(componentChangeDiffs, output)
 	if err != nil {
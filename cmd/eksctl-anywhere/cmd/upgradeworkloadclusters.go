@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/task"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+type upgradeWorkloadClustersOptions struct {
+	managementKubeconfig string
+	bundlesOverride      string
+	concurrency          int
+	maxFailures          int
+	output               string
+}
+
+var uwc = &upgradeWorkloadClustersOptions{}
+
+var upgradeWorkloadClustersCmd = &cobra.Command{
+	Use:   "workload-clusters",
+	Short: "Upgrade all the workload clusters of a management cluster",
+	Long: "This command upgrades all the workload clusters managed by a management cluster, in waves of --concurrency clusters. " +
+		"The health of the upgraded clusters and of the management cluster is checked after each wave, " +
+		"and the upgrade stops once more than --max-failures clusters have failed",
+	PreRunE:      preRunUpgradeWorkloadClusters,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := uwc.upgradeWorkloadClusters(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to upgrade workload clusters: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	upgradeCmd.AddCommand(upgradeWorkloadClustersCmd)
+	upgradeWorkloadClustersCmd.Flags().StringVar(&uwc.managementKubeconfig, "management-kubeconfig", "", "Management cluster kubeconfig file")
+	upgradeWorkloadClustersCmd.Flags().StringVar(&uwc.bundlesOverride, "bundle", "", "Bundles manifest to upgrade the clusters to. Defaults to the Bundles of this eksctl anywhere version")
+	upgradeWorkloadClustersCmd.Flags().IntVar(&uwc.concurrency, "concurrency", 1, "Number of clusters upgraded at the same time in each wave")
+	upgradeWorkloadClustersCmd.Flags().IntVar(&uwc.maxFailures, "max-failures", 0, "Number of failed cluster upgrades tolerated before stopping")
+	upgradeWorkloadClustersCmd.Flags().StringVarP(&uwc.output, outputFlagName, "o", outputDefault, "Output format of the summary report: text|json")
+
+	if err := upgradeWorkloadClustersCmd.MarkFlagRequired("management-kubeconfig"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func preRunUpgradeWorkloadClusters(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+
+	if uwc.concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	if uwc.maxFailures < 0 {
+		return fmt.Errorf("max-failures can't be negative")
+	}
+
+	if uwc.output != outputText && uwc.output != outputJson {
+		return fmt.Errorf("invalid output format %s, must be one of: %s, %s", uwc.output, outputText, outputJson)
+	}
+
+	return nil
+}
+
+func (uwc *upgradeWorkloadClustersOptions) upgradeWorkloadClusters(ctx context.Context) error {
+	if !validations.FileExistsAndIsNotEmpty(uwc.managementKubeconfig) {
+		return kubeconfig.NewMissingFileError(uwc.managementKubeconfig)
+	}
+
+	if uwc.bundlesOverride != "" && !validations.FileExists(uwc.bundlesOverride) {
+		return fmt.Errorf("the bundles file %s does not exist", uwc.bundlesOverride)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(filepath.Dir(uwc.managementKubeconfig)).
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementCluster := &types.Cluster{KubeconfigFile: uwc.managementKubeconfig}
+	clusters, err := deps.Kubectl.GetEksaClusters(ctx, managementCluster)
+	if err != nil {
+		return err
+	}
+
+	var workloadClusters []v1alpha1.Cluster
+	for _, c := range clusters {
+		if c.IsSelfManaged() {
+			managementCluster.Name = c.Name
+			continue
+		}
+		workloadClusters = append(workloadClusters, c)
+	}
+	if managementCluster.Name == "" {
+		return fmt.Errorf("no self-managed EKS-A cluster found with kubeconfig %s", uwc.managementKubeconfig)
+	}
+
+	sort.Slice(workloadClusters, func(i, j int) bool {
+		if workloadClusters[i].Namespace != workloadClusters[j].Namespace {
+			return workloadClusters[i].Namespace < workloadClusters[j].Namespace
+		}
+		return workloadClusters[i].Name < workloadClusters[j].Name
+	})
+	logger.Info("Found workload clusters", "managementCluster", managementCluster.Name, "count", len(workloadClusters))
+
+	upgrader := &workloadClusterUpgrader{
+		bundlesOverride: uwc.bundlesOverride,
+		kubectl:         deps.Kubectl,
+		client:          deps.UnAuthKubeClient.KubeconfigClient(managementCluster.KubeconfigFile),
+	}
+	healthChecker := &clusterHealthChecker{kubectl: deps.Kubectl}
+
+	upgradeClusters := workflows.NewUpgradeWorkloadClusters(upgrader, healthChecker, uwc.concurrency, uwc.maxFailures)
+	report, err := upgradeClusters.Run(ctx, managementCluster, workloadClusters)
+	if report == nil {
+		return err
+	}
+	if printErr := uwc.printReport(report); printErr != nil {
+		return printErr
+	}
+
+	return err
+}
+
+func (uwc *upgradeWorkloadClustersOptions) printReport(report *types.WorkloadClustersUpgradeReport) error {
+	if uwc.output == outputJson {
+		content, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed serializing the upgrade report to json: %v", err)
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tNAMESPACE\tWAVE\tSTATUS\tERROR")
+	for _, r := range report.Results {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", r.Name, r.Namespace, r.Wave, r.Status, r.Error)
+	}
+	fmt.Fprintf(w, "\n%d upgraded, %d failed, %d skipped\n",
+		report.Count(types.WorkloadClusterUpgraded),
+		report.Count(types.WorkloadClusterFailed),
+		report.Count(types.WorkloadClusterSkipped),
+	)
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	fmt.Print(buffer.String())
+	return nil
+}
+
+// workloadClusterUpgrader upgrades a workload cluster with the same workflow as upgrade cluster,
+// using the cluster config currently in the management cluster.
+type workloadClusterUpgrader struct {
+	bundlesOverride string
+	kubectl         *executables.Kubectl
+	client          kubernetes.Client
+}
+
+// UpgradeManagementComponents upgrades the components all the workload clusters share in the management
+// cluster, using the config of cluster c, without upgrading c.
+func (u *workloadClusterUpgrader) UpgradeManagementComponents(ctx context.Context, managementCluster *types.Cluster, c *v1alpha1.Cluster) error {
+	return u.upgrade(ctx, managementCluster, c, task.UpgradeManagementComponents)
+}
+
+// UpgradeWorkloadCluster upgrades cluster c, expecting the management components to be already upgraded,
+// so several clusters can be upgraded at the same time.
+func (u *workloadClusterUpgrader) UpgradeWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, c *v1alpha1.Cluster) error {
+	return u.upgrade(ctx, managementCluster, c, task.UpgradeClusterOnly)
+}
+
+func (u *workloadClusterUpgrader) upgrade(ctx context.Context, managementCluster *types.Cluster, c *v1alpha1.Cluster, scope task.UpgradeScope) error {
+	config, err := cluster.NewFullConfigClientBuilder().Build(ctx, u.client, c)
+	if err != nil {
+		return err
+	}
+
+	// The Bundles in the cluster are the ones being upgraded from. Without a reference,
+	// the cluster config defaults to the Bundles passed to the command.
+	config.Cluster.Spec.BundlesRef = nil
+
+	content, err := clustermarshaller.MarshalClusterConfig(config)
+	if err != nil {
+		return err
+	}
+
+	writer, err := filewriter.NewWriter(c.Name)
+	if err != nil {
+		return err
+	}
+
+	configFile, err := writer.Write(fmt.Sprintf("%s-upgrade-cluster-config.yaml", c.Name), content, filewriter.PersistentFile)
+	if err != nil {
+		return fmt.Errorf("writing cluster config: %v", err)
+	}
+
+	kubeconfigFile, err := u.workloadKubeconfig(ctx, managementCluster, c.Name, writer)
+	if err != nil {
+		return err
+	}
+
+	opts := &upgradeClusterOptions{
+		clusterOptions: clusterOptions{
+			fileName:             configFile,
+			bundlesOverride:      u.bundlesOverride,
+			managementKubeconfig: managementCluster.KubeconfigFile,
+		},
+		wConfig:      kubeconfigFile,
+		upgradeScope: scope,
+	}
+
+	return opts.upgrade(ctx)
+}
+
+// workloadKubeconfig returns the path to the kubeconfig of the workload cluster,
+// writing it from the management cluster if it doesn't exist yet.
+func (u *workloadClusterUpgrader) workloadKubeconfig(ctx context.Context, managementCluster *types.Cluster, clusterName string, writer filewriter.FileWriter) (string, error) {
+	kubeconfigFile := kubeconfig.FromClusterName(clusterName)
+	if validations.FileExistsAndIsNotEmpty(kubeconfigFile) {
+		return kubeconfigFile, nil
+	}
+
+	secret, err := u.kubectl.GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, fmt.Sprintf("%s-kubeconfig", clusterName), constants.EksaSystemNamespace)
+	if err != nil {
+		return "", fmt.Errorf("getting workload cluster kubeconfig: %v", err)
+	}
+
+	return writer.Write(filepath.Base(kubeconfigFile), secret.Data["value"], filewriter.PersistentFile, filewriter.Permission0600)
+}
+
+// clusterHealthChecker checks the control plane and machine deployments of a cluster are ready.
+type clusterHealthChecker struct {
+	kubectl *executables.Kubectl
+}
+
+func (h *clusterHealthChecker) CheckClusterHealth(ctx context.Context, managementCluster *types.Cluster, clusterName string) error {
+	if err := h.kubectl.ValidateControlPlaneNodes(ctx, managementCluster, clusterName); err != nil {
+		return err
+	}
+
+	machineDeployments, err := h.kubectl.GetMachineDeployments(ctx, executables.WithCluster(managementCluster), executables.WithNamespace(constants.EksaSystemNamespace))
+	if err != nil {
+		return err
+	}
+
+	for _, md := range machineDeployments {
+		if md.Spec.ClusterName != clusterName {
+			continue
+		}
+		if md.Status.Phase != "Running" {
+			return fmt.Errorf("machine deployment %s is in %s phase", md.Name, md.Status.Phase)
+		}
+		if md.Status.ReadyReplicas != md.Status.Replicas {
+			return fmt.Errorf("%d machine deployment %s replicas are not ready", md.Status.Replicas-md.Status.ReadyReplicas, md.Name)
+		}
+	}
+
+	return nil
+}
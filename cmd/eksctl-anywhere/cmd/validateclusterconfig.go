@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/configvalidations"
+	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+)
+
+const (
+	validateOperationCreate  = "create"
+	validateOperationUpgrade = "upgrade"
+)
+
+type validateClusterConfigOptions struct {
+	clusterOptions
+	online                bool
+	operation             string
+	wConfig               string
+	hardwareCSVPath       string
+	tinkerbellBootstrapIP string
+	output                string
+}
+
+var vcc = &validateClusterConfigOptions{}
+
+var validateClusterConfigCmd = &cobra.Command{
+	Use:   "cluster-config -f <cluster-config-file> [flags]",
+	Short: "Validate a cluster config file",
+	Long: "This command validates a cluster config file without creating or changing anything. " +
+		"By default it only runs the validations that don't need access to the infrastructure. " +
+		"With --online it also runs the provider, GitOps and registry validations of the create or upgrade operation.",
+	PreRunE:      preRunValidateClusterConfig,
+	SilenceUsage: true,
+	RunE:         vcc.validateClusterConfig,
+}
+
+func init() {
+	validateCmd.AddCommand(validateClusterConfigCmd)
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	validateClusterConfigCmd.Flags().BoolVar(&vcc.online, "online", false, "Also run the validations that need access to the infrastructure, the GitOps repository and the registry")
+	validateClusterConfigCmd.Flags().StringVar(&vcc.operation, "operation", validateOperationCreate, "Operation to run the online validations for: create|upgrade")
+	validateClusterConfigCmd.Flags().StringVar(&vcc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	validateClusterConfigCmd.Flags().StringVar(&vcc.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.wConfig, "w-config", "w", "", "Kubeconfig file to use when validating the upgrade of a workload cluster")
+	validateClusterConfigCmd.Flags().StringVarP(
+		&vcc.hardwareCSVPath,
+		TinkerbellHardwareCSVFlagName,
+		TinkerbellHardwareCSVFlagAlias,
+		"",
+		TinkerbellHardwareCSVFlagDescription,
+	)
+	validateClusterConfigCmd.Flags().StringVar(&vcc.tinkerbellBootstrapIP, "tinkerbell-bootstrap-ip", "", "Override the local tinkerbell IP in the bootstrap cluster")
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+
+	if err := validateClusterConfigCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func preRunValidateClusterConfig(cmd *cobra.Command, args []string) error {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		err := viper.BindPFlag(flag.Name, flag)
+		if err != nil {
+			log.Fatalf("Error initializing flags: %v", err)
+		}
+	})
+
+	if vcc.operation != validateOperationCreate && vcc.operation != validateOperationUpgrade {
+		return fmt.Errorf("invalid operation %s, must be one of: %s, %s", vcc.operation, validateOperationCreate, validateOperationUpgrade)
+	}
+
+	if vcc.output != outputText && vcc.output != outputJson {
+		return fmt.Errorf("invalid output format %s, must be one of: %s, %s", vcc.output, outputText, outputJson)
+	}
+
+	return nil
+}
+
+func (vcc *validateClusterConfigOptions) validateClusterConfig(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, vcc.fileName)
+	offlineErr := runner.Run()
+	report := runner.Report()
+
+	if offlineErr == nil && vcc.online {
+		onlineReport, err := vcc.runOnlineValidations(ctx)
+		if onlineReport == nil {
+			// The online validations couldn't even be set up, report it as a failed check
+			onlineReport = &validations.Report{
+				Results: []validations.ReportResult{{Name: "set up online validations", Error: err.Error()}},
+			}
+		}
+		report = mergeReports(report, onlineReport)
+	}
+
+	if err := vcc.printReport(report); err != nil {
+		return err
+	}
+
+	if !report.Passed {
+		return fmt.Errorf("cluster config %s is not valid", vcc.fileName)
+	}
+
+	return nil
+}
+
+func (vcc *validateClusterConfigOptions) runOnlineValidations(ctx context.Context) (*validations.Report, error) {
+	clusterSpec, err := newClusterSpec(vcc.clusterOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	dirs, err := vcc.directoriesToMount(clusterSpec, cliConfig, vcc.wConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	deps, err := dependencies.ForSpec(ctx, clusterSpec).WithExecutableMountDirs(dirs...).
+		WithCliConfig(cliConfig).
+		WithProvider(vcc.fileName, clusterSpec.Cluster, false, vcc.hardwareCSVPath, false, vcc.tinkerbellBootstrapIP).
+		WithFluxAddonClient(clusterSpec.Cluster, clusterSpec.FluxConfig, cliConfig).
+		WithWriter().
+		WithKubectl().
+		Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer close(ctx, deps)
+
+	if !features.IsActive(features.CloudStackProvider()) && deps.Provider.Name() == constants.CloudStackProviderName {
+		return nil, fmt.Errorf("provider cloudstack is not supported in this release")
+	}
+
+	if !features.IsActive(features.SnowProvider()) && deps.Provider.Name() == constants.SnowProviderName {
+		return nil, fmt.Errorf("provider snow is not supported in this release")
+	}
+
+	if vcc.operation == validateOperationUpgrade {
+		return vcc.runUpgradeValidations(ctx, clusterSpec, deps)
+	}
+
+	return vcc.runCreateValidations(ctx, clusterSpec, cliConfig, deps)
+}
+
+func (vcc *validateClusterConfigOptions) runCreateValidations(ctx context.Context, clusterSpec *cluster.Spec, cliConfig *config.CliConfig, deps *dependencies.Dependencies) (*validations.Report, error) {
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: kubeconfig.FromClusterName(clusterSpec.Cluster.Name),
+	}
+
+	managementCluster := workloadCluster
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = &types.Cluster{
+			Name:           clusterSpec.ManagementCluster.Name,
+			KubeconfigFile: clusterSpec.ManagementCluster.KubeconfigFile,
+		}
+	}
+
+	createValidations := createvalidations.New(&validations.Opts{
+		Kubectl:           deps.Kubectl,
+		Spec:              clusterSpec,
+		WorkloadCluster:   workloadCluster,
+		ManagementCluster: managementCluster,
+		Provider:          deps.Provider,
+		CliConfig:         cliConfig,
+	})
+
+	createCluster := workflows.NewCreate(nil, deps.Provider, nil, deps.FluxAddonClient, deps.Writer, nil, nil)
+	return createCluster.RunPreflights(ctx, clusterSpec, createValidations)
+}
+
+func (vcc *validateClusterConfigOptions) runUpgradeValidations(ctx context.Context, clusterSpec *cluster.Spec, deps *dependencies.Dependencies) (*validations.Report, error) {
+	if deps.Provider.Name() == constants.TinkerbellProviderName {
+		return nil, fmt.Errorf("upgrade operation is not supported for provider tinkerbell")
+	}
+
+	workloadCluster := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: getKubeconfigPath(clusterSpec.Cluster.Name, vcc.wConfig),
+	}
+	if !validations.FileExistsAndIsNotEmpty(workloadCluster.KubeconfigFile) {
+		return nil, kubeconfig.NewMissingFileError(workloadCluster.KubeconfigFile)
+	}
+
+	managementCluster := workloadCluster
+	if clusterSpec.ManagementCluster != nil {
+		managementCluster = clusterSpec.ManagementCluster
+	}
+
+	upgradeValidations := upgradevalidations.New(&validations.Opts{
+		Kubectl:           deps.Kubectl,
+		Spec:              clusterSpec,
+		WorkloadCluster:   workloadCluster,
+		ManagementCluster: managementCluster,
+		Provider:          deps.Provider,
+	})
+
+	upgradeCluster := workflows.NewUpgrade(nil, deps.Provider, nil, nil, deps.FluxAddonClient, deps.Writer, nil, nil)
+	return upgradeCluster.RunPreflights(ctx, clusterSpec, managementCluster, workloadCluster, upgradeValidations)
+}
+
+func (vcc *validateClusterConfigOptions) printReport(report *validations.Report) error {
+	if vcc.output == outputJson {
+		return printPreflightReport(report)
+	}
+
+	passed := 0
+	for _, r := range report.Results {
+		if r.Passed {
+			passed++
+		}
+	}
+
+	if report.Passed {
+		logger.MarkSuccess(fmt.Sprintf("Cluster config %s is valid, %d validations passed", vcc.fileName, passed))
+	} else {
+		logger.MarkFail(fmt.Sprintf("Cluster config %s is not valid, %d of %d validations failed", vcc.fileName, len(report.Results)-passed, len(report.Results)))
+	}
+
+	return nil
+}
+
+// mergeReports combines the results of several reports in order.
+func mergeReports(reports ...*validations.Report) *validations.Report {
+	merged := &validations.Report{Passed: true}
+	for _, r := range reports {
+		merged.Passed = merged.Passed && r.Passed
+		merged.Results = append(merged.Results, r.Results...)
+	}
+
+	return merged
+}
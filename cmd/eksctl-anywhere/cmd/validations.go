@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"runtime"
 
@@ -33,3 +34,16 @@ func commonValidation(ctx context.Context, clusterConfigFile string) (*v1alpha1.
 	}
 	return clusterConfig, nil
 }
+
+const preflightOnlyFlagDescription = "Only run the setup and preflight validations and print a JSON report, without changing anything"
+
+// printPreflightReport prints the JSON report of a preflight only run to stdout.
+func printPreflightReport(report *validations.Report) error {
+	jsonReport, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed serializing the preflight report to json: %v", err)
+	}
+
+	fmt.Println(string(jsonReport))
+	return nil
+}
@@ -0,0 +1,9 @@
+// Package crd gives access to the EKS-A CRDs generated by controller-gen.
+package crd
+
+import "embed"
+
+// Bases contains the CRD manifests in the bases folder, one file per kind.
+//
+//go:embed bases/*.yaml
+var Bases embed.FS
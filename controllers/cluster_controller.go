@@ -7,26 +7,36 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
 	"github.com/aws/eks-anywhere/pkg/controller/handlers"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
@@ -38,14 +48,27 @@ const (
 	clusterFinalizerName = "clusters.anywhere.eks.amazonaws.com/finalizer"
 )
 
+// Reasons of the events recorded for clusters.
+const (
+	reasonReconcileFailed = "ReconcileFailed"
+	reasonClusterReady    = "ClusterReady"
+	reasonClusterNotReady = "ClusterNotReady"
+	reasonDeleting        = "Deleting"
+
+	reasonAWSIamMappingsReconciled = "AWSIamAuthMappingsReconciled"
+)
+
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
 	client                  client.Client
 	log                     logr.Logger
+	recorder                record.EventRecorder
 	validator               *vsphere.Validator
 	defaulter               *vsphere.Defaulter
 	tracker                 *remote.ClusterCacheTracker
 	buildProviderReconciler ProviderReconcilerBuilder
+	// watcher is the controller built for the reconciler, used to watch objects in the workload clusters.
+	watcher remote.Watcher
 }
 
 type ProviderClusterReconciler interface {
@@ -54,15 +77,16 @@ type ProviderClusterReconciler interface {
 
 // TODO: this is not ideal and will need a refactor. I will follow up but for now this
 // allows us to decouple the cluster reconciler main logic from provider specific logic
-type ProviderReconcilerBuilder func(datacenterKind string, client client.Client, log logr.Logger, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (ProviderClusterReconciler, error)
+type ProviderReconcilerBuilder func(datacenterKind string, client client.Client, log logr.Logger, recorder record.EventRecorder, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (ProviderClusterReconciler, error)
 
-func NewClusterReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme, govc *executables.Govc, tracker *remote.ClusterCacheTracker, buildProviderReconciler ProviderReconcilerBuilder) *ClusterReconciler {
+func NewClusterReconciler(client client.Client, log logr.Logger, recorder record.EventRecorder, scheme *runtime.Scheme, govc *executables.Govc, tracker *remote.ClusterCacheTracker, buildProviderReconciler ProviderReconcilerBuilder) *ClusterReconciler {
 	validator := vsphere.NewValidator(govc, &networkutils.DefaultNetClient{})
 	defaulter := vsphere.NewDefaulter(govc)
 
 	return &ClusterReconciler{
 		client:                  client,
 		log:                     log,
+		recorder:                recorder,
 		validator:               validator,
 		defaulter:               defaulter,
 		tracker:                 tracker,
@@ -73,8 +97,10 @@ func NewClusterReconciler(client client.Client, log logr.Logger, scheme *runtime
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	childObjectHandler := handlers.ChildObjectToClusters(r.log)
+	capiObjectHandler := handlers.CAPIObjectToCluster(mgr.GetClient(), r.log)
+	isCNIDaemonSet := predicate.NewPredicateFuncs(clusters.IsCNIDaemonSet)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&anywherev1.Cluster{}).
 		Watches(
 			&source.Kind{Type: &anywherev1.OIDCConfig{}},
@@ -108,7 +134,45 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &anywherev1.SnowMachineConfig{}},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
 		).
-		Complete(r)
+		Watches(
+			&source.Kind{Type: &controlplanev1.KubeadmControlPlane{}},
+			handler.EnqueueRequestsFromMapFunc(capiObjectHandler),
+		).
+		Watches(
+			&source.Kind{Type: &clusterv1.MachineDeployment{}},
+			handler.EnqueueRequestsFromMapFunc(capiObjectHandler),
+		).
+		// The CNI of self-managed clusters runs in the management cluster, the CNI of the
+		// other clusters is watched through the tracker once their control plane is initialized.
+		Watches(
+			&source.Kind{Type: &appsv1.DaemonSet{}},
+			handler.EnqueueRequestsFromMapFunc(r.selfManagedClusters),
+			builder.WithPredicates(isCNIDaemonSet),
+		).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	r.watcher = c
+
+	return nil
+}
+
+// selfManagedClusters enqueues the self-managed clusters, the ones running in the management cluster.
+func (r *ClusterReconciler) selfManagedClusters(o client.Object) []reconcile.Request {
+	clusterList := &anywherev1.ClusterList{}
+	if err := r.client.List(context.Background(), clusterList); err != nil {
+		r.log.Error(err, "Listing clusters for CNI daemonset", "name", o.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, c := range clusterList.Items {
+		if c.IsSelfManaged() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: c.Namespace, Name: c.Name}})
+		}
+	}
+	return requests
 }
 
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters;vspheredatacenterconfigs;vspheremachineconfigs;dockerdatacenterconfigs;bundles;awsiamconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -116,9 +180,11 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/status;vspheredatacenterconfigs/status;vspheremachineconfigs/status;dockerdatacenterconfigs/status;bundles/status;awsiamconfigs/status,verbs=;get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters/finalizers;vspheredatacenterconfigs/finalizers;vspheremachineconfigs/finalizers;dockerdatacenterconfigs/finalizers;bundles/finalizers;awsiamconfigs/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=test,resources=test,verbs=get;list;watch;create;update;patch;delete;kill
 // +kubebuilder:rbac:groups=distro.eks.amazonaws.com,resources=releases,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch;update
 func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := r.log.WithValues("cluster", req.NamespacedName)
 	// Fetch the Cluster object
@@ -155,29 +221,120 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 	// If the cluster is paused, return without any further processing.
 	if cluster.IsReconcilePaused() {
 		log.Info("Cluster reconciliation is paused")
+		conditions.MarkTrue(cluster, anywherev1.ReconcilePausedCondition)
 		return ctrl.Result{}, nil
 	}
+	conditions.Delete(cluster, anywherev1.ReconcilePausedCondition)
+
+	if err := r.reconcileAWSIamAuthMappings(ctx, cluster, log); err != nil {
+		failureMessage := err.Error()
+		cluster.Status.FailureMessage = &failureMessage
+		log.Error(err, "Failed to reconcile aws-iam-authenticator mappings")
+		r.recorder.Event(cluster, corev1.EventTypeWarning, reasonReconcileFailed, failureMessage)
+		return ctrl.Result{}, err
+	}
 
 	if cluster.IsSelfManaged() {
 		log.Info("Ignoring self managed cluster")
-		return ctrl.Result{}, nil
+		// Self managed clusters are reconciled by the CLI, the controller only reports the status of their machines.
+		return r.updateStatus(ctx, cluster, ctrl.Result{})
 	}
 
 	if err = r.ensureClusterOwnerReferences(ctx, cluster); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	start := time.Now()
 	result, err := r.reconcile(ctx, cluster, log)
+	metrics.ObserveReconcile(cluster, start, result, err)
 	if err != nil {
 		failureMessage := err.Error()
 		cluster.Status.FailureMessage = &failureMessage
 		log.Error(err, "Failed to reconcile Cluster")
+		r.recorder.Event(cluster, corev1.EventTypeWarning, reasonReconcileFailed, failureMessage)
+		if _, statusErr := r.updateStatus(ctx, cluster, result); statusErr != nil {
+			log.Error(statusErr, "Failed to update Cluster status")
+		}
+		return result, err
+	}
+
+	cluster.Status.FailureMessage = nil
+	cluster.Status.ObservedGeneration = cluster.Generation
+	return r.updateStatus(ctx, cluster, result)
+}
+
+// updateStatus updates the cluster status from its CAPI objects and its CNI. Changes to them trigger
+// a new reconciliation through the watches, so the cluster isn't requeued until it's ready.
+func (r *ClusterReconciler) updateStatus(ctx context.Context, cluster *anywherev1.Cluster, result ctrl.Result) (ctrl.Result, error) {
+	defer metrics.ObservePhase(metrics.Provider(cluster), metrics.PhaseStatus, time.Now())
+
+	workloadClient, err := r.workloadClusterClient(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	wasReady := conditions.IsTrue(cluster, clusterv1.ReadyCondition)
+	if err := clusters.UpdateClusterStatus(ctx, r.client, workloadClient, cluster); err != nil {
+		return ctrl.Result{}, err
+	}
+	metrics.RecordClusterStatus(cluster)
+	r.recordReadyTransition(cluster, wasReady)
+
+	return result, nil
+}
+
+// workloadClusterClient returns a client for the cluster itself, or nil until its control plane is initialized.
+// The controller runs in the management cluster, so a self-managed cluster is reached with its own client.
+// For the other clusters, it also watches the CNI so the status is updated when it changes.
+func (r *ClusterReconciler) workloadClusterClient(ctx context.Context, clus *anywherev1.Cluster) (client.Client, error) {
+	capiCluster := &clusterv1.Cluster{}
+	capiClusterName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: clus.Name}
+	if err := r.client.Get(ctx, capiClusterName, capiCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !conditions.IsTrue(capiCluster, clusterv1.ControlPlaneInitializedCondition) {
+		return nil, nil
+	}
+
+	if clus.IsSelfManaged() {
+		return r.client, nil
+	}
+
+	clusterKey := types.NamespacedName{Namespace: clus.Namespace, Name: clus.Name}
+	if err := r.tracker.Watch(ctx, remote.WatchInput{
+		Name:    "cluster-cni-daemonset",
+		Cluster: capiClusterName,
+		Watcher: r.watcher,
+		Kind:    &appsv1.DaemonSet{},
+		EventHandler: handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: clusterKey}}
+		}),
+		Predicates: []predicate.Predicate{predicate.NewPredicateFuncs(clusters.IsCNIDaemonSet)},
+	}); err != nil {
+		return nil, fmt.Errorf("watching cluster CNI: %v", err)
+	}
+
+	return r.tracker.GetClient(ctx, capiClusterName)
+}
+
+// recordReadyTransition records an event when the cluster Ready condition changes.
+func (r *ClusterReconciler) recordReadyTransition(cluster *anywherev1.Cluster, wasReady bool) {
+	isReady := conditions.IsTrue(cluster, clusterv1.ReadyCondition)
+	switch {
+	case isReady && !wasReady:
+		r.recorder.Event(cluster, corev1.EventTypeNormal, reasonClusterReady, "Cluster is ready")
+	case !isReady && wasReady:
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, reasonClusterNotReady, "Cluster is not ready: %s", conditions.GetMessage(cluster, clusterv1.ReadyCondition))
 	}
-	return result, err
 }
 
 func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *anywherev1.Cluster, log logr.Logger) (ctrl.Result, error) {
-	clusterProviderReconciler, err := r.buildProviderReconciler(cluster.Spec.DatacenterRef.Kind, r.client, r.log, r.validator, r.defaulter, r.tracker)
+	defer metrics.ObservePhase(metrics.Provider(cluster), metrics.PhaseProviderReconcile, time.Now())
+
+	clusterProviderReconciler, err := r.buildProviderReconciler(cluster.Spec.DatacenterRef.Kind, r.client, r.log, r.recorder, r.validator, r.defaulter, r.tracker)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -189,6 +346,57 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *anywherev1.C
 	return reconcileResult.ToCtrlResult(), nil
 }
 
+// reconcileAWSIamAuthMappings keeps the aws-iam-authenticator mappings in the cluster in sync with the
+// AWSIamConfig it references, so roles and users can be mapped without an upgrade. It doesn't depend
+// on the provider, so it runs for every cluster, including self-managed ones.
+func (r *ClusterReconciler) reconcileAWSIamAuthMappings(ctx context.Context, clus *anywherev1.Cluster, log logr.Logger) error {
+	defer metrics.ObservePhase(metrics.Provider(clus), metrics.PhaseAWSIamAuthMappings, time.Now())
+
+	awsIamConfig, err := cluster.GetAWSIamConfigForCluster(ctx, clus, r.awsIamConfig)
+	if err != nil {
+		return err
+	}
+	if awsIamConfig == nil {
+		return nil
+	}
+
+	clusterClient, err := r.workloadClusterClient(ctx, clus)
+	if err != nil {
+		return err
+	}
+	if clusterClient == nil {
+		log.Info("Skipping aws-iam-authenticator mappings reconciliation, control plane is not initialized")
+		return nil
+	}
+
+	// The aws-iam-authenticator server is installed by the CLI, so its mappings are only managed once it's there.
+	authConfig := &corev1.ConfigMap{}
+	authConfigName := types.NamespacedName{Namespace: constants.KubeSystemNamespace, Name: awsiamauth.ConfigMapName}
+	if err := clusterClient.Get(ctx, authConfigName, authConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Skipping aws-iam-authenticator mappings reconciliation, aws-iam-authenticator is not installed")
+			return nil
+		}
+		return err
+	}
+
+	log.Info("Reconciling aws-iam-authenticator mappings", "awsIamConfig", awsIamConfig.Name)
+	if err := awsiamauth.ReconcileMappings(ctx, clusterClient, awsIamConfig); err != nil {
+		return err
+	}
+	r.recorder.Eventf(clus, corev1.EventTypeNormal, reasonAWSIamMappingsReconciled, "Reconciled aws-iam-authenticator mappings from AWSIamConfig %s", awsIamConfig.Name)
+
+	return nil
+}
+
+func (r *ClusterReconciler) awsIamConfig(ctx context.Context, name, namespace string) (*anywherev1.AWSIamConfig, error) {
+	awsIamConfig := &anywherev1.AWSIamConfig{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, awsIamConfig); err != nil {
+		return nil, err
+	}
+	return awsIamConfig, nil
+}
+
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywherev1.Cluster) (ctrl.Result, error) {
 	capiCluster := &clusterv1.Cluster{}
 	capiClusterName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}
@@ -198,6 +406,7 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywhe
 	switch {
 	case err == nil:
 		r.log.Info("Deleting CAPI cluster", "name", capiCluster.Name)
+		r.recorder.Event(cluster, corev1.EventTypeNormal, reasonDeleting, "Deleting CAPI cluster")
 		if err := r.client.Delete(ctx, capiCluster); err != nil {
 			r.log.Info("Error deleting CAPI cluster", "name", capiCluster.Name)
 			return ctrl.Result{}, err
@@ -208,6 +417,7 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywhe
 
 		// TODO delete GitOps,Datacenter and MachineConfig objects
 		controllerutil.RemoveFinalizer(cluster, clusterFinalizerName)
+		metrics.DeleteClusterStatus(cluster)
 	default:
 		return ctrl.Result{}, err
 
@@ -216,6 +426,8 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *anywhe
 }
 
 func (r *ClusterReconciler) ensureClusterOwnerReferences(ctx context.Context, clus *anywherev1.Cluster) error {
+	defer metrics.ObservePhase(metrics.Provider(clus), metrics.PhaseOwnerReferences, time.Now())
+
 	builder := cluster.NewDefaultConfigClientBuilder()
 	config, err := builder.Build(ctx, clientutil.NewKubeClient(r.client), clus)
 	if err != nil {
@@ -242,10 +454,10 @@ func (r *ClusterReconciler) ensureClusterOwnerReferences(ctx context.Context, cl
 	return nil
 }
 
-func BuildProviderReconciler(datacenterKind string, client client.Client, log logr.Logger, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (ProviderClusterReconciler, error) {
+func BuildProviderReconciler(datacenterKind string, client client.Client, log logr.Logger, recorder record.EventRecorder, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (ProviderClusterReconciler, error) {
 	switch datacenterKind {
 	case anywherev1.VSphereDatacenterKind:
-		return reconciler.NewVSphereReconciler(client, log, validator, defaulter, tracker), nil
+		return reconciler.NewVSphereReconciler(client, log, recorder, validator, defaulter, tracker), nil
 	}
 	return nil, fmt.Errorf("invalid data center type %s", datacenterKind)
 }
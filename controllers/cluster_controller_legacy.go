@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +51,7 @@ func NewClusterReconcilerLegacy(client client.Client, log logr.Logger, scheme *r
 // +kubebuilder:rbac:groups=distro.eks.amazonaws.com,resources=releases,verbs=get;list;watch
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=fluxconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=snowdatacenterconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=snowippools,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=snowmachineconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=gitopsconfigs,verbs=get;list;watch;create;update;patch;delete
 
@@ -84,8 +86,10 @@ func (r *ClusterReconcilerLegacy) Reconcile(ctx context.Context, req ctrl.Reques
 	// If the external object is paused, return without any further processing.
 	if cluster.IsReconcilePaused() {
 		r.Log.Info("eksa reconciliation is paused")
+		conditions.MarkTrue(cluster, anywherev1.ReconcilePausedCondition)
 		return ctrl.Result{}, nil
 	}
+	conditions.Delete(cluster, anywherev1.ReconcilePausedCondition)
 
 	// dry run
 	result, err := r.reconcile(ctx, req.NamespacedName, true)
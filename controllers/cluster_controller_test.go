@@ -13,7 +13,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -21,6 +23,7 @@ import (
 
 	_ "github.com/aws/eks-anywhere/internal/test/envtest"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
@@ -54,6 +57,7 @@ func TestClusterReconcilerSkipManagement(t *testing.T) {
 	r := &ClusterReconciler{
 		client:                  cl,
 		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
 		validator:               validator,
 		defaulter:               defaulter,
 		buildProviderReconciler: BuildProviderReconciler,
@@ -72,10 +76,13 @@ func TestClusterReconcilerSkipManagement(t *testing.T) {
 	govcClient.EXPECT().GetTags(ctx, machineConfigCP.Spec.Template).Return([]string{"os:ubuntu", fmt.Sprintf("eksdRelease:%s", bundle.Spec.VersionsBundles[0].EksD.Name)}, nil).Times(0)
 	govcClient.EXPECT().GetWorkloadAvailableSpace(ctx, machineConfigCP.Spec.Datastore).Return(100.0, nil).Times(2).Times(0)
 
-	_, err := r.Reconcile(ctx, req)
+	result, err := r.Reconcile(ctx, req)
 	if err != nil {
 		t.Fatalf("reconcile: (%v)", err)
 	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected self managed cluster not to be requeued. RequeueAfter:%s", result.RequeueAfter)
+	}
 
 	apiCluster := &anywherev1.Cluster{}
 
@@ -86,6 +93,134 @@ func TestClusterReconcilerSkipManagement(t *testing.T) {
 	if apiCluster.Status.FailureMessage != nil {
 		t.Errorf("Expected failure message to be nil. FailureMessage:%s", *apiCluster.Status.FailureMessage)
 	}
+	if apiCluster.Status.ObservedGeneration != 0 {
+		t.Errorf("Expected observed generation not to be set for a self managed cluster. ObservedGeneration:%d", apiCluster.Status.ObservedGeneration)
+	}
+	if conditions.Has(apiCluster, anywherev1.ReadyForUpgradeCondition) {
+		t.Errorf("Expected ReadyForUpgrade not to be reported for a self managed cluster")
+	}
+}
+
+func TestClusterReconcilerPausedCondition(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := createCluster()
+	cluster.PauseReconcile()
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster).Build()
+	r := &ClusterReconciler{
+		client:                  cl,
+		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
+		buildProviderReconciler: BuildProviderReconciler,
+	}
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	apiCluster := &anywherev1.Cluster{}
+	g.Expect(cl.Get(ctx, req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(apiCluster, anywherev1.ReconcilePausedCondition)).To(BeTrue())
+
+	apiCluster.ClearPauseAnnotation()
+	g.Expect(cl.Update(ctx, apiCluster)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(cl.Get(ctx, req.NamespacedName, apiCluster)).To(Succeed())
+	g.Expect(conditions.Has(apiCluster, anywherev1.ReconcilePausedCondition)).To(BeFalse())
+}
+
+func TestClusterReconcilerReconcileAWSIamAuthMappingsSelfManaged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := createCluster()
+	cluster.Spec.IdentityProviderRefs = []anywherev1.Ref{{Kind: anywherev1.AWSIamConfigKind, Name: "aws-iam-config"}}
+	awsIamConfig := &anywherev1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-iam-config", Namespace: namespace},
+		Spec: anywherev1.AWSIamConfigSpec{
+			AWSRegion:   "test-region",
+			BackendMode: []string{awsiamauth.EKSConfigMapBackendMode},
+			MapRoles: []anywherev1.MapRoles{
+				{RoleARN: "arn:aws:iam::123456789012:role/admin", Username: "admin", Groups: []string{"system:masters"}},
+			},
+		},
+	}
+	capiCluster := newCAPICluster(name, namespace)
+	conditions.MarkTrue(capiCluster, clusterv1.ControlPlaneInitializedCondition)
+	authConfig := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: awsiamauth.ConfigMapName, Namespace: "kube-system"},
+	}
+	awsAuth := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-auth", Namespace: "kube-system"},
+		Data: map[string]string{
+			"mapRoles": "- rolearn: arn:aws:iam::123456789012:role/manual\n  username: manual\n  groups: []\n",
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster, awsIamConfig, capiCluster, authConfig, awsAuth).Build()
+	r := &ClusterReconciler{
+		client:                  cl,
+		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
+		buildProviderReconciler: BuildProviderReconciler,
+	}
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(cl.Get(ctx, types.NamespacedName{Name: "aws-auth", Namespace: "kube-system"}, awsAuth)).To(Succeed())
+	g.Expect(awsAuth.Data["mapRoles"]).To(ContainSubstring("arn:aws:iam::123456789012:role/manual"))
+	g.Expect(awsAuth.Data["mapRoles"]).To(ContainSubstring("arn:aws:iam::123456789012:role/admin"))
+}
+
+func TestClusterReconcilerReconcileAWSIamAuthMappingsNotInstalled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := createCluster()
+	cluster.Spec.IdentityProviderRefs = []anywherev1.Ref{{Kind: anywherev1.AWSIamConfigKind, Name: "aws-iam-config"}}
+	awsIamConfig := &anywherev1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-iam-config", Namespace: namespace},
+		Spec: anywherev1.AWSIamConfigSpec{
+			BackendMode: []string{awsiamauth.EKSConfigMapBackendMode},
+		},
+	}
+	capiCluster := newCAPICluster(name, namespace)
+	conditions.MarkTrue(capiCluster, clusterv1.ControlPlaneInitializedCondition)
+
+	cl := fake.NewClientBuilder().WithRuntimeObjects(cluster, awsIamConfig, capiCluster).Build()
+	r := &ClusterReconciler{
+		client:                  cl,
+		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
+		buildProviderReconciler: BuildProviderReconciler,
+	}
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	awsAuth := &apiv1.ConfigMap{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "aws-auth", Namespace: "kube-system"}, awsAuth)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
 }
 
 func TestClusterReconcilerSuccess(t *testing.T) {
@@ -116,6 +251,7 @@ func TestClusterReconcilerSuccess(t *testing.T) {
 	r := &ClusterReconciler{
 		client:                  cl,
 		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
 		validator:               validator,
 		defaulter:               defaulter,
 		buildProviderReconciler: BuildProviderReconciler,
@@ -178,6 +314,7 @@ func TestClusterReconcilerFailToSetUpMachineConfigCP(t *testing.T) {
 	r := &ClusterReconciler{
 		client:                  cl,
 		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
 		validator:               validator,
 		defaulter:               defaulter,
 		buildProviderReconciler: BuildProviderReconciler,
@@ -242,6 +379,7 @@ func TestClusterReconcilerDeleteExistingCAPIClusterSuccess(t *testing.T) {
 	r := &ClusterReconciler{
 		client:                  cl,
 		log:                     logf.Log,
+		recorder:                record.NewFakeRecorder(100),
 		validator:               validator,
 		defaulter:               defaulter,
 		buildProviderReconciler: BuildProviderReconciler,
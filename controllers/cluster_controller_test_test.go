@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -67,7 +68,7 @@ func TestClusterReconcilerEnsureOwnerReferences(t *testing.T) {
 	cb := fake.NewClientBuilder()
 	cl := cb.WithRuntimeObjects(objs...).Build()
 
-	r := controllers.NewClusterReconciler(cl, nullLog(), cl.Scheme(), nil, nil, newDummyProviderReconcilerBuilder())
+	r := controllers.NewClusterReconciler(cl, nullLog(), record.NewFakeRecorder(10), cl.Scheme(), nil, nil, newDummyProviderReconcilerBuilder())
 	_, err := r.Reconcile(ctx, clusterRequest(cluster))
 	g.Expect(err).NotTo(HaveOccurred())
 
@@ -84,7 +85,7 @@ func TestClusterReconcilerEnsureOwnerReferences(t *testing.T) {
 
 func TestClusterReconcilerSetupWithManager(t *testing.T) {
 	client := env.Client()
-	r := controllers.NewClusterReconciler(client, logf.Log, client.Scheme(), nil, nil, newDummyProviderReconcilerBuilder())
+	r := controllers.NewClusterReconciler(client, logf.Log, record.NewFakeRecorder(10), client.Scheme(), nil, nil, newDummyProviderReconcilerBuilder())
 
 	g := NewWithT(t)
 	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
@@ -94,7 +95,7 @@ func TestBuildProviderReconcilerVSphere(t *testing.T) {
 	g := NewWithT(t)
 	cl := fake.NewClientBuilder().WithRuntimeObjects().Build()
 
-	got, err := controllers.BuildProviderReconciler(anywherev1.VSphereDatacenterKind, cl, nullLog(), &vsphere.Validator{}, &vsphere.Defaulter{}, &remote.ClusterCacheTracker{})
+	got, err := controllers.BuildProviderReconciler(anywherev1.VSphereDatacenterKind, cl, nullLog(), record.NewFakeRecorder(10), &vsphere.Validator{}, &vsphere.Defaulter{}, &remote.ClusterCacheTracker{})
 
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(got).To(BeAssignableToTypeOf(&reconciler.VSphereClusterReconciler{}))
@@ -104,13 +105,13 @@ func TestBuildProviderReconcilerUnknown(t *testing.T) {
 	g := NewWithT(t)
 	cl := fake.NewClientBuilder().WithRuntimeObjects().Build()
 
-	_, err := controllers.BuildProviderReconciler("unknown-datacenter", cl, nullLog(), &vsphere.Validator{}, &vsphere.Defaulter{}, &remote.ClusterCacheTracker{})
+	_, err := controllers.BuildProviderReconciler("unknown-datacenter", cl, nullLog(), record.NewFakeRecorder(10), &vsphere.Validator{}, &vsphere.Defaulter{}, &remote.ClusterCacheTracker{})
 
 	g.Expect(err).To(MatchError(ContainSubstring("invalid data center type unknown-datacenter")))
 }
 
 func newDummyProviderReconcilerBuilder() controllers.ProviderReconcilerBuilder {
-	return func(datacenterKind string, client client.Client, log logr.Logger, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (controllers.ProviderClusterReconciler, error) {
+	return func(datacenterKind string, client client.Client, log logr.Logger, recorder record.EventRecorder, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) (controllers.ProviderClusterReconciler, error) {
 		return dummyProviderReconciler{}, nil
 	}
 }
@@ -26,6 +26,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -34,6 +35,7 @@ type ResourceFetcher interface {
 	KubeadmConfigTemplate(ctx context.Context, cs *anywherev1.Cluster, wnc anywherev1.WorkerNodeGroupConfiguration) (*kubeadmv1.KubeadmConfigTemplate, error)
 	VSphereWorkerMachineTemplate(ctx context.Context, cs *anywherev1.Cluster, wnc anywherev1.WorkerNodeGroupConfiguration) (*vspherev1.VSphereMachineTemplate, error)
 	VSphereCredentials(ctx context.Context) (*corev1.Secret, error)
+	CloudStackCredentials(ctx context.Context, clusterName, credentialsRef string) (*corev1.Secret, error)
 	FetchObject(ctx context.Context, objectKey types.NamespacedName, obj client.Object) error
 	FetchObjectByName(ctx context.Context, name string, namespace string, obj client.Object) error
 	Fetch(ctx context.Context, name string, namespace string, kind string, apiVersion string) (*unstructured.Unstructured, error)
@@ -365,6 +367,16 @@ func (r *CapiResourceFetcher) VSphereCredentials(ctx context.Context) (*corev1.S
 	return secret, nil
 }
 
+// CloudStackCredentials retrieves the Secret holding the credentials credentialsRef points to for a cluster.
+func (r *CapiResourceFetcher) CloudStackCredentials(ctx context.Context, clusterName, credentialsRef string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.FetchObjectByName(ctx, decoder.CredentialsSecretName(clusterName, credentialsRef), constants.EksaSystemNamespace, secret)
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
 func (r *CapiResourceFetcher) bundles(ctx context.Context, name, namespace string) (*releasev1alpha1.Bundles, error) {
 	clusterBundle := &releasev1alpha1.Bundles{}
 	err := r.FetchObjectByName(ctx, name, namespace, clusterBundle)
@@ -60,6 +60,21 @@ func (mr *MockResourceFetcherMockRecorder) AWSIamConfig(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AWSIamConfig", reflect.TypeOf((*MockResourceFetcher)(nil).AWSIamConfig), arg0, arg1, arg2)
 }
 
+// CloudStackCredentials mocks base method.
+func (m *MockResourceFetcher) CloudStackCredentials(arg0 context.Context, arg1, arg2 string) (*v1.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudStackCredentials", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloudStackCredentials indicates an expected call of CloudStackCredentials.
+func (mr *MockResourceFetcherMockRecorder) CloudStackCredentials(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudStackCredentials", reflect.TypeOf((*MockResourceFetcher)(nil).CloudStackCredentials), arg0, arg1, arg2)
+}
+
 // ControlPlane mocks base method.
 func (m *MockResourceFetcher) ControlPlane(arg0 context.Context, arg1 *v1alpha1.Cluster) (*v1beta13.KubeadmControlPlane, error) {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
 	anywhereTypes "github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -44,6 +45,8 @@ func NewClusterReconciler(resourceFetcher ResourceFetcher, resourceUpdater Resou
 			ResourceFetcher: resourceFetcher,
 			ResourceUpdater: resourceUpdater,
 			now:             now,
+			authenticator:   cloudstack.NewAPIAuthenticator(),
+			authenticated:   newAuthenticatedProfiles(),
 		},
 		dockerTemplate: DockerTemplate{
 			ResourceFetcher: resourceFetcher,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
@@ -15,6 +16,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/providers/docker"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
@@ -43,7 +45,33 @@ type VsphereTemplate struct {
 type CloudStackTemplate struct {
 	ResourceFetcher
 	ResourceUpdater
-	now anywhereTypes.NowFunc
+	now           anywhereTypes.NowFunc
+	authenticator cloudstack.ProfileAuthenticator
+	authenticated *authenticatedProfiles
+}
+
+// authenticatedProfiles remembers the last profile authenticated from each credentials Secret, so
+// the credentials are only authenticated against the CloudStack API again when they change.
+type authenticatedProfiles struct {
+	mu       sync.Mutex
+	profiles map[string]decoder.CloudStackProfileConfig
+}
+
+func newAuthenticatedProfiles() *authenticatedProfiles {
+	return &authenticatedProfiles{profiles: map[string]decoder.CloudStackProfileConfig{}}
+}
+
+func (a *authenticatedProfiles) contains(secretName string, profile decoder.CloudStackProfileConfig) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	authenticated, ok := a.profiles[secretName]
+	return ok && authenticated == profile
+}
+
+func (a *authenticatedProfiles) add(secretName string, profile decoder.CloudStackProfileConfig) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.profiles[secretName] = profile
 }
 
 type AWSIamConfigTemplate struct {
@@ -175,6 +203,10 @@ func (r *CloudStackTemplate) TemplateResources(ctx context.Context, eksaCluster
 	for _, wnConfig := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
 		workerNodeGroupMachineSpecs[wnConfig.MachineGroupRef.Name] = workerCsmcs[wnConfig.MachineGroupRef.Name].Spec
 	}
+	if err := r.validateAvailabilityZoneCredentials(ctx, clusterSpec.Cluster.Name, csdc); err != nil {
+		return nil, err
+	}
+
 	// control plane and etcd updates are prohibited in controller so those specs should not change
 	templateBuilder := cloudstack.NewCloudStackTemplateBuilder(&csdc.Spec, &cpCsmc.Spec, &etcdCsmc.Spec, workerNodeGroupMachineSpecs, r.now)
 	clusterName := clusterSpec.Cluster.Name
@@ -216,6 +248,34 @@ func (r *CloudStackTemplate) TemplateResources(ctx context.Context, eksaCluster
 	return generateTemplateResources(templateBuilder, clusterSpec, workloadTemplateNames, kubeadmconfigTemplateNames, cpOpt)
 }
 
+// validateAvailabilityZoneCredentials ensures the Secret each availability zone's credentialsRef
+// points to holds credentials for the availability zone management API endpoint and that the
+// endpoint accepts them. Credentials already accepted by the endpoint aren't authenticated again.
+func (r *CloudStackTemplate) validateAvailabilityZoneCredentials(ctx context.Context, clusterName string, csdc anywherev1.CloudStackDatacenterConfig) error {
+	for _, az := range csdc.Spec.AvailabilityZones {
+		secret, err := r.CloudStackCredentials(ctx, clusterName, az.CredentialsRef)
+		if err != nil {
+			return fmt.Errorf("fetching credentials for availability zone %s: %v", az.Name, err)
+		}
+		profile, err := decoder.ParseCloudStackProfileFromSecret(az.CredentialsRef, secret)
+		if err != nil {
+			return fmt.Errorf("parsing credentials for availability zone %s: %v", az.Name, err)
+		}
+		if profile.ManagementUrl != az.ManagementApiEndpoint {
+			return fmt.Errorf("cloudstack secret %s management url (%s) differs from availability zone %s management url (%s)",
+				secret.Name, profile.ManagementUrl, az.Name, az.ManagementApiEndpoint)
+		}
+		if r.authenticated.contains(secret.Name, *profile) {
+			continue
+		}
+		if err := r.authenticator.Authenticate(ctx, *profile); err != nil {
+			return fmt.Errorf("validating credentials for availability zone %s: %v", az.Name, err)
+		}
+		r.authenticated.add(secret.Name, *profile)
+	}
+	return nil
+}
+
 func (r *CloudStackTemplate) getControlPlaneTemplateName(ctx context.Context, eksaCluster *anywherev1.Cluster, oldCsdc *anywherev1.CloudStackDatacenterConfig, csdc anywherev1.CloudStackDatacenterConfig, oldCpCsmc *anywherev1.CloudStackMachineConfig, cpCsmc anywherev1.CloudStackMachineConfig, clusterName string) (string, error) {
 	var controlPlaneTemplateName string
 	updateControlPlaneTemplate := cloudstack.AnyImmutableFieldChanged(oldCsdc, &csdc, oldCpCsmc, &cpCsmc)
@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,12 +20,18 @@ import (
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
 )
 
+// Reasons of the events recorded for VSphereDatacenterConfigs.
+const (
+	reasonSpecValid   = "SpecValid"
+	reasonSpecInvalid = "SpecInvalid"
+)
+
 // VSphereDatacenterReconciler reconciles a VSphereDatacenterConfig object
 type VSphereDatacenterReconciler struct {
 	reconciler.VSphereReconciler
 }
 
-func NewVSphereDatacenterReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme, govc *executables.Govc) *VSphereDatacenterReconciler {
+func NewVSphereDatacenterReconciler(client client.Client, log logr.Logger, recorder record.EventRecorder, scheme *runtime.Scheme, govc *executables.Govc) *VSphereDatacenterReconciler {
 	validator := vsphere.NewValidator(govc, &networkutils.DefaultNetClient{})
 	defaulter := vsphere.NewDefaulter(govc)
 
@@ -31,6 +39,7 @@ func NewVSphereDatacenterReconciler(client client.Client, log logr.Logger, schem
 		reconciler.VSphereReconciler{
 			Client:    client,
 			Log:       log,
+			Recorder:  recorder,
 			Validator: validator,
 			Defaulter: defaulter,
 		},
@@ -91,14 +100,19 @@ func (r *VSphereDatacenterReconciler) reconcile(ctx context.Context, vsphereData
 		return ctrl.Result{}, err
 	}
 	if err := r.Defaulter.SetDefaultsForDatacenterConfig(ctx, vsphereDatacenter); err != nil {
+		r.Recorder.Eventf(vsphereDatacenter, corev1.EventTypeWarning, reasonSpecInvalid, "Failed setting default values: %v", err)
 		return ctrl.Result{}, fmt.Errorf("failed setting default values for vsphere datacenter config: %v", err)
 	}
 	// Determine if VsphereDatacenterConfig is valid
 	if err := r.Validator.ValidateVCenterConfig(ctx, vsphereDatacenter); err != nil {
 		log.Error(err, "Failed to validate VsphereDatacenterConfig")
+		r.Recorder.Eventf(vsphereDatacenter, corev1.EventTypeWarning, reasonSpecInvalid, "Failed validating vCenter config: %v", err)
 		return ctrl.Result{}, err
 	}
 
+	if !vsphereDatacenter.Status.SpecValid {
+		r.Recorder.Event(vsphereDatacenter, corev1.EventTypeNormal, reasonSpecValid, "vCenter config is valid")
+	}
 	vsphereDatacenter.Status.SpecValid = true
 
 	return ctrl.Result{}, nil
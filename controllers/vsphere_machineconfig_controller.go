@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -13,14 +15,16 @@ import (
 
 // VSphereMachineConfigReconciler reconciles a VSphereDatacenterConfig object
 type VSphereMachineConfigReconciler struct {
-	client client.Client
-	log    logr.Logger
+	client   client.Client
+	log      logr.Logger
+	recorder record.EventRecorder
 }
 
-func NewVSphereMachineConfigReconciler(client client.Client, log logr.Logger, scheme *runtime.Scheme) *VSphereMachineConfigReconciler {
+func NewVSphereMachineConfigReconciler(client client.Client, log logr.Logger, recorder record.EventRecorder, scheme *runtime.Scheme) *VSphereMachineConfigReconciler {
 	return &VSphereMachineConfigReconciler{
-		client: client,
-		log:    log,
+		client:   client,
+		log:      log,
+		recorder: recorder,
 	}
 }
 
@@ -33,7 +37,25 @@ func (r *VSphereMachineConfigReconciler) SetupWithManager(mgr ctrl.Manager) erro
 
 // TODO: add here kubebuilder permissions as neeeded
 func (r *VSphereMachineConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
-	_ = r.log.WithValues("vsphereMachineConfig", req.NamespacedName)
+	log := r.log.WithValues("vsphereMachineConfig", req.NamespacedName)
+
+	machineConfig := &anywherev1.VSphereMachineConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, machineConfig); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// There's no need to go any further if the VSphereMachineConfig is marked for deletion.
+	if !machineConfig.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if err := machineConfig.Validate(); err != nil {
+		log.Error(err, "Failed to validate VSphereMachineConfig")
+		r.recorder.Eventf(machineConfig, corev1.EventTypeWarning, reasonSpecInvalid, "Failed validating machine config: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	r.recorder.Event(machineConfig, corev1.EventTypeNormal, reasonSpecValid, "Machine config is valid")
 
 	return ctrl.Result{}, nil
 }
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestVSphereMachineConfigReconcilerReconcileValid(t *testing.T) {
+	g := NewWithT(t)
+	machineConfig := &anywherev1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cp", Namespace: "default"},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(machineConfig).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := NewVSphereMachineConfigReconciler(cl, logf.Log, recorder, nil)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-cp", Namespace: "default"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(Equal("Normal SpecValid Machine config is valid"))
+}
+
+func TestVSphereMachineConfigReconcilerReconcileNotFound(t *testing.T) {
+	g := NewWithT(t)
+	cl := fake.NewClientBuilder().Build()
+	recorder := record.NewFakeRecorder(10)
+	r := NewVSphereMachineConfigReconciler(cl, logf.Log, recorder, nil)
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-cp", Namespace: "default"}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(recorder.Events).To(BeEmpty())
+}
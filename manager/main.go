@@ -22,9 +22,12 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/aws/eks-anywhere/controllers"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller/events"
+	eksametrics "github.com/aws/eks-anywhere/pkg/controller/metrics"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/features"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -94,6 +97,7 @@ func main() {
 	// Setup the context that's going to be used in controllers and for the manager.
 	ctx := ctrl.SetupSignalHandler()
 
+	setupMetrics()
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
 	//+kubebuilder:scaffold:builder
@@ -106,6 +110,16 @@ func main() {
 	}
 }
 
+func setupMetrics() {
+	collectors := append(eksametrics.Collectors(), anywherev1.WebhookCollectors()...)
+	for _, c := range collectors {
+		if err := metrics.Registry.Register(c); err != nil {
+			setupLog.Error(err, "unable to register metrics")
+			os.Exit(1)
+		}
+	}
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	if features.IsActive(features.FullLifecycleAPI()) {
 		// This feature doesn't support running the binaries through docker on the controller image so relying on the
@@ -135,6 +149,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		if err := (controllers.NewClusterReconciler(
 			mgr.GetClient(),
 			ctrl.Log.WithName("controllers").WithName(anywherev1.ClusterKind),
+			events.NewDedupRecorder(mgr.GetEventRecorderFor("cluster-controller")),
 			mgr.GetScheme(),
 			deps.Govc,
 			tracker,
@@ -148,6 +163,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		if err := (controllers.NewVSphereDatacenterReconciler(
 			mgr.GetClient(),
 			ctrl.Log.WithName("controllers").WithName(anywherev1.VSphereDatacenterKind),
+			events.NewDedupRecorder(mgr.GetEventRecorderFor("vspheredatacenterconfig-controller")),
 			mgr.GetScheme(),
 			deps.Govc,
 		)).SetupWithManager(mgr); err != nil {
@@ -158,6 +174,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		if err := (controllers.NewVSphereMachineConfigReconciler(
 			mgr.GetClient(),
 			ctrl.Log.WithName("controllers").WithName(anywherev1.VSphereMachineConfigKind),
+			events.NewDedupRecorder(mgr.GetEventRecorderFor("vspheremachineconfig-controller")),
 			mgr.GetScheme(),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", anywherev1.VSphereMachineConfigKind)
@@ -181,39 +198,40 @@ func setupLegacyClusterReconciler(mgr ctrl.Manager) {
 }
 
 func setupWebhooks(mgr ctrl.Manager) {
-	if err := (&anywherev1.Cluster{}).SetupWebhookWithManager(mgr); err != nil {
+	recorder := events.NewDedupRecorder(mgr.GetEventRecorderFor("eksa-webhook"))
+	if err := (&anywherev1.Cluster{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.ClusterKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.VSphereDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.VSphereDatacenterConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereDatacenterKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.VSphereMachineConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.VSphereMachineConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereMachineConfigKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.CloudStackDatacenterConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.CloudStackDatacenterConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.CloudStackDatacenterKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.CloudStackMachineConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.CloudStackMachineConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.CloudStackMachineConfigKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.GitOpsConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.GitOpsConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.GitOpsConfigKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.OIDCConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.OIDCConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.OIDCConfigKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.AWSIamConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.AWSIamConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.AWSIamConfigKind)
 		os.Exit(1)
 	}
-	if err := (&anywherev1.FluxConfig{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&anywherev1.FluxConfig{}).SetupWebhookWithManager(mgr, recorder); err != nil {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.FluxConfigKind)
 		os.Exit(1)
 	}
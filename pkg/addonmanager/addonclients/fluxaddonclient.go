@@ -1,10 +1,13 @@
 package addonclients
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -86,6 +89,9 @@ type Flux interface {
 	// ResumeKustomization resumes a paused Kustomization
 	ResumeKustomization(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 
+	// KustomizationSuspended returns true if the reconciliation of the Kustomization is suspended
+	KustomizationSuspended(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) (bool, error)
+
 	// ForceReconcileGitRepo sync git repo with latest commit
 	ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, namespace string) error
 
@@ -251,6 +257,23 @@ func (f *FluxAddonClient) ResumeGitOpsKustomization(ctx context.Context, cluster
 	})
 }
 
+// GitOpsKustomizationPaused returns true if the reconciliation of the Flux kustomization is suspended.
+// It returns false if GitOps is not configured.
+func (f *FluxAddonClient) GitOpsKustomizationPaused(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error) {
+	if f.shouldSkipFlux() {
+		return false, nil
+	}
+
+	var suspended bool
+	err := f.retrier.Retry(func() error {
+		var err error
+		suspended, err = f.flux.KustomizationSuspended(ctx, cluster, clusterSpec.FluxConfig)
+		return err
+	})
+
+	return suspended, err
+}
+
 func (f *FluxAddonClient) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error {
 	if f.shouldSkipFlux() {
 		logger.Info("GitOps field not specified, update git repo skipped")
@@ -286,6 +309,45 @@ func (f *FluxAddonClient) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cl
 	return nil
 }
 
+// DiffGitEksaSpec returns the paths, relative to the root of the git repository, of the files
+// UpdateGitEksaSpec would change for the cluster. It syncs the local copy of the repository to compare
+// the rendered files with their current content, but it doesn't write, commit or push anything.
+func (f *FluxAddonClient) DiffGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) ([]string, error) {
+	if f.shouldSkipFlux() {
+		return nil, nil
+	}
+
+	fc := &fluxForCluster{
+		FluxAddonClient:  f,
+		clusterSpec:      clusterSpec,
+		datacenterConfig: datacenterConfig,
+		machineConfigs:   machineConfigs,
+	}
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	files, err := fc.eksaSystemFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, file := range files {
+		filePath := path.Join(fc.eksaSystemDir(), file.name)
+		current, err := ioutil.ReadFile(filepath.Join(f.gitTools.Writer.Dir(), filePath))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading %s from git repo: %v", filePath, err)
+		}
+		if !bytes.Equal(current, file.content) {
+			changed = append(changed, filePath)
+		}
+	}
+
+	return changed, nil
+}
+
 func (f *FluxAddonClient) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
 	if f.shouldSkipFlux() {
 		return nil
@@ -445,38 +507,67 @@ func (fc *fluxForCluster) writeEksaSystemFiles() error {
 		return err
 	}
 
-	logger.V(3).Info("Generating eks-a cluster config file...")
-	if err := fc.generateClusterConfigFile(w); err != nil {
+	files, err := fc.eksaSystemFiles()
+	if err != nil {
 		return err
 	}
 
-	logger.V(3).Info("Generating eks-a kustomization file...")
-	return fc.generateEksaKustomizeFile(w)
+	for _, file := range files {
+		if filePath, err := w.Write(file.name, file.content, filewriter.PersistentFile); err != nil {
+			return fmt.Errorf("writing eks-a file into %s: %v", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+type gitFile struct {
+	name    string
+	content []byte
 }
 
-func (fc *fluxForCluster) generateClusterConfigFile(w filewriter.FileWriter) error {
+// eksaSystemFiles renders the files in the eks-a system dir of the repository: the cluster config and its kustomization.
+func (fc *fluxForCluster) eksaSystemFiles() ([]gitFile, error) {
+	logger.V(3).Info("Generating eks-a cluster config file...")
 	resourcesSpec, err := clustermarshaller.MarshalClusterSpec(fc.clusterSpec, fc.datacenterConfig, fc.machineConfigs)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if filePath, err := w.Write(clusterConfigFileName, resourcesSpec, filewriter.PersistentFile); err != nil {
-		return fmt.Errorf("writing eks-a cluster config file into %s: %v", filePath, err)
+
+	kustomization, err := eksaKustomization()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return []gitFile{
+		{name: clusterConfigFileName, content: resourcesSpec},
+		{name: kustomizeFileName, content: kustomization},
+	}, nil
 }
 
 func (fc *fluxForCluster) generateEksaKustomizeFile(w filewriter.FileWriter) error {
-	values := map[string]string{
-		"ConfigFileName": clusterConfigFileName,
+	kustomization, err := eksaKustomization()
+	if err != nil {
+		return err
 	}
-	t := templater.New(w)
-	if filePath, err := t.WriteToFile(eksaKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
+	if filePath, err := w.Write(kustomizeFileName, kustomization, filewriter.PersistentFile); err != nil {
 		return fmt.Errorf("writing eks-a kustomization manifest file into %s: %v", filePath, err)
 	}
 	return nil
 }
 
+func eksaKustomization() ([]byte, error) {
+	logger.V(3).Info("Generating eks-a kustomization file...")
+	values := map[string]string{
+		"ConfigFileName": clusterConfigFileName,
+	}
+	kustomization, err := templater.Execute(eksaKustomizeContent, values)
+	if err != nil {
+		return nil, fmt.Errorf("generating eks-a kustomization manifest: %v", err)
+	}
+	return kustomization, nil
+}
+
 func (fc *fluxForCluster) initFluxWriter() (filewriter.FileWriter, error) {
 	w, err := fc.gitTools.Writer.WithDir(fc.fluxSystemDir())
 	if err != nil {
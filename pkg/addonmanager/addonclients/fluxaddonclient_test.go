@@ -367,6 +367,26 @@ func TestFluxAddonClientResumeKustomization(t *testing.T) {
 	}
 }
 
+func TestFluxAddonClientGitOpsKustomizationPaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := &types.Cluster{}
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f, m, _ := newAddonClient(t)
+
+	m.flux.EXPECT().KustomizationSuspended(ctx, cluster, clusterSpec.FluxConfig).Return(true, nil)
+
+	g.Expect(f.GitOpsKustomizationPaused(ctx, cluster, clusterSpec)).To(BeTrue())
+}
+
+func TestFluxAddonClientGitOpsKustomizationPausedSkip(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f := addonclients.NewFluxAddonClient(nil, nil, nil)
+
+	g.Expect(f.GitOpsKustomizationPaused(context.Background(), &types.Cluster{}, clusterSpec)).To(BeFalse())
+}
+
 func TestFluxAddonClientUpdateGitRepoEksaSpecLocalRepoNotExists(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "management-cluster"
@@ -483,6 +503,73 @@ func TestFluxAddonClientUpdateGitRepoEksaSpecErrorAddFile(t *testing.T) {
 	}
 }
 
+func TestFluxAddonClientDiffGitEksaSpecNewRepo(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterName := "management-cluster"
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f, m, _ := newAddonClient(t)
+
+	m.gitClient.EXPECT().Clone(ctx).Return(nil)
+	m.gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	files, err := f.DiffGitEksaSpec(ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(Equal([]string{
+		"clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml",
+		"clusters/management-cluster/management-cluster/eksa-system/kustomization.yaml",
+	}))
+}
+
+func TestFluxAddonClientDiffGitEksaSpec(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterName := "management-cluster"
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f, m, gitTools := newAddonClient(t)
+	if _, err := gitTools.Writer.WithDir(".git"); err != nil {
+		t.Fatal(err)
+	}
+	dc := datacenterConfig(clusterName)
+	machineConfigs := []providers.MachineConfig{machineConfig(clusterName)}
+
+	m.gitClient.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil).Times(3)
+	m.gitClient.EXPECT().Add("clusters/management-cluster/management-cluster/eksa-system").Return(nil)
+	m.gitClient.EXPECT().Commit(test.OfType("string")).Return(nil)
+	m.gitClient.EXPECT().Push(ctx).Return(nil)
+	g.Expect(f.UpdateGitEksaSpec(ctx, clusterSpec, dc, machineConfigs)).To(Succeed())
+
+	files, err := f.DiffGitEksaSpec(ctx, clusterSpec, dc, machineConfigs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(BeEmpty())
+
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 5
+	files, err = f.DiffGitEksaSpec(ctx, clusterSpec, dc, machineConfigs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(files).To(Equal([]string{"clusters/management-cluster/management-cluster/eksa-system/eksa-cluster.yaml"}))
+}
+
+func TestFluxAddonClientDiffGitEksaSpecSyncError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterName := "management-cluster"
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster(clusterName), "")
+	f, m, _ := newAddonClient(t)
+
+	m.gitClient.EXPECT().Clone(ctx).Return(errors.New("failed to clone")).Times(2)
+
+	_, err := f.DiffGitEksaSpec(ctx, clusterSpec, datacenterConfig(clusterName), []providers.MachineConfig{machineConfig(clusterName)})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestFluxAddonClientDiffGitEksaSpecSkip(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := newClusterSpec(t, v1alpha1.NewCluster("management-cluster"), "")
+	f := addonclients.NewFluxAddonClient(nil, nil, nil)
+
+	g.Expect(f.DiffGitEksaSpec(context.Background(), clusterSpec, nil, nil)).To(BeNil())
+}
+
 func TestFluxAddonClientUpdateGitRepoEksaSpecErrorCommit(t *testing.T) {
 	ctx := context.Background()
 	clusterName := "management-cluster"
@@ -93,6 +93,21 @@ func (mr *MockFluxMockRecorder) ForceReconcileGitRepo(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceReconcileGitRepo", reflect.TypeOf((*MockFlux)(nil).ForceReconcileGitRepo), arg0, arg1, arg2)
 }
 
+// KustomizationSuspended mocks base method.
+func (m *MockFlux) KustomizationSuspended(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KustomizationSuspended", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KustomizationSuspended indicates an expected call of KustomizationSuspended.
+func (mr *MockFluxMockRecorder) KustomizationSuspended(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KustomizationSuspended", reflect.TypeOf((*MockFlux)(nil).KustomizationSuspended), arg0, arg1, arg2)
+}
+
 // PauseKustomization mocks base method.
 func (m *MockFlux) PauseKustomization(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.FluxConfig) error {
 	m.ctrl.T.Helper()
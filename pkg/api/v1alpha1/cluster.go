@@ -26,6 +26,8 @@ const (
 	YamlSeparator            = "\n---\n"
 	RegistryMirrorCAKey      = "EKSA_REGISTRY_MIRROR_CA"
 	podSubnetNodeMaskMaxDiff = 16
+	// defaultIPv6NodeCIDRMaskSize is the kube-controller-manager default node mask size for IPv6
+	defaultIPv6NodeCIDRMaskSize = 64
 )
 
 // +kubebuilder:object:generate=false
@@ -180,6 +182,8 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateMirrorConfig,
 	validatePodIAMConfig,
 	validateControlPlaneLabels,
+	validateControlPlaneLoadBalancer,
+	validateLoadBalancerConfiguration,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -382,6 +386,93 @@ func validateControlPlaneReplicas(clusterConfig *Cluster) error {
 	return nil
 }
 
+func validateControlPlaneLoadBalancer(clusterConfig *Cluster) error {
+	endpoint := clusterConfig.Spec.ControlPlaneConfiguration.Endpoint
+	if endpoint == nil || endpoint.LoadBalancer == "" {
+		return nil
+	}
+	switch endpoint.LoadBalancer {
+	case KubeVipLoadBalancer:
+		return nil
+	case ExternalLoadBalancer, HAProxyStaticPodLoadBalancer:
+		kind := clusterConfig.Spec.DatacenterRef.Kind
+		if kind != VSphereDatacenterKind && kind != CloudStackDatacenterKind && kind != TinkerbellDatacenterKind {
+			return fmt.Errorf("control plane endpoint load balancer %s is not supported for %s", endpoint.LoadBalancer, kind)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid control plane endpoint load balancer %s, supported values are %s, %s and %s",
+			endpoint.LoadBalancer, KubeVipLoadBalancer, ExternalLoadBalancer, HAProxyStaticPodLoadBalancer)
+	}
+}
+
+func validateLoadBalancerConfiguration(clusterConfig *Cluster) error {
+	lbConfig := clusterConfig.Spec.LoadBalancerConfiguration
+	if lbConfig == nil {
+		return nil
+	}
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+	if kind != VSphereDatacenterKind && kind != TinkerbellDatacenterKind {
+		return fmt.Errorf("loadBalancerConfiguration is not supported for %s", kind)
+	}
+	if len(lbConfig.IPPools) == 0 {
+		return errors.New("loadBalancerConfiguration must contain at least one IP pool")
+	}
+
+	clusterCIDRs := append([]string{}, clusterConfig.Spec.ClusterNetwork.Pods.CidrBlocks...)
+	clusterCIDRs = append(clusterCIDRs, clusterConfig.Spec.ClusterNetwork.Services.CidrBlocks...)
+	var controlPlaneIP net.IP
+	if clusterConfig.Spec.ControlPlaneConfiguration.Endpoint != nil {
+		controlPlaneIP = net.ParseIP(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
+	}
+
+	poolRanges := make(map[string]*networkutils.IPRange, len(lbConfig.IPPools))
+	for _, pool := range lbConfig.IPPools {
+		name := pool.Namespace
+		if name == "" {
+			name = "global"
+		}
+		if _, ok := poolRanges[name]; ok {
+			return fmt.Errorf("loadBalancerConfiguration can only have one IP pool per namespace, found multiple for %s", name)
+		}
+
+		poolRange, err := loadBalancerIPPoolRange(pool)
+		if err != nil {
+			return fmt.Errorf("invalid loadBalancerConfiguration IP pool for %s: %v", name, err)
+		}
+
+		for _, cidr := range clusterCIDRs {
+			cidrRange, err := networkutils.ParseCIDRRange(cidr)
+			if err != nil {
+				continue
+			}
+			if poolRange.Overlaps(cidrRange) {
+				return fmt.Errorf("loadBalancerConfiguration IP pool for %s overlaps with cluster network CIDR %s", name, cidr)
+			}
+		}
+		if controlPlaneIP != nil && poolRange.Contains(controlPlaneIP) {
+			return fmt.Errorf("loadBalancerConfiguration IP pool for %s contains the control plane endpoint %s", name, controlPlaneIP)
+		}
+		for otherName, otherRange := range poolRanges {
+			if poolRange.Overlaps(otherRange) {
+				return fmt.Errorf("loadBalancerConfiguration IP pools for %s and %s overlap", otherName, name)
+			}
+		}
+		poolRanges[name] = poolRange
+	}
+	return nil
+}
+
+func loadBalancerIPPoolRange(pool LoadBalancerIPPool) (*networkutils.IPRange, error) {
+	if (pool.CIDR == "") == (pool.Range == "") {
+		return nil, errors.New("exactly one of cidr and range must be specified")
+	}
+	if pool.CIDR != "" {
+		return networkutils.ParseCIDRRange(pool.CIDR)
+	}
+	return networkutils.ParseIPRange(pool.Range)
+}
+
 func validateControlPlaneLabels(clusterConfig *Cluster) error {
 	if err := validateNodeLabels(clusterConfig.Spec.ControlPlaneConfiguration.Labels, field.NewPath("spec", "controlPlaneConfiguration", "labels")); err != nil {
 		return fmt.Errorf("labels for control plane not valid: %v", err)
@@ -473,39 +564,93 @@ func validateNetworking(clusterConfig *Cluster) error {
 	if len(clusterNetwork.Services.CidrBlocks) <= 0 {
 		return errors.New("services CIDR block not specified or empty")
 	}
-	if len(clusterNetwork.Pods.CidrBlocks) > 1 {
-		return fmt.Errorf("multiple CIDR blocks for Pods are not yet supported")
+	if len(clusterNetwork.Pods.CidrBlocks) > 2 {
+		return fmt.Errorf("at most two CIDR blocks, one per IP family, can be specified for Pods")
 	}
-	if len(clusterNetwork.Services.CidrBlocks) > 1 {
-		return fmt.Errorf("multiple CIDR blocks for Services are not yet supported")
+	if len(clusterNetwork.Services.CidrBlocks) > 2 {
+		return fmt.Errorf("at most two CIDR blocks, one per IP family, can be specified for Services")
 	}
-	_, podCIDRIpNet, err := net.ParseCIDR(clusterNetwork.Pods.CidrBlocks[0])
-	if err != nil {
-		return fmt.Errorf("invalid CIDR block format for Pods: %s. Please specify a valid CIDR block for pod subnet", clusterNetwork.Pods)
+
+	podCIDRIpNets := make([]*net.IPNet, 0, len(clusterNetwork.Pods.CidrBlocks))
+	for _, cidrBlock := range clusterNetwork.Pods.CidrBlocks {
+		_, podCIDRIpNet, err := net.ParseCIDR(cidrBlock)
+		if err != nil {
+			return fmt.Errorf("invalid CIDR block format for Pods: %s. Please specify a valid CIDR block for pod subnet", clusterNetwork.Pods)
+		}
+		podCIDRIpNets = append(podCIDRIpNets, podCIDRIpNet)
 	}
-	_, _, err = net.ParseCIDR(clusterNetwork.Services.CidrBlocks[0])
-	if err != nil {
-		return fmt.Errorf("invalid CIDR block for Services: %s. Please specify a valid CIDR block for service subnet", clusterNetwork.Services)
+	for _, cidrBlock := range clusterNetwork.Services.CidrBlocks {
+		if _, _, err := net.ParseCIDR(cidrBlock); err != nil {
+			return fmt.Errorf("invalid CIDR block for Services: %s. Please specify a valid CIDR block for service subnet", clusterNetwork.Services)
+		}
+	}
+
+	podFamilies := cidrBlocksIPFamilies(clusterNetwork.Pods.CidrBlocks)
+	serviceFamilies := cidrBlocksIPFamilies(clusterNetwork.Services.CidrBlocks)
+	if len(podFamilies) == 2 && podFamilies[0] == podFamilies[1] {
+		return fmt.Errorf("dual-stack CIDR blocks for Pods must contain one IPv4 and one IPv6 block")
+	}
+	if len(serviceFamilies) == 2 && serviceFamilies[0] == serviceFamilies[1] {
+		return fmt.Errorf("dual-stack CIDR blocks for Services must contain one IPv4 and one IPv6 block")
+	}
+	if !ipFamiliesEqual(podFamilies, serviceFamilies) {
+		return fmt.Errorf("CIDR blocks for Pods and Services must have the same IP families in the same order")
 	}
 
-	if clusterNetwork.Nodes != nil && clusterNetwork.Nodes.CIDRMaskSize != nil {
-		podMaskSize, _ := podCIDRIpNet.Mask.Size()
-		nodeCidrMaskSize := clusterNetwork.Nodes.CIDRMaskSize
-		// the pod subnet mask needs to allow one or multiple node-masks
-		// i.e. if it has a /24 the node mask must be between 24 and 32 for ipv4
-		// the below validations are run by kubeadm and we are bubbling those up here for better customer experience
-		if podMaskSize > *nodeCidrMaskSize {
-			return fmt.Errorf("the size of pod subnet with mask %d is smaller than the size of node subnet with mask %d", podMaskSize, *nodeCidrMaskSize)
-		} else if (*nodeCidrMaskSize - podMaskSize) > podSubnetNodeMaskMaxDiff {
-			// PodSubnetNodeMaskMaxDiff is limited to 16 due to an issue with uncompressed IP bitmap in core
-			// The node subnet mask size must be no more than the pod subnet mask size + 16
-			return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, *nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
+	if clusterNetwork.IsIPv6Enabled() {
+		kind := clusterConfig.Spec.DatacenterRef.Kind
+		if kind != VSphereDatacenterKind && kind != DockerDatacenterKind {
+			return fmt.Errorf("IPv6 cluster networking is not supported for %s", kind)
+		}
+	}
+
+	for i, podCIDRIpNet := range podCIDRIpNets {
+		// the node mask size in the cluster spec applies to the primary IP family
+		// other families use the kube-controller-manager default
+		var nodeCidrMaskSize int
+		if i == 0 && clusterNetwork.Nodes != nil && clusterNetwork.Nodes.CIDRMaskSize != nil {
+			nodeCidrMaskSize = *clusterNetwork.Nodes.CIDRMaskSize
+		} else if podFamilies[i] == IPv6Family {
+			nodeCidrMaskSize = defaultIPv6NodeCIDRMaskSize
+		} else {
+			continue
+		}
+
+		if err := validateNodeCIDRMaskSize(podCIDRIpNet, nodeCidrMaskSize); err != nil {
+			return err
 		}
 	}
 
 	return validateCNIPlugin(clusterNetwork)
 }
 
+func validateNodeCIDRMaskSize(podCIDRIpNet *net.IPNet, nodeCidrMaskSize int) error {
+	podMaskSize, _ := podCIDRIpNet.Mask.Size()
+	// the pod subnet mask needs to allow one or multiple node-masks
+	// i.e. if it has a /24 the node mask must be between 24 and 32 for ipv4
+	// the below validations are run by kubeadm and we are bubbling those up here for better customer experience
+	if podMaskSize > nodeCidrMaskSize {
+		return fmt.Errorf("the size of pod subnet with mask %d is smaller than the size of node subnet with mask %d", podMaskSize, nodeCidrMaskSize)
+	} else if (nodeCidrMaskSize - podMaskSize) > podSubnetNodeMaskMaxDiff {
+		// PodSubnetNodeMaskMaxDiff is limited to 16 due to an issue with uncompressed IP bitmap in core
+		// The node subnet mask size must be no more than the pod subnet mask size + 16
+		return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
+	}
+	return nil
+}
+
+func ipFamiliesEqual(a, b []IPFamily) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func validateCNIPlugin(network ClusterNetwork) error {
 	if network.CNI != "" {
 		if network.CNIConfig != nil {
@@ -538,6 +683,10 @@ func validateCNIConfig(cniConfig *CNIConfig) error {
 		cniPluginSpecified++
 	}
 
+	if cniConfig.None != nil {
+		cniPluginSpecified++
+	}
+
 	if cniPluginSpecified == 0 {
 		allErrs = append(allErrs, fmt.Errorf("no cni plugin specified"))
 	} else if cniPluginSpecified > 1 {
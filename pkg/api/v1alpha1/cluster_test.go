@@ -1781,6 +1781,26 @@ func TestClusterNetworkEquals(t *testing.T) {
 				CNIConfig: &CNIConfig{Kindnetd: &KindnetdConfig{}},
 			},
 		},
+		{
+			name: "previous != new, new cniConfig format, cilium to user managed cni",
+			want: false,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{None: &NoneCNIConfig{}},
+			},
+		},
+		{
+			name: "previous == new, new cniConfig format, user managed cni",
+			want: true,
+			prev: &ClusterNetwork{
+				CNIConfig: &CNIConfig{None: &NoneCNIConfig{}},
+			},
+			new: &ClusterNetwork{
+				CNIConfig: &CNIConfig{None: &NoneCNIConfig{}},
+			},
+		},
 		{
 			name: "previous == new, new cniConfig format, same cni",
 			want: true,
@@ -2063,6 +2083,235 @@ func TestValidateNetworking(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "dual-stack valid",
+			wantErr: nil,
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+								"fd00:1::/56",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+								"fd00:2::/108",
+							},
+						},
+						Nodes: &Nodes{
+							CIDRMaskSize: nodeCidrMaskSize,
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "ipv6 only valid",
+			wantErr: nil,
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: DockerDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"fd00:1::/56",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"fd00:2::/108",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "too many pods CIDR blocks",
+			wantErr: fmt.Errorf("at most two CIDR blocks, one per IP family, can be specified for Pods"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+								"fd00:1::/56",
+								"10.0.0.0/16",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "too many services CIDR blocks",
+			wantErr: fmt.Errorf("at most two CIDR blocks, one per IP family, can be specified for Services"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+								"fd00:2::/108",
+								"10.0.0.0/16",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "dual-stack pods CIDR blocks same family",
+			wantErr: fmt.Errorf("dual-stack CIDR blocks for Pods must contain one IPv4 and one IPv6 block"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+								"10.0.0.0/16",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "dual-stack services CIDR blocks same family",
+			wantErr: fmt.Errorf("dual-stack CIDR blocks for Services must contain one IPv4 and one IPv6 block"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"10.96.0.0/12",
+								"10.0.0.0/16",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "pods and services with different ip families",
+			wantErr: fmt.Errorf("CIDR blocks for Pods and Services must have the same IP families in the same order"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"192.168.0.0/16",
+								"fd00:1::/56",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"fd00:2::/108",
+								"10.96.0.0/12",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "ipv6 not supported by provider",
+			wantErr: fmt.Errorf("IPv6 cluster networking is not supported for CloudStackDatacenterConfig"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: CloudStackDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"fd00:1::/56",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"fd00:2::/108",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
+		{
+			name:    "ipv6 pods CIDR block too big for default node mask",
+			wantErr: fmt.Errorf("pod subnet mask (40) and node-mask (64) difference is greater than 16"),
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods: Pods{
+							CidrBlocks: []string{
+								"fd00:1::/40",
+							},
+						},
+						Services: Services{
+							CidrBlocks: []string{
+								"fd00:2::/108",
+							},
+						},
+						CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2097,6 +2346,25 @@ func TestValidateCNIConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "user managed CNI and cilium specified",
+			wantErr: fmt.Errorf("validating cniConfig: cannot specify more than one cni plugins"),
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					Cilium: &CiliumConfig{},
+					None:   &NoneCNIConfig{},
+				},
+			},
+		},
+		{
+			name:    "user managed CNI",
+			wantErr: nil,
+			clusterNetwork: &ClusterNetwork{
+				CNIConfig: &CNIConfig{
+					None: &NoneCNIConfig{},
+				},
+			},
+		},
 		{
 			name:    "invalid cilium policy enforcement mode",
 			wantErr: fmt.Errorf("validating cniConfig: cilium policyEnforcementMode \"invalid\" not supported"),
@@ -2164,6 +2432,185 @@ func TestValidateCNIConfig(t *testing.T) {
 	}
 }
 
+func TestValidateControlPlaneLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantErr        string
+		loadBalancer   ControlPlaneLoadBalancerType
+		datacenterKind string
+	}{
+		{
+			name:           "default load balancer",
+			wantErr:        "",
+			loadBalancer:   "",
+			datacenterKind: DockerDatacenterKind,
+		},
+		{
+			name:           "kube-vip load balancer",
+			wantErr:        "",
+			loadBalancer:   KubeVipLoadBalancer,
+			datacenterKind: SnowDatacenterKind,
+		},
+		{
+			name:           "external load balancer",
+			wantErr:        "",
+			loadBalancer:   ExternalLoadBalancer,
+			datacenterKind: VSphereDatacenterKind,
+		},
+		{
+			name:           "external load balancer tinkerbell",
+			wantErr:        "",
+			loadBalancer:   ExternalLoadBalancer,
+			datacenterKind: TinkerbellDatacenterKind,
+		},
+		{
+			name:           "haproxy static pod load balancer",
+			wantErr:        "",
+			loadBalancer:   HAProxyStaticPodLoadBalancer,
+			datacenterKind: CloudStackDatacenterKind,
+		},
+		{
+			name:           "haproxy static pod load balancer not supported",
+			wantErr:        "control plane endpoint load balancer haproxy-static-pod is not supported for DockerDatacenterConfig",
+			loadBalancer:   HAProxyStaticPodLoadBalancer,
+			datacenterKind: DockerDatacenterKind,
+		},
+		{
+			name:           "external load balancer not supported",
+			wantErr:        "control plane endpoint load balancer external is not supported for SnowDatacenterConfig",
+			loadBalancer:   ExternalLoadBalancer,
+			datacenterKind: SnowDatacenterKind,
+		},
+		{
+			name:           "invalid load balancer",
+			wantErr:        "invalid control plane endpoint load balancer nginx, supported values are kube-vip, external and haproxy-static-pod",
+			loadBalancer:   "nginx",
+			datacenterKind: CloudStackDatacenterKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: &Endpoint{
+							Host:         "1.2.3.4",
+							LoadBalancer: tt.loadBalancer,
+						},
+					},
+					DatacenterRef: Ref{
+						Kind: tt.datacenterKind,
+					},
+				},
+			}
+			err := validateControlPlaneLoadBalancer(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateLoadBalancerConfiguration(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantErr        string
+		datacenterKind string
+		ipPools        []LoadBalancerIPPool
+	}{
+		{
+			name:           "valid pools",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools: []LoadBalancerIPPool{
+				{CIDR: "10.0.2.0/28"},
+				{Namespace: "apps", Range: "10.0.1.10-10.0.1.20"},
+			},
+		},
+		{
+			name:           "unsupported provider",
+			wantErr:        "loadBalancerConfiguration is not supported for DockerDatacenterConfig",
+			datacenterKind: DockerDatacenterKind,
+			ipPools:        []LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+		},
+		{
+			name:           "no pools",
+			wantErr:        "loadBalancerConfiguration must contain at least one IP pool",
+			datacenterKind: TinkerbellDatacenterKind,
+		},
+		{
+			name:           "cidr and range",
+			wantErr:        "invalid loadBalancerConfiguration IP pool for global: exactly one of cidr and range must be specified",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools:        []LoadBalancerIPPool{{CIDR: "10.0.2.0/28", Range: "10.0.1.10-10.0.1.20"}},
+		},
+		{
+			name:           "invalid range",
+			wantErr:        "invalid loadBalancerConfiguration IP pool for apps: invalid ip range 10.0.1.10",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools:        []LoadBalancerIPPool{{Namespace: "apps", Range: "10.0.1.10"}},
+		},
+		{
+			name:           "duplicated namespace",
+			wantErr:        "loadBalancerConfiguration can only have one IP pool per namespace, found multiple for apps",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools: []LoadBalancerIPPool{
+				{Namespace: "apps", CIDR: "10.0.2.0/28"},
+				{Namespace: "apps", Range: "10.0.1.10-10.0.1.20"},
+			},
+		},
+		{
+			name:           "overlaps with pods",
+			wantErr:        "loadBalancerConfiguration IP pool for global overlaps with cluster network CIDR 192.168.0.0/16",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools:        []LoadBalancerIPPool{{Range: "192.168.10.10-192.168.10.20"}},
+		},
+		{
+			name:           "contains control plane endpoint",
+			wantErr:        "loadBalancerConfiguration IP pool for global contains the control plane endpoint 10.0.0.5",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools:        []LoadBalancerIPPool{{Range: "10.0.0.1-10.0.0.10"}},
+		},
+		{
+			name:           "pools overlap",
+			wantErr:        "loadBalancerConfiguration IP pools for global and apps overlap",
+			datacenterKind: VSphereDatacenterKind,
+			ipPools: []LoadBalancerIPPool{
+				{CIDR: "10.0.1.0/24"},
+				{Namespace: "apps", Range: "10.0.1.10-10.0.1.20"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						Endpoint: &Endpoint{Host: "10.0.0.5"},
+					},
+					ClusterNetwork: ClusterNetwork{
+						Pods:     Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+						Services: Services{CidrBlocks: []string{"10.96.0.0/12"}},
+					},
+					DatacenterRef: Ref{Kind: tt.datacenterKind},
+					LoadBalancerConfiguration: &LoadBalancerConfiguration{
+						IPPools: tt.ipPools,
+					},
+				},
+			}
+			err := validateLoadBalancerConfiguration(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
 func TestValidateMirrorConfig(t *testing.T) {
 	tests := []struct {
 		name    string
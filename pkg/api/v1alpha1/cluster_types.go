@@ -8,6 +8,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
 )
 
 const (
@@ -49,6 +50,8 @@ type ClusterSpec struct {
 	RegistryMirrorConfiguration *RegistryMirrorConfiguration `json:"registryMirrorConfiguration,omitempty"`
 	ManagementCluster           ManagementCluster            `json:"managementCluster,omitempty"`
 	PodIAMConfig                *PodIAMConfig                `json:"podIamConfig,omitempty"`
+	// LoadBalancerConfiguration defines the kube-vip load balancer for services of type LoadBalancer.
+	LoadBalancerConfiguration *LoadBalancerConfiguration `json:"loadBalancerConfiguration,omitempty"`
 	// BundlesRef contains a reference to the Bundles containing the desired dependencies for the cluster
 	BundlesRef *BundlesRef `json:"bundlesRef,omitempty"`
 }
@@ -96,6 +99,12 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if !n.Spec.BundlesRef.Equal(o.Spec.BundlesRef) {
 		return false
 	}
+	if !n.Spec.LoadBalancerConfiguration.Equal(o.Spec.LoadBalancerConfiguration) {
+		return false
+	}
+	if !n.Spec.PodIAMConfig.Equal(o.Spec.PodIAMConfig) {
+		return false
+	}
 
 	return true
 }
@@ -215,6 +224,8 @@ func (n *ControlPlaneConfiguration) Equal(o *ControlPlaneConfiguration) bool {
 type Endpoint struct {
 	// Host defines the ip that you want to use to connect to the control plane
 	Host string `json:"host"`
+	// LoadBalancer defines how the control plane endpoint is load balanced. Defaults to kube-vip.
+	LoadBalancer ControlPlaneLoadBalancerType `json:"loadBalancer,omitempty"`
 }
 
 func (n *Endpoint) Equal(o *Endpoint) bool {
@@ -224,9 +235,29 @@ func (n *Endpoint) Equal(o *Endpoint) bool {
 	if n == nil || o == nil {
 		return false
 	}
-	return n.Host == o.Host
+	return n.Host == o.Host && n.LoadBalancerType() == o.LoadBalancerType()
+}
+
+// LoadBalancerType returns the control plane load balancer type, defaulting to kube-vip when not set.
+func (n *Endpoint) LoadBalancerType() ControlPlaneLoadBalancerType {
+	if n == nil || n.LoadBalancer == "" {
+		return KubeVipLoadBalancer
+	}
+	return n.LoadBalancer
 }
 
+// ControlPlaneLoadBalancerType defines the load balancer in front of the control plane endpoint.
+type ControlPlaneLoadBalancerType string
+
+const (
+	// KubeVipLoadBalancer runs kube-vip as a static pod in the control plane nodes to hold the endpoint VIP.
+	KubeVipLoadBalancer ControlPlaneLoadBalancerType = "kube-vip"
+	// ExternalLoadBalancer relies on a load balancer managed outside of EKS Anywhere to serve the endpoint.
+	ExternalLoadBalancer ControlPlaneLoadBalancerType = "external"
+	// HAProxyStaticPodLoadBalancer runs HAProxy as a static pod in the control plane nodes.
+	HAProxyStaticPodLoadBalancer ControlPlaneLoadBalancerType = "haproxy-static-pod"
+)
+
 type WorkerNodeGroupConfiguration struct {
 	// Name refers to the name of the worker node group
 	Name string `json:"name,omitempty"`
@@ -349,6 +380,49 @@ func (n *ClusterNetwork) Equal(o *ClusterNetwork) bool {
 	return n.Pods.Equal(&o.Pods) && n.Services.Equal(&o.Services) && n.DNS.Equal(&o.DNS)
 }
 
+// IPFamilies returns the IP families of the cluster networking, in the order of the pod CIDR blocks.
+// The first one is the primary IP family of the cluster.
+func (n *ClusterNetwork) IPFamilies() []IPFamily {
+	return cidrBlocksIPFamilies(n.Pods.CidrBlocks)
+}
+
+// PrimaryIPFamily returns the IP family of the first pod CIDR block. It defaults to IPv4.
+func (n *ClusterNetwork) PrimaryIPFamily() IPFamily {
+	families := n.IPFamilies()
+	if len(families) == 0 {
+		return IPv4Family
+	}
+	return families[0]
+}
+
+// IsDualStack returns true if the cluster networking uses both IPv4 and IPv6.
+func (n *ClusterNetwork) IsDualStack() bool {
+	families := n.IPFamilies()
+	return len(families) == 2 && families[0] != families[1]
+}
+
+// IsIPv6Enabled returns true if any of the pod or service CIDR blocks is IPv6.
+func (n *ClusterNetwork) IsIPv6Enabled() bool {
+	for _, family := range append(n.IPFamilies(), cidrBlocksIPFamilies(n.Services.CidrBlocks)...) {
+		if family == IPv6Family {
+			return true
+		}
+	}
+	return false
+}
+
+func cidrBlocksIPFamilies(cidrBlocks []string) []IPFamily {
+	families := make([]IPFamily, 0, len(cidrBlocks))
+	for _, cidrBlock := range cidrBlocks {
+		if networkutils.IsIPv6CIDR(cidrBlock) {
+			families = append(families, IPv6Family)
+		} else {
+			families = append(families, IPv4Family)
+		}
+	}
+	return families
+}
+
 func getCNIConfig(cn *ClusterNetwork) *CNIConfig {
 	/* Only needed since we're introducing CNIConfig to replace the deprecated CNI field. This way we can compare the individual fields
 	for the CNI plugin configuration*/
@@ -392,9 +466,17 @@ func (n *CNIConfig) Equal(o *CNIConfig) bool {
 	if !n.Kindnetd.Equal(o.Kindnetd) {
 		return false
 	}
+	if !n.None.Equal(o.None) {
+		return false
+	}
 	return true
 }
 
+// IsUserManaged returns true if the CNI plugin is installed and upgraded by the user instead of EKS-A.
+func (n *CNIConfig) IsUserManaged() bool {
+	return n != nil && n.None != nil
+}
+
 func (n *CiliumConfig) Equal(o *CiliumConfig) bool {
 	if n == o {
 		return true
@@ -415,6 +497,16 @@ func (n *KindnetdConfig) Equal(o *KindnetdConfig) bool {
 	return true
 }
 
+func (n *NoneCNIConfig) Equal(o *NoneCNIConfig) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return true
+}
+
 func UsersSliceEqual(a, b []UserConfiguration) bool {
 	if len(a) != len(b) {
 		return false
@@ -465,6 +557,9 @@ func CNIPluginSame(n ClusterNetwork, o ClusterNetwork) bool {
 			if (n.CNIConfig.Kindnetd != nil && o.CNIConfig.Kindnetd == nil) || (n.CNIConfig.Kindnetd == nil && o.CNIConfig.Kindnetd != nil) {
 				return false
 			}
+			if (n.CNIConfig.None != nil && o.CNIConfig.None == nil) || (n.CNIConfig.None == nil && o.CNIConfig.None != nil) {
+				return false
+			}
 		}
 	}
 
@@ -557,13 +652,25 @@ const (
 	Kube123 KubernetesVersion = "1.23"
 )
 
+// +kubebuilder:validation:Enum=cilium;cilium-enterprise;kindnetd
 type CNI string
 
+// IPFamily is the IP family used by the cluster networking.
+type IPFamily string
+
+const (
+	IPv4Family IPFamily = "IPv4"
+	IPv6Family IPFamily = "IPv6"
+)
+
+// +kubebuilder:validation:Enum=default;always;never
 type CiliumPolicyEnforcementMode string
 
 type CNIConfig struct {
 	Cilium   *CiliumConfig   `json:"cilium,omitempty"`
 	Kindnetd *KindnetdConfig `json:"kindnetd,omitempty"`
+	// None disables the CNI installation and upgrade. The user is responsible for installing a CNI plugin in the cluster.
+	None *NoneCNIConfig `json:"none,omitempty"`
 }
 
 type CiliumConfig struct {
@@ -573,6 +680,8 @@ type CiliumConfig struct {
 
 type KindnetdConfig struct{}
 
+type NoneCNIConfig struct{}
+
 const (
 	Cilium           CNI = "cilium"
 	CiliumEnterprise CNI = "cilium-enterprise"
@@ -605,6 +714,31 @@ type ClusterStatus struct {
 	EksdReleaseRef *EksdReleaseRef `json:"eksdReleaseRef,omitempty"`
 	// +optional
 	Conditions []clusterv1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the latest generation of the Cluster spec reconciled by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ControlPlane reports the node counts of the control plane
+	// +optional
+	ControlPlane *NodeGroupStatus `json:"controlPlane,omitempty"`
+	// WorkerNodeGroups reports the node counts of each worker node group
+	// +optional
+	WorkerNodeGroups []NodeGroupStatus `json:"workerNodeGroups,omitempty"`
+	// KubernetesVersion is the minimum Kubernetes version running in the control plane nodes
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// BundlesRef refers to the Bundles the cluster nodes were last fully rolled out with
+	// +optional
+	BundlesRef *BundlesRef `json:"bundlesRef,omitempty"`
+}
+
+// NodeGroupStatus defines the observed node counts of a group of nodes
+type NodeGroupStatus struct {
+	// Name refers to the name of the worker node group, empty for the control plane
+	Name string `json:"name,omitempty"`
+	// Replicas is the number of machines in the node group
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of machines in the node group with a ready node
+	ReadyReplicas int32 `json:"readyReplicas"`
 }
 
 type EksdReleaseRef struct {
@@ -689,6 +823,41 @@ func (n *ManagementCluster) Equal(o ManagementCluster) bool {
 	return n.Name == o.Name
 }
 
+// LoadBalancerConfiguration defines the kube-vip service load balancer deployed in the cluster.
+type LoadBalancerConfiguration struct {
+	// IPPools defines the addresses that can be assigned to services of type LoadBalancer.
+	IPPools []LoadBalancerIPPool `json:"ipPools"`
+}
+
+func (n *LoadBalancerConfiguration) Equal(o *LoadBalancerConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	if len(n.IPPools) != len(o.IPPools) {
+		return false
+	}
+	for i := range n.IPPools {
+		if n.IPPools[i] != o.IPPools[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadBalancerIPPool defines a set of addresses for services of type LoadBalancer.
+// Only one of CIDR and Range can be specified.
+type LoadBalancerIPPool struct {
+	// Namespace restricts the pool to the services in that namespace. Pools without a namespace are used for every namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// CIDR defines the pool addresses as a CIDR block.
+	CIDR string `json:"cidr,omitempty"`
+	// Range defines the pool addresses as a range of IPs in the form <start ip>-<end ip>.
+	Range string `json:"range,omitempty"`
+}
+
 type PodIAMConfig struct {
 	ServiceAccountIssuer string `json:"serviceAccountIssuer"`
 }
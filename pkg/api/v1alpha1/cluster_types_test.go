@@ -1168,6 +1168,39 @@ func TestClusterEqualDifferentBundlesRef(t *testing.T) {
 	g.Expect(cluster1.Equal(cluster2)).To(BeFalse())
 }
 
+func TestEndpointEqual(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		endpoint1, endpoint2 *v1alpha1.Endpoint
+		want                 bool
+	}{
+		{
+			testName:  "default load balancer equals kube-vip",
+			endpoint1: &v1alpha1.Endpoint{Host: "1.2.3.4"},
+			endpoint2: &v1alpha1.Endpoint{Host: "1.2.3.4", LoadBalancer: v1alpha1.KubeVipLoadBalancer},
+			want:      true,
+		},
+		{
+			testName:  "different load balancer",
+			endpoint1: &v1alpha1.Endpoint{Host: "1.2.3.4"},
+			endpoint2: &v1alpha1.Endpoint{Host: "1.2.3.4", LoadBalancer: v1alpha1.ExternalLoadBalancer},
+			want:      false,
+		},
+		{
+			testName:  "different host",
+			endpoint1: &v1alpha1.Endpoint{Host: "1.2.3.4"},
+			endpoint2: &v1alpha1.Endpoint{Host: "1.2.3.5"},
+			want:      false,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.endpoint1.Equal(tt.endpoint2)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestControlPlaneConfigurationEqual(t *testing.T) {
 	var emptyTaints []corev1.Taint
 	taint1 := corev1.Taint{Key: "key1"}
@@ -20,6 +20,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -30,9 +31,11 @@ import (
 // log is for logging in this package.
 var clusterlog = logf.Log.WithName("cluster-resource")
 
-func (r *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the Cluster webhooks. Validation rejections are recorded with recorder.
+func (r *Cluster) SetupWebhookWithManager(mgr ctrl.Manager, recorder record.EventRecorder) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(newRejectionRecorder(ClusterKind, recorder)).
 		Complete()
 }
 
@@ -155,38 +158,34 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Invalid(field.NewPath("spec", "GitOpsRef"), new.Spec.GitOpsRef, "field is immutable"))
 	}
 
-	if !old.IsSelfManaged() {
-		clusterlog.Info("Cluster config is associated with workload cluster", "name", old.Name)
-
-		oldAWSIamConfig, newAWSIamConfig := &Ref{}, &Ref{}
-		for _, identityProvider := range new.Spec.IdentityProviderRefs {
-			if identityProvider.Kind == AWSIamConfigKind {
-				newAWSIamConfig = &identityProvider
-			}
-		}
-
-		for _, identityProvider := range old.Spec.IdentityProviderRefs {
-			if identityProvider.Kind == AWSIamConfigKind {
-				oldAWSIamConfig = &identityProvider
-			}
+	// OIDC identity providers can be added, removed or replaced, the control plane is rolled out
+	// with the new configuration. The AWS IAM Authenticator can't be reconfigured.
+	oldAWSIamConfig, newAWSIamConfig := &Ref{}, &Ref{}
+	for _, identityProvider := range new.Spec.IdentityProviderRefs {
+		if identityProvider.Kind == AWSIamConfigKind {
+			newAWSIamConfig = &identityProvider
 		}
+	}
 
-		if !oldAWSIamConfig.Equal(newAWSIamConfig) {
-			allErrs = append(
-				allErrs,
-				field.Invalid(field.NewPath("spec", "AWS Iam Config"), newAWSIamConfig.Kind, "field is immutable"))
+	for _, identityProvider := range old.Spec.IdentityProviderRefs {
+		if identityProvider.Kind == AWSIamConfigKind {
+			oldAWSIamConfig = &identityProvider
 		}
-		return allErrs
 	}
 
-	clusterlog.Info("Cluster config is associated with management cluster", "name", old.Name)
-
-	if !RefSliceEqual(new.Spec.IdentityProviderRefs, old.Spec.IdentityProviderRefs) {
+	if !oldAWSIamConfig.Equal(newAWSIamConfig) {
 		allErrs = append(
 			allErrs,
-			field.Invalid(field.NewPath("spec", "IdentityProviderRefs"), new.Spec.IdentityProviderRefs, "field is immutable"))
+			field.Invalid(field.NewPath("spec", "AWS Iam Config"), newAWSIamConfig.Kind, "field is immutable"))
 	}
 
+	if !old.IsSelfManaged() {
+		clusterlog.Info("Cluster config is associated with workload cluster", "name", old.Name)
+		return allErrs
+	}
+
+	clusterlog.Info("Cluster config is associated with management cluster", "name", old.Name)
+
 	if old.Spec.KubernetesVersion != new.Spec.KubernetesVersion {
 		allErrs = append(
 			allErrs,
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions and condition reasons for the Cluster object.
+// The Ready condition (clusterv1.ReadyCondition) summarizes ControlPlaneReady, WorkersReady and CNIReady.
+
+const (
+	// ControlPlaneReadyCondition reports whether all the control plane machines are up to date and their nodes are ready.
+	ControlPlaneReadyCondition clusterv1.ConditionType = "ControlPlaneReady"
+
+	// WorkersReadyCondition reports whether all the worker machines are up to date and their nodes are ready.
+	WorkersReadyCondition clusterv1.ConditionType = "WorkersReady"
+
+	// CNIReadyCondition reports whether the pods of the CNI installed by EKS Anywhere are up to date and ready
+	// on all the nodes. It's not reported when the CNI is managed by the user.
+	CNIReadyCondition clusterv1.ConditionType = "CNIReady"
+
+	// DefaultCNIConfiguredCondition reports whether the CNI is installed and upgraded by EKS Anywhere.
+	DefaultCNIConfiguredCondition clusterv1.ConditionType = "DefaultCNIConfigured"
+
+	// ReadyForUpgradeCondition reports whether the cluster is ready and its last reconciliation succeeded,
+	// so it's safe to start an upgrade. It's not reported for self-managed clusters, which the controller doesn't reconcile.
+	ReadyForUpgradeCondition clusterv1.ConditionType = "ReadyForUpgrade"
+
+	// ReconcilePausedCondition is set to true while the reconciliation of the cluster is paused
+	// with the paused annotation. It's removed when the reconciliation resumes.
+	ReconcilePausedCondition clusterv1.ConditionType = "ReconcilePaused"
+)
+
+const (
+	// ControlPlaneInitializingReason is used when the control plane hasn't been created yet.
+	ControlPlaneInitializingReason = "ControlPlaneInitializing"
+
+	// WorkersInitializingReason is used when a worker node group hasn't been created yet.
+	WorkersInitializingReason = "WorkersInitializing"
+
+	// ScalingUpReason is used when a node group has fewer machines than requested.
+	ScalingUpReason = "ScalingUp"
+
+	// ScalingDownReason is used when a node group has more machines than requested.
+	ScalingDownReason = "ScalingDown"
+
+	// RollingUpgradeInProgressReason is used when some machines of a node group are not up to date.
+	RollingUpgradeInProgressReason = "RollingUpgradeInProgress"
+
+	// NodesNotReadyReason is used when some nodes of a node group are not ready.
+	NodesNotReadyReason = "NodesNotReady"
+
+	// CNINotInstalledReason is used when the CNI DaemonSet doesn't exist in the cluster.
+	CNINotInstalledReason = "CNINotInstalled"
+
+	// CNINotReadyReason is used when some CNI pods are not ready.
+	CNINotReadyReason = "CNINotReady"
+
+	// DefaultCNIDisabledReason is used when the CNI is managed by the user.
+	DefaultCNIDisabledReason = "DefaultCNIDisabled"
+
+	// ClusterNotReadyReason is used when the control plane, the workers or the CNI are not ready.
+	ClusterNotReadyReason = "ClusterNotReady"
+
+	// ReconciliationFailedReason is used when the cluster is not ready for an upgrade because its last
+	// reconciliation failed.
+	ReconciliationFailedReason = "ReconciliationFailed"
+
+	// ReconciliationInProgressReason is used when the cluster is not ready for an upgrade because the
+	// controller hasn't reconciled its latest spec yet.
+	ReconciliationInProgressReason = "ReconciliationInProgress"
+)
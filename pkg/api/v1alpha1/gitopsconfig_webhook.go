@@ -6,6 +6,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -14,9 +15,11 @@ import (
 // log is for logging in this package.
 var gitopsconfiglog = logf.Log.WithName("gitopsconfig-resource")
 
-func (r *GitOpsConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the GitOpsConfig webhooks. Validation rejections are recorded with recorder.
+func (r *GitOpsConfig) SetupWebhookWithManager(mgr ctrl.Manager, recorder record.EventRecorder) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(newRejectionRecorder(GitOpsConfigKind, recorder)).
 		Complete()
 }
 
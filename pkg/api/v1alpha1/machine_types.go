@@ -1,5 +1,6 @@
 package v1alpha1
 
+// +kubebuilder:validation:Enum=ubuntu;bottlerocket
 type OSFamily string
 
 const (
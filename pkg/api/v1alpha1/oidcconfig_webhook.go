@@ -5,7 +5,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -14,9 +14,11 @@ import (
 // log is for logging in this package.
 var oidcconfiglog = logf.Log.WithName("oidcconfig-resource")
 
-func (r *OIDCConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the OIDCConfig webhooks. Validation rejections are recorded with recorder.
+func (r *OIDCConfig) SetupWebhookWithManager(mgr ctrl.Manager, recorder record.EventRecorder) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(newRejectionRecorder(OIDCConfigKind, recorder)).
 		Complete()
 }
 
@@ -42,21 +44,14 @@ func (r *OIDCConfig) ValidateUpdate(old runtime.Object) error {
 	}
 
 	if oldOIDCConfig.IsManaged() {
-		clusterlog.Info("OIDC config is associated with workload cluster", "name", oldOIDCConfig.Name)
-		return nil
+		oidcconfiglog.Info("OIDC config is associated with workload cluster", "name", oldOIDCConfig.Name)
+	} else {
+		oidcconfiglog.Info("OIDC config is associated with management cluster", "name", oldOIDCConfig.Name)
 	}
 
-	clusterlog.Info("OIDC config is associated with management cluster", "name", oldOIDCConfig.Name)
-
-	var allErrs field.ErrorList
-
-	allErrs = append(allErrs, validateImmutableOIDCFields(r, oldOIDCConfig)...)
-
-	if len(allErrs) == 0 {
-		return nil
-	}
-
-	return apierrors.NewInvalid(GroupVersion.WithKind(OIDCConfigKind).GroupKind(), r.Name, allErrs)
+	// OIDC configs are mutable for both management and workload clusters. Changes are rolled out to
+	// the control plane of the clusters referencing the config.
+	return nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -65,16 +60,3 @@ func (r *OIDCConfig) ValidateDelete() error {
 
 	return nil
 }
-
-func validateImmutableOIDCFields(new, old *OIDCConfig) field.ErrorList {
-	var allErrs field.ErrorList
-
-	if !new.Spec.Equal(&old.Spec) {
-		allErrs = append(
-			allErrs,
-			field.Invalid(field.NewPath("spec", "OIDCConfig"), new, "config is immutable"),
-		)
-	}
-
-	return allErrs
-}
@@ -16,7 +16,7 @@ func TestValidateUpdateOIDCClientIdMgmtCluster(t *testing.T) {
 
 	c.Spec.ClientId = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCGroupsClaimMgmtCluster(t *testing.T) {
@@ -26,7 +26,7 @@ func TestValidateUpdateOIDCGroupsClaimMgmtCluster(t *testing.T) {
 
 	c.Spec.GroupsClaim = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCGroupsPrefixMgmtCluster(t *testing.T) {
@@ -36,7 +36,7 @@ func TestValidateUpdateOIDCGroupsPrefixMgmtCluster(t *testing.T) {
 
 	c.Spec.GroupsPrefix = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCIssuerUrlMgmtCluster(t *testing.T) {
@@ -46,7 +46,7 @@ func TestValidateUpdateOIDCIssuerUrlMgmtCluster(t *testing.T) {
 
 	c.Spec.IssuerUrl = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCUsernameClaimMgmtCluster(t *testing.T) {
@@ -56,7 +56,7 @@ func TestValidateUpdateOIDCUsernameClaimMgmtCluster(t *testing.T) {
 
 	c.Spec.UsernameClaim = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCUsernamePrefixMgmtCluster(t *testing.T) {
@@ -66,7 +66,7 @@ func TestValidateUpdateOIDCUsernamePrefixMgmtCluster(t *testing.T) {
 
 	c.Spec.UsernamePrefix = "test2"
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCRequiredClaimsMgmtCluster(t *testing.T) {
@@ -76,7 +76,7 @@ func TestValidateUpdateOIDCRequiredClaimsMgmtCluster(t *testing.T) {
 
 	c.Spec.RequiredClaims = []v1alpha1.OIDCConfigRequiredClaim{{Claim: "test", Value: "value2"}}
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestValidateUpdateOIDCRequiredClaimsMultipleMgmtCluster(t *testing.T) {
@@ -89,7 +89,7 @@ func TestValidateUpdateOIDCRequiredClaimsMultipleMgmtCluster(t *testing.T) {
 		Value: "value2",
 	})
 	o := NewWithT(t)
-	o.Expect(c.ValidateUpdate(&ocOld)).NotTo(Succeed())
+	o.Expect(c.ValidateUpdate(&ocOld)).To(Succeed())
 }
 
 func TestClusterValidateUpdateOIDCclientIdMutableUpdateNameWorkloadCluster(t *testing.T) {
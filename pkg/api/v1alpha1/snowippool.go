@@ -0,0 +1,94 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+)
+
+const SnowIPPoolKind = "SnowIPPool"
+
+// maxSnowIPPoolSize caps the size of a SnowIPPool. IPv6 ranges can hold more addresses than an int,
+// and no cluster needs more than this.
+const maxSnowIPPoolSize = math.MaxInt32
+
+// Size returns the number of ip addresses in all the pools, capped at math.MaxInt32.
+func (s *SnowIPPool) Size() int {
+	size := new(big.Int)
+	for _, pool := range s.Spec.Pools {
+		start, end := net.ParseIP(pool.IPStart), net.ParseIP(pool.IPEnd)
+		if start == nil || end == nil {
+			continue
+		}
+		diff := new(big.Int).Sub(new(big.Int).SetBytes(end.To16()), new(big.Int).SetBytes(start.To16()))
+		if diff.Sign() >= 0 {
+			size.Add(size, diff.Add(diff, big.NewInt(1)))
+		}
+	}
+
+	if size.Cmp(big.NewInt(maxSnowIPPoolSize)) > 0 {
+		return maxSnowIPPoolSize
+	}
+	return int(size.Int64())
+}
+
+func validateSnowIPPool(pool *SnowIPPool) error {
+	if len(pool.Spec.Pools) == 0 {
+		return errors.New("SnowIPPool Pools must contain at least one pool")
+	}
+
+	for i, p := range pool.Spec.Pools {
+		if err := validateIPPool(p); err != nil {
+			return fmt.Errorf("SnowIPPool Pools[%d]: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateIPPool(pool IPPool) error {
+	start := net.ParseIP(pool.IPStart)
+	if start == nil {
+		return fmt.Errorf("ipStart %s is invalid", pool.IPStart)
+	}
+
+	end := net.ParseIP(pool.IPEnd)
+	if end == nil {
+		return fmt.Errorf("ipEnd %s is invalid", pool.IPEnd)
+	}
+
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return fmt.Errorf("ipStart %s and ipEnd %s must be of the same ip family", pool.IPStart, pool.IPEnd)
+	}
+
+	if bytes.Compare(start.To16(), end.To16()) > 0 {
+		return fmt.Errorf("ipStart %s must be before ipEnd %s", pool.IPStart, pool.IPEnd)
+	}
+
+	_, subnet, err := net.ParseCIDR(pool.Subnet)
+	if err != nil {
+		return fmt.Errorf("subnet %s is invalid: %v", pool.Subnet, err)
+	}
+
+	if !subnet.Contains(start) || !subnet.Contains(end) {
+		return fmt.Errorf("ip range %s - %s is not within subnet %s", pool.IPStart, pool.IPEnd, pool.Subnet)
+	}
+
+	gateway := net.ParseIP(pool.Gateway)
+	if gateway == nil {
+		return fmt.Errorf("gateway %s is invalid", pool.Gateway)
+	}
+
+	if !subnet.Contains(gateway) {
+		return fmt.Errorf("gateway %s is not within subnet %s", pool.Gateway, pool.Subnet)
+	}
+
+	if bytes.Compare(gateway.To16(), start.To16()) >= 0 && bytes.Compare(gateway.To16(), end.To16()) <= 0 {
+		return fmt.Errorf("gateway %s must not be within ip range %s - %s", pool.Gateway, pool.IPStart, pool.IPEnd)
+	}
+
+	return nil
+}
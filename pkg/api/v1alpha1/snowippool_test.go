@@ -0,0 +1,168 @@
+package v1alpha1
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSnowIPPoolValidate(t *testing.T) {
+	validPool := func() IPPool {
+		return IPPool{
+			IPStart: "10.0.0.10",
+			IPEnd:   "10.0.0.20",
+			Subnet:  "10.0.0.0/24",
+			Gateway: "10.0.0.1",
+		}
+	}
+	tests := []struct {
+		name    string
+		pools   func() []IPPool
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			pools:   func() []IPPool { return []IPPool{validPool()} },
+			wantErr: "",
+		},
+		{
+			name:    "no pools",
+			pools:   func() []IPPool { return nil },
+			wantErr: "SnowIPPool Pools must contain at least one pool",
+		},
+		{
+			name: "invalid ip start",
+			pools: func() []IPPool {
+				p := validPool()
+				p.IPStart = "10.0.0"
+				return []IPPool{p}
+			},
+			wantErr: "SnowIPPool Pools[0]: ipStart 10.0.0 is invalid",
+		},
+		{
+			name: "invalid ip end",
+			pools: func() []IPPool {
+				p := validPool()
+				p.IPEnd = ""
+				return []IPPool{p}
+			},
+			wantErr: "ipEnd  is invalid",
+		},
+		{
+			name: "start after end",
+			pools: func() []IPPool {
+				p := validPool()
+				p.IPStart = "10.0.0.30"
+				return []IPPool{p}
+			},
+			wantErr: "ipStart 10.0.0.30 must be before ipEnd 10.0.0.20",
+		},
+		{
+			name: "mixed ip families",
+			pools: func() []IPPool {
+				p := validPool()
+				p.IPEnd = "fd00::1"
+				return []IPPool{p}
+			},
+			wantErr: "ipStart 10.0.0.10 and ipEnd fd00::1 must be of the same ip family",
+		},
+		{
+			name: "invalid subnet",
+			pools: func() []IPPool {
+				p := validPool()
+				p.Subnet = "10.0.0.0"
+				return []IPPool{p}
+			},
+			wantErr: "subnet 10.0.0.0 is invalid",
+		},
+		{
+			name: "range outside subnet",
+			pools: func() []IPPool {
+				p := validPool()
+				p.IPEnd = "10.0.1.20"
+				return []IPPool{p}
+			},
+			wantErr: "ip range 10.0.0.10 - 10.0.1.20 is not within subnet 10.0.0.0/24",
+		},
+		{
+			name: "gateway outside subnet",
+			pools: func() []IPPool {
+				p := validPool()
+				p.Gateway = "10.0.1.1"
+				return []IPPool{p}
+			},
+			wantErr: "gateway 10.0.1.1 is not within subnet 10.0.0.0/24",
+		},
+		{
+			name: "gateway in range",
+			pools: func() []IPPool {
+				p := validPool()
+				p.Gateway = "10.0.0.15"
+				return []IPPool{p}
+			},
+			wantErr: "gateway 10.0.0.15 must not be within ip range 10.0.0.10 - 10.0.0.20",
+		},
+		{
+			name: "second pool invalid",
+			pools: func() []IPPool {
+				p := validPool()
+				p.Gateway = "invalid"
+				return []IPPool{validPool(), p}
+			},
+			wantErr: "SnowIPPool Pools[1]: gateway invalid is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			pool := &SnowIPPool{Spec: SnowIPPoolSpec{Pools: tt.pools()}}
+			err := pool.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestSnowIPPoolSize(t *testing.T) {
+	g := NewWithT(t)
+	pool := &SnowIPPool{
+		Spec: SnowIPPoolSpec{
+			Pools: []IPPool{
+				{IPStart: "10.0.0.10", IPEnd: "10.0.0.20"},
+				{IPStart: "10.0.1.255", IPEnd: "10.0.2.0"},
+				{IPStart: "10.0.3.5", IPEnd: "10.0.3.5"},
+				{IPStart: "invalid", IPEnd: "10.0.3.5"},
+			},
+		},
+	}
+	g.Expect(pool.Size()).To(Equal(14))
+}
+
+func TestSnowIPPoolSizeIPv6(t *testing.T) {
+	g := NewWithT(t)
+	pool := &SnowIPPool{
+		Spec: SnowIPPoolSpec{
+			Pools: []IPPool{
+				{IPStart: "fd00::10", IPEnd: "fd00::1f"},
+			},
+		},
+	}
+	g.Expect(pool.Size()).To(Equal(16))
+}
+
+func TestSnowIPPoolSizeCapped(t *testing.T) {
+	g := NewWithT(t)
+	pool := &SnowIPPool{
+		Spec: SnowIPPoolSpec{
+			Pools: []IPPool{
+				{IPStart: "fd00::", IPEnd: "fd00::ffff:ffff:ffff:ffff"},
+				{IPStart: "fd01::", IPEnd: "fd01::ffff:ffff:ffff:ffff"},
+			},
+		},
+	}
+	g.Expect(pool.Size()).To(Equal(math.MaxInt32))
+}
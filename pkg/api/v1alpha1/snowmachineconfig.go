@@ -59,6 +59,69 @@ func validateSnowMachineConfig(config *SnowMachineConfig) error {
 	if len(config.Spec.Devices) == 0 {
 		return errors.New("SnowMachineConfig Devices must contain at least one device IP")
 	}
+
+	switch config.Spec.PlacementPolicy {
+	case "", SnowPlacementSpread, SnowPlacementPack:
+	default:
+		return fmt.Errorf("SnowMachineConfig PlacementPolicy %s is not supported, please use one of the following: %s, %s", config.Spec.PlacementPolicy, SnowPlacementSpread, SnowPlacementPack)
+	}
+
+	if config.Spec.Network != nil {
+		if err := validateSnowNetwork(config.Spec.Network); err != nil {
+			return fmt.Errorf("SnowMachineConfig Network: %v", err)
+		}
+	}
+	return nil
+}
+
+const (
+	maxSnowDirectNetworkInterfaces = 8
+	maxVlanID                      = 4095
+)
+
+func validateSnowNetwork(network *SnowNetwork) error {
+	dnis := network.DirectNetworkInterfaces
+	if len(dnis) == 0 {
+		return errors.New("directNetworkInterfaces must contain at least one DNI")
+	}
+
+	if len(dnis) > maxSnowDirectNetworkInterfaces {
+		return fmt.Errorf("directNetworkInterfaces can contain at most %d DNIs", maxSnowDirectNetworkInterfaces)
+	}
+
+	indexes := make(map[int]struct{}, len(dnis))
+	primaries := 0
+	for _, dni := range dnis {
+		if dni.Index < 1 || dni.Index > maxSnowDirectNetworkInterfaces {
+			return fmt.Errorf("DNI index %d must be between 1 and %d", dni.Index, maxSnowDirectNetworkInterfaces)
+		}
+
+		if _, ok := indexes[dni.Index]; ok {
+			return fmt.Errorf("DNI index %d is duplicated", dni.Index)
+		}
+		indexes[dni.Index] = struct{}{}
+
+		if dni.VlanID != nil && (*dni.VlanID < 0 || *dni.VlanID > maxVlanID) {
+			return fmt.Errorf("DNI %d vlanID %d must be between 0 and %d", dni.Index, *dni.VlanID, maxVlanID)
+		}
+
+		if dni.DHCP == (dni.IPPoolRef != nil) {
+			return fmt.Errorf("DNI %d must use either dhcp or an ipPoolRef", dni.Index)
+		}
+
+		if dni.IPPoolRef != nil && dni.IPPoolRef.Kind != SnowIPPoolKind {
+			return fmt.Errorf("DNI %d ipPoolRef kind %s is not supported, please use %s", dni.Index, dni.IPPoolRef.Kind, SnowIPPoolKind)
+		}
+
+		if dni.Primary {
+			primaries++
+		}
+	}
+
+	if primaries != 1 {
+		return errors.New("directNetworkInterfaces must contain exactly one primary DNI")
+	}
+
 	return nil
 }
 
@@ -72,4 +135,23 @@ func setSnowMachineConfigDefaults(config *SnowMachineConfig) {
 		config.Spec.PhysicalNetworkConnector = DefaultSnowPhysicalNetworkConnectorType
 		logger.V(1).Info("SnowMachineConfig PhysicalNetworkConnector is empty. Using default", "default physical network connector", DefaultSnowPhysicalNetworkConnectorType)
 	}
+
+	if config.Spec.Network != nil && len(config.Spec.Network.DirectNetworkInterfaces) == 1 {
+		config.Spec.Network.DirectNetworkInterfaces[0].Primary = true
+	}
+}
+
+// IPPoolRefs returns the references to the SnowIPPools used by the machine DNIs.
+func (s *SnowMachineConfig) IPPoolRefs() []Ref {
+	if s.Spec.Network == nil {
+		return nil
+	}
+
+	var refs []Ref
+	for _, dni := range s.Spec.Network.DirectNetworkInterfaces {
+		if dni.IPPoolRef != nil {
+			refs = append(refs, *dni.IPPoolRef)
+		}
+	}
+	return refs
 }
@@ -123,6 +123,117 @@ func TestSnowValidate(t *testing.T) {
 			},
 			wantErr: "Devices must contain at least one device IP",
 		},
+		{
+			name: "pack placement policy",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:           "ami-1",
+					InstanceType:    DefaultSnowInstanceType,
+					Devices:         []string{"1.2.3.4"},
+					PlacementPolicy: SnowPlacementPack,
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid placement policy",
+			obj: &SnowMachineConfig{
+				Spec: SnowMachineConfigSpec{
+					AMIID:           "ami-1",
+					InstanceType:    DefaultSnowInstanceType,
+					Devices:         []string{"1.2.3.4"},
+					PlacementPolicy: "random",
+				},
+			},
+			wantErr: "PlacementPolicy random is not supported",
+		},
+		{
+			name: "valid network",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true},
+				SnowDirectNetworkInterface{Index: 2, VlanID: int32Ptr(100), IPPoolRef: &Ref{Kind: SnowIPPoolKind, Name: "pool"}},
+			),
+			wantErr: "",
+		},
+		{
+			name:    "empty network",
+			obj:     givenSnowMachineConfigWithNetwork(),
+			wantErr: "SnowMachineConfig Network: directNetworkInterfaces must contain at least one DNI",
+		},
+		{
+			name: "too many dnis",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true},
+				SnowDirectNetworkInterface{Index: 2, DHCP: true},
+				SnowDirectNetworkInterface{Index: 3, DHCP: true},
+				SnowDirectNetworkInterface{Index: 4, DHCP: true},
+				SnowDirectNetworkInterface{Index: 5, DHCP: true},
+				SnowDirectNetworkInterface{Index: 6, DHCP: true},
+				SnowDirectNetworkInterface{Index: 7, DHCP: true},
+				SnowDirectNetworkInterface{Index: 8, DHCP: true},
+				SnowDirectNetworkInterface{Index: 9, DHCP: true},
+			),
+			wantErr: "directNetworkInterfaces can contain at most 8 DNIs",
+		},
+		{
+			name: "invalid dni index",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 0, DHCP: true, Primary: true},
+			),
+			wantErr: "DNI index 0 must be between 1 and 8",
+		},
+		{
+			name: "duplicated dni index",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true},
+				SnowDirectNetworkInterface{Index: 1, DHCP: true},
+			),
+			wantErr: "DNI index 1 is duplicated",
+		},
+		{
+			name: "invalid vlan id",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true, VlanID: int32Ptr(4096)},
+			),
+			wantErr: "DNI 1 vlanID 4096 must be between 0 and 4095",
+		},
+		{
+			name: "dhcp and ip pool",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true, IPPoolRef: &Ref{Kind: SnowIPPoolKind, Name: "pool"}},
+			),
+			wantErr: "DNI 1 must use either dhcp or an ipPoolRef",
+		},
+		{
+			name: "neither dhcp nor ip pool",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, Primary: true},
+			),
+			wantErr: "DNI 1 must use either dhcp or an ipPoolRef",
+		},
+		{
+			name: "invalid ip pool kind",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, Primary: true, IPPoolRef: &Ref{Kind: "IPPool", Name: "pool"}},
+			),
+			wantErr: "DNI 1 ipPoolRef kind IPPool is not supported",
+		},
+		{
+			name: "no primary dni",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true},
+				SnowDirectNetworkInterface{Index: 2, DHCP: true},
+			),
+			wantErr: "directNetworkInterfaces must contain exactly one primary DNI",
+		},
+		{
+			name: "multiple primary dnis",
+			obj: givenSnowMachineConfigWithNetwork(
+				SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true},
+				SnowDirectNetworkInterface{Index: 2, DHCP: true, Primary: true},
+			),
+			wantErr: "directNetworkInterfaces must contain exactly one primary DNI",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -137,6 +248,40 @@ func TestSnowValidate(t *testing.T) {
 	}
 }
 
+func givenSnowMachineConfigWithNetwork(dnis ...SnowDirectNetworkInterface) *SnowMachineConfig {
+	return &SnowMachineConfig{
+		Spec: SnowMachineConfigSpec{
+			AMIID:        "ami-1",
+			InstanceType: DefaultSnowInstanceType,
+			Devices:      []string{"1.2.3.4"},
+			Network: &SnowNetwork{
+				DirectNetworkInterfaces: dnis,
+			},
+		},
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestSnowSetDefaultsSinglePrimaryDNI(t *testing.T) {
+	g := NewWithT(t)
+	m := givenSnowMachineConfigWithNetwork(SnowDirectNetworkInterface{Index: 1, DHCP: true})
+	m.SetDefaults()
+	g.Expect(m.Spec.Network.DirectNetworkInterfaces[0].Primary).To(BeTrue())
+}
+
+func TestSnowMachineConfigIPPoolRefs(t *testing.T) {
+	g := NewWithT(t)
+	m := givenSnowMachineConfigWithNetwork(
+		SnowDirectNetworkInterface{Index: 1, DHCP: true, Primary: true},
+		SnowDirectNetworkInterface{Index: 2, IPPoolRef: &Ref{Kind: SnowIPPoolKind, Name: "pool"}},
+	)
+	g.Expect(m.IPPoolRefs()).To(Equal([]Ref{{Kind: SnowIPPoolKind, Name: "pool"}}))
+	g.Expect((&SnowMachineConfig{}).IPPoolRefs()).To(BeNil())
+}
+
 func TestSetControlPlaneAnnotation(t *testing.T) {
 	g := NewWithT(t)
 	m := &SnowMachineConfig{}
@@ -10,10 +10,17 @@ const (
 	SbeCXLarge  SnowInstanceType = "sbe-c.xlarge"
 	SbeC2XLarge SnowInstanceType = "sbe-c.2xlarge"
 	SbeC4XLarge SnowInstanceType = "sbe-c.4xlarge"
+
+	SnowPlacementSpread SnowPlacementPolicy = "spread"
+	SnowPlacementPack   SnowPlacementPolicy = "pack"
 )
 
 type PhysicalNetworkConnectorType string
 
+// +kubebuilder:validation:Enum=spread;pack
+type SnowPlacementPolicy string
+
+// +kubebuilder:validation:Enum=sbe-c.large;sbe-c.xlarge;sbe-c.2xlarge;sbe-c.4xlarge
 type SnowInstanceType string
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -38,6 +45,39 @@ type SnowMachineConfigSpec struct {
 
 	// Devices contains a device ip list assigned by the user to provision machines.
 	Devices []string `json:"devices,omitempty"`
+
+	// PlacementPolicy determines how machines are assigned to devices.
+	// Valid values: "spread" (default) distributes machines evenly across all devices and "pack" places all machines on the first device.
+	PlacementPolicy SnowPlacementPolicy `json:"placementPolicy,omitempty"`
+
+	// Network provides the custom network setting for the machine.
+	// When omitted, the machine gets a single DHCP direct network interface using PhysicalNetworkConnector.
+	Network *SnowNetwork `json:"network,omitempty"`
+}
+
+// SnowNetwork specifies the network configurations for snow.
+type SnowNetwork struct {
+	// DirectNetworkInterfaces contains a list of direct network interface (DNI) configuration.
+	DirectNetworkInterfaces []SnowDirectNetworkInterface `json:"directNetworkInterfaces,omitempty"`
+}
+
+// SnowDirectNetworkInterface defines a direct network interface (DNI) configuration.
+type SnowDirectNetworkInterface struct {
+	// Index is the index number of DNI used to clarify the position in the list. Usually starts with 1.
+	Index int `json:"index,omitempty"`
+
+	// VlanID is the vlan id assigned by the user for the DNI.
+	VlanID *int32 `json:"vlanID,omitempty"`
+
+	// DHCP defines whether DHCP is used to assign ip for the DNI.
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// IPPool contains a reference to a snow ip pool which provides a range of ip addresses.
+	// When specified, an ip address selected from the pool is allocated to this DNI.
+	IPPoolRef *Ref `json:"ipPoolRef,omitempty"`
+
+	// Primary indicates whether the DNI is primary or not.
+	Primary bool `json:"primary,omitempty"`
 }
 
 func (s *SnowMachineConfig) SetManagedBy(clusterName string) {
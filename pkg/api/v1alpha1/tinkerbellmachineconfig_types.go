@@ -10,10 +10,11 @@ import (
 
 // TinkerbellMachineConfigSpec defines the desired state of TinkerbellMachineConfig
 type TinkerbellMachineConfigSpec struct {
-	HardwareSelector HardwareSelector    `json:"hardwareSelector"`
-	TemplateRef      Ref                 `json:"templateRef,omitempty"`
-	OSFamily         OSFamily            `json:"osFamily"`
-	Users            []UserConfiguration `json:"users,omitempty"`
+	HardwareSelector HardwareSelector `json:"hardwareSelector"`
+	TemplateRef      Ref              `json:"templateRef,omitempty"`
+	// +kubebuilder:validation:Required
+	OSFamily OSFamily            `json:"osFamily,omitempty"`
+	Users    []UserConfiguration `json:"users,omitempty"`
 }
 
 // HardwareSelector models a simple key-value selector used in Tinkerbell providioning.
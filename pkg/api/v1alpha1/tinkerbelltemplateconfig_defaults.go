@@ -39,7 +39,8 @@ warnings:
 `
 )
 
-func getDiskPart(disk string) string {
+// GetDiskPart returns the prefix of the partition device paths for disk.
+func GetDiskPart(disk string) string {
 	switch {
 	case strings.Contains(disk, "nvme"):
 		return fmt.Sprintf("%sp", disk)
@@ -61,7 +62,7 @@ func GetDefaultActionsFromBundle(b v1alpha1.VersionsBundle, disk, osImageOverrid
 	metadataUrls := fmt.Sprintf("http://%s:50061,http://%s:50061", tinkerbellLocalIp, tinkerbellLBIp)
 
 	if osFamily == Bottlerocket {
-		diskPart = fmt.Sprintf("%s12", getDiskPart(disk))
+		diskPart = fmt.Sprintf("%s12", GetDiskPart(disk))
 		defaultActions = append(defaultActions,
 			withNetplanAction(b, diskPart, osFamily),
 			withBottlerocketBootconfigAction(b, diskPart),
@@ -69,7 +70,7 @@ func GetDefaultActionsFromBundle(b v1alpha1.VersionsBundle, disk, osImageOverrid
 			withRebootAction(b),
 		)
 	} else {
-		diskPart = fmt.Sprintf("%s2", getDiskPart(disk))
+		diskPart = fmt.Sprintf("%s2", GetDiskPart(disk))
 		defaultActions = append(defaultActions,
 			withNetplanAction(b, diskPart, osFamily),
 			withDisableCloudInitNetworkCapabilities(b, diskPart),
@@ -162,6 +162,30 @@ func TestGetTinkerbellTemplateConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			testName: "tinkerbell template config referencing a template library",
+			fileName: "testdata/cluster_1_21_valid_tinkerbell_template_library.yaml",
+			wantConfigs: map[string]*TinkerbellTemplateConfig{
+				"tink-test-library": {
+					TypeMeta: metav1.TypeMeta{
+						Kind:       TinkerbellTemplateConfigKind,
+						APIVersion: SchemeBuilder.GroupVersion.String(),
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "tink-test-library",
+					},
+					Spec: TinkerbellTemplateConfigSpec{
+						Library: &TinkerbellTemplateLibraryRef{
+							Name:       "eks-anywhere",
+							Version:    "v1",
+							Template:   "ubuntu-raid1",
+							Parameters: map[string]string{"raidDisk1": "/dev/sdb"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
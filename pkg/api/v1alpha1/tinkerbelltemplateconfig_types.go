@@ -15,7 +15,24 @@ import (
 // TinkerbellTemplateConfigSpec defines the desired state of TinkerbellTemplateConfig
 type TinkerbellTemplateConfigSpec struct {
 	// Template defines a Tinkerbell workflow template with specific tasks and actions.
-	Template tinkerbell.Workflow `json:"template"`
+	Template tinkerbell.Workflow `json:"template,omitempty"`
+	// Library references a template from a template library instead of defining it in Template.
+	Library *TinkerbellTemplateLibraryRef `json:"library,omitempty"`
+}
+
+// TinkerbellTemplateLibraryRef references a template in a named and versioned template library.
+type TinkerbellTemplateLibraryRef struct {
+	// Name of the template library. The library shipped in the bundle is named eks-anywhere.
+	Name string `json:"name"`
+	// Version of the template library.
+	Version string `json:"version"`
+	// Template is the name of the template in the library.
+	Template string `json:"template"`
+	// Source is an https URL or a local path to the template library, for example the raw URL of
+	// a file in a git repository. The library shipped in the bundle is used when empty.
+	Source string `json:"source,omitempty"`
+	// Parameters sets template parameters, taking precedence over values read from hardware labels.
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // TinkerbellTemplateConfigStatus defines the observed state of TinkerbellTemplateConfig
@@ -17,6 +17,8 @@ type VSphereDatacenterConfigSpec struct {
 	Datacenter string `json:"datacenter"`
 	Network    string `json:"network"`
 	Server     string `json:"server"`
+	// Thumbprint is only needed when the vCenter certificate is self-signed and insecure is false.
+	// +kubebuilder:validation:Optional
 	Thumbprint string `json:"thumbprint"`
 	Insecure   bool   `json:"insecure"`
 }
@@ -20,6 +20,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -30,9 +31,11 @@ import (
 // log is for logging in this package.
 var vspheredatacenterconfiglog = logf.Log.WithName("vspheredatacenterconfig-resource")
 
-func (r *VSphereDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the VSphereDatacenterConfig webhooks. Validation rejections are recorded with recorder.
+func (r *VSphereDatacenterConfig) SetupWebhookWithManager(mgr ctrl.Manager, recorder record.EventRecorder) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(newRejectionRecorder(VSphereDatacenterKind, recorder)).
 		Complete()
 }
 
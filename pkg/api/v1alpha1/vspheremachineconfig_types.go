@@ -8,12 +8,18 @@ import (
 
 // VSphereMachineConfigSpec defines the desired state of VSphereMachineConfig
 type VSphereMachineConfigSpec struct {
-	DiskGiB           int                 `json:"diskGiB,omitempty"`
-	Datastore         string              `json:"datastore"`
-	Folder            string              `json:"folder"`
-	NumCPUs           int                 `json:"numCPUs"`
-	MemoryMiB         int                 `json:"memoryMiB"`
-	OSFamily          OSFamily            `json:"osFamily"`
+	DiskGiB   int    `json:"diskGiB,omitempty"`
+	Datastore string `json:"datastore"`
+	Folder    string `json:"folder"`
+	// NumCPUs defaults to 2.
+	// +kubebuilder:validation:Optional
+	NumCPUs int `json:"numCPUs"`
+	// MemoryMiB defaults to 8192.
+	// +kubebuilder:validation:Optional
+	MemoryMiB int `json:"memoryMiB"`
+	// OSFamily defaults to bottlerocket.
+	// +kubebuilder:validation:Optional
+	OSFamily          OSFamily            `json:"osFamily,omitempty"`
 	ResourcePool      string              `json:"resourcePool"`
 	StoragePolicyName string              `json:"storagePolicyName,omitempty"`
 	Template          string              `json:"template,omitempty"`
@@ -0,0 +1,152 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Operations of the validation webhooks.
+const (
+	webhookOperationCreate = "create"
+	webhookOperationUpdate = "update"
+	webhookOperationDelete = "delete"
+)
+
+// webhookRejectedReason is the reason of the events recorded when a validation webhook rejects an object.
+const webhookRejectedReason = "ValidationRejected"
+
+// webhookRejectionUnknownCause is the cause of the rejections that aren't API errors.
+const webhookRejectionUnknownCause = "Unknown"
+
+var (
+	webhookRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eksa_webhook_rejections_total",
+			Help: "Total number of validation failures in the requests rejected by the EKS Anywhere validation webhooks per kind, operation, field and cause.",
+		},
+		[]string{"kind", "operation", "field", "cause"},
+	)
+
+	// fieldPathIndexes matches the list indexes and map keys in a field path, which are dropped
+	// from the field label to bound its cardinality.
+	fieldPathIndexes = regexp.MustCompile(`\[[^]]*\]`)
+)
+
+// WebhookCollectors returns the Prometheus collectors of the validation webhooks, to be registered by the manager.
+func WebhookCollectors() []prometheus.Collector {
+	return []prometheus.Collector{webhookRejections}
+}
+
+// rejectionRecorder is an admission.CustomValidator that validates objects with their own
+// webhook.Validator implementation and records the rejections.
+type rejectionRecorder struct {
+	kind     string
+	recorder record.EventRecorder
+}
+
+var _ admission.CustomValidator = &rejectionRecorder{}
+
+// newRejectionRecorder builds a rejectionRecorder for objects of kind. Rejected updates are
+// recorded as events with recorder, if not nil.
+func newRejectionRecorder(kind string, recorder record.EventRecorder) *rejectionRecorder {
+	return &rejectionRecorder{
+		kind:     kind,
+		recorder: recorder,
+	}
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *rejectionRecorder) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	validator, err := v.validator(obj)
+	if err != nil {
+		return err
+	}
+	err = validator.ValidateCreate()
+	v.record(obj, webhookOperationCreate, err)
+	return err
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *rejectionRecorder) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+	validator, err := v.validator(newObj)
+	if err != nil {
+		return err
+	}
+	err = validator.ValidateUpdate(oldObj)
+	v.record(newObj, webhookOperationUpdate, err)
+	return err
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *rejectionRecorder) ValidateDelete(_ context.Context, obj runtime.Object) error {
+	validator, err := v.validator(obj)
+	if err != nil {
+		return err
+	}
+	err = validator.ValidateDelete()
+	v.record(obj, webhookOperationDelete, err)
+	return err
+}
+
+func (v *rejectionRecorder) validator(obj runtime.Object) (webhook.Validator, error) {
+	validator, ok := obj.(webhook.Validator)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a %s but got a %T", v.kind, obj))
+	}
+	return validator, nil
+}
+
+// record counts err, if any, as a rejection of obj by the validation webhook of operation.
+// Rejected updates are also recorded as events, since only then the object exists to hold them.
+func (v *rejectionRecorder) record(obj runtime.Object, operation string, err error) {
+	if err == nil {
+		return
+	}
+
+	recordWebhookRejection(v.kind, operation, err)
+
+	if v.recorder != nil && operation == webhookOperationUpdate {
+		v.recorder.Eventf(obj, corev1.EventTypeWarning, webhookRejectedReason, "Update rejected: %v", err)
+	}
+}
+
+// recordWebhookRejection counts err, if any, as a rejection of an object of kind by the validation webhook
+// of operation. Each field error of an Invalid API error is counted with its field and type as cause,
+// other API errors with no field and their reason as cause, and any other error as an Unknown cause.
+func recordWebhookRejection(kind, operation string, err error) {
+	if err == nil {
+		return
+	}
+
+	for _, cause := range webhookRejectionCauses(err) {
+		webhookRejections.WithLabelValues(kind, operation, fieldPathIndexes.ReplaceAllString(cause.Field, ""), string(cause.Type)).Inc()
+	}
+}
+
+func webhookRejectionCauses(err error) []metav1.StatusCause {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return []metav1.StatusCause{{Type: webhookRejectionUnknownCause}}
+	}
+
+	if details := status.Status().Details; details != nil && len(details.Causes) > 0 {
+		return details.Causes
+	}
+
+	reason := apierrors.ReasonForError(err)
+	if reason == metav1.StatusReasonUnknown {
+		return []metav1.StatusCause{{Type: webhookRejectionUnknownCause}}
+	}
+	return []metav1.StatusCause{{Type: metav1.CauseType(reason)}}
+}
@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordWebhookRejection(t *testing.T) {
+	g := NewWithT(t)
+	webhookRejections.Reset()
+
+	recordWebhookRejection(ClusterKind, webhookOperationCreate, nil)
+	g.Expect(testutil.CollectAndCount(webhookRejections)).To(Equal(0))
+
+	invalid := apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: ClusterKind}, "my-cluster", field.ErrorList{
+		field.Forbidden(field.NewPath("spec", "controlPlaneConfiguration"), "field is immutable"),
+		field.Invalid(field.NewPath("spec", "workerNodeGroupConfigurations").Index(1).Child("count"), -1, "must be positive"),
+	})
+	recordWebhookRejection(ClusterKind, webhookOperationUpdate, invalid)
+	recordWebhookRejection(ClusterKind, webhookOperationUpdate, invalid)
+	recordWebhookRejection(ClusterKind, webhookOperationCreate, apierrors.NewBadRequest("bad request"))
+	recordWebhookRejection(ClusterKind, webhookOperationCreate, errors.New("other error"))
+
+	g.Expect(testutil.ToFloat64(webhookRejections.WithLabelValues(ClusterKind, webhookOperationUpdate, "spec.controlPlaneConfiguration", "FieldValueForbidden"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(webhookRejections.WithLabelValues(ClusterKind, webhookOperationUpdate, "spec.workerNodeGroupConfigurations.count", "FieldValueInvalid"))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(webhookRejections.WithLabelValues(ClusterKind, webhookOperationCreate, "", "BadRequest"))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(webhookRejections.WithLabelValues(ClusterKind, webhookOperationCreate, "", "Unknown"))).To(Equal(1.0))
+	g.Expect(testutil.CollectAndCount(webhookRejections)).To(Equal(4))
+}
+
+func TestRejectionRecorderEvents(t *testing.T) {
+	g := NewWithT(t)
+	recorder := record.NewFakeRecorder(10)
+	v := newRejectionRecorder(ClusterKind, recorder)
+	cluster := &Cluster{}
+
+	v.record(cluster, webhookOperationUpdate, nil)
+	v.record(cluster, webhookOperationCreate, apierrors.NewBadRequest("create rejected"))
+	v.record(cluster, webhookOperationUpdate, apierrors.NewBadRequest("update rejected"))
+
+	g.Expect(recorder.Events).To(HaveLen(1))
+	g.Expect(<-recorder.Events).To(Equal("Warning ValidationRejected Update rejected: update rejected"))
+}
+
+func TestRejectionRecorderValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	webhookRejections.Reset()
+	recorder := record.NewFakeRecorder(10)
+	v := newRejectionRecorder(ClusterKind, recorder)
+	oldCluster := &Cluster{Spec: ClusterSpec{ControlPlaneConfiguration: ControlPlaneConfiguration{Endpoint: &Endpoint{Host: "1.1.1.1"}}}}
+	newCluster := oldCluster.DeepCopy()
+	newCluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "1.1.1.2"
+
+	err := v.ValidateUpdate(context.Background(), oldCluster, newCluster)
+	g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	g.Expect(testutil.ToFloat64(webhookRejections.WithLabelValues(ClusterKind, webhookOperationUpdate, "spec.ControlPlaneConfiguration.endpoint", "FieldValueInvalid"))).To(Equal(1.0))
+	g.Expect(recorder.Events).To(HaveLen(1))
+}
+
+func TestRejectionRecorderValidateCreateSuccess(t *testing.T) {
+	g := NewWithT(t)
+	webhookRejections.Reset()
+	v := newRejectionRecorder(ClusterKind, nil)
+	cluster := &Cluster{}
+	cluster.PauseReconcile()
+
+	g.Expect(v.ValidateCreate(context.Background(), cluster)).To(Succeed())
+	g.Expect(testutil.CollectAndCount(webhookRejections)).To(Equal(0))
+}
+
+func TestRejectionRecorderNotValidator(t *testing.T) {
+	g := NewWithT(t)
+	v := newRejectionRecorder(ClusterKind, nil)
+
+	err := v.ValidateCreate(context.Background(), &ClusterList{})
+	g.Expect(apierrors.IsBadRequest(err)).To(BeTrue())
+}
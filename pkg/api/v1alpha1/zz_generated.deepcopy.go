@@ -249,6 +249,11 @@ func (in *CNIConfig) DeepCopyInto(out *CNIConfig) {
 		*out = new(KindnetdConfig)
 		**out = **in
 	}
+	if in.None != nil {
+		in, out := &in.None, &out.None
+		*out = new(NoneCNIConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIConfig.
@@ -687,6 +692,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(PodIAMConfig)
 		**out = **in
 	}
+	if in.LoadBalancerConfiguration != nil {
+		in, out := &in.LoadBalancerConfiguration, &out.LoadBalancerConfiguration
+		*out = new(LoadBalancerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BundlesRef != nil {
 		in, out := &in.BundlesRef, &out.BundlesRef
 		*out = new(BundlesRef)
@@ -724,6 +734,21 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(NodeGroupStatus)
+		**out = **in
+	}
+	if in.WorkerNodeGroups != nil {
+		in, out := &in.WorkerNodeGroups, &out.WorkerNodeGroups
+		*out = make([]NodeGroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.BundlesRef != nil {
+		in, out := &in.BundlesRef, &out.BundlesRef
+		*out = new(BundlesRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1205,6 +1230,21 @@ func (in HardwareSelector) DeepCopy() HardwareSelector {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPool) DeepCopyInto(out *IPPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
+func (in *IPPool) DeepCopy() *IPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KindnetdConfig) DeepCopyInto(out *KindnetdConfig) {
 	*out = *in
@@ -1220,6 +1260,41 @@ func (in *KindnetdConfig) DeepCopy() *KindnetdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerConfiguration) DeepCopyInto(out *LoadBalancerConfiguration) {
+	*out = *in
+	if in.IPPools != nil {
+		in, out := &in.IPPools, &out.IPPools
+		*out = make([]LoadBalancerIPPool, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerConfiguration.
+func (in *LoadBalancerConfiguration) DeepCopy() *LoadBalancerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerIPPool) DeepCopyInto(out *LoadBalancerIPPool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerIPPool.
+func (in *LoadBalancerIPPool) DeepCopy() *LoadBalancerIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementCluster) DeepCopyInto(out *ManagementCluster) {
 	*out = *in
@@ -1275,6 +1350,21 @@ func (in *MapUsers) DeepCopy() *MapUsers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupStatus) DeepCopyInto(out *NodeGroupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupStatus.
+func (in *NodeGroupStatus) DeepCopy() *NodeGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nodes) DeepCopyInto(out *Nodes) {
 	*out = *in
@@ -1295,6 +1385,21 @@ func (in *Nodes) DeepCopy() *Nodes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoneCNIConfig) DeepCopyInto(out *NoneCNIConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoneCNIConfig.
+func (in *NoneCNIConfig) DeepCopy() *NoneCNIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NoneCNIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 	*out = *in
@@ -1635,6 +1740,125 @@ func (in *SnowDatacenterConfigStatus) DeepCopy() *SnowDatacenterConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowDirectNetworkInterface) DeepCopyInto(out *SnowDirectNetworkInterface) {
+	*out = *in
+	if in.VlanID != nil {
+		in, out := &in.VlanID, &out.VlanID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPPoolRef != nil {
+		in, out := &in.IPPoolRef, &out.IPPoolRef
+		*out = new(Ref)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowDirectNetworkInterface.
+func (in *SnowDirectNetworkInterface) DeepCopy() *SnowDirectNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowDirectNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPool) DeepCopyInto(out *SnowIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPool.
+func (in *SnowIPPool) DeepCopy() *SnowIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnowIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPoolList) DeepCopyInto(out *SnowIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnowIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolList.
+func (in *SnowIPPoolList) DeepCopy() *SnowIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnowIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPoolSpec) DeepCopyInto(out *SnowIPPoolSpec) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]IPPool, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolSpec.
+func (in *SnowIPPoolSpec) DeepCopy() *SnowIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowIPPoolStatus) DeepCopyInto(out *SnowIPPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowIPPoolStatus.
+func (in *SnowIPPoolStatus) DeepCopy() *SnowIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnowMachineConfig) DeepCopyInto(out *SnowMachineConfig) {
 	*out = *in
@@ -1702,6 +1926,11 @@ func (in *SnowMachineConfigSpec) DeepCopyInto(out *SnowMachineConfigSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(SnowNetwork)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowMachineConfigSpec.
@@ -1750,6 +1979,28 @@ func (in SymlinkMaps) DeepCopy() SymlinkMaps {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnowNetwork) DeepCopyInto(out *SnowNetwork) {
+	*out = *in
+	if in.DirectNetworkInterfaces != nil {
+		in, out := &in.DirectNetworkInterfaces, &out.DirectNetworkInterfaces
+		*out = make([]SnowDirectNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnowNetwork.
+func (in *SnowNetwork) DeepCopy() *SnowNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(SnowNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TinkerbellDatacenterConfig) DeepCopyInto(out *TinkerbellDatacenterConfig) {
 	*out = *in
@@ -2006,6 +2257,11 @@ func (in *TinkerbellTemplateConfigList) DeepCopyObject() runtime.Object {
 func (in *TinkerbellTemplateConfigSpec) DeepCopyInto(out *TinkerbellTemplateConfigSpec) {
 	*out = *in
 	in.Template.DeepCopyInto(&out.Template)
+	if in.Library != nil {
+		in, out := &in.Library, &out.Library
+		*out = new(TinkerbellTemplateLibraryRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellTemplateConfigSpec.
@@ -2033,6 +2289,28 @@ func (in *TinkerbellTemplateConfigStatus) DeepCopy() *TinkerbellTemplateConfigSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TinkerbellTemplateLibraryRef) DeepCopyInto(out *TinkerbellTemplateLibraryRef) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TinkerbellTemplateLibraryRef.
+func (in *TinkerbellTemplateLibraryRef) DeepCopy() *TinkerbellTemplateLibraryRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TinkerbellTemplateLibraryRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserConfiguration) DeepCopyInto(out *UserConfiguration) {
 	*out = *in
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/smithy-go"
 )
 
@@ -14,6 +15,15 @@ type EC2Client interface {
 	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
 	DescribeKeyPairs(ctx context.Context, params *ec2.DescribeKeyPairsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeKeyPairsOutput, error)
 	ImportKeyPair(ctx context.Context, params *ec2.ImportKeyPairInput, optFns ...func(*ec2.Options)) (*ec2.ImportKeyPairOutput, error)
+	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error)
+	DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error)
+}
+
+// EC2InstanceType is the compute capacity of an ec2 instance type.
+type EC2InstanceType struct {
+	Name      string
+	VCPU      int
+	MemoryMiB int64
 }
 
 func NewEC2Client(config aws.Config) *ec2.Client {
@@ -70,3 +80,59 @@ func (c *Client) EC2ImportKeyPair(ctx context.Context, keyName string, keyMateri
 	}
 	return nil
 }
+
+// EC2InstanceTypes calls aws sdk ec2.DescribeInstanceTypes to fetch the instance types
+// available and their default vCPU and memory.
+func (c *Client) EC2InstanceTypes(ctx context.Context) ([]EC2InstanceType, error) {
+	params := &ec2.DescribeInstanceTypesInput{}
+	var instanceTypes []EC2InstanceType
+	for {
+		out, err := c.ec2.DescribeInstanceTypes(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("aws describe instance types: %v", err)
+		}
+		for _, t := range out.InstanceTypes {
+			instanceType := EC2InstanceType{Name: string(t.InstanceType)}
+			if t.VCpuInfo != nil && t.VCpuInfo.DefaultVCpus != nil {
+				instanceType.VCPU = int(*t.VCpuInfo.DefaultVCpus)
+			}
+			if t.MemoryInfo != nil && t.MemoryInfo.SizeInMiB != nil {
+				instanceType.MemoryMiB = *t.MemoryInfo.SizeInMiB
+			}
+			instanceTypes = append(instanceTypes, instanceType)
+		}
+		if out.NextToken == nil {
+			return instanceTypes, nil
+		}
+		params = &ec2.DescribeInstanceTypesInput{NextToken: out.NextToken}
+	}
+}
+
+// EC2RunningInstanceTypes calls aws sdk ec2.DescribeInstances to fetch the instance type
+// of every pending or running instance.
+func (c *Client) EC2RunningInstanceTypes(ctx context.Context) ([]string, error) {
+	params := &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{string(types.InstanceStateNamePending), string(types.InstanceStateNameRunning)},
+			},
+		},
+	}
+	var instanceTypes []string
+	for {
+		out, err := c.ec2.DescribeInstances(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("aws describe instances: %v", err)
+		}
+		for _, r := range out.Reservations {
+			for _, i := range r.Instances {
+				instanceTypes = append(instanceTypes, string(i.InstanceType))
+			}
+		}
+		if out.NextToken == nil {
+			return instanceTypes, nil
+		}
+		params.NextToken = out.NextToken
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	sdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/golang/mock/gomock"
@@ -116,3 +117,63 @@ func TestEC2ImportKeyPair(t *testing.T) {
 	err := g.client.EC2ImportKeyPair(g.ctx, key, val)
 	g.Expect(err).To(Succeed())
 }
+
+func TestEC2InstanceTypes(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeInstanceTypes(g.ctx, &ec2.DescribeInstanceTypesInput{}).Return(&ec2.DescribeInstanceTypesOutput{
+		InstanceTypes: []types.InstanceTypeInfo{
+			{
+				InstanceType: "sbe-c.large",
+				VCpuInfo:     &types.VCpuInfo{DefaultVCpus: sdk.Int32(2)},
+				MemoryInfo:   &types.MemoryInfo{SizeInMiB: sdk.Int64(8192)},
+			},
+		},
+		NextToken: sdk.String("next"),
+	}, nil)
+	g.ec2.EXPECT().DescribeInstanceTypes(g.ctx, &ec2.DescribeInstanceTypesInput{NextToken: sdk.String("next")}).Return(&ec2.DescribeInstanceTypesOutput{
+		InstanceTypes: []types.InstanceTypeInfo{
+			{
+				InstanceType: "sbe-c.xlarge",
+				VCpuInfo:     &types.VCpuInfo{DefaultVCpus: sdk.Int32(4)},
+				MemoryInfo:   &types.MemoryInfo{SizeInMiB: sdk.Int64(16384)},
+			},
+		},
+	}, nil)
+	got, err := g.client.EC2InstanceTypes(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal([]aws.EC2InstanceType{
+		{Name: "sbe-c.large", VCPU: 2, MemoryMiB: 8192},
+		{Name: "sbe-c.xlarge", VCPU: 4, MemoryMiB: 16384},
+	}))
+}
+
+func TestEC2InstanceTypesError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeInstanceTypes(g.ctx, &ec2.DescribeInstanceTypesInput{}).Return(nil, errors.New("error"))
+	_, err := g.client.EC2InstanceTypes(g.ctx)
+	g.Expect(err).To(MatchError("aws describe instance types: error"))
+}
+
+func TestEC2RunningInstanceTypes(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeInstances(g.ctx, gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{
+			{
+				Instances: []types.Instance{
+					{InstanceType: "sbe-c.large"},
+					{InstanceType: "sbe-c.2xlarge"},
+				},
+			},
+		},
+	}, nil)
+	got, err := g.client.EC2RunningInstanceTypes(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal([]string{"sbe-c.large", "sbe-c.2xlarge"}))
+}
+
+func TestEC2RunningInstanceTypesError(t *testing.T) {
+	g := newEC2Test(t)
+	g.ec2.EXPECT().DescribeInstances(g.ctx, gomock.Any()).Return(nil, errors.New("error"))
+	_, err := g.client.EC2RunningInstanceTypes(g.ctx)
+	g.Expect(err).To(MatchError("aws describe instances: error"))
+}
@@ -53,6 +53,8 @@ func (a *AwsIamAuthTemplateBuilder) GenerateManifest(clusterSpec *cluster.Spec,
 		"clusterID":          clusterId.String(),
 		"backendMode":        strings.Join(clusterSpec.AWSIamConfig.Spec.BackendMode, ","),
 		"partition":          clusterSpec.AWSIamConfig.Spec.Partition,
+		"managedMappingsKey": ManagedMappingsAnnotation,
+		"managedMappings":    ManagedMappings(clusterSpec.AWSIamConfig),
 	}
 
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints != nil {
@@ -98,11 +100,21 @@ func (a *AwsIamAuth) GenerateCertKeyPairSecret() ([]byte, error) {
 }
 
 func (a *AwsIamAuth) GenerateAwsIamAuthKubeconfig(clusterSpec *cluster.Spec, serverUrl, tlsCert string) ([]byte, error) {
+	return a.templateBuilder.GenerateKubeconfig(clusterSpec, a.clusterId, serverUrl, tlsCert)
+}
+
+// GenerateAwsIamAuthKubeconfigForClusterID generates the aws-iam-authenticator kubeconfig for a cluster
+// whose aws-iam-authenticator server was already configured with clusterId.
+func (a *AwsIamAuth) GenerateAwsIamAuthKubeconfigForClusterID(clusterSpec *cluster.Spec, clusterId uuid.UUID, serverUrl, tlsCert string) ([]byte, error) {
+	return a.templateBuilder.GenerateKubeconfig(clusterSpec, clusterId, serverUrl, tlsCert)
+}
+
+func (a *AwsIamAuthTemplateBuilder) GenerateKubeconfig(clusterSpec *cluster.Spec, clusterId uuid.UUID, serverUrl, tlsCert string) ([]byte, error) {
 	data := map[string]string{
 		"clusterName": clusterSpec.Cluster.Name,
 		"server":      serverUrl,
 		"cert":        tlsCert,
-		"clusterID":   a.clusterId.String(),
+		"clusterID":   clusterId.String(),
 	}
 	awsIamAuthKubeconfig, err := templater.Execute(awsIamAuthKubeconfigTemplate, data)
 	if err != nil {
@@ -112,3 +112,16 @@ func givenClusterSpec() *cluster.Spec {
 	})
 	return clusterSpec
 }
+
+func TestGenerateAwsIamAuthKubeconfigForClusterIDSuccess(t *testing.T) {
+	s := givenClusterSpec()
+	serverUrl := "0.0.0.0:0000"
+	tlsCrt := "test-ca"
+
+	awsIamAuth := awsiamauth.NewAwsIamAuth(nil, uuid.New())
+	gotFileContent, err := awsIamAuth.GenerateAwsIamAuthKubeconfigForClusterID(s, uuid.MustParse("36db102f-9e1e-4ca4-8300-271d30b14161"), serverUrl, tlsCrt)
+	if err != nil {
+		t.Fatalf("awsiamauth.GenerateAwsIamAuthKubeconfigForClusterID()\n error = %v\n wantErr = nil", err)
+	}
+	test.AssertContentToFile(t, string(gotFileContent), wantKubeconfigContent)
+}
@@ -0,0 +1,339 @@
+package awsiamauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+)
+
+const (
+	// EKSConfigMapBackendMode reads mappings from the aws-auth ConfigMap.
+	EKSConfigMapBackendMode = "EKSConfigMap"
+	// CRDBackendMode reads mappings from IAMIdentityMapping objects.
+	CRDBackendMode = "CRD"
+
+	// ConfigMapName is the name of the ConfigMap holding the aws-iam-authenticator server config.
+	ConfigMapName = "aws-iam-authenticator"
+	// MappingsConfigMapName is the name of the ConfigMap read by the EKSConfigMap backend.
+	MappingsConfigMapName = "aws-auth"
+
+	// MappingLabel is set on the IAMIdentityMappings managed by EKS Anywhere. Its value is the
+	// name of the AWSIamConfig the mapping was built from.
+	MappingLabel = "anywhere.eks.amazonaws.com/aws-iam-config"
+
+	// ManagedMappingsAnnotation lists the ARNs of the aws-auth ConfigMap mappings managed by EKS
+	// Anywhere. Mappings for other ARNs were added by other means and are left untouched.
+	ManagedMappingsAnnotation = "anywhere.eks.amazonaws.com/managed-mappings"
+
+	mapRolesKey = "mapRoles"
+	mapUsersKey = "mapUsers"
+	roleARNKey  = "rolearn"
+	userARNKey  = "userarn"
+)
+
+// IAMIdentityMappingGVK is the GroupVersionKind of the aws-iam-authenticator IAMIdentityMapping CRD.
+var IAMIdentityMappingGVK = schema.GroupVersionKind{
+	Group:   "iamauthenticator.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "IAMIdentityMapping",
+}
+
+// MappingsConfigMap builds the aws-auth ConfigMap with the roles and users mapped in config.
+func MappingsConfigMap(config *v1alpha1.AWSIamConfig) (*corev1.ConfigMap, error) {
+	templateBuilder := NewAwsIamAuthTemplateBuilder()
+	data := map[string]string{}
+
+	mapRoles, err := templateBuilder.mapRolesToYaml(config.Spec.MapRoles)
+	if err != nil {
+		return nil, err
+	}
+	if mapRoles != "" {
+		data["mapRoles"] = mapRoles
+	}
+
+	mapUsers, err := templateBuilder.mapUsersToYaml(config.Spec.MapUsers)
+	if err != nil {
+		return nil, err
+	}
+	if mapUsers != "" {
+		data["mapUsers"] = mapUsers
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        MappingsConfigMapName,
+			Namespace:   constants.KubeSystemNamespace,
+			Annotations: map[string]string{ManagedMappingsAnnotation: ManagedMappings(config)},
+		},
+		Data: data,
+	}, nil
+}
+
+// IAMIdentityMappings builds an IAMIdentityMapping for each role and user mapped in config.
+// Mapping names are derived from the ARN so they are stable across reconciliations.
+func IAMIdentityMappings(config *v1alpha1.AWSIamConfig) []*unstructured.Unstructured {
+	mappings := make([]*unstructured.Unstructured, 0, len(config.Spec.MapRoles)+len(config.Spec.MapUsers))
+	for _, role := range config.Spec.MapRoles {
+		mappings = append(mappings, iamIdentityMapping(config.Name, role.RoleARN, role.Username, role.Groups))
+	}
+	for _, user := range config.Spec.MapUsers {
+		mappings = append(mappings, iamIdentityMapping(config.Name, user.UserARN, user.Username, user.Groups))
+	}
+
+	return mappings
+}
+
+func iamIdentityMapping(configName, arn, username string, groups []string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"arn":      arn,
+		"username": username,
+	}
+	if len(groups) > 0 {
+		g := make([]interface{}, 0, len(groups))
+		for _, group := range groups {
+			g = append(g, group)
+		}
+		spec["groups"] = g
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetGroupVersionKind(IAMIdentityMappingGVK)
+	u.SetName(iamIdentityMappingName(arn))
+	u.SetLabels(map[string]string{MappingLabel: configName})
+
+	return u
+}
+
+func iamIdentityMappingName(arn string) string {
+	return fmt.Sprintf("eksa-%x", sha256.Sum256([]byte(arn)))[:37]
+}
+
+// ReconcileMappings applies the roles and users mapped in config to the aws-iam-authenticator
+// backends enabled in config, using c to talk to the cluster running aws-iam-authenticator.
+// Mappings previously created for a config that are no longer mapped are deleted, mappings
+// that weren't created from the config are preserved.
+func ReconcileMappings(ctx context.Context, c client.Client, config *v1alpha1.AWSIamConfig) error {
+	for _, backendMode := range config.Spec.BackendMode {
+		switch backendMode {
+		case EKSConfigMapBackendMode:
+			if err := reconcileMappingsConfigMap(ctx, c, config); err != nil {
+				return err
+			}
+		case CRDBackendMode:
+			if err := reconcileIAMIdentityMappings(ctx, c, config); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconcileMappingsConfigMap merges the roles and users mapped in config into the aws-auth ConfigMap.
+// The ARNs managed by EKS Anywhere are tracked in an annotation, so entries added by hand survive and
+// entries removed from the config are removed from the ConfigMap.
+func reconcileMappingsConfigMap(ctx context.Context, c client.Client, config *v1alpha1.AWSIamConfig) error {
+	desired, err := MappingsConfigMap(config)
+	if err != nil {
+		return fmt.Errorf("building aws-iam-authenticator mappings ConfigMap: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, cm)
+	if apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired); err != nil {
+			return fmt.Errorf("creating aws-iam-authenticator mappings ConfigMap: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("retrieving aws-iam-authenticator mappings ConfigMap: %v", err)
+	}
+
+	previouslyManaged := map[string]struct{}{}
+	if v := cm.Annotations[ManagedMappingsAnnotation]; v != "" {
+		for _, arn := range strings.Split(v, ",") {
+			previouslyManaged[arn] = struct{}{}
+		}
+	}
+
+	roles := make([]map[string]interface{}, 0, len(config.Spec.MapRoles))
+	for _, r := range config.Spec.MapRoles {
+		roles = append(roles, mappingEntry(roleARNKey, r.RoleARN, r.Username, r.Groups))
+	}
+	users := make([]map[string]interface{}, 0, len(config.Spec.MapUsers))
+	for _, u := range config.Spec.MapUsers {
+		users = append(users, mappingEntry(userARNKey, u.UserARN, u.Username, u.Groups))
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	for _, m := range []struct {
+		key, arnKey string
+		desired     []map[string]interface{}
+	}{
+		{key: mapRolesKey, arnKey: roleARNKey, desired: roles},
+		{key: mapUsersKey, arnKey: userARNKey, desired: users},
+	} {
+		merged, err := mergeMappings(cm.Data[m.key], m.desired, m.arnKey, previouslyManaged)
+		if err != nil {
+			return fmt.Errorf("merging %s of aws-iam-authenticator mappings ConfigMap: %v", m.key, err)
+		}
+		if merged == "" {
+			delete(cm.Data, m.key)
+		} else {
+			cm.Data[m.key] = merged
+		}
+	}
+
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[ManagedMappingsAnnotation] = ManagedMappings(config)
+
+	if err := c.Update(ctx, cm); err != nil {
+		return fmt.Errorf("updating aws-iam-authenticator mappings ConfigMap: %v", err)
+	}
+
+	return nil
+}
+
+// ManagedMappings returns the value of the ManagedMappingsAnnotation for the roles and users mapped in config.
+func ManagedMappings(config *v1alpha1.AWSIamConfig) string {
+	arns := make([]string, 0, len(config.Spec.MapRoles)+len(config.Spec.MapUsers))
+	for _, r := range config.Spec.MapRoles {
+		arns = append(arns, r.RoleARN)
+	}
+	for _, u := range config.Spec.MapUsers {
+		arns = append(arns, u.UserARN)
+	}
+	return strings.Join(arns, ",")
+}
+
+func mappingEntry(arnKey, arn, username string, groups []string) map[string]interface{} {
+	entry := map[string]interface{}{
+		arnKey:     arn,
+		"username": username,
+	}
+	g := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		g = append(g, group)
+	}
+	entry["groups"] = g
+	return entry
+}
+
+// mergeMappings replaces the entries of the existing mappings yaml list with the desired entry for
+// the same ARN, drops the entries in previouslyManaged that aren't desired anymore, keeps all other
+// entries and appends the desired entries that weren't there yet.
+func mergeMappings(existing string, desired []map[string]interface{}, arnKey string, previouslyManaged map[string]struct{}) (string, error) {
+	var current []map[string]interface{}
+	if err := yaml.Unmarshal([]byte(existing), &current); err != nil {
+		return "", err
+	}
+
+	desiredByARN := make(map[string]map[string]interface{}, len(desired))
+	for _, entry := range desired {
+		desiredByARN[entry[arnKey].(string)] = entry
+	}
+
+	merged := make([]map[string]interface{}, 0, len(current)+len(desired))
+	added := map[string]struct{}{}
+	for _, entry := range current {
+		arn, _ := entry[arnKey].(string)
+		if d, ok := desiredByARN[arn]; ok {
+			if _, dup := added[arn]; !dup {
+				merged = append(merged, d)
+				added[arn] = struct{}{}
+			}
+			continue
+		}
+		if _, ok := previouslyManaged[arn]; ok {
+			continue
+		}
+		merged = append(merged, entry)
+	}
+
+	for _, entry := range desired {
+		if _, ok := added[entry[arnKey].(string)]; !ok {
+			merged = append(merged, entry)
+		}
+	}
+
+	if len(merged) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func reconcileIAMIdentityMappings(ctx context.Context, c client.Client, config *v1alpha1.AWSIamConfig) error {
+	desired := map[string]struct{}{}
+	for _, mapping := range IAMIdentityMappings(config) {
+		if err := serverside.ReconcileObject(ctx, c, mapping); err != nil {
+			return fmt.Errorf("applying IAMIdentityMapping: %v", err)
+		}
+		desired[mapping.GetName()] = struct{}{}
+	}
+
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(IAMIdentityMappingGVK.GroupVersion().WithKind(IAMIdentityMappingGVK.Kind + "List"))
+	if err := c.List(ctx, existing, client.HasLabels{MappingLabel}); err != nil {
+		return fmt.Errorf("listing IAMIdentityMappings: %v", err)
+	}
+
+	for i := range existing.Items {
+		mapping := &existing.Items[i]
+		if _, ok := desired[mapping.GetName()]; ok {
+			continue
+		}
+		if err := c.Delete(ctx, mapping); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting IAMIdentityMapping %s: %v", mapping.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// ParseClusterID reads the cluster ID aws-iam-authenticator was configured with from its
+// server config ConfigMap.
+func ParseClusterID(cm *corev1.ConfigMap) (uuid.UUID, error) {
+	serverConfig := struct {
+		ClusterID string `yaml:"clusterID"`
+	}{}
+	if err := yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &serverConfig); err != nil {
+		return uuid.Nil, fmt.Errorf("parsing aws-iam-authenticator config: %v", err)
+	}
+
+	clusterID, err := uuid.Parse(serverConfig.ClusterID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("parsing aws-iam-authenticator cluster ID: %v", err)
+	}
+
+	return clusterID, nil
+}
@@ -0,0 +1,238 @@
+package awsiamauth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+)
+
+func givenAWSIamConfig() *v1alpha1.AWSIamConfig {
+	return &v1alpha1.AWSIamConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-iam-config"},
+		Spec: v1alpha1.AWSIamConfigSpec{
+			AWSRegion:   "test-region",
+			BackendMode: []string{awsiamauth.EKSConfigMapBackendMode, awsiamauth.CRDBackendMode},
+			MapRoles: []v1alpha1.MapRoles{
+				{
+					RoleARN:  "arn:aws:iam::123456789012:role/admin",
+					Username: "admin",
+					Groups:   []string{"system:masters"},
+				},
+			},
+			MapUsers: []v1alpha1.MapUsers{
+				{
+					UserARN:  "arn:aws:iam::123456789012:user/dev",
+					Username: "dev",
+				},
+			},
+		},
+	}
+}
+
+func TestMappingsConfigMap(t *testing.T) {
+	g := NewWithT(t)
+
+	cm, err := awsiamauth.MappingsConfigMap(givenAWSIamConfig())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Name).To(Equal("aws-auth"))
+	g.Expect(cm.Namespace).To(Equal("kube-system"))
+	g.Expect(cm.Kind).To(Equal("ConfigMap"))
+	g.Expect(cm.Data).To(Equal(map[string]string{
+		"mapRoles": "- rolearn: arn:aws:iam::123456789012:role/admin\n  username: admin\n  groups:\n    - system:masters",
+		"mapUsers": "- userarn: arn:aws:iam::123456789012:user/dev\n  username: dev\n  groups: []",
+	}))
+}
+
+func TestMappingsConfigMapNoMappings(t *testing.T) {
+	g := NewWithT(t)
+	config := givenAWSIamConfig()
+	config.Spec.MapRoles = nil
+	config.Spec.MapUsers = nil
+
+	cm, err := awsiamauth.MappingsConfigMap(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cm.Data).To(BeEmpty())
+}
+
+func TestIAMIdentityMappings(t *testing.T) {
+	g := NewWithT(t)
+
+	mappings := awsiamauth.IAMIdentityMappings(givenAWSIamConfig())
+	g.Expect(mappings).To(HaveLen(2))
+
+	role := mappings[0]
+	g.Expect(role.GroupVersionKind()).To(Equal(awsiamauth.IAMIdentityMappingGVK))
+	g.Expect(role.GetName()).To(HavePrefix("eksa-"))
+	g.Expect(role.GetName()).To(HaveLen(37))
+	g.Expect(role.GetLabels()).To(HaveKeyWithValue(awsiamauth.MappingLabel, "aws-iam-config"))
+	g.Expect(role.Object["spec"]).To(Equal(map[string]interface{}{
+		"arn":      "arn:aws:iam::123456789012:role/admin",
+		"username": "admin",
+		"groups":   []interface{}{"system:masters"},
+	}))
+
+	user := mappings[1]
+	g.Expect(user.GetName()).NotTo(Equal(role.GetName()))
+	g.Expect(user.Object["spec"]).To(Equal(map[string]interface{}{
+		"arn":      "arn:aws:iam::123456789012:user/dev",
+		"username": "dev",
+	}))
+
+	g.Expect(awsiamauth.IAMIdentityMappings(givenAWSIamConfig())[0].GetName()).To(Equal(role.GetName()))
+}
+
+func TestParseClusterID(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    uuid.UUID
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: "clusterID: 36db102f-9e1e-4ca4-8300-271d30b14161\n",
+			want:   uuid.MustParse("36db102f-9e1e-4ca4-8300-271d30b14161"),
+		},
+		{
+			name:    "missing",
+			config:  "",
+			wantErr: "parsing aws-iam-authenticator cluster ID",
+		},
+		{
+			name:    "invalid yaml",
+			config:  "clusterID: [",
+			wantErr: "parsing aws-iam-authenticator config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cm := &corev1.ConfigMap{Data: map[string]string{"config.yaml": tt.config}}
+
+			got, err := awsiamauth.ParseClusterID(cm)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestReconcileMappingsConfigMapCreate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config := givenAWSIamConfig()
+	config.Spec.BackendMode = []string{awsiamauth.EKSConfigMapBackendMode}
+	c := fake.NewClientBuilder().Build()
+
+	g.Expect(awsiamauth.ReconcileMappings(ctx, c, config)).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "aws-auth"}, cm)).To(Succeed())
+	g.Expect(cm.Annotations).To(HaveKeyWithValue(
+		awsiamauth.ManagedMappingsAnnotation,
+		"arn:aws:iam::123456789012:role/admin,arn:aws:iam::123456789012:user/dev",
+	))
+	g.Expect(cm.Data).To(HaveKey("mapRoles"))
+	g.Expect(cm.Data).To(HaveKey("mapUsers"))
+}
+
+func TestReconcileMappingsConfigMapRevokesMappingsFromCreate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config := givenAWSIamConfig()
+	config.Spec.BackendMode = []string{awsiamauth.EKSConfigMapBackendMode}
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.AWSIamConfig = config
+	})
+
+	manifest, err := awsiamauth.NewAwsIamAuthTemplateBuilder().GenerateManifest(spec, uuid.New())
+	g.Expect(err).NotTo(HaveOccurred())
+	objs, err := clientutil.YamlToClientObjects(manifest)
+	g.Expect(err).NotTo(HaveOccurred())
+	builder := fake.NewClientBuilder()
+	for _, obj := range objs {
+		if obj.GetName() == awsiamauth.MappingsConfigMapName {
+			builder = builder.WithObjects(obj)
+		}
+	}
+	c := builder.Build()
+
+	config.Spec.MapUsers = nil
+	g.Expect(awsiamauth.ReconcileMappings(ctx, c, config)).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "aws-auth"}, cm)).To(Succeed())
+	g.Expect(cm.Annotations).To(HaveKeyWithValue(awsiamauth.ManagedMappingsAnnotation, "arn:aws:iam::123456789012:role/admin"))
+	g.Expect(cm.Data).To(HaveKey("mapRoles"))
+	g.Expect(cm.Data).NotTo(HaveKey("mapUsers"))
+}
+
+func TestReconcileMappingsConfigMapMerge(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config := givenAWSIamConfig()
+	config.Spec.BackendMode = []string{awsiamauth.EKSConfigMapBackendMode}
+	config.Spec.MapUsers = nil
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-auth",
+			Namespace: "kube-system",
+			Annotations: map[string]string{
+				awsiamauth.ManagedMappingsAnnotation: "arn:aws:iam::123456789012:role/admin,arn:aws:iam::123456789012:user/dev",
+			},
+		},
+		Data: map[string]string{
+			"mapRoles": `- rolearn: arn:aws:iam::123456789012:role/manual
+  username: manual
+  groups:
+    - viewers
+- rolearn: arn:aws:iam::123456789012:role/admin
+  username: old-admin
+  groups: []
+`,
+			"mapUsers": `- userarn: arn:aws:iam::123456789012:user/dev
+  username: dev
+  groups: []
+`,
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	g.Expect(awsiamauth.ReconcileMappings(ctx, c, config)).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "aws-auth"}, cm)).To(Succeed())
+	g.Expect(cm.Annotations).To(HaveKeyWithValue(awsiamauth.ManagedMappingsAnnotation, "arn:aws:iam::123456789012:role/admin"))
+	g.Expect(cm.Data).NotTo(HaveKey("mapUsers"))
+
+	var roles []map[string]interface{}
+	g.Expect(yaml.Unmarshal([]byte(cm.Data["mapRoles"]), &roles)).To(Succeed())
+	g.Expect(roles).To(Equal([]map[string]interface{}{
+		{
+			"rolearn":  "arn:aws:iam::123456789012:role/manual",
+			"username": "manual",
+			"groups":   []interface{}{"viewers"},
+		},
+		{
+			"rolearn":  "arn:aws:iam::123456789012:role/admin",
+			"username": "admin",
+			"groups":   []interface{}{"system:masters"},
+		},
+	}))
+}
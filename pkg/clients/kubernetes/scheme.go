@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -16,6 +17,7 @@ var schemeAdders = []schemeAdder{
 	controlplanev1.AddToScheme,
 	anywherev1.AddToScheme,
 	snowv1.AddToScheme,
+	apiextensionsv1.AddToScheme,
 }
 
 func addToScheme(scheme *runtime.Scheme, schemeAdder ...schemeAdder) error {
@@ -6,9 +6,23 @@ func NewDefaultConfigClientBuilder() *ConfigClientBuilder {
 	return NewConfigClientBuilder().Register(
 		getSnowDatacenter,
 		getSnowMachineConfigs,
+		getSnowIPPools,
 		getOIDC,
 		getAWSIam,
 		getGitOps,
 		getFluxConfig,
 	)
 }
+
+// NewFullConfigClientBuilder returns a ConfigClientBuilder with the default processors
+// plus the ones to retrieve the datacenter and machine configs of all providers,
+// so the resulting Config contains every object of the cluster config
+func NewFullConfigClientBuilder() *ConfigClientBuilder {
+	return NewDefaultConfigClientBuilder().Register(
+		getVSphereDatacenter,
+		getVSphereMachineConfigs,
+		getCloudStackDatacenter,
+		getCloudStackMachineConfigs,
+		getDockerDatacenter,
+	)
+}
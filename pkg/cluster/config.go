@@ -16,6 +16,7 @@ type Config struct {
 	VSphereMachineConfigs    map[string]*anywherev1.VSphereMachineConfig
 	CloudStackMachineConfigs map[string]*anywherev1.CloudStackMachineConfig
 	SnowMachineConfigs       map[string]*anywherev1.SnowMachineConfig
+	SnowIPPools              map[string]*anywherev1.SnowIPPool
 	OIDCConfigs              map[string]*anywherev1.OIDCConfig
 	AWSIAMConfigs            map[string]*anywherev1.AWSIamConfig
 	GitOpsConfig             *anywherev1.GitOpsConfig
@@ -34,10 +35,33 @@ func (c *Config) SnowMachineConfig(name string) *anywherev1.SnowMachineConfig {
 	return c.SnowMachineConfigs[name]
 }
 
+func (c *Config) SnowIPPool(name string) *anywherev1.SnowIPPool {
+	return c.SnowIPPools[name]
+}
+
 func (c *Config) OIDCConfig(name string) *anywherev1.OIDCConfig {
 	return c.OIDCConfigs[name]
 }
 
+// ReferencedOIDCConfigs returns the OIDCConfigs referenced by the cluster, in the order of its
+// identity provider refs.
+func (c *Config) ReferencedOIDCConfigs() []*anywherev1.OIDCConfig {
+	if c.Cluster == nil {
+		return nil
+	}
+
+	var configs []*anywherev1.OIDCConfig
+	for _, idr := range c.Cluster.Spec.IdentityProviderRefs {
+		if idr.Kind != anywherev1.OIDCConfigKind {
+			continue
+		}
+		if o, ok := c.OIDCConfigs[idr.Name]; ok {
+			configs = append(configs, o)
+		}
+	}
+	return configs
+}
+
 func (c *Config) AWSIamConfig(name string) *anywherev1.AWSIamConfig {
 	return c.AWSIAMConfigs[name]
 }
@@ -67,6 +91,20 @@ func (c *Config) DeepCopy() *Config {
 		c2.CloudStackMachineConfigs[k] = v.DeepCopy()
 	}
 
+	if c.SnowMachineConfigs != nil {
+		c2.SnowMachineConfigs = make(map[string]*anywherev1.SnowMachineConfig, len(c.SnowMachineConfigs))
+	}
+	for k, v := range c.SnowMachineConfigs {
+		c2.SnowMachineConfigs[k] = v.DeepCopy()
+	}
+
+	if c.SnowIPPools != nil {
+		c2.SnowIPPools = make(map[string]*anywherev1.SnowIPPool, len(c.SnowIPPools))
+	}
+	for k, v := range c.SnowIPPools {
+		c2.SnowIPPools[k] = v.DeepCopy()
+	}
+
 	if c.OIDCConfigs != nil {
 		c2.OIDCConfigs = make(map[string]*anywherev1.OIDCConfig, len(c.OIDCConfigs))
 	}
@@ -89,7 +127,7 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 	objs := make(
 		[]kubernetes.Object,
 		0,
-		len(c.VSphereMachineConfigs)+len(c.SnowMachineConfigs)+len(c.CloudStackMachineConfigs)+4,
+		len(c.VSphereMachineConfigs)+len(c.SnowMachineConfigs)+len(c.SnowIPPools)+len(c.CloudStackMachineConfigs)+4,
 		// machine configs length + datacenter + OIDC + IAM + gitops
 	)
 
@@ -114,6 +152,10 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.SnowIPPools {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	for _, e := range c.OIDCConfigs {
 		objs = appendIfNotNil(objs, e)
 	}
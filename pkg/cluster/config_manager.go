@@ -99,6 +99,18 @@ func (c *ConfigManager) Validate(config *Config) error {
 	return nil
 }
 
+// APIObjects returns a new empty API object for each kind the ConfigManager can parse, indexed by kind
+func (c *ConfigManager) APIObjects() map[string]APIObject {
+	objs := map[string]APIObject{
+		anywherev1.ClusterKind: &anywherev1.Cluster{},
+	}
+	for kind, generateApiObj := range c.entry.APIObjectMapping {
+		objs[kind] = generateApiObj()
+	}
+
+	return objs
+}
+
 type basicAPIObject struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
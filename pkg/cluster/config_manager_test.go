@@ -240,3 +240,16 @@ func TestConfigManagerValidateMultipleSuccess(t *testing.T) {
 
 	g.Expect(c.Validate(config)).To(Succeed())
 }
+
+func TestConfigManagerAPIObjects(t *testing.T) {
+	g := NewWithT(t)
+	c := cluster.NewConfigManager()
+	g.Expect(c.RegisterMapping(anywherev1.DockerDatacenterKind, func() cluster.APIObject {
+		return &anywherev1.DockerDatacenterConfig{}
+	})).To(Succeed())
+
+	objs := c.APIObjects()
+	g.Expect(objs).To(HaveLen(2))
+	g.Expect(objs[anywherev1.ClusterKind]).To(Equal(&anywherev1.Cluster{}))
+	g.Expect(objs[anywherev1.DockerDatacenterKind]).To(Equal(&anywherev1.DockerDatacenterConfig{}))
+}
@@ -22,6 +22,8 @@ type EksdReleaseFetch func(ctx context.Context, name, namespace string) (*eksdv1
 
 type OIDCFetch func(ctx context.Context, name, namespace string) (*v1alpha1.OIDCConfig, error)
 
+type AWSIamConfigFetch func(ctx context.Context, name, namespace string) (*v1alpha1.AWSIamConfig, error)
+
 // BuildSpec constructs a cluster.Spec for an eks-a cluster by retrieving all
 // necessary objects using fetch methods
 // This is deprecated in favour of BuildSpec
@@ -54,11 +56,11 @@ func BuildSpecForCluster(ctx context.Context, cluster *v1alpha1.Cluster, bundles
 	if err != nil {
 		return nil, err
 	}
-	oidcConfig, err := GetOIDCForCluster(ctx, cluster, oidcFetch)
+	oidcConfigs, err := GetOIDCConfigsForCluster(ctx, cluster, oidcFetch)
 	if err != nil {
 		return nil, err
 	}
-	return BuildSpecFromBundles(cluster, bundles, WithEksdRelease(eksd), WithGitOpsConfig(gitOpsConfig), WithFluxConfig(fluxConfig), WithOIDCConfig(oidcConfig))
+	return BuildSpecFromBundles(cluster, bundles, WithEksdRelease(eksd), WithGitOpsConfig(gitOpsConfig), WithFluxConfig(fluxConfig), WithOIDCConfigs(oidcConfigs...))
 }
 
 func GetBundlesForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch BundlesFetch) (*v1alpha1release.Bundles, error) {
@@ -154,6 +156,43 @@ func GetOIDCForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch OID
 	return nil, nil
 }
 
+// GetOIDCConfigsForCluster fetches all the OIDCConfigs referenced by the cluster, in the order of its
+// identity provider refs.
+func GetOIDCConfigsForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch OIDCFetch) ([]*v1alpha1.OIDCConfig, error) {
+	if fetch == nil {
+		return nil, nil
+	}
+
+	var oidcConfigs []*v1alpha1.OIDCConfig
+	for _, identityProvider := range cluster.Spec.IdentityProviderRefs {
+		if identityProvider.Kind == v1alpha1.OIDCConfigKind {
+			oidc, err := fetch(ctx, identityProvider.Name, cluster.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed fetching OIDCConfig for cluster: %v", err)
+			}
+			oidcConfigs = append(oidcConfigs, oidc)
+		}
+	}
+	return oidcConfigs, nil
+}
+
+func GetAWSIamConfigForCluster(ctx context.Context, cluster *v1alpha1.Cluster, fetch AWSIamConfigFetch) (*v1alpha1.AWSIamConfig, error) {
+	if fetch == nil || cluster.Spec.IdentityProviderRefs == nil {
+		return nil, nil
+	}
+
+	for _, identityProvider := range cluster.Spec.IdentityProviderRefs {
+		if identityProvider.Kind == v1alpha1.AWSIamConfigKind {
+			awsIamConfig, err := fetch(ctx, identityProvider.Name, cluster.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed fetching AWSIamConfig for cluster: %v", err)
+			}
+			return awsIamConfig, nil
+		}
+	}
+	return nil, nil
+}
+
 // BuildSpec constructs a cluster.Spec for an eks-a cluster by retrieving all
 // necessary objects from the cluster using a kubernetes client
 func BuildSpec(ctx context.Context, client Client, cluster *v1alpha1.Cluster) (*Spec, error) {
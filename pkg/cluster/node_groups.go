@@ -24,3 +24,62 @@ func NodeGroupsToDelete(currentSpec, newSpec *Spec) []eksav1alpha1.WorkerNodeGro
 	}
 	return nodeGroupsToDelete
 }
+
+// OnlyWorkerNodeGroupCountsChanged returns true when the only difference between the Cluster, eks-d release
+// and OIDC configs of both specs is the count of some of the existing worker node groups.
+// The datacenter, machine and AWS IAM configs aren't part of the specs built from a cluster, the cluster manager
+// compares them with the objects in the cluster.
+func OnlyWorkerNodeGroupCountsChanged(currentSpec, newSpec *Spec) bool {
+	if currentSpec.VersionsBundle.EksD.Name != newSpec.VersionsBundle.EksD.Name {
+		return false
+	}
+
+	if !newSpec.OIDCConfigsEqual(currentSpec) {
+		return false
+	}
+
+	currentWorkerConfigs := currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations
+	newWorkerConfigs := newSpec.Cluster.Spec.WorkerNodeGroupConfigurations
+	if len(currentWorkerConfigs) != len(newWorkerConfigs) {
+		return false
+	}
+
+	currentCounts := make(map[string]int, len(currentWorkerConfigs))
+	for _, w := range currentWorkerConfigs {
+		currentCounts[workerNodeGroupName(w)] = w.Count
+	}
+
+	countsChanged := false
+	scaledCluster := newSpec.Cluster.DeepCopy()
+	for i := range scaledCluster.Spec.WorkerNodeGroupConfigurations {
+		w := &scaledCluster.Spec.WorkerNodeGroupConfigurations[i]
+		count, ok := currentCounts[workerNodeGroupName(*w)]
+		if !ok {
+			return false
+		}
+		if w.Count != count {
+			countsChanged = true
+			w.Count = count
+		}
+		// Current spec doesn't have the default name since we never set the defaults at the api server level
+		w.Name = currentWorkerNodeGroupName(currentWorkerConfigs, workerNodeGroupName(*w))
+	}
+
+	return countsChanged && scaledCluster.Equal(currentSpec.Cluster)
+}
+
+func workerNodeGroupName(w eksav1alpha1.WorkerNodeGroupConfiguration) string {
+	if w.Name == "" {
+		return "md-0"
+	}
+	return w.Name
+}
+
+func currentWorkerNodeGroupName(currentWorkerConfigs []eksav1alpha1.WorkerNodeGroupConfiguration, name string) string {
+	for _, w := range currentWorkerConfigs {
+		if workerNodeGroupName(w) == name {
+			return w.Name
+		}
+	}
+	return name
+}
@@ -130,3 +130,128 @@ func TestNodeGroupsToDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestOnlyWorkerNodeGroupCountsChanged(t *testing.T) {
+	workerNodeGroups := func(name string, count int) []anywherev1.WorkerNodeGroupConfiguration {
+		return []anywherev1.WorkerNodeGroupConfiguration{
+			{
+				Name:  name,
+				Count: count,
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.VSphereMachineConfigKind,
+					Name: "machine-config-1",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		new, current *cluster.Spec
+		want         bool
+	}{
+		{
+			name: "no changes",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+			}),
+			want: false,
+		},
+		{
+			name: "count changed",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 5)
+			}),
+			want: true,
+		},
+		{
+			name: "count changed, current missing default name",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("", 3)
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 5)
+			}),
+			want: true,
+		},
+		{
+			name: "count and kubernetes version changed",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = anywherev1.Kube121
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.KubernetesVersion = anywherev1.Kube122
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 5)
+			}),
+			want: false,
+		},
+		{
+			name: "node group renamed",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-1", 5)
+			}),
+			want: false,
+		},
+		{
+			name: "eks-d release changed",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+				s.VersionsBundle.EksD.Name = "kubernetes-1-21-eks-7"
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 5)
+				s.VersionsBundle.EksD.Name = "kubernetes-1-21-eks-8"
+			}),
+			want: false,
+		},
+		{
+			name: "count and pod iam config changed",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 5)
+				s.Cluster.Spec.PodIAMConfig = &anywherev1.PodIAMConfig{ServiceAccountIssuer: "https://issuer"}
+			}),
+			want: false,
+		},
+		{
+			name: "count and secondary oidc config changed",
+			current: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 3)
+				s.Cluster.Spec.IdentityProviderRefs = []anywherev1.Ref{{Kind: anywherev1.OIDCConfigKind, Name: "primary"}, {Kind: anywherev1.OIDCConfigKind, Name: "secondary"}}
+				s.OIDCConfigs = map[string]*anywherev1.OIDCConfig{
+					"primary":   {Spec: anywherev1.OIDCConfigSpec{IssuerUrl: "https://primary"}},
+					"secondary": {Spec: anywherev1.OIDCConfigSpec{IssuerUrl: "https://secondary"}},
+				}
+			}),
+			new: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Spec.WorkerNodeGroupConfigurations = workerNodeGroups("md-0", 5)
+				s.Cluster.Spec.IdentityProviderRefs = []anywherev1.Ref{{Kind: anywherev1.OIDCConfigKind, Name: "primary"}, {Kind: anywherev1.OIDCConfigKind, Name: "secondary"}}
+				s.OIDCConfigs = map[string]*anywherev1.OIDCConfig{
+					"primary":   {Spec: anywherev1.OIDCConfigSpec{IssuerUrl: "https://primary"}},
+					"secondary": {Spec: anywherev1.OIDCConfigSpec{IssuerUrl: "https://other"}},
+				}
+			}),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cluster.OnlyWorkerNodeGroupCountsChanged(tt.current, tt.new); got != tt.want {
+				t.Errorf("OnlyWorkerNodeGroupCountsChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
@@ -31,6 +32,7 @@ func oidcEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			validateOIDCIssuers,
 		},
 	}
 }
@@ -68,3 +70,55 @@ func getOIDC(ctx context.Context, client Client, c *Config) error {
 
 	return nil
 }
+
+// validateOIDCIssuers ensures the cluster references a single OIDCConfig. Several OIDC issuers
+// need the API server structured authentication configuration, which none of the supported
+// Kubernetes versions provide, so additional identity providers have to be configured with an
+// AWSIamConfig next to the primary OIDCConfig.
+func validateOIDCIssuers(c *Config) error {
+	oidcRefs := map[string]struct{}{}
+	for _, idr := range c.Cluster.Spec.IdentityProviderRefs {
+		if idr.Kind == anywherev1.OIDCConfigKind {
+			oidcRefs[idr.Name] = struct{}{}
+		}
+	}
+
+	if len(oidcRefs) > 1 {
+		return fmt.Errorf(
+			"multiple OIDCConfigs require structured authentication configuration, which isn't supported by Kubernetes %s: "+
+				"reference a single OIDCConfig and use an AWSIamConfig for additional identity providers",
+			c.Cluster.Spec.KubernetesVersion,
+		)
+	}
+
+	return nil
+}
+
+// primaryOIDCConfig returns the first OIDCConfig referenced by the cluster.
+func primaryOIDCConfig(c *Config) *anywherev1.OIDCConfig {
+	if configs := c.ReferencedOIDCConfigs(); len(configs) > 0 {
+		return configs[0]
+	}
+	return nil
+}
+
+// OIDCConfigsEqual returns true if both specs reference the same OIDCConfigs, in the same order and
+// with the same configuration.
+func (s *Spec) OIDCConfigsEqual(other *Spec) bool {
+	if s.OIDCConfig != nil && other.OIDCConfig != nil && !s.OIDCConfig.Spec.Equal(&other.OIDCConfig.Spec) {
+		return false
+	}
+
+	configs := s.ReferencedOIDCConfigs()
+	otherConfigs := other.ReferencedOIDCConfigs()
+	if len(configs) != len(otherConfigs) {
+		return false
+	}
+	for i := range configs {
+		if !configs[i].Spec.Equal(&otherConfigs[i].Spec) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -56,3 +56,49 @@ func TestDefaultConfigClientBuilderOIDC(t *testing.T) {
 	g.Expect(len(config.OIDCConfigs)).To(Equal(1))
 	g.Expect(config.OIDCConfigs["my-oidc"]).To(Equal(oidcConfig))
 }
+
+func TestValidateConfigMultipleOIDCConfigs(t *testing.T) {
+	g := NewWithT(t)
+	c := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-cluster",
+				Namespace: "default",
+			},
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube123,
+				IdentityProviderRefs: []anywherev1.Ref{
+					{Kind: anywherev1.OIDCConfigKind, Name: "oidc-1"},
+					{Kind: anywherev1.OIDCConfigKind, Name: "oidc-2"},
+				},
+			},
+		},
+	}
+
+	g.Expect(cluster.ValidateConfig(c)).To(
+		MatchError(ContainSubstring("multiple OIDCConfigs require structured authentication configuration, which isn't supported by Kubernetes 1.23")),
+	)
+}
+
+func TestConfigReferencedOIDCConfigs(t *testing.T) {
+	g := NewWithT(t)
+	oidc1 := &anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "oidc-1"}}
+	oidc2 := &anywherev1.OIDCConfig{ObjectMeta: metav1.ObjectMeta{Name: "oidc-2"}}
+	c := &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			Spec: anywherev1.ClusterSpec{
+				IdentityProviderRefs: []anywherev1.Ref{
+					{Kind: anywherev1.OIDCConfigKind, Name: "oidc-2"},
+					{Kind: anywherev1.AWSIamConfigKind, Name: "aws-iam"},
+					{Kind: anywherev1.OIDCConfigKind, Name: "oidc-1"},
+				},
+			},
+		},
+		OIDCConfigs: map[string]*anywherev1.OIDCConfig{
+			"oidc-1": oidc1,
+			"oidc-2": oidc2,
+		},
+	}
+
+	g.Expect(c.ReferencedOIDCConfigs()).To(Equal([]*anywherev1.OIDCConfig{oidc2, oidc1}))
+}
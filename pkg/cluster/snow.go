@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
@@ -15,10 +16,14 @@ func snowEntry() *ConfigManagerEntry {
 			anywherev1.SnowMachineConfigKind: func() APIObject {
 				return &anywherev1.SnowMachineConfig{}
 			},
+			anywherev1.SnowIPPoolKind: func() APIObject {
+				return &anywherev1.SnowIPPool{}
+			},
 		},
 		Processors: []ParsedProcessor{
 			processSnowDatacenter,
 			machineConfigsProcessor(processSnowMachineConfig),
+			processSnowIPPools,
 		},
 		Defaulters: []Defaulter{
 			func(c *Config) error {
@@ -54,6 +59,18 @@ func snowEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			func(c *Config) error {
+				for _, p := range c.SnowIPPools {
+					if err := p.Validate(); err != nil {
+						return err
+					}
+					if err := validateSameNamespace(c, p); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			validateSnowIPPoolsSize,
 		},
 	}
 }
@@ -86,6 +103,67 @@ func processSnowMachineConfig(c *Config, objects ObjectLookup, machineRef *anywh
 	c.SnowMachineConfigs[m.GetName()] = m.(*anywherev1.SnowMachineConfig)
 }
 
+func processSnowIPPools(c *Config, objects ObjectLookup) {
+	for _, m := range c.SnowMachineConfigs {
+		for _, ref := range m.IPPoolRefs() {
+			p := objects.GetFromRef(c.Cluster.APIVersion, ref)
+			if p == nil {
+				continue
+			}
+
+			if c.SnowIPPools == nil {
+				c.SnowIPPools = map[string]*anywherev1.SnowIPPool{}
+			}
+			c.SnowIPPools[p.GetName()] = p.(*anywherev1.SnowIPPool)
+		}
+	}
+}
+
+// validateSnowIPPoolsSize checks every SnowIPPool has enough addresses for all the DNIs that use it.
+// Each machine group needs one extra address so a rolling upgrade can create a new machine before
+// deleting an old one.
+func validateSnowIPPoolsSize(c *Config) error {
+	required := map[string]int{}
+	addMachines := func(ref *anywherev1.Ref, count int) error {
+		if ref == nil || ref.Kind != anywherev1.SnowMachineConfigKind {
+			return nil
+		}
+		m, ok := c.SnowMachineConfigs[ref.Name]
+		if !ok {
+			return nil
+		}
+		for _, poolRef := range m.IPPoolRefs() {
+			if _, ok := c.SnowIPPools[poolRef.Name]; !ok {
+				return fmt.Errorf("SnowIPPool %s referenced by SnowMachineConfig %s not found", poolRef.Name, m.Name)
+			}
+			required[poolRef.Name] += count + 1
+		}
+		return nil
+	}
+
+	if err := addMachines(c.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef, c.Cluster.Spec.ControlPlaneConfiguration.Count); err != nil {
+		return err
+	}
+	if c.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		if err := addMachines(c.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef, c.Cluster.Spec.ExternalEtcdConfiguration.Count); err != nil {
+			return err
+		}
+	}
+	for _, w := range c.Cluster.Spec.WorkerNodeGroupConfigurations {
+		if err := addMachines(w.MachineGroupRef, w.Count); err != nil {
+			return err
+		}
+	}
+
+	for name, count := range required {
+		if size := c.SnowIPPools[name].Size(); size < count {
+			return fmt.Errorf("SnowIPPool %s has %d ip addresses but the machines using it require %d, including one extra per machine group for rolling upgrades", name, size, count)
+		}
+	}
+
+	return nil
+}
+
 func SetSnowMachineConfigsAnnotations(c *Config) error {
 	if c.SnowMachineConfigs == nil {
 		return nil
@@ -119,6 +197,28 @@ func getSnowDatacenter(ctx context.Context, client Client, c *Config) error {
 	return nil
 }
 
+func getSnowIPPools(ctx context.Context, client Client, c *Config) error {
+	for _, m := range c.SnowMachineConfigs {
+		for _, ref := range m.IPPoolRefs() {
+			if _, ok := c.SnowIPPools[ref.Name]; ok {
+				continue
+			}
+
+			pool := &anywherev1.SnowIPPool{}
+			if err := client.Get(ctx, ref.Name, c.Cluster.Namespace, pool); err != nil {
+				return err
+			}
+
+			if c.SnowIPPools == nil {
+				c.SnowIPPools = map[string]*anywherev1.SnowIPPool{}
+			}
+			c.SnowIPPools[pool.Name] = pool
+		}
+	}
+
+	return nil
+}
+
 func getSnowMachineConfigs(ctx context.Context, client Client, c *Config) error {
 	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.SnowDatacenterKind {
 		return nil
@@ -213,3 +213,75 @@ func TestDefaultConfigClientBuilderSnowCluster(t *testing.T) {
 	g.Expect(config.SnowMachineConfigs["machine-1"]).To(Equal(machineControlPlane))
 	g.Expect(config.SnowMachineConfigs["machine-2"]).To(Equal(machineWorker))
 }
+
+func TestDefaultConfigClientBuilderSnowClusterWithIPPool(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.SnowDatacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.SnowMachineConfigKind,
+					Name: "machine-1",
+				},
+			},
+		},
+	}
+	machine := &anywherev1.SnowMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+		},
+		Spec: anywherev1.SnowMachineConfigSpec{
+			Network: &anywherev1.SnowNetwork{
+				DirectNetworkInterfaces: []anywherev1.SnowDirectNetworkInterface{
+					{
+						Index:     1,
+						Primary:   true,
+						IPPoolRef: &anywherev1.Ref{Kind: anywherev1.SnowIPPoolKind, Name: "pool"},
+					},
+				},
+			},
+		},
+	}
+	pool := &anywherev1.SnowIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+		},
+	}
+
+	client.EXPECT().Get(ctx, "datacenter", "default", &anywherev1.SnowDatacenterConfig{}).Return(nil)
+	client.EXPECT().Get(ctx, "machine-1", "default", &anywherev1.SnowMachineConfig{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.SnowMachineConfig)
+			m.ObjectMeta = machine.ObjectMeta
+			m.Spec = machine.Spec
+			return nil
+		},
+	)
+	client.EXPECT().Get(ctx, "pool", "default", &anywherev1.SnowIPPool{}).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			p := obj.(*anywherev1.SnowIPPool)
+			p.ObjectMeta = pool.ObjectMeta
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.SnowIPPools).To(HaveLen(1))
+	g.Expect(config.SnowIPPools["pool"]).To(Equal(pool))
+	g.Expect(config.ChildObjects()).To(ContainElement(pool))
+}
@@ -146,6 +146,24 @@ func WithOIDCConfig(oidcConfig *eksav1alpha1.OIDCConfig) SpecOpt {
 	}
 }
 
+// WithOIDCConfigs sets all the OIDCConfigs referenced by the cluster, the first one being the primary.
+func WithOIDCConfigs(oidcConfigs ...*eksav1alpha1.OIDCConfig) SpecOpt {
+	return func(s *Spec) {
+		for _, o := range oidcConfigs {
+			if o == nil {
+				continue
+			}
+			if s.OIDCConfig == nil {
+				s.OIDCConfig = o
+			}
+			if s.Config.OIDCConfigs == nil {
+				s.Config.OIDCConfigs = map[string]*eksav1alpha1.OIDCConfig{}
+			}
+			s.Config.OIDCConfigs[o.Name] = o
+		}
+	}
+}
+
 func NewSpec(opts ...SpecOpt) *Spec {
 	s := &Spec{
 		Config:              &Config{},
@@ -249,11 +267,8 @@ func (s *Spec) init(config *Config, bundles *v1alpha1.Bundles, versionsBundle *v
 		break
 	}
 
-	// Get first oidc config if it exists
-	for _, oc := range s.Config.OIDCConfigs {
-		s.OIDCConfig = oc
-		break
-	}
+	// Get the primary oidc config if it exists
+	s.OIDCConfig = primaryOIDCConfig(s.Config)
 
 	return nil
 }
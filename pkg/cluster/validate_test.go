@@ -75,3 +75,30 @@ func TestValidateConfigDifferentNamespace(t *testing.T) {
 		MatchError(ContainSubstring("VSphereDatacenterConfig and Cluster objects must have the same namespace specified")),
 	)
 }
+
+func TestValidateConfigSnowIPPool(t *testing.T) {
+	g := NewWithT(t)
+	c := clusterConfigFromFile(t, "testdata/cluster_snow_ip_pool.yaml")
+	g.Expect(c.SnowIPPools).To(HaveKey("eksa-unit-test-pool"))
+	g.Expect(cluster.ValidateConfig(c)).To(Succeed())
+}
+
+func TestValidateConfigSnowIPPoolTooSmall(t *testing.T) {
+	g := NewWithT(t)
+	c := clusterConfigFromFile(t, "testdata/cluster_snow_ip_pool.yaml")
+	c.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 3
+
+	g.Expect(cluster.ValidateConfig(c)).To(
+		MatchError(ContainSubstring("SnowIPPool eksa-unit-test-pool has 7 ip addresses but the machines using it require 8")),
+	)
+}
+
+func TestValidateConfigSnowIPPoolNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := clusterConfigFromFile(t, "testdata/cluster_snow_ip_pool.yaml")
+	delete(c.SnowIPPools, "eksa-unit-test-pool")
+
+	g.Expect(cluster.ValidateConfig(c)).To(
+		MatchError(ContainSubstring("SnowIPPool eksa-unit-test-pool referenced by SnowMachineConfig")),
+	)
+}
@@ -1,6 +1,10 @@
 package cluster
 
-import anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+import (
+	"context"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
 
 func vsphereEntry() *ConfigManagerEntry {
 	return &ConfigManagerEntry{
@@ -86,3 +90,42 @@ func processVSphereMachineConfig(c *Config, objects ObjectLookup, machineRef *an
 
 	c.VSphereMachineConfigs[m.GetName()] = m.(*anywherev1.VSphereMachineConfig)
 }
+
+func getVSphereDatacenter(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.VSphereDatacenterKind {
+		return nil
+	}
+
+	datacenter := &anywherev1.VSphereDatacenterConfig{}
+	if err := client.Get(ctx, c.Cluster.Spec.DatacenterRef.Name, c.Cluster.Namespace, datacenter); err != nil {
+		return err
+	}
+
+	c.VSphereDatacenter = datacenter
+	return nil
+}
+
+func getVSphereMachineConfigs(ctx context.Context, client Client, c *Config) error {
+	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.VSphereDatacenterKind {
+		return nil
+	}
+
+	if c.VSphereMachineConfigs == nil {
+		c.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{}
+	}
+
+	for _, machineRef := range c.Cluster.MachineConfigRefs() {
+		if machineRef.Kind != anywherev1.VSphereMachineConfigKind {
+			continue
+		}
+
+		machine := &anywherev1.VSphereMachineConfig{}
+		if err := client.Get(ctx, machineRef.Name, c.Cluster.Namespace, machine); err != nil {
+			return err
+		}
+
+		c.VSphereMachineConfigs[machine.Name] = machine
+	}
+
+	return nil
+}
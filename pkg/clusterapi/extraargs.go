@@ -57,7 +57,26 @@ func NodeCIDRMaskExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
 		return nil
 	}
 	args := ExtraArgs{}
-	args.AddIfNotEmpty("node-cidr-mask-size", strconv.Itoa(*clusterNetwork.Nodes.CIDRMaskSize))
+	maskSize := strconv.Itoa(*clusterNetwork.Nodes.CIDRMaskSize)
+	if clusterNetwork.IsDualStack() {
+		// kube-controller-manager doesn't accept node-cidr-mask-size for dual-stack clusters
+		// the mask size applies to the primary IP family
+		args.AddIfNotEmpty(fmt.Sprintf("node-cidr-mask-size-%s", strings.ToLower(string(clusterNetwork.PrimaryIPFamily()))), maskSize)
+	} else {
+		args.AddIfNotEmpty("node-cidr-mask-size", maskSize)
+	}
+	return args
+}
+
+// NodeIPExtraArgs returns the kubelet args needed for the node to advertise an address
+// of the cluster primary IP family. IPv4 is the kubelet default, so no args are needed for it.
+func NodeIPExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
+	if clusterNetwork == nil || clusterNetwork.PrimaryIPFamily() != v1alpha1.IPv6Family {
+		return nil
+	}
+	args := ExtraArgs{}
+	// the unspecified address makes kubelet pick the default IPv6 address of the host
+	args.AddIfNotEmpty("node-ip", "::")
 	return args
 }
 
@@ -427,6 +427,51 @@ func TestAppend(t *testing.T) {
 	}
 }
 
+func TestNodeIPExtraArgs(t *testing.T) {
+	tests := []struct {
+		testName       string
+		clusterNetwork *v1alpha1.ClusterNetwork
+		want           clusterapi.ExtraArgs
+	}{
+		{
+			testName:       "no cluster network config",
+			clusterNetwork: nil,
+			want:           nil,
+		},
+		{
+			testName: "ipv4",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+			},
+			want: nil,
+		},
+		{
+			testName: "ipv4 primary dual-stack",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16", "fd00:1::/56"}},
+			},
+			want: nil,
+		},
+		{
+			testName: "ipv6 primary",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"fd00:1::/56"}},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-ip": "::",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			if got := clusterapi.NodeIPExtraArgs(tt.clusterNetwork); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NodeIPExtraArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 	nodeCidrMaskSize := new(int)
 	*nodeCidrMaskSize = 28
@@ -463,6 +508,16 @@ func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			testName: "with nodes config dual-stack",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods:  v1alpha1.Pods{CidrBlocks: []string{"fd00:1::/56", "192.168.0.0/16"}},
+				Nodes: &v1alpha1.Nodes{CIDRMaskSize: nodeCidrMaskSize},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-cidr-mask-size-ipv6": "28",
+			},
+		},
 	}
 
 	for _, tt := range tests {
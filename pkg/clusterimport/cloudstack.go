@@ -0,0 +1,117 @@
+package clusterimport
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// importCloudStack maps the CloudStackCluster and CloudStackMachineTemplates to a CloudStackDatacenterConfig
+// and a CloudStackMachineConfig per machine group.
+func (i *importer) importCloudStack() error {
+	cloudstackCluster := i.objs.CloudStack.Cluster
+	if cloudstackCluster == nil {
+		return fmt.Errorf("%s is required to import a CloudStack cluster", cloudstackClusterKind)
+	}
+
+	datacenter := &anywherev1.CloudStackDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: anywherev1.GroupVersion.String(),
+			Kind:       anywherev1.CloudStackDatacenterKind,
+		},
+		ObjectMeta: i.objectMeta(i.config.Cluster.Name),
+		Spec: anywherev1.CloudStackDatacenterConfigSpec{
+			Domain:  cloudstackCluster.Spec.Domain,
+			Account: cloudstackCluster.Spec.Account,
+		},
+	}
+	for _, zone := range cloudstackCluster.Spec.Zones {
+		datacenter.Spec.Zones = append(datacenter.Spec.Zones, anywherev1.CloudStackZone{
+			Id:   zone.ID,
+			Name: zone.Name,
+			Network: anywherev1.CloudStackResourceIdentifier{
+				Id:   zone.Network.ID,
+				Name: zone.Network.Name,
+			},
+		})
+	}
+	i.config.CloudStackDatacenter = datacenter
+	i.config.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.CloudStackDatacenterKind, Name: datacenter.Name}
+	i.report(cloudstackClusterKind, cloudstackCluster.Name, "spec.identityRef",
+		"the CloudStack management API endpoint is only in the identity secret, it must be set in the CloudStackDatacenterConfig managementApiEndpoint")
+
+	if i.config.Cluster.Spec.ControlPlaneConfiguration.Endpoint == nil && cloudstackCluster.Spec.ControlPlaneEndpoint.Host != "" {
+		i.config.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: cloudstackCluster.Spec.ControlPlaneEndpoint.Host}
+	}
+
+	i.config.CloudStackMachineConfigs = map[string]*anywherev1.CloudStackMachineConfig{}
+	reported := map[string]bool{}
+	for _, group := range i.machineGroups {
+		template, ok := i.objs.CloudStack.MachineTemplates[group.machineTemplate]
+		if !ok {
+			return fmt.Errorf("%s %s not found", cloudstackMachineTemplateKind, group.machineTemplate)
+		}
+		if !reported[template.Name] {
+			i.reportCloudStackMachineTemplate(template)
+			reported[template.Name] = true
+		}
+		i.config.CloudStackMachineConfigs[group.machineConfigName] = i.cloudstackMachineConfig(group, template)
+	}
+
+	return nil
+}
+
+func (i *importer) cloudstackMachineConfig(group machineGroup, template *cloudstackv1.CloudStackMachineTemplate) *anywherev1.CloudStackMachineConfig {
+	spec := template.Spec.Spec.Spec
+
+	return &anywherev1.CloudStackMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: anywherev1.GroupVersion.String(),
+			Kind:       anywherev1.CloudStackMachineConfigKind,
+		},
+		ObjectMeta: i.objectMeta(group.machineConfigName),
+		Spec: anywherev1.CloudStackMachineConfigSpec{
+			Template: anywherev1.CloudStackResourceIdentifier{
+				Id:   spec.Template.ID,
+				Name: spec.Template.Name,
+			},
+			ComputeOffering: anywherev1.CloudStackResourceIdentifier{
+				Id:   spec.Offering.ID,
+				Name: spec.Offering.Name,
+			},
+			DiskOffering: anywherev1.CloudStackResourceDiskOffering{
+				CloudStackResourceIdentifier: anywherev1.CloudStackResourceIdentifier{
+					Id:   spec.DiskOffering.ID,
+					Name: spec.DiskOffering.Name,
+				},
+				CustomSize: spec.DiskOffering.CustomSize,
+				MountPath:  spec.DiskOffering.MountPath,
+				Device:     spec.DiskOffering.Device,
+				Filesystem: spec.DiskOffering.Filesystem,
+				Label:      spec.DiskOffering.Label,
+			},
+			Users:             users(group.users),
+			Affinity:          spec.Affinity,
+			AffinityGroupIds:  spec.AffinityGroupIDs,
+			UserCustomDetails: spec.Details,
+		},
+	}
+}
+
+func (i *importer) reportCloudStackMachineTemplate(template *cloudstackv1.CloudStackMachineTemplate) {
+	name := template.Name
+	spec := template.Spec.Spec.Spec
+
+	if spec.SSHKey != "" {
+		i.report(cloudstackMachineTemplateKind, name, "spec.template.spec.sshKey", "EKS-A configures the SSH access with the machine config users")
+	}
+	if spec.AffinityGroupRef != nil {
+		i.report(cloudstackMachineTemplateKind, name, "spec.template.spec.cloudstackaffinityref", "EKS-A only sets affinity groups by ID or affinity type")
+	}
+	if spec.ZoneID != "" || spec.ZoneName != "" {
+		i.report(cloudstackMachineTemplateKind, name, "spec.template.spec.zone", "EKS-A places the machines in the zones of the CloudStackDatacenterConfig")
+	}
+}
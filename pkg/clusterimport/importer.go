@@ -0,0 +1,435 @@
+package clusterimport
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	capiClusterKind       = "Cluster"
+	machineDeploymentKind = "MachineDeployment"
+	nodeLabelsArg         = "node-labels"
+)
+
+// generatedKubeletArgs are the kubelet extra args EKS-A sets in the nodes it creates.
+var generatedKubeletArgs = map[string]struct{}{
+	nodeLabelsArg:       {},
+	"tls-cipher-suites": {},
+	"cloud-provider":    {},
+	"provider-id":       {},
+	"read-only-port":    {},
+	"anonymous-auth":    {},
+	"cgroup-driver":     {},
+	"eviction-hard":     {},
+	"resolv-conf":       {},
+	"node-ip":           {},
+}
+
+// kubernetesVersionRegex matches a kubernetes version tag, like v1.21.2 or v1.21.2-eks-1-21-4.
+var kubernetesVersionRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)\.\d+`)
+
+// UnsupportedField is a field of a CAPI object that can't be represented in the EKS-A cluster config.
+// EKS-A overwrites or drops it once it reconciles the imported cluster.
+type UnsupportedField struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Result is the EKS-A cluster config imported from the CAPI objects of a cluster,
+// along with the fields of those objects it can't represent.
+type Result struct {
+	Config      *cluster.Config
+	Unsupported []UnsupportedField
+}
+
+// machineGroup is a set of machines that maps to an EKS-A machine config:
+// the control plane or a worker node group.
+type machineGroup struct {
+	machineConfigName string
+	machineTemplate   string
+	users             []bootstrapv1.User
+}
+
+type importer struct {
+	objs          *CAPIObjects
+	config        *cluster.Config
+	machineGroups []machineGroup
+	unsupported   []UnsupportedField
+}
+
+// Import maps the CAPI objects of a cluster to an EKS-A Cluster, datacenter config and machine configs,
+// the inverse of the objects built by the clusterapi package. The cluster is managed by managementCluster
+// and its objects are created in namespace.
+// All the EKS-A objects are paused, so nothing is reconciled until the imported config has been reviewed.
+func Import(objs *CAPIObjects, managementCluster *anywherev1.Cluster, namespace string) (*Result, error) {
+	if objs.Cluster == nil || objs.KubeadmControlPlane == nil {
+		return nil, fmt.Errorf("a CAPI Cluster and a %s are required to import a cluster", kubeadmControlPlaneKind)
+	}
+	if err := validateAdoptable(objs); err != nil {
+		return nil, err
+	}
+
+	i := &importer{
+		objs:   objs,
+		config: &cluster.Config{},
+	}
+
+	if err := i.importCluster(managementCluster, namespace); err != nil {
+		return nil, err
+	}
+	i.importControlPlane()
+	if err := i.importWorkerNodeGroups(); err != nil {
+		return nil, err
+	}
+
+	var machineConfigKind string
+	switch {
+	case objs.VSphere != nil:
+		if err := i.importVSphere(); err != nil {
+			return nil, err
+		}
+		machineConfigKind = anywherev1.VSphereMachineConfigKind
+	case objs.CloudStack != nil:
+		if err := i.importCloudStack(); err != nil {
+			return nil, err
+		}
+		machineConfigKind = anywherev1.CloudStackMachineConfigKind
+	default:
+		return nil, fmt.Errorf("the %s or %s objects are required to import a cluster", vsphereClusterKind, cloudstackClusterKind)
+	}
+
+	spec := &i.config.Cluster.Spec
+	spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Kind: machineConfigKind, Name: i.machineGroups[0].machineConfigName}
+	for j := range spec.WorkerNodeGroupConfigurations {
+		spec.WorkerNodeGroupConfigurations[j].MachineGroupRef = &anywherev1.Ref{Kind: machineConfigKind, Name: i.machineGroups[j+1].machineConfigName}
+	}
+
+	pauseReconcile(i.config)
+
+	return &Result{
+		Config:      i.config,
+		Unsupported: i.unsupported,
+	}, nil
+}
+
+// validateAdoptable checks the CAPI objects are in the namespace and follow the names EKS-A generates.
+// Otherwise EKS-A wouldn't adopt them once the cluster is resumed: it would create a second set of objects
+// next to them and the commands working on the CAPI cluster wouldn't find it.
+func validateAdoptable(objs *CAPIObjects) error {
+	clusterName := objs.Cluster.Name
+	var mismatches []string
+	if objs.Cluster.Namespace != constants.EksaSystemNamespace {
+		mismatches = append(mismatches, fmt.Sprintf("%s %s is in namespace %s", capiClusterKind, clusterName, objs.Cluster.Namespace))
+	}
+	if objs.KubeadmControlPlane.Name != clusterName {
+		mismatches = append(mismatches, fmt.Sprintf("%s %s isn't named %s", kubeadmControlPlaneKind, objs.KubeadmControlPlane.Name, clusterName))
+	}
+	if objs.VSphere != nil && objs.VSphere.Cluster != nil && objs.VSphere.Cluster.Name != clusterName {
+		mismatches = append(mismatches, fmt.Sprintf("%s %s isn't named %s", vsphereClusterKind, objs.VSphere.Cluster.Name, clusterName))
+	}
+	if objs.CloudStack != nil && objs.CloudStack.Cluster != nil && objs.CloudStack.Cluster.Name != clusterName {
+		mismatches = append(mismatches, fmt.Sprintf("%s %s isn't named %s", cloudstackClusterKind, objs.CloudStack.Cluster.Name, clusterName))
+	}
+	for _, md := range objs.MachineDeployments {
+		if !strings.HasPrefix(md.Name, clusterName+"-") {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s isn't named %s-<worker node group>", machineDeploymentKind, md.Name, clusterName))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("EKS-A only adopts the CAPI objects in the %s namespace named after the cluster, the cluster can't be imported: %s",
+			constants.EksaSystemNamespace, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+func (i *importer) report(kind, name, field, reason string) {
+	i.unsupported = append(i.unsupported, UnsupportedField{
+		Kind:   kind,
+		Name:   name,
+		Field:  field,
+		Reason: reason,
+	})
+}
+
+func (i *importer) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   i.config.Cluster.Namespace,
+		Annotations: map[string]string{},
+	}
+}
+
+func (i *importer) importCluster(managementCluster *anywherev1.Cluster, namespace string) error {
+	capiCluster := i.objs.Cluster
+	kcp := i.objs.KubeadmControlPlane
+
+	kubernetesVersion, err := kubernetesVersion(kcp.Spec.Version)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(kcp.Spec.Version, "-eks-") {
+		i.report(kubeadmControlPlaneKind, kcp.Name, "spec.version",
+			fmt.Sprintf("EKS-A runs EKS Distro, the nodes are replaced with EKS Distro %s nodes", kubernetesVersion))
+	}
+
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: anywherev1.GroupVersion.String(),
+			Kind:       anywherev1.ClusterKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capiCluster.Name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: kubernetesVersion,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{None: &anywherev1.NoneCNIConfig{}},
+			},
+		},
+	}
+	c.SetManagedBy(managementCluster.Name)
+	if managementCluster.Spec.BundlesRef != nil {
+		c.Spec.BundlesRef = managementCluster.Spec.BundlesRef.DeepCopy()
+	}
+	i.config.Cluster = c
+
+	if capiCluster.Spec.ManagedExternalEtcdRef != nil {
+		i.report(capiClusterKind, capiCluster.Name, "spec.managedExternalEtcdRef", "external etcd clusters can't be imported")
+	}
+
+	if host := capiCluster.Spec.ControlPlaneEndpoint.Host; host != "" {
+		c.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: host}
+	}
+
+	network := capiCluster.Spec.ClusterNetwork
+	if network == nil {
+		i.report(capiClusterKind, capiCluster.Name, "spec.clusterNetwork", "not set, the pods and services CIDR blocks must be set in the EKS-A Cluster")
+		return nil
+	}
+	if network.Pods != nil {
+		c.Spec.ClusterNetwork.Pods.CidrBlocks = network.Pods.CIDRBlocks
+	}
+	if network.Services != nil {
+		c.Spec.ClusterNetwork.Services.CidrBlocks = network.Services.CIDRBlocks
+	}
+	if network.APIServerPort != nil && *network.APIServerPort != 6443 {
+		i.report(capiClusterKind, capiCluster.Name, "spec.clusterNetwork.apiServerPort", "EKS-A clusters serve the API on port 6443")
+	}
+	if network.ServiceDomain != "" && network.ServiceDomain != "cluster.local" {
+		i.report(capiClusterKind, capiCluster.Name, "spec.clusterNetwork.serviceDomain", "EKS-A clusters use the cluster.local service domain")
+	}
+
+	return nil
+}
+
+func (i *importer) importControlPlane() {
+	kcp := i.objs.KubeadmControlPlane
+	spec := kcp.Spec.KubeadmConfigSpec
+
+	if kcp.Spec.Replicas != nil {
+		i.config.Cluster.Spec.ControlPlaneConfiguration.Count = int(*kcp.Spec.Replicas)
+	}
+
+	if spec.InitConfiguration != nil {
+		registration := spec.InitConfiguration.NodeRegistration
+		i.config.Cluster.Spec.ControlPlaneConfiguration.Taints = registration.Taints
+		i.config.Cluster.Spec.ControlPlaneConfiguration.Labels = nodeLabels(registration.KubeletExtraArgs)
+		i.reportKubeletArgs(kubeadmControlPlaneKind, kcp.Name, "spec.kubeadmConfigSpec.initConfiguration", registration.KubeletExtraArgs)
+	}
+	if spec.JoinConfiguration != nil {
+		registration := spec.JoinConfiguration.NodeRegistration
+		cp := i.config.Cluster.Spec.ControlPlaneConfiguration
+		if !anywherev1.TaintsSliceEqual(registration.Taints, cp.Taints) || !labelsEqual(nodeLabels(registration.KubeletExtraArgs), cp.Labels) {
+			i.report(kubeadmControlPlaneKind, kcp.Name, "spec.kubeadmConfigSpec.joinConfiguration.nodeRegistration",
+				"EKS-A sets the same taints and labels in all the control plane nodes, the ones in initConfiguration are kept")
+		}
+		i.reportKubeletArgs(kubeadmControlPlaneKind, kcp.Name, "spec.kubeadmConfigSpec.joinConfiguration", registration.KubeletExtraArgs)
+	}
+
+	if spec.ClusterConfiguration != nil {
+		if spec.ClusterConfiguration.Etcd.External != nil {
+			i.report(kubeadmControlPlaneKind, kcp.Name, "spec.kubeadmConfigSpec.clusterConfiguration.etcd.external", "external etcd clusters can't be imported")
+		}
+		components := []struct {
+			name string
+			args map[string]string
+		}{
+			{name: "apiServer", args: spec.ClusterConfiguration.APIServer.ExtraArgs},
+			{name: "controllerManager", args: spec.ClusterConfiguration.ControllerManager.ExtraArgs},
+			{name: "scheduler", args: spec.ClusterConfiguration.Scheduler.ExtraArgs},
+		}
+		for _, c := range components {
+			if len(c.args) > 0 {
+				i.report(kubeadmControlPlaneKind, kcp.Name, fmt.Sprintf("spec.kubeadmConfigSpec.clusterConfiguration.%s.extraArgs", c.name), "EKS-A generates the control plane components args")
+			}
+		}
+	}
+	i.reportKubeadmConfigSpec(kubeadmControlPlaneKind, kcp.Name, "spec.kubeadmConfigSpec", spec)
+
+	i.machineGroups = append(i.machineGroups, machineGroup{
+		machineConfigName: i.config.Cluster.Name + "-cp",
+		machineTemplate:   kcp.Spec.MachineTemplate.InfrastructureRef.Name,
+		users:             spec.Users,
+	})
+}
+
+func (i *importer) importWorkerNodeGroups() error {
+	clusterName := i.config.Cluster.Name
+	machineDeployments := append(i.objs.MachineDeployments[:0:0], i.objs.MachineDeployments...)
+	sort.Slice(machineDeployments, func(a, b int) bool {
+		return machineDeployments[a].Name < machineDeployments[b].Name
+	})
+
+	for _, md := range machineDeployments {
+		groupName := strings.TrimPrefix(md.Name, clusterName+"-")
+		if md.Spec.Template.Spec.FailureDomain != nil {
+			i.report(machineDeploymentKind, md.Name, "spec.template.spec.failureDomain", "EKS-A doesn't set failure domains in worker node groups")
+		}
+
+		wng := anywherev1.WorkerNodeGroupConfiguration{Name: groupName}
+		if md.Spec.Replicas != nil {
+			wng.Count = int(*md.Spec.Replicas)
+		}
+
+		configRef := md.Spec.Template.Spec.Bootstrap.ConfigRef
+		if configRef == nil {
+			return fmt.Errorf("MachineDeployment %s doesn't reference a %s", md.Name, kubeadmConfigTemplateKind)
+		}
+		kct, ok := i.objs.KubeadmConfigTemplates[configRef.Name]
+		if !ok {
+			return fmt.Errorf("%s %s of MachineDeployment %s not found", kubeadmConfigTemplateKind, configRef.Name, md.Name)
+		}
+		spec := kct.Spec.Template.Spec
+		if spec.JoinConfiguration != nil {
+			registration := spec.JoinConfiguration.NodeRegistration
+			wng.Taints = registration.Taints
+			wng.Labels = nodeLabels(registration.KubeletExtraArgs)
+			i.reportKubeletArgs(kubeadmConfigTemplateKind, kct.Name, "spec.template.spec.joinConfiguration", registration.KubeletExtraArgs)
+		}
+		i.reportKubeadmConfigSpec(kubeadmConfigTemplateKind, kct.Name, "spec.template.spec", spec)
+
+		i.config.Cluster.Spec.WorkerNodeGroupConfigurations = append(i.config.Cluster.Spec.WorkerNodeGroupConfigurations, wng)
+		i.machineGroups = append(i.machineGroups, machineGroup{
+			machineConfigName: md.Name,
+			machineTemplate:   md.Spec.Template.Spec.InfrastructureRef.Name,
+			users:             spec.Users,
+		})
+	}
+
+	return nil
+}
+
+func (i *importer) reportKubeletArgs(kind, name, path string, args map[string]string) {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		if _, ok := generatedKubeletArgs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		i.report(kind, name, fmt.Sprintf("%s.nodeRegistration.kubeletExtraArgs.%s", path, k), "EKS-A generates the kubelet args")
+	}
+}
+
+func (i *importer) reportKubeadmConfigSpec(kind, name, path string, spec bootstrapv1.KubeadmConfigSpec) {
+	if len(spec.Files) > 0 {
+		i.report(kind, name, path+".files", "EKS-A generates the files written in the nodes")
+	}
+	if len(spec.PreKubeadmCommands) > 0 {
+		i.report(kind, name, path+".preKubeadmCommands", "EKS-A generates the commands run in the nodes")
+	}
+	if len(spec.PostKubeadmCommands) > 0 {
+		i.report(kind, name, path+".postKubeadmCommands", "EKS-A generates the commands run in the nodes")
+	}
+}
+
+// reportDatacenterField reports a field of a machine template that EKS-A keeps in the datacenter config,
+// shared by all the machines, when it differs from the value imported into the datacenter config.
+func (i *importer) reportDatacenterField(kind, name, field string, value, datacenterValue interface{}) {
+	if !reflect.DeepEqual(value, datacenterValue) {
+		i.report(kind, name, field, fmt.Sprintf("EKS-A uses the same %s for all the machines of a cluster, %v is used", field, datacenterValue))
+	}
+}
+
+func kubernetesVersion(version string) (anywherev1.KubernetesVersion, error) {
+	m := kubernetesVersionRegex.FindStringSubmatch(version)
+	if m == nil {
+		return "", fmt.Errorf("invalid kubernetes version %s", version)
+	}
+
+	return anywherev1.KubernetesVersion(fmt.Sprintf("%s.%s", m[1], m[2])), nil
+}
+
+// nodeLabels parses the node-labels kubelet arg, the inverse of clusterapi.WorkerNodeLabelsExtraArgs.
+func nodeLabels(kubeletArgs map[string]string) map[string]string {
+	arg := kubeletArgs[nodeLabelsArg]
+	if arg == "" {
+		return nil
+	}
+
+	labels := map[string]string{}
+	for _, label := range strings.Split(arg, ",") {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) == 2 {
+			labels[kv[0]] = kv[1]
+		} else {
+			labels[kv[0]] = ""
+		}
+	}
+
+	return labels
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func users(users []bootstrapv1.User) []anywherev1.UserConfiguration {
+	if len(users) == 0 {
+		return nil
+	}
+
+	configs := make([]anywherev1.UserConfiguration, 0, len(users))
+	for _, u := range users {
+		configs = append(configs, anywherev1.UserConfiguration{
+			Name:              u.Name,
+			SshAuthorizedKeys: u.SSHAuthorizedKeys,
+		})
+	}
+
+	return configs
+}
+
+func pauseReconcile(config *cluster.Config) {
+	config.Cluster.PauseReconcile()
+	if config.VSphereDatacenter != nil {
+		config.VSphereDatacenter.PauseReconcile()
+	}
+	if config.CloudStackDatacenter != nil {
+		config.CloudStackDatacenter.PauseReconcile()
+	}
+	for _, m := range config.VSphereMachineConfigs {
+		m.PauseReconcile()
+	}
+	for _, m := range config.CloudStackMachineConfigs {
+		m.PauseReconcile()
+	}
+}
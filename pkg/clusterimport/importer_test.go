@@ -0,0 +1,367 @@
+package clusterimport_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func managementCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mgmt"},
+		Spec: anywherev1.ClusterSpec{
+			BundlesRef: &anywherev1.BundlesRef{
+				APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+				Name:       "bundles-1",
+				Namespace:  "eksa-system",
+			},
+		},
+	}
+}
+
+func capiObjects() *clusterimport.CAPIObjects {
+	return &clusterimport.CAPIObjects{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: constants.EksaSystemNamespace},
+			Spec: clusterv1.ClusterSpec{
+				ClusterNetwork: &clusterv1.ClusterNetwork{
+					Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+					Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.96.0.0/12"}},
+				},
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "1.2.3.4", Port: 6443},
+			},
+		},
+		KubeadmControlPlane: &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: constants.EksaSystemNamespace},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Replicas: int32Ptr(3),
+				Version:  "v1.21.2-eks-1-21-4",
+				MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+					InfrastructureRef: corev1.ObjectReference{Name: "w01-control-plane-1"},
+				},
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					InitConfiguration: &bootstrapv1.InitConfiguration{
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+							KubeletExtraArgs: map[string]string{"cloud-provider": "external", "node-labels": "cp=true"},
+							Taints:           []corev1.Taint{{Key: "key1", Value: "val1", Effect: corev1.TaintEffectNoSchedule}},
+						},
+					},
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+							KubeletExtraArgs: map[string]string{"cloud-provider": "external", "node-labels": "cp=true"},
+							Taints:           []corev1.Taint{{Key: "key1", Value: "val1", Effect: corev1.TaintEffectNoSchedule}},
+						},
+					},
+					Users: []bootstrapv1.User{{Name: "capv", SSHAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+				},
+			},
+		},
+		MachineDeployments: []clusterv1.MachineDeployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "w01-md-0", Namespace: constants.EksaSystemNamespace},
+				Spec: clusterv1.MachineDeploymentSpec{
+					ClusterName: "w01",
+					Replicas:    int32Ptr(2),
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Bootstrap: clusterv1.Bootstrap{
+								ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "w01-md-0-1"},
+							},
+							InfrastructureRef: corev1.ObjectReference{Name: "w01-md-0-1"},
+						},
+					},
+				},
+			},
+		},
+		KubeadmConfigTemplates: map[string]*bootstrapv1.KubeadmConfigTemplate{
+			"w01-md-0-1": {
+				ObjectMeta: metav1.ObjectMeta{Name: "w01-md-0-1", Namespace: constants.EksaSystemNamespace},
+				Spec: bootstrapv1.KubeadmConfigTemplateSpec{
+					Template: bootstrapv1.KubeadmConfigTemplateResource{
+						Spec: bootstrapv1.KubeadmConfigSpec{
+							JoinConfiguration: &bootstrapv1.JoinConfiguration{
+								NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+									KubeletExtraArgs: map[string]string{"node-labels": "app=web,tier=front"},
+								},
+							},
+							Users: []bootstrapv1.User{{Name: "capv", SSHAuthorizedKeys: []string{"ssh-rsa BBBB"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func vsphereMachineTemplate(name string) *vspherev1.VSphereMachineTemplate {
+	return &vspherev1.VSphereMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Spec: vspherev1.VSphereMachineTemplateSpec{
+			Template: vspherev1.VSphereMachineTemplateResource{
+				Spec: vspherev1.VSphereMachineSpec{
+					VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{
+						Template:     "/SDDC-Datacenter/vm/Templates/ubuntu-2004-kube-v1.21.2",
+						CloneMode:    vspherev1.LinkedClone,
+						Server:       "vsphere.example.com",
+						Datacenter:   "SDDC-Datacenter",
+						Folder:       "/SDDC-Datacenter/vm",
+						Datastore:    "/SDDC-Datacenter/datastore/WorkloadDatastore",
+						ResourcePool: "*/Resources",
+						Network: vspherev1.NetworkSpec{
+							Devices: []vspherev1.NetworkDeviceSpec{{NetworkName: "/SDDC-Datacenter/network/sddc-cgw-network-1", DHCP4: true}},
+						},
+						NumCPUs:   2,
+						MemoryMiB: 8192,
+						DiskGiB:   25,
+					},
+				},
+			},
+		},
+	}
+}
+
+func vsphereObjects() *clusterimport.CAPIObjects {
+	objs := capiObjects()
+	objs.VSphere = &clusterimport.VSphereObjects{
+		Cluster: &vspherev1.VSphereCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: constants.EksaSystemNamespace},
+			Spec: vspherev1.VSphereClusterSpec{
+				Server:     "vsphere.example.com",
+				Thumbprint: "ABCDEFG",
+			},
+		},
+		MachineTemplates: map[string]*vspherev1.VSphereMachineTemplate{
+			"w01-control-plane-1": vsphereMachineTemplate("w01-control-plane-1"),
+			"w01-md-0-1":          vsphereMachineTemplate("w01-md-0-1"),
+		},
+	}
+	return objs
+}
+
+func cloudstackObjects() *clusterimport.CAPIObjects {
+	objs := capiObjects()
+	template := func(name string) *cloudstackv1.CloudStackMachineTemplate {
+		return &cloudstackv1.CloudStackMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+			Spec: cloudstackv1.CloudStackMachineTemplateSpec{
+				Spec: cloudstackv1.CloudStackMachineTemplateResource{
+					Spec: cloudstackv1.CloudStackMachineSpec{
+						Template: cloudstackv1.CloudStackResourceIdentifier{Name: "rhel8-kube-1.21"},
+						Offering: cloudstackv1.CloudStackResourceIdentifier{Name: "m4-large"},
+						DiskOffering: cloudstackv1.CloudStackResourceDiskOffering{
+							CloudStackResourceIdentifier: cloudstackv1.CloudStackResourceIdentifier{Name: "Small"},
+							MountPath:                    "/data",
+							Device:                       "/dev/vdb",
+							Filesystem:                   "ext4",
+							Label:                        "data_disk",
+						},
+						Details:          map[string]string{"foo": "bar"},
+						AffinityGroupIDs: []string{"ag-1"},
+					},
+				},
+			},
+		}
+	}
+	objs.CloudStack = &clusterimport.CloudStackObjects{
+		Cluster: &cloudstackv1.CloudStackCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: constants.EksaSystemNamespace},
+			Spec: cloudstackv1.CloudStackClusterSpec{
+				Zones:   []cloudstackv1.Zone{{Name: "zone1", Network: cloudstackv1.Network{Name: "net1"}}},
+				Account: "admin",
+				Domain:  "domain1",
+			},
+		},
+		MachineTemplates: map[string]*cloudstackv1.CloudStackMachineTemplate{
+			"w01-control-plane-1": template("w01-control-plane-1"),
+			"w01-md-0-1":          template("w01-md-0-1"),
+		},
+	}
+	return objs
+}
+
+func TestImportVSphere(t *testing.T) {
+	g := NewWithT(t)
+
+	result, err := clusterimport.Import(vsphereObjects(), managementCluster(), "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Unsupported).To(BeEmpty())
+
+	config := result.Config
+	g.Expect(config.Cluster.Name).To(Equal("w01"))
+	g.Expect(config.Cluster.Namespace).To(Equal("default"))
+	g.Expect(config.Cluster.IsReconcilePaused()).To(BeTrue())
+	g.Expect(config.Cluster.Spec.ManagementCluster.Name).To(Equal("mgmt"))
+	g.Expect(config.Cluster.Spec.BundlesRef.Name).To(Equal("bundles-1"))
+	g.Expect(config.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube121))
+	g.Expect(config.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks).To(ConsistOf("192.168.0.0/16"))
+	g.Expect(config.Cluster.Spec.ClusterNetwork.Services.CidrBlocks).To(ConsistOf("10.96.0.0/12"))
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "w01"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration).To(Equal(anywherev1.ControlPlaneConfiguration{
+		Count:           3,
+		Endpoint:        &anywherev1.Endpoint{Host: "1.2.3.4"},
+		MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "w01-cp"},
+		Taints:          []corev1.Taint{{Key: "key1", Value: "val1", Effect: corev1.TaintEffectNoSchedule}},
+		Labels:          map[string]string{"cp": "true"},
+	}))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations).To(Equal([]anywherev1.WorkerNodeGroupConfiguration{
+		{
+			Name:            "md-0",
+			Count:           2,
+			MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "w01-md-0"},
+			Labels:          map[string]string{"app": "web", "tier": "front"},
+		},
+	}))
+
+	g.Expect(config.VSphereDatacenter.Spec).To(Equal(anywherev1.VSphereDatacenterConfigSpec{
+		Datacenter: "SDDC-Datacenter",
+		Network:    "/SDDC-Datacenter/network/sddc-cgw-network-1",
+		Server:     "vsphere.example.com",
+		Thumbprint: "ABCDEFG",
+	}))
+	g.Expect(config.VSphereDatacenter.IsReconcilePaused()).To(BeTrue())
+
+	g.Expect(config.VSphereMachineConfigs).To(HaveLen(2))
+	cp := config.VsphereMachineConfig("w01-cp")
+	g.Expect(cp.IsReconcilePaused()).To(BeTrue())
+	g.Expect(cp.Spec).To(Equal(anywherev1.VSphereMachineConfigSpec{
+		DiskGiB:      25,
+		Datastore:    "/SDDC-Datacenter/datastore/WorkloadDatastore",
+		Folder:       "/SDDC-Datacenter/vm",
+		NumCPUs:      2,
+		MemoryMiB:    8192,
+		OSFamily:     anywherev1.Ubuntu,
+		ResourcePool: "*/Resources",
+		Template:     "/SDDC-Datacenter/vm/Templates/ubuntu-2004-kube-v1.21.2",
+		Users:        []anywherev1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+	}))
+	g.Expect(config.VsphereMachineConfig("w01-md-0").Spec.Users).To(Equal(
+		[]anywherev1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa BBBB"}}},
+	))
+}
+
+func TestImportVSphereUnsupportedFields(t *testing.T) {
+	g := NewWithT(t)
+	objs := vsphereObjects()
+	objs.KubeadmControlPlane.Spec.Version = "v1.22.6"
+	objs.KubeadmControlPlane.Spec.KubeadmConfigSpec.PreKubeadmCommands = []string{"hostname \"{{ ds.meta_data.hostname }}\""}
+	objs.KubeadmControlPlane.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.KubeletExtraArgs["max-pods"] = "200"
+	worker := objs.VSphere.MachineTemplates["w01-md-0-1"]
+	worker.Spec.Template.Spec.Network.Devices = append(worker.Spec.Template.Spec.Network.Devices, vspherev1.NetworkDeviceSpec{NetworkName: "storage", DHCP4: true})
+	worker.Spec.Template.Spec.Datacenter = "other-datacenter"
+	worker.Spec.Template.Spec.CustomVMXKeys = map[string]string{"key": "value"}
+	worker.Spec.Template.Spec.Template = "bottlerocket-kube-v1.22"
+
+	result, err := clusterimport.Import(objs, managementCluster(), "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Config.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube122))
+	g.Expect(result.Config.VsphereMachineConfig("w01-md-0").Spec.OSFamily).To(Equal(anywherev1.Bottlerocket))
+
+	fields := make([]string, 0, len(result.Unsupported))
+	for _, f := range result.Unsupported {
+		fields = append(fields, f.Kind+" "+f.Name+" "+f.Field)
+	}
+	g.Expect(fields).To(ConsistOf(
+		"KubeadmControlPlane w01 spec.version",
+		"KubeadmControlPlane w01 spec.kubeadmConfigSpec.joinConfiguration.nodeRegistration.kubeletExtraArgs.max-pods",
+		"KubeadmControlPlane w01 spec.kubeadmConfigSpec.preKubeadmCommands",
+		"VSphereMachineTemplate w01-md-0-1 spec.template.spec.datacenter",
+		"VSphereMachineTemplate w01-md-0-1 spec.template.spec.network.devices",
+		"VSphereMachineTemplate w01-md-0-1 spec.template.spec.customVMXKeys",
+	))
+}
+
+func TestImportObjectsNotAdoptable(t *testing.T) {
+	g := NewWithT(t)
+	objs := vsphereObjects()
+	objs.Cluster.Namespace = "default"
+	objs.KubeadmControlPlane.Name = "w01-control-plane"
+	objs.MachineDeployments[0].Name = "workers"
+
+	_, err := clusterimport.Import(objs, managementCluster(), "default")
+	g.Expect(err).To(MatchError(ContainSubstring("Cluster w01 is in namespace default")))
+	g.Expect(err).To(MatchError(ContainSubstring("KubeadmControlPlane w01-control-plane isn't named w01")))
+	g.Expect(err).To(MatchError(ContainSubstring("MachineDeployment workers isn't named w01-<worker node group>")))
+}
+
+func TestImportCloudStack(t *testing.T) {
+	g := NewWithT(t)
+
+	result, err := clusterimport.Import(cloudstackObjects(), managementCluster(), "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Unsupported).To(ConsistOf(clusterimport.UnsupportedField{
+		Kind:   "CloudStackCluster",
+		Name:   "w01",
+		Field:  "spec.identityRef",
+		Reason: "the CloudStack management API endpoint is only in the identity secret, it must be set in the CloudStackDatacenterConfig managementApiEndpoint",
+	}))
+
+	config := result.Config
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.CloudStackDatacenterKind, Name: "w01"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef).To(Equal(&anywherev1.Ref{Kind: anywherev1.CloudStackMachineConfigKind, Name: "w01-cp"}))
+	g.Expect(config.CloudStackDatacenter.IsReconcilePaused()).To(BeTrue())
+	g.Expect(config.CloudStackDatacenter.Spec).To(Equal(anywherev1.CloudStackDatacenterConfigSpec{
+		Domain:  "domain1",
+		Account: "admin",
+		Zones: []anywherev1.CloudStackZone{
+			{Name: "zone1", Network: anywherev1.CloudStackResourceIdentifier{Name: "net1"}},
+		},
+	}))
+
+	g.Expect(config.CloudStackMachineConfigs).To(HaveLen(2))
+	worker := config.CloudStackMachineConfig("w01-md-0")
+	g.Expect(worker.IsReconcilePaused()).To(BeTrue())
+	g.Expect(worker.Spec).To(Equal(anywherev1.CloudStackMachineConfigSpec{
+		Template:        anywherev1.CloudStackResourceIdentifier{Name: "rhel8-kube-1.21"},
+		ComputeOffering: anywherev1.CloudStackResourceIdentifier{Name: "m4-large"},
+		DiskOffering: anywherev1.CloudStackResourceDiskOffering{
+			CloudStackResourceIdentifier: anywherev1.CloudStackResourceIdentifier{Name: "Small"},
+			MountPath:                    "/data",
+			Device:                       "/dev/vdb",
+			Filesystem:                   "ext4",
+			Label:                        "data_disk",
+		},
+		Users:             []anywherev1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa BBBB"}}},
+		AffinityGroupIds:  []string{"ag-1"},
+		UserCustomDetails: map[string]string{"foo": "bar"},
+	}))
+}
+
+func TestImportErrorInvalidKubernetesVersion(t *testing.T) {
+	g := NewWithT(t)
+	objs := vsphereObjects()
+	objs.KubeadmControlPlane.Spec.Version = "latest"
+
+	_, err := clusterimport.Import(objs, managementCluster(), "default")
+	g.Expect(err).To(MatchError("invalid kubernetes version latest"))
+}
+
+func TestImportErrorMissingMachineTemplate(t *testing.T) {
+	g := NewWithT(t)
+	objs := vsphereObjects()
+	delete(objs.VSphere.MachineTemplates, "w01-md-0-1")
+
+	_, err := clusterimport.Import(objs, managementCluster(), "default")
+	g.Expect(err).To(MatchError("VSphereMachineTemplate w01-md-0-1 not found"))
+}
+
+func TestImportErrorNoInfrastructureObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := clusterimport.Import(capiObjects(), managementCluster(), "default")
+	g.Expect(err).To(MatchError("the VSphereCluster or CloudStackCluster objects are required to import a cluster"))
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/clusterimport (interfaces: KubectlClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	executables "github.com/aws/eks-anywhere/pkg/executables"
+	gomock "github.com/golang/mock/gomock"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// GetMachineDeployments mocks base method.
+func (m *MockKubectlClient) GetMachineDeployments(arg0 context.Context, arg1 ...executables.KubectlOpt) ([]v1beta1.MachineDeployment, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMachineDeployments", varargs...)
+	ret0, _ := ret[0].([]v1beta1.MachineDeployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachineDeployments indicates an expected call of GetMachineDeployments.
+func (mr *MockKubectlClientMockRecorder) GetMachineDeployments(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineDeployments", reflect.TypeOf((*MockKubectlClient)(nil).GetMachineDeployments), varargs...)
+}
+
+// GetObject mocks base method.
+func (m *MockKubectlClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockKubectlClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockKubectlClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
@@ -0,0 +1,183 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	kubeadmControlPlaneKind       = "KubeadmControlPlane"
+	kubeadmConfigTemplateKind     = "KubeadmConfigTemplate"
+	vsphereClusterKind            = "VSphereCluster"
+	vsphereMachineTemplateKind    = "VSphereMachineTemplate"
+	cloudstackClusterKind         = "CloudStackCluster"
+	cloudstackMachineTemplateKind = "CloudStackMachineTemplate"
+
+	capiClusterResourceType               = "clusters.cluster.x-k8s.io"
+	kubeadmControlPlaneResourceType       = "kubeadmcontrolplanes.controlplane.cluster.x-k8s.io"
+	kubeadmConfigTemplateResourceType     = "kubeadmconfigtemplates.bootstrap.cluster.x-k8s.io"
+	vsphereClusterResourceType            = "vsphereclusters.infrastructure.cluster.x-k8s.io"
+	vsphereMachineTemplateResourceType    = "vspheremachinetemplates.infrastructure.cluster.x-k8s.io"
+	cloudstackClusterResourceType         = "cloudstackclusters.infrastructure.cluster.x-k8s.io"
+	cloudstackMachineTemplateResourceType = "cloudstackmachinetemplates.infrastructure.cluster.x-k8s.io"
+)
+
+// CAPIObjects are the CAPI and infrastructure provider objects of a cluster created outside of EKS-A.
+// Only one of VSphere and CloudStack is set, depending on the infrastructure provider of the cluster.
+type CAPIObjects struct {
+	Cluster                *clusterv1.Cluster
+	KubeadmControlPlane    *controlplanev1.KubeadmControlPlane
+	MachineDeployments     []clusterv1.MachineDeployment
+	KubeadmConfigTemplates map[string]*bootstrapv1.KubeadmConfigTemplate
+	VSphere                *VSphereObjects
+	CloudStack             *CloudStackObjects
+}
+
+// VSphereObjects are the CAPV objects of a cluster. The machine templates are indexed by name.
+type VSphereObjects struct {
+	Cluster          *vspherev1.VSphereCluster
+	MachineTemplates map[string]*vspherev1.VSphereMachineTemplate
+}
+
+// CloudStackObjects are the CAPC objects of a cluster. The machine templates are indexed by name.
+type CloudStackObjects struct {
+	Cluster          *cloudstackv1.CloudStackCluster
+	MachineTemplates map[string]*cloudstackv1.CloudStackMachineTemplate
+}
+
+type KubectlClient interface {
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
+	GetMachineDeployments(ctx context.Context, opts ...executables.KubectlOpt) ([]clusterv1.MachineDeployment, error)
+}
+
+// Reader reads the CAPI objects of a cluster following the references from its CAPI Cluster.
+type Reader struct {
+	kubectl KubectlClient
+}
+
+func NewReader(kubectl KubectlClient) *Reader {
+	return &Reader{kubectl: kubectl}
+}
+
+// Read reads the CAPI objects of the cluster clusterName in namespace from managementCluster.
+func (r *Reader) Read(ctx context.Context, managementCluster *types.Cluster, clusterName, namespace string) (*CAPIObjects, error) {
+	kubeconfig := managementCluster.KubeconfigFile
+	objs := &CAPIObjects{
+		Cluster:                &clusterv1.Cluster{},
+		KubeadmConfigTemplates: map[string]*bootstrapv1.KubeadmConfigTemplate{},
+	}
+
+	if err := r.kubectl.GetObject(ctx, capiClusterResourceType, clusterName, namespace, kubeconfig, objs.Cluster); err != nil {
+		return nil, fmt.Errorf("reading CAPI cluster: %v", err)
+	}
+
+	controlPlaneRef := objs.Cluster.Spec.ControlPlaneRef
+	if controlPlaneRef == nil || controlPlaneRef.Kind != kubeadmControlPlaneKind {
+		return nil, fmt.Errorf("CAPI cluster %s doesn't reference a %s, only kubeadm control planes can be imported", clusterName, kubeadmControlPlaneKind)
+	}
+	objs.KubeadmControlPlane = &controlplanev1.KubeadmControlPlane{}
+	if err := r.kubectl.GetObject(ctx, kubeadmControlPlaneResourceType, controlPlaneRef.Name, namespace, kubeconfig, objs.KubeadmControlPlane); err != nil {
+		return nil, fmt.Errorf("reading %s: %v", kubeadmControlPlaneKind, err)
+	}
+
+	machineDeployments, err := r.kubectl.GetMachineDeployments(ctx, executables.WithCluster(managementCluster), executables.WithNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+	for _, md := range machineDeployments {
+		if md.Spec.ClusterName != clusterName {
+			continue
+		}
+		objs.MachineDeployments = append(objs.MachineDeployments, md)
+
+		configRef := md.Spec.Template.Spec.Bootstrap.ConfigRef
+		if configRef == nil || configRef.Kind != kubeadmConfigTemplateKind {
+			return nil, fmt.Errorf("MachineDeployment %s doesn't reference a %s, only kubeadm bootstrap configs can be imported", md.Name, kubeadmConfigTemplateKind)
+		}
+		kct := &bootstrapv1.KubeadmConfigTemplate{}
+		if err := r.kubectl.GetObject(ctx, kubeadmConfigTemplateResourceType, configRef.Name, namespace, kubeconfig, kct); err != nil {
+			return nil, fmt.Errorf("reading %s for MachineDeployment %s: %v", kubeadmConfigTemplateKind, md.Name, err)
+		}
+		objs.KubeadmConfigTemplates[kct.Name] = kct
+	}
+
+	machineTemplateNames := []string{objs.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name}
+	for _, md := range objs.MachineDeployments {
+		machineTemplateNames = append(machineTemplateNames, md.Spec.Template.Spec.InfrastructureRef.Name)
+	}
+
+	infrastructureRef := objs.Cluster.Spec.InfrastructureRef
+	if infrastructureRef == nil {
+		return nil, fmt.Errorf("CAPI cluster %s doesn't reference an infrastructure cluster", clusterName)
+	}
+
+	switch infrastructureRef.Kind {
+	case vsphereClusterKind:
+		objs.VSphere, err = r.readVSphere(ctx, kubeconfig, infrastructureRef.Name, namespace, machineTemplateNames)
+	case cloudstackClusterKind:
+		objs.CloudStack, err = r.readCloudStack(ctx, kubeconfig, infrastructureRef.Name, namespace, machineTemplateNames)
+	default:
+		return nil, fmt.Errorf("infrastructure cluster kind %s is not supported, only %s and %s clusters can be imported", infrastructureRef.Kind, vsphereClusterKind, cloudstackClusterKind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return objs, nil
+}
+
+func (r *Reader) readVSphere(ctx context.Context, kubeconfig, clusterName, namespace string, machineTemplateNames []string) (*VSphereObjects, error) {
+	objs := &VSphereObjects{
+		Cluster:          &vspherev1.VSphereCluster{},
+		MachineTemplates: map[string]*vspherev1.VSphereMachineTemplate{},
+	}
+	if err := r.kubectl.GetObject(ctx, vsphereClusterResourceType, clusterName, namespace, kubeconfig, objs.Cluster); err != nil {
+		return nil, fmt.Errorf("reading %s: %v", vsphereClusterKind, err)
+	}
+
+	for _, name := range machineTemplateNames {
+		if _, ok := objs.MachineTemplates[name]; ok {
+			continue
+		}
+		template := &vspherev1.VSphereMachineTemplate{}
+		if err := r.kubectl.GetObject(ctx, vsphereMachineTemplateResourceType, name, namespace, kubeconfig, template); err != nil {
+			return nil, fmt.Errorf("reading %s %s: %v", vsphereMachineTemplateKind, name, err)
+		}
+		objs.MachineTemplates[name] = template
+	}
+
+	return objs, nil
+}
+
+func (r *Reader) readCloudStack(ctx context.Context, kubeconfig, clusterName, namespace string, machineTemplateNames []string) (*CloudStackObjects, error) {
+	objs := &CloudStackObjects{
+		Cluster:          &cloudstackv1.CloudStackCluster{},
+		MachineTemplates: map[string]*cloudstackv1.CloudStackMachineTemplate{},
+	}
+	if err := r.kubectl.GetObject(ctx, cloudstackClusterResourceType, clusterName, namespace, kubeconfig, objs.Cluster); err != nil {
+		return nil, fmt.Errorf("reading %s: %v", cloudstackClusterKind, err)
+	}
+
+	for _, name := range machineTemplateNames {
+		if _, ok := objs.MachineTemplates[name]; ok {
+			continue
+		}
+		template := &cloudstackv1.CloudStackMachineTemplate{}
+		if err := r.kubectl.GetObject(ctx, cloudstackMachineTemplateResourceType, name, namespace, kubeconfig, template); err != nil {
+			return nil, fmt.Errorf("reading %s %s: %v", cloudstackMachineTemplateKind, name, err)
+		}
+		objs.MachineTemplates[name] = template
+	}
+
+	return objs, nil
+}
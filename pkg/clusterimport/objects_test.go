@@ -0,0 +1,164 @@
+package clusterimport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/clusterimport/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type readerTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	reader            *clusterimport.Reader
+	managementCluster *types.Cluster
+}
+
+func newReaderTest(t *testing.T) *readerTest {
+	ctrl := gomock.NewController(t)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	return &readerTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		kubectl:           kubectl,
+		reader:            clusterimport.NewReader(kubectl),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+	}
+}
+
+func (tt *readerTest) expectGetObject(resourceType, name string, obj runtime.Object) *gomock.Call {
+	return tt.kubectl.EXPECT().GetObject(tt.ctx, resourceType, name, "default", "mgmt.kubeconfig", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, into runtime.Object) error {
+			switch o := into.(type) {
+			case *clusterv1.Cluster:
+				*o = *obj.(*clusterv1.Cluster)
+			case *controlplanev1.KubeadmControlPlane:
+				*o = *obj.(*controlplanev1.KubeadmControlPlane)
+			case *bootstrapv1.KubeadmConfigTemplate:
+				*o = *obj.(*bootstrapv1.KubeadmConfigTemplate)
+			case *vspherev1.VSphereCluster:
+				*o = *obj.(*vspherev1.VSphereCluster)
+			case *vspherev1.VSphereMachineTemplate:
+				*o = *obj.(*vspherev1.VSphereMachineTemplate)
+			}
+			return nil
+		},
+	)
+}
+
+func readerCAPICluster() *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef:   &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: "w01-control-plane"},
+			InfrastructureRef: &corev1.ObjectReference{Kind: "VSphereCluster", Name: "w01"},
+		},
+	}
+}
+
+func readerMachineDeployment(name, clusterName string) clusterv1.MachineDeployment {
+	return clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: clusterName,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: name},
+					},
+					InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: "w01-worker"},
+				},
+			},
+		},
+	}
+}
+
+func TestReaderReadVSphere(t *testing.T) {
+	tt := newReaderTest(t)
+	capiCluster := readerCAPICluster()
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "w01-control-plane", Namespace: "default"},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: "w01-control-plane"},
+			},
+		},
+	}
+	md0 := readerMachineDeployment("w01-md-0", "w01")
+	md1 := readerMachineDeployment("w01-md-1", "w01")
+	otherMD := readerMachineDeployment("w02-md-0", "w02")
+	kct0 := &bootstrapv1.KubeadmConfigTemplate{ObjectMeta: metav1.ObjectMeta{Name: "w01-md-0"}}
+	kct1 := &bootstrapv1.KubeadmConfigTemplate{ObjectMeta: metav1.ObjectMeta{Name: "w01-md-1"}}
+	vsphereCluster := &vspherev1.VSphereCluster{ObjectMeta: metav1.ObjectMeta{Name: "w01"}}
+	cpTemplate := &vspherev1.VSphereMachineTemplate{ObjectMeta: metav1.ObjectMeta{Name: "w01-control-plane"}}
+	workerTemplate := &vspherev1.VSphereMachineTemplate{ObjectMeta: metav1.ObjectMeta{Name: "w01-worker"}}
+
+	tt.expectGetObject("clusters.cluster.x-k8s.io", "w01", capiCluster)
+	tt.expectGetObject("kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01-control-plane", kcp)
+	tt.kubectl.EXPECT().GetMachineDeployments(tt.ctx, gomock.Any(), gomock.Any()).Return([]clusterv1.MachineDeployment{md0, otherMD, md1}, nil)
+	tt.expectGetObject("kubeadmconfigtemplates.bootstrap.cluster.x-k8s.io", "w01-md-0", kct0)
+	tt.expectGetObject("kubeadmconfigtemplates.bootstrap.cluster.x-k8s.io", "w01-md-1", kct1)
+	tt.expectGetObject("vsphereclusters.infrastructure.cluster.x-k8s.io", "w01", vsphereCluster)
+	tt.expectGetObject("vspheremachinetemplates.infrastructure.cluster.x-k8s.io", "w01-control-plane", cpTemplate)
+	tt.expectGetObject("vspheremachinetemplates.infrastructure.cluster.x-k8s.io", "w01-worker", workerTemplate).Times(1)
+
+	objs, err := tt.reader.Read(tt.ctx, tt.managementCluster, "w01", "default")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(objs.Cluster).To(Equal(capiCluster))
+	tt.Expect(objs.KubeadmControlPlane).To(Equal(kcp))
+	tt.Expect(objs.MachineDeployments).To(Equal([]clusterv1.MachineDeployment{md0, md1}))
+	tt.Expect(objs.KubeadmConfigTemplates).To(Equal(map[string]*bootstrapv1.KubeadmConfigTemplate{"w01-md-0": kct0, "w01-md-1": kct1}))
+	tt.Expect(objs.CloudStack).To(BeNil())
+	tt.Expect(objs.VSphere.Cluster).To(Equal(vsphereCluster))
+	tt.Expect(objs.VSphere.MachineTemplates).To(Equal(map[string]*vspherev1.VSphereMachineTemplate{
+		"w01-control-plane": cpTemplate,
+		"w01-worker":        workerTemplate,
+	}))
+}
+
+func TestReaderReadErrorGettingCluster(t *testing.T) {
+	tt := newReaderTest(t)
+	tt.kubectl.EXPECT().GetObject(tt.ctx, "clusters.cluster.x-k8s.io", "w01", "default", "mgmt.kubeconfig", gomock.Any()).Return(errors.New("not found"))
+
+	_, err := tt.reader.Read(tt.ctx, tt.managementCluster, "w01", "default")
+	tt.Expect(err).To(MatchError("reading CAPI cluster: not found"))
+}
+
+func TestReaderReadErrorUnsupportedInfrastructure(t *testing.T) {
+	tt := newReaderTest(t)
+	capiCluster := readerCAPICluster()
+	capiCluster.Spec.InfrastructureRef.Kind = "AWSCluster"
+	kcp := &controlplanev1.KubeadmControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "w01-control-plane"}}
+
+	tt.expectGetObject("clusters.cluster.x-k8s.io", "w01", capiCluster)
+	tt.expectGetObject("kubeadmcontrolplanes.controlplane.cluster.x-k8s.io", "w01-control-plane", kcp)
+	tt.kubectl.EXPECT().GetMachineDeployments(tt.ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	_, err := tt.reader.Read(tt.ctx, tt.managementCluster, "w01", "default")
+	tt.Expect(err).To(MatchError("infrastructure cluster kind AWSCluster is not supported, only VSphereCluster and CloudStackCluster clusters can be imported"))
+}
+
+func TestReaderReadErrorUnsupportedControlPlane(t *testing.T) {
+	tt := newReaderTest(t)
+	capiCluster := readerCAPICluster()
+	capiCluster.Spec.ControlPlaneRef.Kind = "TalosControlPlane"
+
+	tt.expectGetObject("clusters.cluster.x-k8s.io", "w01", capiCluster)
+
+	_, err := tt.reader.Read(tt.ctx, tt.managementCluster, "w01", "default")
+	tt.Expect(err).To(MatchError("CAPI cluster w01 doesn't reference a KubeadmControlPlane, only kubeadm control planes can be imported"))
+}
@@ -0,0 +1,150 @@
+package clusterimport
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// importVSphere maps the VSphereCluster and VSphereMachineTemplates to a VSphereDatacenterConfig and
+// a VSphereMachineConfig per machine group. The datacenter and network come from the control plane
+// machine template, since EKS-A shares them between all the machines of the cluster.
+func (i *importer) importVSphere() error {
+	vsphereCluster := i.objs.VSphere.Cluster
+	if vsphereCluster == nil {
+		return fmt.Errorf("%s is required to import a vSphere cluster", vsphereClusterKind)
+	}
+
+	controlPlaneTemplate, err := i.vsphereMachineTemplate(i.machineGroups[0].machineTemplate)
+	if err != nil {
+		return err
+	}
+	controlPlaneSpec := controlPlaneTemplate.Spec.Template.Spec
+
+	datacenter := &anywherev1.VSphereDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: anywherev1.GroupVersion.String(),
+			Kind:       anywherev1.VSphereDatacenterKind,
+		},
+		ObjectMeta: i.objectMeta(i.config.Cluster.Name),
+		Spec: anywherev1.VSphereDatacenterConfigSpec{
+			Datacenter: controlPlaneSpec.Datacenter,
+			Server:     vsphereCluster.Spec.Server,
+			Thumbprint: vsphereCluster.Spec.Thumbprint,
+		},
+	}
+	if len(controlPlaneSpec.Network.Devices) > 0 {
+		datacenter.Spec.Network = controlPlaneSpec.Network.Devices[0].NetworkName
+	}
+	i.config.VSphereDatacenter = datacenter
+	i.config.Cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: datacenter.Name}
+
+	if i.config.Cluster.Spec.ControlPlaneConfiguration.Endpoint == nil && vsphereCluster.Spec.ControlPlaneEndpoint.Host != "" {
+		i.config.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &anywherev1.Endpoint{Host: vsphereCluster.Spec.ControlPlaneEndpoint.Host}
+	}
+
+	i.config.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{}
+	reported := map[string]bool{}
+	for _, group := range i.machineGroups {
+		template, err := i.vsphereMachineTemplate(group.machineTemplate)
+		if err != nil {
+			return err
+		}
+		if !reported[template.Name] {
+			i.reportVSphereMachineTemplate(template)
+			reported[template.Name] = true
+		}
+		i.config.VSphereMachineConfigs[group.machineConfigName] = i.vsphereMachineConfig(group, template)
+	}
+
+	return nil
+}
+
+func (i *importer) vsphereMachineTemplate(name string) (*vspherev1.VSphereMachineTemplate, error) {
+	template, ok := i.objs.VSphere.MachineTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("%s %s not found", vsphereMachineTemplateKind, name)
+	}
+
+	return template, nil
+}
+
+func (i *importer) vsphereMachineConfig(group machineGroup, template *vspherev1.VSphereMachineTemplate) *anywherev1.VSphereMachineConfig {
+	spec := template.Spec.Template.Spec
+
+	osFamily := anywherev1.Ubuntu
+	if strings.Contains(strings.ToLower(spec.Template), string(anywherev1.Bottlerocket)) {
+		osFamily = anywherev1.Bottlerocket
+	}
+
+	return &anywherev1.VSphereMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: anywherev1.GroupVersion.String(),
+			Kind:       anywherev1.VSphereMachineConfigKind,
+		},
+		ObjectMeta: i.objectMeta(group.machineConfigName),
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			DiskGiB:           int(spec.DiskGiB),
+			Datastore:         spec.Datastore,
+			Folder:            spec.Folder,
+			NumCPUs:           int(spec.NumCPUs),
+			MemoryMiB:         int(spec.MemoryMiB),
+			OSFamily:          osFamily,
+			ResourcePool:      spec.ResourcePool,
+			StoragePolicyName: spec.StoragePolicyName,
+			Template:          spec.Template,
+			Users:             users(group.users),
+		},
+	}
+}
+
+func (i *importer) reportVSphereMachineTemplate(template *vspherev1.VSphereMachineTemplate) {
+	name := template.Name
+	spec := template.Spec.Template.Spec
+	datacenter := i.config.VSphereDatacenter.Spec
+
+	i.reportDatacenterField(vsphereMachineTemplateKind, name, "spec.template.spec.datacenter", spec.Datacenter, datacenter.Datacenter)
+	if spec.Server != "" {
+		i.reportDatacenterField(vsphereMachineTemplateKind, name, "spec.template.spec.server", spec.Server, datacenter.Server)
+	}
+	if spec.Thumbprint != "" {
+		i.reportDatacenterField(vsphereMachineTemplateKind, name, "spec.template.spec.thumbprint", spec.Thumbprint, datacenter.Thumbprint)
+	}
+
+	devices := spec.Network.Devices
+	if len(devices) > 0 {
+		i.reportDatacenterField(vsphereMachineTemplateKind, name, "spec.template.spec.network.devices[0].networkName", devices[0].NetworkName, datacenter.Network)
+		if !devices[0].DHCP4 || len(devices[0].IPAddrs) > 0 {
+			i.report(vsphereMachineTemplateKind, name, "spec.template.spec.network.devices[0]", "EKS-A machines get their IPv4 address with DHCP")
+		}
+		if len(devices[0].Routes) > 0 || len(devices[0].Nameservers) > 0 {
+			i.report(vsphereMachineTemplateKind, name, "spec.template.spec.network.devices[0]", "EKS-A doesn't set routes or nameservers in the network devices")
+		}
+	}
+	if len(devices) > 1 {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.network.devices", "EKS-A machines have a single network device")
+	}
+	if len(spec.Network.Routes) > 0 {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.network.routes", "EKS-A doesn't set routes in the machines")
+	}
+
+	if spec.CloneMode == vspherev1.FullClone {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.cloneMode", "EKS-A machines are linked clones")
+	}
+	if spec.Snapshot != "" {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.snapshot", "EKS-A clones the machines from the latest snapshot of the template")
+	}
+	if spec.NumCoresPerSocket != 0 {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.numCoresPerSocket", "EKS-A doesn't set the number of cores per socket")
+	}
+	if len(spec.CustomVMXKeys) > 0 {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.customVMXKeys", "EKS-A doesn't set custom VMX keys")
+	}
+	if len(spec.TagIDs) > 0 {
+		i.report(vsphereMachineTemplateKind, name, "spec.template.spec.tagIDs", "EKS-A doesn't tag the machines")
+	}
+}
@@ -3,6 +3,7 @@ package clustermanager
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -10,11 +11,19 @@ import (
 	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -39,10 +48,13 @@ const (
 	ctrlPlaneWaitStr       = "60m"
 	etcdWaitStr            = "60m"
 	deploymentWaitStr      = "30m"
+	userManagedCNIWaitStr  = "60m"
 	ctrlPlaneInProgressStr = "1m"
 	etcdInProgressStr      = "1m"
 )
 
+var capiClusterResourceType = fmt.Sprintf("clusters.%s", clusterv1.GroupVersion.Group)
+
 type ClusterManager struct {
 	*Upgrader
 	clusterClient      *retrierClient
@@ -70,16 +82,19 @@ type ClusterClient interface {
 	GetEksaFluxConfig(ctx context.Context, fluxConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.FluxConfig, error)
 	GetEksaOIDCConfig(ctx context.Context, oidcConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.OIDCConfig, error)
 	DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster) error
+	SetCAPIClusterPaused(ctx context.Context, managementCluster *types.Cluster, clusterName string, paused bool) error
 	DeleteGitOpsConfig(ctx context.Context, managementCluster *types.Cluster, gitOpsName, namespace string) error
 	DeleteOIDCConfig(ctx context.Context, managementCluster *types.Cluster, oidcConfigName, oidcConfigNamespace string) error
 	DeleteAWSIamConfig(ctx context.Context, managementCluster *types.Cluster, awsIamConfigName, awsIamConfigNamespace string) error
 	DeleteEKSACluster(ctx context.Context, managementCluster *types.Cluster, eksaClusterName, eksaClusterNamespace string) error
 	InitInfrastructure(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
 	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
+	WaitForNodesReady(ctx context.Context, cluster *types.Cluster, timeout string) error
 	SaveLog(ctx context.Context, cluster *types.Cluster, deployment *types.Deployment, fileName string, writer filewriter.FileWriter) error
 	GetMachines(ctx context.Context, cluster *types.Cluster, clusterName string) ([]types.Machine, error)
 	GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error)
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
+	GetEksaClusters(ctx context.Context, cluster *types.Cluster) ([]v1alpha1.Cluster, error)
 	GetEksaVSphereDatacenterConfig(ctx context.Context, VSphereDatacenterName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereDatacenterConfig, error)
 	GetEksaCloudStackDatacenterConfig(ctx context.Context, cloudstackDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.CloudStackDatacenterConfig, error)
 	UpdateEnvironmentVariablesInNamespace(ctx context.Context, resourceType, resourceName string, envMap map[string]string, cluster *types.Cluster, namespace string) error
@@ -93,13 +108,16 @@ type ClusterClient interface {
 	ValidateControlPlaneNodes(ctx context.Context, cluster *types.Cluster, clusterName string) error
 	ValidateWorkerNodes(ctx context.Context, clusterName string, kubeconfigFile string) error
 	CountMachineDeploymentReplicasReady(ctx context.Context, clusterName string, kubeconfigFile string) (int, int, error)
+	ScaleMachineDeployment(ctx context.Context, machineDeploymentName string, replicas int, cluster *types.Cluster, namespace string) error
 	GetBundles(ctx context.Context, kubeconfigFile, name, namespace string) (*releasev1alpha1.Bundles, error)
 	GetApiServerUrl(ctx context.Context, cluster *types.Cluster) (string, error)
 	GetClusterCATlsCert(ctx context.Context, clusterName string, cluster *types.Cluster, namespace string) ([]byte, error)
 	KubeconfigSecretAvailable(ctx context.Context, kubeconfig string, clusterName string, namespace string) (bool, error)
 	DeleteOldWorkerNodeGroup(ctx context.Context, machineDeployment *clusterv1.MachineDeployment, kubeconfig string) error
 	GetMachineDeployment(ctx context.Context, workerNodeGroupName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
+	GetObject(ctx context.Context, resourceType, name, namespace, kubeconfig string, obj runtime.Object) error
 	GetEksdRelease(ctx context.Context, name, namespace, kubeconfigFile string) (*eksdv1alpha1.Release, error)
+	GetConfigMap(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.ConfigMap, error)
 }
 
 type Networking interface {
@@ -112,6 +130,7 @@ type AwsIamAuth interface {
 	GenerateManifest(clusterSpec *cluster.Spec) ([]byte, error)
 	GenerateCertKeyPairSecret() ([]byte, error)
 	GenerateAwsIamAuthKubeconfig(clusterSpec *cluster.Spec, serverUrl, tlsCert string) ([]byte, error)
+	GenerateAwsIamAuthKubeconfigForClusterID(clusterSpec *cluster.Spec, clusterId uuid.UUID, serverUrl, tlsCert string) ([]byte, error)
 }
 
 type ClusterManagerOpt func(*ClusterManager)
@@ -482,17 +501,83 @@ func (c *ClusterManager) EKSAClusterSpecChanged(ctx context.Context, cluster *ty
 		return true, nil
 	}
 
-	if newClusterSpec.OIDCConfig != nil && currentClusterSpec.OIDCConfig != nil {
-		if !newClusterSpec.OIDCConfig.Spec.Equal(&currentClusterSpec.OIDCConfig.Spec) {
-			logger.V(3).Info("OIDC config changes detected")
-			return true, nil
-		}
+	if !newClusterSpec.OIDCConfigsEqual(currentClusterSpec) {
+		logger.V(3).Info("OIDC config changes detected")
+		return true, nil
 	}
 
 	logger.V(3).Info("Clusters are the same")
 	return false, nil
 }
 
+// OnlyWorkerNodeGroupCountsChanged returns true when the only difference between the cluster config in the
+// cluster and newSpec is the count of some of the existing worker node groups, so the cluster can be scaled
+// without rolling out new machines. Besides the specs, it compares the datacenter, machine and AWS IAM configs
+// of newSpec with the ones in managementCluster.
+func (c *ClusterManager) OnlyWorkerNodeGroupCountsChanged(ctx context.Context, managementCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, provider providers.Provider) (bool, error) {
+	if !cluster.OnlyWorkerNodeGroupCountsChanged(currentSpec, newSpec) {
+		return false, nil
+	}
+
+	objs := []v1alpha1.Marshallable{provider.DatacenterConfig(newSpec).Marshallable()}
+	for _, m := range provider.MachineConfigs(newSpec) {
+		objs = append(objs, m.Marshallable())
+	}
+	if newSpec.AWSIamConfig != nil {
+		objs = append(objs, newSpec.AWSIamConfig)
+	}
+
+	for _, obj := range objs {
+		changed, err := c.specChanged(ctx, managementCluster, newSpec.Cluster.Namespace, obj)
+		if err != nil {
+			return false, err
+		}
+		if changed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// specChanged returns true if the spec of the EKS-A object obj differs from the one of the object in the cluster.
+func (c *ClusterManager) specChanged(ctx context.Context, managementCluster *types.Cluster, namespace string, obj v1alpha1.Marshallable) (bool, error) {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return false, fmt.Errorf("marshalling eks-a object: %v", err)
+	}
+	desired := &unstructured.Unstructured{}
+	if err := desired.UnmarshalJSON(content); err != nil {
+		return false, fmt.Errorf("unmarshalling eks-a object: %v", err)
+	}
+	if desired.GetNamespace() != "" {
+		namespace = desired.GetNamespace()
+	}
+	if namespace == "" {
+		namespace = constants.DefaultNamespace
+	}
+
+	resourceType := fmt.Sprintf("%ss.%s", strings.ToLower(desired.GetKind()), v1alpha1.GroupVersion.Group)
+	current := &unstructured.Unstructured{}
+	err = c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.GetObject(ctx, resourceType, desired.GetName(), namespace, managementCluster.KubeconfigFile, current)
+		},
+	)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting %s %s: %v", desired.GetKind(), desired.GetName(), err)
+	}
+
+	if !equality.Semantic.DeepEqual(current.Object["spec"], desired.Object["spec"]) {
+		logger.V(3).Info("Existing and new spec differ", "kind", desired.GetKind(), "name", desired.GetName())
+		return true, nil
+	}
+	return false, nil
+}
+
 func (c *ClusterManager) InstallCAPI(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error {
 	err := c.clusterClient.InitInfrastructure(ctx, clusterSpec, cluster, provider)
 	if err != nil {
@@ -524,6 +609,15 @@ func (c *ClusterManager) waitForCAPI(ctx context.Context, cluster *types.Cluster
 }
 
 func (c *ClusterManager) InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if clusterSpec.Cluster.Spec.ClusterNetwork.CNIConfig.IsUserManaged() {
+		logger.Info("CNI is user managed, skipping CNI installation. Install a CNI plugin using the cluster kubeconfig", "kubeconfig", cluster.KubeconfigFile)
+		logger.V(3).Info("Waiting for nodes to be ready")
+		if err := c.clusterClient.WaitForNodesReady(ctx, cluster, userManagedCNIWaitStr); err != nil {
+			return fmt.Errorf("waiting for nodes to be ready with user managed CNI: %v", err)
+		}
+		return nil
+	}
+
 	providerNamespaces := getProviderNamespaces(provider.GetDeployments())
 	networkingManifestContent, err := c.networking.GenerateManifest(ctx, clusterSpec, providerNamespaces)
 	if err != nil {
@@ -630,6 +724,29 @@ func (c *ClusterManager) CreateAwsIamAuthCaSecret(ctx context.Context, cluster *
 }
 
 func (c *ClusterManager) generateAwsIamAuthKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	return c.writeAwsIamAuthKubeconfig(ctx, managementCluster, workloadCluster, func(serverUrl, tlsCert string) ([]byte, error) {
+		return c.awsIamAuth.GenerateAwsIamAuthKubeconfig(clusterSpec, serverUrl, tlsCert)
+	})
+}
+
+// RefreshAwsIamAuthKubeconfig regenerates the aws-iam-authenticator kubeconfig of an existing cluster.
+// The cluster ID is read from the aws-iam-authenticator config running in workloadCluster.
+func (c *ClusterManager) RefreshAwsIamAuthKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	cm, err := c.clusterClient.GetConfigMap(ctx, workloadCluster.KubeconfigFile, awsiamauth.ConfigMapName, constants.KubeSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("retrieving aws-iam-authenticator config: %v", err)
+	}
+	clusterId, err := awsiamauth.ParseClusterID(cm)
+	if err != nil {
+		return err
+	}
+
+	return c.writeAwsIamAuthKubeconfig(ctx, managementCluster, workloadCluster, func(serverUrl, tlsCert string) ([]byte, error) {
+		return c.awsIamAuth.GenerateAwsIamAuthKubeconfigForClusterID(clusterSpec, clusterId, serverUrl, tlsCert)
+	})
+}
+
+func (c *ClusterManager) writeAwsIamAuthKubeconfig(ctx context.Context, managementCluster, workloadCluster *types.Cluster, generate func(serverUrl, tlsCert string) ([]byte, error)) error {
 	fileName := fmt.Sprintf("%s-aws.kubeconfig", workloadCluster.Name)
 	serverUrl, err := c.clusterClient.GetApiServerUrl(ctx, workloadCluster)
 	if err != nil {
@@ -639,7 +756,7 @@ func (c *ClusterManager) generateAwsIamAuthKubeconfig(ctx context.Context, manag
 	if err != nil {
 		return fmt.Errorf("generating aws-iam-authenticator kubeconfig: %v", err)
 	}
-	awsIamAuthKubeconfigContent, err := c.awsIamAuth.GenerateAwsIamAuthKubeconfig(clusterSpec, serverUrl, string(tlsCert))
+	awsIamAuthKubeconfigContent, err := generate(serverUrl, string(tlsCert))
 	if err != nil {
 		return fmt.Errorf("generating aws-iam-authenticator kubeconfig: %v", err)
 	}
@@ -724,6 +841,27 @@ func (c *ClusterManager) waitForControlPlaneReplicasReady(ctx context.Context, m
 	return nil
 }
 
+// ScaleWorkerNodeGroups sets the replicas of the worker node groups machine deployments to the counts in the cluster spec
+// and waits for them to be ready. It's only safe to use when the worker node group counts are the only change in the spec.
+func (c *ClusterManager) ScaleWorkerNodeGroups(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	for _, workerNodeGroupConfiguration := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machineDeploymentName := clusterapi.MachineDeploymentName(clusterSpec, workerNodeGroupConfiguration)
+		logger.V(3).Info("Scaling machine deployment", "name", machineDeploymentName, "replicas", workerNodeGroupConfiguration.Count)
+		replicas := workerNodeGroupConfiguration.Count
+		err := c.Retrier.Retry(
+			func() error {
+				return c.clusterClient.ScaleMachineDeployment(ctx, machineDeploymentName, replicas, managementCluster, constants.EksaSystemNamespace)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("scaling worker node group %s: %v", workerNodeGroupConfiguration.Name, err)
+		}
+	}
+
+	logger.V(3).Info("Waiting for machine deployment replicas to be ready after scaling")
+	return c.waitForMachineDeploymentReplicasReady(ctx, managementCluster, clusterSpec)
+}
+
 func (c *ClusterManager) waitForMachineDeploymentReplicasReady(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	ready, total := 0, 0
 	policy := func(_ int, _ error) (bool, time.Duration) {
@@ -983,6 +1121,86 @@ func (c *ClusterManager) ResumeEKSAControllerReconcile(ctx context.Context, clus
 	return nil
 }
 
+// PauseCAPIClusterReconcile pauses the reconciliation of the CAPI cluster and all its CAPI objects.
+func (c *ClusterManager) PauseCAPIClusterReconcile(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.SetCAPIClusterPaused(ctx, managementCluster, clusterSpec.Cluster.Name, true)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("pausing capi cluster reconciliation: %v", err)
+	}
+	return nil
+}
+
+// ResumeCAPIClusterReconcile resumes the reconciliation of the CAPI cluster and all its CAPI objects.
+func (c *ClusterManager) ResumeCAPIClusterReconcile(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.SetCAPIClusterPaused(ctx, managementCluster, clusterSpec.Cluster.Name, false)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("resuming capi cluster reconciliation: %v", err)
+	}
+	return nil
+}
+
+// EKSAControllerReconcilePaused returns true if the reconciliation of the EKS-A cluster is paused.
+func (c *ClusterManager) EKSAControllerReconcilePaused(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error) {
+	var eksaCluster *v1alpha1.Cluster
+	err := c.Retrier.Retry(
+		func() error {
+			var err error
+			eksaCluster, err = c.clusterClient.GetEksaCluster(ctx, cluster, clusterSpec.Cluster.Name)
+			return err
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("getting eks-a cluster reconciliation state: %v", err)
+	}
+	return eksaCluster.IsReconcilePaused(), nil
+}
+
+// CAPIClusterReconcilePaused returns true if the reconciliation of the CAPI cluster is paused.
+func (c *ClusterManager) CAPIClusterReconcilePaused(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error) {
+	capiCluster := &clusterv1.Cluster{}
+	err := c.Retrier.Retry(
+		func() error {
+			return c.clusterClient.GetObject(ctx, capiClusterResourceType, clusterSpec.Cluster.Name, constants.EksaSystemNamespace, managementCluster.KubeconfigFile, capiCluster)
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("getting capi cluster reconciliation state: %v", err)
+	}
+	return capiCluster.Spec.Paused, nil
+}
+
+// ReconcilePausedClusters returns the names of the EKS-A clusters in the management cluster
+// whose reconciliation is paused.
+func (c *ClusterManager) ReconcilePausedClusters(ctx context.Context, managementCluster *types.Cluster) ([]string, error) {
+	var clusters []v1alpha1.Cluster
+	err := c.Retrier.Retry(
+		func() error {
+			var err error
+			clusters, err = c.clusterClient.GetEksaClusters(ctx, managementCluster)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting eks-a clusters: %v", err)
+	}
+
+	var paused []string
+	for i := range clusters {
+		if clusters[i].IsReconcilePaused() {
+			paused = append(paused, clusters[i].Name)
+		}
+	}
+	return paused, nil
+}
+
 func (c *ClusterManager) applyResource(ctx context.Context, cluster *types.Cluster, resourcesSpec []byte) error {
 	err := c.Retrier.Retry(
 		func() error {
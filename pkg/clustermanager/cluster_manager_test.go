@@ -2,15 +2,19 @@ package clustermanager_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -84,6 +88,36 @@ func TestClusterManagerInstallNetworkingClientError(t *testing.T) {
 	}
 }
 
+func TestClusterManagerInstallNetworkingUserManagedCNI(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{None: &v1alpha1.NoneCNIConfig{}}
+	})
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().WaitForNodesReady(ctx, workloadCluster, "60m")
+
+	if err := c.InstallNetworking(ctx, workloadCluster, clusterSpec, m.provider); err != nil {
+		t.Errorf("ClusterManager.InstallNetworking() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerInstallNetworkingUserManagedCNIWaitError(t *testing.T) {
+	ctx := context.Background()
+	workloadCluster := &types.Cluster{KubeconfigFile: "kubeconfig"}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{None: &v1alpha1.NoneCNIConfig{}}
+	})
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().WaitForNodesReady(ctx, workloadCluster, "60m").Return(errors.New("timed out"))
+
+	if err := c.InstallNetworking(ctx, workloadCluster, clusterSpec, m.provider); err == nil {
+		t.Errorf("ClusterManager.InstallNetworking() error = nil, wantErr not nil")
+	}
+}
+
 func TestClusterManagerInstallStorageClassSuccess(t *testing.T) {
 	ctx := context.Background()
 	cluster := &types.Cluster{}
@@ -879,6 +913,51 @@ func TestClusterManagerMoveCAPISuccess(t *testing.T) {
 	}
 }
 
+func TestClusterManagerScaleWorkerNodeGroupsSuccess(t *testing.T) {
+	mgmt := &types.Cluster{
+		Name:           "mgmt-cluster",
+		KubeconfigFile: "mgmt.kubeconfig",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{Name: "md-0", Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}},
+			{Name: "md-1", Count: 1, MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}},
+		}
+	})
+	ctx := context.Background()
+
+	c, m := newClusterManager(t)
+	m.client.EXPECT().ScaleMachineDeployment(ctx, "cluster-name-md-0", 3, mgmt, constants.EksaSystemNamespace)
+	m.client.EXPECT().ScaleMachineDeployment(ctx, "cluster-name-md-1", 1, mgmt, constants.EksaSystemNamespace)
+	m.client.EXPECT().CountMachineDeploymentReplicasReady(ctx, clusterSpec.Cluster.Name, mgmt.KubeconfigFile).Return(4, 4, nil)
+
+	if err := c.ScaleWorkerNodeGroups(ctx, mgmt, clusterSpec); err != nil {
+		t.Errorf("ClusterManager.ScaleWorkerNodeGroups() error = %v, wantErr nil", err)
+	}
+}
+
+func TestClusterManagerScaleWorkerNodeGroupsError(t *testing.T) {
+	mgmt := &types.Cluster{
+		Name:           "mgmt-cluster",
+		KubeconfigFile: "mgmt.kubeconfig",
+	}
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "cluster-name"
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{
+			{Name: "md-0", Count: 3, MachineGroupRef: &v1alpha1.Ref{Name: "test-wn"}},
+		}
+	})
+	ctx := context.Background()
+
+	c, m := newClusterManager(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	m.client.EXPECT().ScaleMachineDeployment(ctx, "cluster-name-md-0", 3, mgmt, constants.EksaSystemNamespace).Return(errors.New("error scaling"))
+
+	if err := c.ScaleWorkerNodeGroups(ctx, mgmt, clusterSpec); err == nil {
+		t.Error("ClusterManager.ScaleWorkerNodeGroups() error = nil, wantErr not nil")
+	}
+}
+
 func TestClusterManagerMoveCAPIErrorMove(t *testing.T) {
 	from := &types.Cluster{
 		Name: "from-cluster",
@@ -1126,6 +1205,65 @@ func TestClusterManagerResumeEKSAControllerReconcileSuccessWithoutMachineConfig(
 	}
 }
 
+func TestClusterManagerPauseCAPIClusterReconcileSuccess(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().SetCAPIClusterPaused(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name, true).Return(nil)
+
+	tt.Expect(tt.clusterManager.PauseCAPIClusterReconcile(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerPauseCAPIClusterReconcileError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(2, 0)))
+	tt.mocks.client.EXPECT().SetCAPIClusterPaused(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name, true).Return(errors.New("error from patch")).Times(2)
+
+	tt.Expect(tt.clusterManager.PauseCAPIClusterReconcile(tt.ctx, tt.cluster, tt.clusterSpec)).To(MatchError(ContainSubstring("pausing capi cluster reconciliation")))
+}
+
+func TestClusterManagerResumeCAPIClusterReconcileSuccess(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().SetCAPIClusterPaused(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name, false).Return(nil)
+
+	tt.Expect(tt.clusterManager.ResumeCAPIClusterReconcile(tt.ctx, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerEKSAControllerReconcilePaused(t *testing.T) {
+	tt := newTest(t)
+	eksaCluster := tt.clusterSpec.Cluster.DeepCopy()
+	eksaCluster.Annotations = map[string]string{eksaCluster.PausedAnnotation(): "true"}
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(eksaCluster, nil)
+
+	tt.Expect(tt.clusterManager.EKSAControllerReconcilePaused(tt.ctx, tt.cluster, tt.clusterSpec)).To(BeTrue())
+}
+
+func TestClusterManagerCAPIClusterReconcilePaused(t *testing.T) {
+	tt := newTest(t)
+	tt.mocks.client.EXPECT().GetObject(tt.ctx, "clusters.cluster.x-k8s.io", tt.clusterSpec.Cluster.Name, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile, &clusterv1.Cluster{}).DoAndReturn(
+		func(_ context.Context, _, _, _, _ string, obj *clusterv1.Cluster) error {
+			obj.Spec.Paused = true
+			return nil
+		},
+	)
+
+	tt.Expect(tt.clusterManager.CAPIClusterReconcilePaused(tt.ctx, tt.cluster, tt.clusterSpec)).To(BeTrue())
+}
+
+func TestClusterManagerCAPIClusterReconcilePausedError(t *testing.T) {
+	tt := newTest(t, clustermanager.WithRetrier(retrier.NewWithMaxRetries(1, 0)))
+	tt.mocks.client.EXPECT().GetObject(tt.ctx, "clusters.cluster.x-k8s.io", tt.clusterSpec.Cluster.Name, constants.EksaSystemNamespace, tt.cluster.KubeconfigFile, &clusterv1.Cluster{}).Return(errors.New("not found"))
+
+	_, err := tt.clusterManager.CAPIClusterReconcilePaused(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting capi cluster reconciliation state: not found")))
+}
+
+func TestClusterManagerReconcilePausedClusters(t *testing.T) {
+	tt := newTest(t)
+	paused := v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "w01", Annotations: map[string]string{tt.clusterSpec.Cluster.PausedAnnotation(): "true"}}}
+	notPaused := v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "w02"}}
+	tt.mocks.client.EXPECT().GetEksaClusters(tt.ctx, tt.cluster).Return([]v1alpha1.Cluster{paused, notPaused}, nil)
+
+	tt.Expect(tt.clusterManager.ReconcilePausedClusters(tt.ctx, tt.cluster)).To(Equal([]string{"w01"}))
+}
+
 func TestClusterManagerInstallCustomComponentsSuccess(t *testing.T) {
 	t.Setenv(features.CloudStackProviderEnvVar, "")
 	ctx := context.Background()
@@ -1287,7 +1425,7 @@ func newSpecChangedTest(t *testing.T, opts ...clustermanager.ClusterManagerOpt)
 func TestClusterManagerClusterSpecChangedNoChanges(t *testing.T) {
 	tt := newSpecChangedTest(t)
 	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: tt.clusterName}}
-	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+	cluster.WithOIDCConfigs(tt.oldOIDCConfig.DeepCopy())(tt.clusterSpec)
 
 	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
 	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
@@ -1298,6 +1436,22 @@ func TestClusterManagerClusterSpecChangedNoChanges(t *testing.T) {
 	assert.False(t, diff, "No changes should have been detected")
 }
 
+func TestClusterManagerClusterSpecChangedOIDCChanged(t *testing.T) {
+	tt := newSpecChangedTest(t)
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: tt.clusterName}}
+	newOIDCConfig := tt.oldOIDCConfig.DeepCopy()
+	newOIDCConfig.Spec.ClientId = "new-client-id"
+	cluster.WithOIDCConfigs(newOIDCConfig)(tt.clusterSpec)
+
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+	tt.mocks.client.EXPECT().GetEksaOIDCConfig(tt.ctx, tt.oldClusterConfig.Spec.IdentityProviderRefs[0].Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(tt.oldOIDCConfig, nil)
+	diff, err := tt.clusterManager.EKSAClusterSpecChanged(tt.ctx, tt.cluster, tt.clusterSpec)
+	assert.Nil(t, err, "Error should be nil")
+	assert.True(t, diff, "Changes should have been detected")
+}
+
 func TestClusterManagerClusterSpecChangedClusterChanged(t *testing.T) {
 	tt := newSpecChangedTest(t)
 	tt.newClusterConfig.Spec.KubernetesVersion = "1.20"
@@ -1327,7 +1481,7 @@ func TestClusterManagerClusterSpecChangedGitOpsDefault(t *testing.T) {
 	tt.oldClusterConfig = tt.clusterSpec.Cluster.DeepCopy()
 	oldGitOpsConfig := tt.clusterSpec.GitOpsConfig.DeepCopy()
 	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: tt.clusterName}}
-	tt.clusterSpec.OIDCConfig = tt.oldOIDCConfig.DeepCopy()
+	cluster.WithOIDCConfigs(tt.oldOIDCConfig.DeepCopy())(tt.clusterSpec)
 
 	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
 	tt.mocks.client.EXPECT().GetEksaGitOpsConfig(tt.ctx, tt.clusterSpec.Cluster.Spec.GitOpsRef.Name, tt.cluster.KubeconfigFile, tt.clusterSpec.Cluster.Namespace).Return(oldGitOpsConfig, nil)
@@ -1340,6 +1494,94 @@ func TestClusterManagerClusterSpecChangedGitOpsDefault(t *testing.T) {
 	assert.False(t, diff, "No changes should have been detected")
 }
 
+func TestClusterManagerRefreshAwsIamAuthKubeconfigSuccess(t *testing.T) {
+	tt := newTest(t)
+	managementCluster := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"}
+	tt.cluster.KubeconfigFile = "cluster-name.kubeconfig"
+	clusterID := uuid.MustParse("36db102f-9e1e-4ca4-8300-271d30b14161")
+	cm := &corev1.ConfigMap{Data: map[string]string{"config.yaml": "clusterID: " + clusterID.String()}}
+	kubeconfig := []byte("kubeconfig")
+
+	tt.mocks.client.EXPECT().GetConfigMap(tt.ctx, tt.cluster.KubeconfigFile, "aws-iam-authenticator", constants.KubeSystemNamespace).Return(cm, nil)
+	tt.mocks.client.EXPECT().GetApiServerUrl(tt.ctx, tt.cluster).Return("https://1.2.3.4:6443", nil)
+	tt.mocks.client.EXPECT().GetClusterCATlsCert(tt.ctx, tt.clusterName, managementCluster, constants.EksaSystemNamespace).Return([]byte("ca"), nil)
+	tt.mocks.awsIamAuth.EXPECT().GenerateAwsIamAuthKubeconfigForClusterID(tt.clusterSpec, clusterID, "https://1.2.3.4:6443", "ca").Return(kubeconfig, nil)
+	tt.mocks.writer.EXPECT().Write(tt.clusterName+"-aws.kubeconfig", kubeconfig, gomock.Any()).Return("cluster-name-aws.kubeconfig", nil)
+
+	tt.Expect(tt.clusterManager.RefreshAwsIamAuthKubeconfig(tt.ctx, managementCluster, tt.cluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestClusterManagerRefreshAwsIamAuthKubeconfigGetConfigMapError(t *testing.T) {
+	tt := newTest(t)
+	tt.cluster.KubeconfigFile = "cluster-name.kubeconfig"
+
+	tt.mocks.client.EXPECT().GetConfigMap(tt.ctx, tt.cluster.KubeconfigFile, "aws-iam-authenticator", constants.KubeSystemNamespace).Return(nil, errors.New("not found"))
+
+	tt.Expect(tt.clusterManager.RefreshAwsIamAuthKubeconfig(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec)).To(MatchError(ContainSubstring("retrieving aws-iam-authenticator config: not found")))
+}
+
+func TestClusterManagerOnlyWorkerNodeGroupCountsChanged(t *testing.T) {
+	tests := []struct {
+		name          string
+		currentSpec   v1alpha1.VSphereMachineConfigSpec
+		wantScaleOnly bool
+	}{
+		{
+			name:          "only counts changed",
+			currentSpec:   v1alpha1.VSphereMachineConfigSpec{Template: "ubuntu", NumCPUs: 2},
+			wantScaleOnly: true,
+		},
+		{
+			name:          "machine config changed",
+			currentSpec:   v1alpha1.VSphereMachineConfigSpec{Template: "ubuntu", NumCPUs: 4},
+			wantScaleOnly: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0", Count: 1}}
+			currentSpec := tt.clusterSpec.DeepCopy()
+			tt.clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = 2
+
+			datacenter := &v1alpha1.VSphereDatacenterConfig{
+				TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.VSphereDatacenterKind},
+				ObjectMeta: metav1.ObjectMeta{Name: "dc", Namespace: "default"},
+				Spec:       v1alpha1.VSphereDatacenterConfigSpec{Server: "vcenter"},
+			}
+			machineConfig := &v1alpha1.VSphereMachineConfig{
+				TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.VSphereMachineConfigKind},
+				ObjectMeta: metav1.ObjectMeta{Name: "md-0", Namespace: "default"},
+				Spec:       v1alpha1.VSphereMachineConfigSpec{Template: "ubuntu", NumCPUs: 2},
+			}
+			tt.mocks.provider.EXPECT().DatacenterConfig(tt.clusterSpec).Return(datacenter)
+			tt.mocks.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{machineConfig})
+			tt.mocks.client.EXPECT().GetObject(tt.ctx, "vspheredatacenterconfigs.anywhere.eks.amazonaws.com", "dc", "default", tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, _, _, _ string, obj *unstructured.Unstructured) error {
+					return unstructuredFromObject(datacenter, obj)
+				},
+			)
+			tt.mocks.client.EXPECT().GetObject(tt.ctx, "vspheremachineconfigs.anywhere.eks.amazonaws.com", "md-0", "default", tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, _, _, _ string, obj *unstructured.Unstructured) error {
+					current := machineConfig.DeepCopy()
+					current.Spec = tc.currentSpec
+					return unstructuredFromObject(current, obj)
+				},
+			)
+
+			tt.Expect(tt.clusterManager.OnlyWorkerNodeGroupCountsChanged(tt.ctx, tt.cluster, currentSpec, tt.clusterSpec, tt.mocks.provider)).To(Equal(tc.wantScaleOnly))
+		})
+	}
+}
+
+func unstructuredFromObject(obj interface{}, u *unstructured.Unstructured) error {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return u.UnmarshalJSON(content)
+}
+
 type testSetup struct {
 	*WithT
 	clusterManager *clustermanager.ClusterManager
@@ -17,6 +17,9 @@ import (
 	v1alpha10 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 	v1alpha11 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -259,6 +262,21 @@ func (mr *MockClusterClientMockRecorder) GetClusters(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusters", reflect.TypeOf((*MockClusterClient)(nil).GetClusters), arg0, arg1)
 }
 
+// GetConfigMap mocks base method.
+func (m *MockClusterClient) GetConfigMap(arg0 context.Context, arg1, arg2, arg3 string) (*v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap.
+func (mr *MockClusterClientMockRecorder) GetConfigMap(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockClusterClient)(nil).GetConfigMap), arg0, arg1, arg2, arg3)
+}
+
 // GetEksaCloudStackDatacenterConfig mocks base method.
 func (m *MockClusterClient) GetEksaCloudStackDatacenterConfig(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha1.CloudStackDatacenterConfig, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +322,21 @@ func (mr *MockClusterClientMockRecorder) GetEksaCluster(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockClusterClient)(nil).GetEksaCluster), arg0, arg1, arg2)
 }
 
+// GetEksaClusters mocks base method.
+func (m *MockClusterClient) GetEksaClusters(arg0 context.Context, arg1 *types.Cluster) ([]v1alpha1.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEksaClusters", arg0, arg1)
+	ret0, _ := ret[0].([]v1alpha1.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEksaClusters indicates an expected call of GetEksaClusters.
+func (mr *MockClusterClientMockRecorder) GetEksaClusters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaClusters", reflect.TypeOf((*MockClusterClient)(nil).GetEksaClusters), arg0, arg1)
+}
+
 // GetEksaFluxConfig mocks base method.
 func (m *MockClusterClient) GetEksaFluxConfig(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha1.FluxConfig, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +476,20 @@ func (mr *MockClusterClientMockRecorder) GetNamespace(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockClusterClient)(nil).GetNamespace), arg0, arg1, arg2)
 }
 
+// GetObject mocks base method.
+func (m *MockClusterClient) GetObject(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockClusterClientMockRecorder) GetObject(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockClusterClient)(nil).GetObject), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetWorkloadKubeconfig mocks base method.
 func (m *MockClusterClient) GetWorkloadKubeconfig(arg0 context.Context, arg1 string, arg2 *types.Cluster) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -529,6 +576,34 @@ func (mr *MockClusterClientMockRecorder) SaveLog(arg0, arg1, arg2, arg3, arg4 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLog", reflect.TypeOf((*MockClusterClient)(nil).SaveLog), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ScaleMachineDeployment mocks base method.
+func (m *MockClusterClient) ScaleMachineDeployment(arg0 context.Context, arg1 string, arg2 int, arg3 *types.Cluster, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScaleMachineDeployment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScaleMachineDeployment indicates an expected call of ScaleMachineDeployment.
+func (mr *MockClusterClientMockRecorder) ScaleMachineDeployment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleMachineDeployment", reflect.TypeOf((*MockClusterClient)(nil).ScaleMachineDeployment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetCAPIClusterPaused mocks base method.
+func (m *MockClusterClient) SetCAPIClusterPaused(arg0 context.Context, arg1 *types.Cluster, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCAPIClusterPaused", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCAPIClusterPaused indicates an expected call of SetCAPIClusterPaused.
+func (mr *MockClusterClientMockRecorder) SetCAPIClusterPaused(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAPIClusterPaused", reflect.TypeOf((*MockClusterClient)(nil).SetCAPIClusterPaused), arg0, arg1, arg2, arg3)
+}
+
 // SetEksaControllerEnvVar mocks base method.
 func (m *MockClusterClient) SetEksaControllerEnvVar(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -669,6 +744,20 @@ func (mr *MockClusterClientMockRecorder) WaitForManagedExternalEtcdReady(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForManagedExternalEtcdReady", reflect.TypeOf((*MockClusterClient)(nil).WaitForManagedExternalEtcdReady), arg0, arg1, arg2, arg3)
 }
 
+// WaitForNodesReady mocks base method.
+func (m *MockClusterClient) WaitForNodesReady(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForNodesReady", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForNodesReady indicates an expected call of WaitForNodesReady.
+func (mr *MockClusterClientMockRecorder) WaitForNodesReady(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForNodesReady", reflect.TypeOf((*MockClusterClient)(nil).WaitForNodesReady), arg0, arg1, arg2)
+}
+
 // MockNetworking is a mock of Networking interface.
 type MockNetworking struct {
 	ctrl     *gomock.Controller
@@ -774,6 +863,21 @@ func (mr *MockAwsIamAuthMockRecorder) GenerateAwsIamAuthKubeconfig(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAwsIamAuthKubeconfig", reflect.TypeOf((*MockAwsIamAuth)(nil).GenerateAwsIamAuthKubeconfig), arg0, arg1, arg2)
 }
 
+// GenerateAwsIamAuthKubeconfigForClusterID mocks base method.
+func (m *MockAwsIamAuth) GenerateAwsIamAuthKubeconfigForClusterID(arg0 *cluster.Spec, arg1 uuid.UUID, arg2, arg3 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateAwsIamAuthKubeconfigForClusterID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateAwsIamAuthKubeconfigForClusterID indicates an expected call of GenerateAwsIamAuthKubeconfigForClusterID.
+func (mr *MockAwsIamAuthMockRecorder) GenerateAwsIamAuthKubeconfigForClusterID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateAwsIamAuthKubeconfigForClusterID", reflect.TypeOf((*MockAwsIamAuth)(nil).GenerateAwsIamAuthKubeconfigForClusterID), arg0, arg1, arg2, arg3)
+}
+
 // GenerateCertKeyPairSecret mocks base method.
 func (m *MockAwsIamAuth) GenerateCertKeyPairSecret() ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,290 @@
+package clustermanager
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	kubeadmControlPlaneKind = "KubeadmControlPlane"
+	machineDeploymentKind   = "MachineDeployment"
+	etcdadmClusterKind      = "EtcdadmCluster"
+	secretKind              = "Secret"
+)
+
+var yamlSeparatorRegex = regexp.MustCompile(`(?m)^---$`)
+
+// DiffClusterUpgrade renders the CAPI and EKS-A objects an upgrade to newClusterSpec would apply and compares
+// them with the ones in the cluster, without changing anything.
+// Only the fields set in the rendered objects are compared, the same way an apply would update them.
+func (c *ClusterManager) DiffClusterUpgrade(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) (*types.UpgradeDiff, error) {
+	eksaMgmtCluster := workloadCluster
+	if managementCluster != nil && managementCluster.ExistingManagement {
+		eksaMgmtCluster = managementCluster
+	}
+
+	currentSpec, err := c.GetCurrentClusterSpec(ctx, eksaMgmtCluster, newClusterSpec.Cluster.Name)
+	if err != nil {
+		return nil, fmt.Errorf("getting current cluster spec: %v", err)
+	}
+
+	cpContent, mdContent, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, eksaMgmtCluster, currentSpec, newClusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("generating capi spec: %v", err)
+	}
+
+	capiObjects, err := unstructuredFromYaml(templater.AppendYamlResources(cpContent, mdContent))
+	if err != nil {
+		return nil, fmt.Errorf("parsing capi spec: %v", err)
+	}
+
+	diff := &types.UpgradeDiff{}
+	for _, obj := range capiObjects {
+		if obj.GetKind() == secretKind {
+			if err := stringDataToData(obj); err != nil {
+				return nil, fmt.Errorf("reading secret %s: %v", obj.GetName(), err)
+			}
+		}
+
+		objDiff, err := c.diffObject(ctx, managementCluster, obj)
+		if err != nil {
+			return nil, err
+		}
+		objDiff.Rollout = triggersRollout(objDiff)
+		diff.CAPIObjects = append(diff.CAPIObjects, *objDiff)
+	}
+
+	eksaObjects, err := eksaObjectsForSpec(newClusterSpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range eksaObjects {
+		objDiff, err := c.diffObject(ctx, eksaMgmtCluster, obj)
+		if err != nil {
+			return nil, err
+		}
+		diff.EKSAObjects = append(diff.EKSAObjects, *objDiff)
+	}
+
+	return diff, nil
+}
+
+func (c *ClusterManager) diffObject(ctx context.Context, cluster *types.Cluster, desired *unstructured.Unstructured) (*types.ObjectDiff, error) {
+	objDiff := &types.ObjectDiff{
+		Kind:      desired.GetKind(),
+		Name:      desired.GetName(),
+		Namespace: desired.GetNamespace(),
+	}
+
+	namespace := desired.GetNamespace()
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	current := &unstructured.Unstructured{}
+	err := c.clusterClient.GetObject(ctx, resourceType(desired), desired.GetName(), namespace, cluster.KubeconfigFile, current)
+	if apierrors.IsNotFound(err) {
+		objDiff.Change = types.ObjectCreated
+		return objDiff, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting %s %s: %v", desired.GetKind(), desired.GetName(), err)
+	}
+
+	for field, value := range desired.Object {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		objDiff.ChangedFields = append(objDiff.ChangedFields, changedFields(field, value, current.Object[field])...)
+	}
+	sort.Strings(objDiff.ChangedFields)
+
+	objDiff.Change = types.ObjectUnchanged
+	if len(objDiff.ChangedFields) > 0 {
+		objDiff.Change = types.ObjectUpdated
+	}
+
+	return objDiff, nil
+}
+
+// stringDataToData moves the stringData of a Secret into its data, encoded the same way
+// the API server stores it, so it can be compared with the Secret in the cluster.
+// Only the changed keys are reported, never their values.
+func stringDataToData(secret *unstructured.Unstructured) error {
+	stringData, _, err := unstructured.NestedStringMap(secret.Object, "stringData")
+	if err != nil {
+		return err
+	}
+	if len(stringData) == 0 {
+		return nil
+	}
+
+	data, _, err := unstructured.NestedStringMap(secret.Object, "data")
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		data = make(map[string]string, len(stringData))
+	}
+	for k, v := range stringData {
+		data[k] = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+
+	unstructured.RemoveNestedField(secret.Object, "stringData")
+	return unstructured.SetNestedStringMap(secret.Object, data, "data")
+}
+
+// changedFields returns the paths of the fields set in desired that differ in current.
+// Empty values in desired match missing fields in current, since the API server drops them.
+func changedFields(path string, desired, current interface{}) []string {
+	if current == nil && isEmpty(desired) {
+		return nil
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		c, ok := current.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		var changed []string
+		for k, v := range d {
+			changed = append(changed, changedFields(path+"."+k, v, c[k])...)
+		}
+		return changed
+	case []interface{}:
+		c, ok := current.([]interface{})
+		if !ok || len(c) != len(d) {
+			return []string{path}
+		}
+		var changed []string
+		for i := range d {
+			changed = append(changed, changedFields(fmt.Sprintf("%s[%d]", path, i), d[i], c[i])...)
+		}
+		return changed
+	default:
+		if !reflect.DeepEqual(desired, current) {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(t) == 0
+	case []interface{}:
+		return len(t) == 0
+	case string:
+		return t == ""
+	default:
+		return false
+	}
+}
+
+// rolloutFields are the fields that make the controllers replace the machines when they change, by kind.
+// Machine templates are immutable, so the providers generate new ones with a different name
+// that show up as changes in the machine template reference of their owners.
+var rolloutFields = map[string][]string{
+	kubeadmControlPlaneKind: {
+		"spec.version",
+		"spec.machineTemplate.infrastructureRef",
+		"spec.kubeadmConfigSpec",
+		"spec.rolloutAfter",
+	},
+	etcdadmClusterKind: {
+		"spec.etcdadmConfigSpec",
+		"spec.infrastructureTemplate",
+	},
+	machineDeploymentKind: {
+		"spec.template",
+	},
+}
+
+// triggersRollout returns true if applying the object changes would replace machines.
+func triggersRollout(d *types.ObjectDiff) bool {
+	if d.Change != types.ObjectUpdated {
+		return false
+	}
+
+	for _, f := range d.ChangedFields {
+		for _, r := range rolloutFields[d.Kind] {
+			if f == r || strings.HasPrefix(f, r+".") || strings.HasPrefix(f, r+"[") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resourceType returns the kubectl resource type for the object, qualified with its group.
+func resourceType(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group == "" {
+		return strings.ToLower(gvk.Kind)
+	}
+	return fmt.Sprintf("%s.%s", strings.ToLower(gvk.Kind), gvk.Group)
+}
+
+func unstructuredFromYaml(content []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, doc := range yamlSeparatorRegex.Split(string(content), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), obj); err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+func eksaObjectsForSpec(spec *cluster.Spec) ([]*unstructured.Unstructured, error) {
+	children := spec.ChildObjects()
+	sort.Slice(children, func(i, j int) bool {
+		ki, kj := children[i].GetObjectKind().GroupVersionKind().Kind, children[j].GetObjectKind().GroupVersionKind().Kind
+		if ki != kj {
+			return ki < kj
+		}
+		return children[i].GetName() < children[j].GetName()
+	})
+
+	objs := []runtime.Object{spec.Cluster}
+	for _, o := range children {
+		objs = append(objs, o)
+	}
+
+	unstructuredObjs := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, o := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s to unstructured: %v", o.GetObjectKind().GroupVersionKind().Kind, err)
+		}
+		unstructuredObjs = append(unstructuredObjs, &unstructured.Unstructured{Object: content})
+	}
+
+	return unstructuredObjs, nil
+}
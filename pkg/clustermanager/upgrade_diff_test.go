@@ -0,0 +1,320 @@
+package clustermanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	desiredControlPlane = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-name
+  namespace: eksa-system
+spec:
+  replicas: 1
+  machineTemplate:
+    infrastructureRef:
+      name: cluster-name-control-plane-template-2
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+kind: VSphereMachineTemplate
+metadata:
+  name: cluster-name-control-plane-template-2
+  namespace: eksa-system
+spec:
+  template:
+    spec:
+      numCPUs: 2
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: cluster-name-secret
+  namespace: eksa-system
+stringData:
+  key: value
+`
+	desiredWorkers = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-0
+  namespace: eksa-system
+spec:
+  replicas: 3
+  template:
+    spec:
+      infrastructureRef:
+        name: cluster-name-worker-template-1
+`
+	currentControlPlane = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-name
+  namespace: eksa-system
+  uid: "1234"
+spec:
+  replicas: 1
+  version: v1.21.2-eks-1-21-4
+  machineTemplate:
+    infrastructureRef:
+      name: cluster-name-control-plane-template-1
+`
+	currentWorkers = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: cluster-name-md-0
+  namespace: eksa-system
+spec:
+  replicas: 1
+  template:
+    spec:
+      infrastructureRef:
+        name: cluster-name-worker-template-1
+`
+)
+
+type upgradeDiffTest struct {
+	*specChangedTest
+	t           *testing.T
+	liveObjects map[string]string
+}
+
+func newUpgradeDiffTest(t *testing.T) *upgradeDiffTest {
+	tt := &upgradeDiffTest{
+		specChangedTest: newSpecChangedTest(t),
+		t:               t,
+		liveObjects: map[string]string{
+			"kubeadmcontrolplane.controlplane.cluster.x-k8s.io/cluster-name": currentControlPlane,
+			"machinedeployment.cluster.x-k8s.io/cluster-name-md-0":           currentWorkers,
+		},
+	}
+	tt.clusterSpec.Cluster.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: v1alpha1.ClusterKind}
+	tt.clusterSpec.OIDCConfig = nil
+	tt.clusterSpec.Cluster.Spec.IdentityProviderRefs = nil
+	tt.oldClusterConfig.Spec.IdentityProviderRefs = nil
+
+	return tt
+}
+
+func (tt *upgradeDiffTest) expectGetCurrentSpec() {
+	tt.mocks.client.EXPECT().GetEksaCluster(tt.ctx, tt.cluster, tt.clusterSpec.Cluster.Name).Return(tt.oldClusterConfig, nil)
+	tt.mocks.client.EXPECT().GetBundles(tt.ctx, tt.cluster.KubeconfigFile, tt.cluster.Name, "").Return(test.Bundles(tt.t), nil)
+	tt.mocks.client.EXPECT().GetEksdRelease(tt.ctx, gomock.Any(), constants.EksaSystemNamespace, gomock.Any())
+}
+
+func (tt *upgradeDiffTest) expectGetObjects() {
+	tt.mocks.client.EXPECT().GetObject(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), tt.cluster.KubeconfigFile, gomock.Any()).DoAndReturn(
+		func(_ context.Context, resourceType, name, namespace, _ string, obj runtime.Object) error {
+			if resourceType == "cluster.anywhere.eks.amazonaws.com" {
+				content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(tt.clusterSpec.Cluster)
+				if err != nil {
+					return err
+				}
+				obj.(*unstructured.Unstructured).Object = content
+				return nil
+			}
+
+			content, ok := tt.liveObjects[resourceType+"/"+name]
+			if !ok {
+				return apierrors.NewNotFound(schema.GroupResource{Resource: resourceType}, name)
+			}
+			return yaml.Unmarshal([]byte(content), obj)
+		},
+	).AnyTimes()
+}
+
+func TestClusterManagerDiffClusterUpgrade(t *testing.T) {
+	tt := newUpgradeDiffTest(t)
+	tt.expectGetCurrentSpec()
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return([]byte(desiredControlPlane), []byte(desiredWorkers), nil)
+	tt.expectGetObjects()
+
+	diff, err := tt.clusterManager.DiffClusterUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff.CAPIObjects).To(Equal([]types.ObjectDiff{
+		{
+			Kind:          "KubeadmControlPlane",
+			Name:          "cluster-name",
+			Namespace:     constants.EksaSystemNamespace,
+			Change:        types.ObjectUpdated,
+			ChangedFields: []string{"spec.machineTemplate.infrastructureRef.name"},
+			Rollout:       true,
+		},
+		{
+			Kind:      "VSphereMachineTemplate",
+			Name:      "cluster-name-control-plane-template-2",
+			Namespace: constants.EksaSystemNamespace,
+			Change:    types.ObjectCreated,
+		},
+		{
+			Kind:      "Secret",
+			Name:      "cluster-name-secret",
+			Namespace: constants.EksaSystemNamespace,
+			Change:    types.ObjectCreated,
+		},
+		{
+			Kind:          "MachineDeployment",
+			Name:          "cluster-name-md-0",
+			Namespace:     constants.EksaSystemNamespace,
+			Change:        types.ObjectUpdated,
+			ChangedFields: []string{"spec.replicas"},
+		},
+	}))
+	tt.Expect(diff.EKSAObjects).To(Equal([]types.ObjectDiff{
+		{
+			Kind:   v1alpha1.ClusterKind,
+			Name:   tt.clusterName,
+			Change: types.ObjectUnchanged,
+		},
+	}))
+	tt.Expect(diff.Rollout()).To(BeTrue())
+	tt.Expect(diff.EKSAChanged()).To(BeFalse())
+}
+
+func TestClusterManagerDiffClusterUpgradeEKSAClusterChanged(t *testing.T) {
+	tt := newUpgradeDiffTest(t)
+	newSpec := tt.clusterSpec.DeepCopy()
+	newSpec.Cluster.Spec.KubernetesVersion = v1alpha1.Kube122
+	tt.expectGetCurrentSpec()
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), newSpec).Return([]byte(currentControlPlane), []byte(currentWorkers), nil)
+	tt.expectGetObjects()
+
+	diff, err := tt.clusterManager.DiffClusterUpgrade(tt.ctx, tt.cluster, tt.cluster, newSpec, tt.mocks.provider)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff.Rollout()).To(BeFalse())
+	tt.Expect(diff.EKSAChanged()).To(BeTrue())
+	tt.Expect(diff.EKSAObjects[0].ChangedFields).To(ConsistOf("spec.kubernetesVersion"))
+}
+
+func TestClusterManagerDiffClusterUpgradeSecretChanged(t *testing.T) {
+	tt := newUpgradeDiffTest(t)
+	tt.liveObjects["secret/cluster-name-secret"] = `apiVersion: v1
+kind: Secret
+metadata:
+  name: cluster-name-secret
+  namespace: eksa-system
+data:
+  key: b2xk
+  other: dmFsdWU=
+`
+	secret := `apiVersion: v1
+kind: Secret
+metadata:
+  name: cluster-name-secret
+  namespace: eksa-system
+data:
+  other: dmFsdWU=
+stringData:
+  key: value
+`
+	tt.expectGetCurrentSpec()
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return([]byte(secret), nil, nil)
+	tt.expectGetObjects()
+
+	diff, err := tt.clusterManager.DiffClusterUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff.CAPIObjects).To(Equal([]types.ObjectDiff{
+		{
+			Kind:          "Secret",
+			Name:          "cluster-name-secret",
+			Namespace:     constants.EksaSystemNamespace,
+			Change:        types.ObjectUpdated,
+			ChangedFields: []string{"data.key"},
+		},
+	}))
+	tt.Expect(diff.Rollout()).To(BeFalse())
+}
+
+func TestClusterManagerDiffClusterUpgradeIgnoresServerDefaultedFields(t *testing.T) {
+	tt := newUpgradeDiffTest(t)
+	tt.liveObjects["kubeadmcontrolplane.controlplane.cluster.x-k8s.io/cluster-name"] = `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-name
+  namespace: eksa-system
+spec:
+  replicas: 1
+  version: v1.21.2-eks-1-21-4
+  rolloutStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxSurge: 1
+  kubeadmConfigSpec:
+    format: cloud-config
+    clusterConfiguration:
+      imageRepository: public.ecr.aws/eks-distro/kubernetes
+  machineTemplate:
+    nodeDrainTimeout: 0s
+    infrastructureRef:
+      name: cluster-name-control-plane-template-1
+`
+	controlPlane := `apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: cluster-name
+  namespace: eksa-system
+spec:
+  replicas: 1
+  version: v1.21.2-eks-1-21-4
+  kubeadmConfigSpec:
+    files: []
+    preKubeadmCommands: []
+    clusterConfiguration:
+      imageRepository: public.ecr.aws/eks-distro/kubernetes
+  machineTemplate:
+    nodeDrainTimeout: 10m
+    infrastructureRef:
+      name: cluster-name-control-plane-template-1
+`
+	tt.expectGetCurrentSpec()
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return([]byte(controlPlane), nil, nil)
+	tt.expectGetObjects()
+
+	diff, err := tt.clusterManager.DiffClusterUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(diff.CAPIObjects).To(Equal([]types.ObjectDiff{
+		{
+			Kind:          "KubeadmControlPlane",
+			Name:          "cluster-name",
+			Namespace:     constants.EksaSystemNamespace,
+			Change:        types.ObjectUpdated,
+			ChangedFields: []string{"spec.machineTemplate.nodeDrainTimeout"},
+		},
+	}))
+	tt.Expect(diff.Rollout()).To(BeFalse())
+}
+
+func TestClusterManagerDiffClusterUpgradeGetObjectError(t *testing.T) {
+	tt := newUpgradeDiffTest(t)
+	tt.expectGetCurrentSpec()
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return([]byte(desiredControlPlane), []byte(desiredWorkers), nil)
+	tt.mocks.client.EXPECT().GetObject(tt.ctx, gomock.Any(), "cluster-name", constants.EksaSystemNamespace, tt.cluster.KubeconfigFile, gomock.Any()).Return(errors.New("connection refused"))
+
+	_, err := tt.clusterManager.DiffClusterUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting KubeadmControlPlane cluster-name: connection refused")))
+}
+
+func TestClusterManagerDiffClusterUpgradeGenerateSpecError(t *testing.T) {
+	tt := newUpgradeDiffTest(t)
+	tt.expectGetCurrentSpec()
+	tt.mocks.provider.EXPECT().GenerateCAPISpecForUpgrade(tt.ctx, tt.cluster, tt.cluster, gomock.Any(), tt.clusterSpec).Return(nil, nil, errors.New("template error"))
+
+	_, err := tt.clusterManager.DiffClusterUpgrade(tt.ctx, tt.cluster, tt.cluster, tt.clusterSpec, tt.mocks.provider)
+	tt.Expect(err).To(MatchError(ContainSubstring("generating capi spec: template error")))
+}
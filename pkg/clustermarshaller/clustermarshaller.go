@@ -2,11 +2,14 @@ package clustermarshaller
 
 import (
 	"fmt"
+	"sort"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -76,3 +79,61 @@ func WriteClusterConfig(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 
 	return nil
 }
+
+// userMetadataFields are the metadata fields kept when marshalling objects read from a cluster.
+var userMetadataFields = []string{"name", "namespace", "labels", "annotations"}
+
+// lastAppliedConfigAnnotation is set by kubectl apply and it's not part of the user config.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// MarshalClusterConfig marshals the Cluster and all its child objects into a multi-document yaml.
+// It's meant for objects read from a cluster: the status and the metadata fields set by the
+// API server are dropped, so the output can be used as a cluster config file.
+func MarshalClusterConfig(config *cluster.Config) ([]byte, error) {
+	children := config.ChildObjects()
+	sort.Slice(children, func(i, j int) bool {
+		ki, kj := children[i].GetObjectKind().GroupVersionKind().Kind, children[j].GetObjectKind().GroupVersionKind().Kind
+		if ki != kj {
+			return ki < kj
+		}
+		return children[i].GetName() < children[j].GetName()
+	})
+
+	objs := append([]kubernetes.Object{config.Cluster}, children...)
+	resources := make([][]byte, 0, len(objs))
+	for _, o := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s %s: %v", o.GetObjectKind().GroupVersionKind().Kind, o.GetName(), err)
+		}
+		delete(content, "status")
+		content["metadata"] = userMetadata(content["metadata"])
+
+		resource, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed marshalling resource for cluster config: %v", err)
+		}
+		resources = append(resources, resource)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
+
+func userMetadata(metadata interface{}) map[string]interface{} {
+	m, _ := metadata.(map[string]interface{})
+	kept := map[string]interface{}{}
+	for _, f := range userMetadataFields {
+		if v, ok := m[f]; ok {
+			kept[f] = v
+		}
+	}
+
+	if annotations, ok := kept["annotations"].(map[string]interface{}); ok {
+		delete(annotations, lastAppliedConfigAnnotation)
+		if len(annotations) == 0 {
+			delete(kept, "annotations")
+		}
+	}
+
+	return kept
+}
@@ -313,3 +313,66 @@ func TestWriteClusterConfigWithFluxConfig(t *testing.T) {
 
 	test.AssertFilesEquals(t, gotFile, "testdata/expected_marshalled_cluster_flux_config.yaml")
 }
+
+func TestMarshalClusterConfig(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		Cluster: &v1alpha1.Cluster{
+			TypeMeta: v1.TypeMeta{
+				Kind:       v1alpha1.ClusterKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:            "workload",
+				Namespace:       "default",
+				UID:             "1234",
+				ResourceVersion: "5678",
+				Annotations: map[string]string{
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				},
+			},
+			Spec: v1alpha1.ClusterSpec{
+				KubernetesVersion: v1alpha1.Kube122,
+				DatacenterRef: v1alpha1.Ref{
+					Kind: v1alpha1.VSphereDatacenterKind,
+					Name: "workload",
+				},
+			},
+			Status: v1alpha1.ClusterStatus{
+				FailureMessage: ptrString("failed"),
+			},
+		},
+		VSphereDatacenter: &v1alpha1.VSphereDatacenterConfig{
+			TypeMeta: v1.TypeMeta{
+				Kind:       v1alpha1.VSphereDatacenterKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "workload",
+				Namespace: "default",
+				Labels:    map[string]string{"team": "a"},
+			},
+			Spec: v1alpha1.VSphereDatacenterConfigSpec{
+				Server: "vsphere.local",
+			},
+		},
+	}
+
+	content, err := clustermarshaller.MarshalClusterConfig(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).NotTo(ContainSubstring("uid"))
+	g.Expect(string(content)).NotTo(ContainSubstring("resourceVersion"))
+	g.Expect(string(content)).NotTo(ContainSubstring("last-applied-configuration"))
+	g.Expect(string(content)).NotTo(ContainSubstring("failureMessage"))
+
+	parsed, err := cluster.ParseConfig(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed.Cluster.Name).To(Equal("workload"))
+	g.Expect(parsed.Cluster.Spec.KubernetesVersion).To(Equal(v1alpha1.Kube122))
+	g.Expect(parsed.VSphereDatacenter.Spec.Server).To(Equal("vsphere.local"))
+	g.Expect(parsed.VSphereDatacenter.Labels).To(Equal(map[string]string{"team": "a"}))
+}
+
+func ptrString(s string) *string {
+	return &s
+}
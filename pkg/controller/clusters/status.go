@@ -0,0 +1,250 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// cniDaemonSets are the names of the DaemonSets running the CNIs installed by EKS Anywhere in kube-system.
+var cniDaemonSets = map[anywherev1.CNI]string{
+	anywherev1.Cilium:           "cilium",
+	anywherev1.CiliumEnterprise: "cilium",
+	anywherev1.Kindnetd:         "kindnet",
+}
+
+// IsCNIDaemonSet returns true if the object is the DaemonSet of a CNI installed by EKS Anywhere.
+func IsCNIDaemonSet(o client.Object) bool {
+	if o.GetNamespace() != constants.KubeSystemNamespace {
+		return false
+	}
+	for _, name := range cniDaemonSets {
+		if o.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateClusterStatus sets the cluster conditions, node counts and versions from the status of the
+// CAPI KubeadmControlPlane and MachineDeployments of the cluster and the CNI running in it.
+// workloadClient reads the cluster itself, it's nil until its control plane is initialized.
+// The ObservedGeneration and FailureMessage of the cluster status must be set before calling it.
+func UpdateClusterStatus(ctx context.Context, client, workloadClient client.Client, cluster *anywherev1.Cluster) error {
+	if err := updateControlPlaneStatus(ctx, client, cluster); err != nil {
+		return err
+	}
+
+	if err := updateWorkersStatus(ctx, client, cluster); err != nil {
+		return err
+	}
+
+	if err := updateCNIStatus(ctx, workloadClient, cluster); err != nil {
+		return err
+	}
+
+	conditions.SetSummary(cluster,
+		conditions.WithConditions(
+			anywherev1.ControlPlaneReadyCondition,
+			anywherev1.WorkersReadyCondition,
+			anywherev1.CNIReadyCondition,
+		),
+	)
+
+	updateReadyForUpgradeStatus(cluster)
+
+	return nil
+}
+
+// nodeGroupState is the observed state of the machines of a node group.
+type nodeGroupState struct {
+	// observed is false when the CAPI controllers haven't processed the latest spec of the node group.
+	observed bool
+	desired  int32
+	replicas int32
+	updated  int32
+	ready    int32
+}
+
+func (s nodeGroupState) condition(t clusterv1.ConditionType, name string) *clusterv1.Condition {
+	switch {
+	case s.replicas < s.desired:
+		return conditions.FalseCondition(t, anywherev1.ScalingUpReason, clusterv1.ConditionSeverityInfo, "%s: scaling up to %d replicas (actual %d)", name, s.desired, s.replicas)
+	case s.replicas > s.desired:
+		return conditions.FalseCondition(t, anywherev1.ScalingDownReason, clusterv1.ConditionSeverityInfo, "%s: scaling down to %d replicas (actual %d)", name, s.desired, s.replicas)
+	case !s.observed || s.updated < s.replicas:
+		return conditions.FalseCondition(t, anywherev1.RollingUpgradeInProgressReason, clusterv1.ConditionSeverityInfo, "%s: %d of %d replicas up to date", name, s.updated, s.replicas)
+	case s.ready < s.replicas:
+		return conditions.FalseCondition(t, anywherev1.NodesNotReadyReason, clusterv1.ConditionSeverityInfo, "%s: %d of %d replicas ready", name, s.ready, s.replicas)
+	default:
+		return conditions.TrueCondition(t)
+	}
+}
+
+func updateControlPlaneStatus(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	key := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: clusterapi.KubeadmControlPlaneName(specForCluster(cluster))}
+	if err := client.Get(ctx, key, kcp); err != nil {
+		if apierrors.IsNotFound(err) {
+			cluster.Status.ControlPlane = nil
+			cluster.Status.KubernetesVersion = ""
+			conditions.MarkFalse(cluster, anywherev1.ControlPlaneReadyCondition, anywherev1.ControlPlaneInitializingReason, clusterv1.ConditionSeverityInfo, "")
+			return nil
+		}
+		return fmt.Errorf("reading control plane status: %v", err)
+	}
+
+	cluster.Status.ControlPlane = &anywherev1.NodeGroupStatus{
+		Replicas:      kcp.Status.Replicas,
+		ReadyReplicas: kcp.Status.ReadyReplicas,
+	}
+	if kcp.Status.Version != nil {
+		cluster.Status.KubernetesVersion = *kcp.Status.Version
+	}
+
+	state := nodeGroupState{
+		observed: kcp.Status.ObservedGeneration >= kcp.Generation,
+		desired:  int32(cluster.Spec.ControlPlaneConfiguration.Count),
+		replicas: kcp.Status.Replicas,
+		updated:  kcp.Status.UpdatedReplicas,
+		ready:    kcp.Status.ReadyReplicas,
+	}
+	conditions.Set(cluster, state.condition(anywherev1.ControlPlaneReadyCondition, "control plane"))
+
+	return nil
+}
+
+func updateWorkersStatus(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	spec := specForCluster(cluster)
+	workerNodeGroups := make([]anywherev1.NodeGroupStatus, 0, len(cluster.Spec.WorkerNodeGroupConfigurations))
+	var notReady *clusterv1.Condition
+	for _, workerNodeGroup := range cluster.Spec.WorkerNodeGroupConfigurations {
+		md := &clusterv1.MachineDeployment{}
+		key := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: clusterapi.MachineDeploymentName(spec, workerNodeGroup)}
+		if err := client.Get(ctx, key, md); err != nil {
+			if apierrors.IsNotFound(err) {
+				if notReady == nil {
+					notReady = conditions.FalseCondition(anywherev1.WorkersReadyCondition, anywherev1.WorkersInitializingReason, clusterv1.ConditionSeverityInfo, "%s: not created yet", workerNodeGroup.Name)
+				}
+				continue
+			}
+			return fmt.Errorf("reading worker node group %s status: %v", workerNodeGroup.Name, err)
+		}
+
+		workerNodeGroups = append(workerNodeGroups, anywherev1.NodeGroupStatus{
+			Name:          workerNodeGroup.Name,
+			Replicas:      md.Status.Replicas,
+			ReadyReplicas: md.Status.ReadyReplicas,
+		})
+
+		state := nodeGroupState{
+			observed: md.Status.ObservedGeneration >= md.Generation,
+			desired:  int32(workerNodeGroup.Count),
+			replicas: md.Status.Replicas,
+			updated:  md.Status.UpdatedReplicas,
+			ready:    md.Status.ReadyReplicas,
+		}
+		if condition := state.condition(anywherev1.WorkersReadyCondition, workerNodeGroup.Name); condition.Status != corev1.ConditionTrue && notReady == nil {
+			notReady = condition
+		}
+	}
+
+	cluster.Status.WorkerNodeGroups = workerNodeGroups
+	if notReady != nil {
+		conditions.Set(cluster, notReady)
+	} else {
+		conditions.MarkTrue(cluster, anywherev1.WorkersReadyCondition)
+	}
+
+	return nil
+}
+
+func updateCNIStatus(ctx context.Context, workloadClient client.Client, cluster *anywherev1.Cluster) error {
+	if cluster.Spec.ClusterNetwork.CNIConfig.IsUserManaged() {
+		conditions.MarkFalse(cluster, anywherev1.DefaultCNIConfiguredCondition, anywherev1.DefaultCNIDisabledReason, clusterv1.ConditionSeverityInfo, "CNI is managed by the user")
+		// EKS Anywhere doesn't know which CNI the user installed, so it can't tell whether it's ready.
+		conditions.Delete(cluster, anywherev1.CNIReadyCondition)
+		return nil
+	}
+	conditions.MarkTrue(cluster, anywherev1.DefaultCNIConfiguredCondition)
+
+	name, ok := cniDaemonSets[cniForCluster(cluster)]
+	if !ok {
+		conditions.Delete(cluster, anywherev1.CNIReadyCondition)
+		return nil
+	}
+
+	if workloadClient == nil {
+		conditions.MarkFalse(cluster, anywherev1.CNIReadyCondition, anywherev1.ControlPlaneInitializingReason, clusterv1.ConditionSeverityInfo, "waiting for the control plane to be initialized")
+		return nil
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err := workloadClient.Get(ctx, types.NamespacedName{Namespace: constants.KubeSystemNamespace, Name: name}, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkFalse(cluster, anywherev1.CNIReadyCondition, anywherev1.CNINotInstalledReason, clusterv1.ConditionSeverityInfo, "%s daemonset not found", name)
+			return nil
+		}
+		return fmt.Errorf("reading CNI status: %v", err)
+	}
+
+	switch {
+	case ds.Status.ObservedGeneration < ds.Generation || ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled:
+		conditions.MarkFalse(cluster, anywherev1.CNIReadyCondition, anywherev1.RollingUpgradeInProgressReason, clusterv1.ConditionSeverityInfo, "%s: %d of %d pods up to date", name, ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)
+	case ds.Status.DesiredNumberScheduled == 0 || ds.Status.NumberReady < ds.Status.DesiredNumberScheduled:
+		conditions.MarkFalse(cluster, anywherev1.CNIReadyCondition, anywherev1.CNINotReadyReason, clusterv1.ConditionSeverityInfo, "%s: %d of %d pods ready", name, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+	default:
+		conditions.MarkTrue(cluster, anywherev1.CNIReadyCondition)
+	}
+
+	return nil
+}
+
+func cniForCluster(cluster *anywherev1.Cluster) anywherev1.CNI {
+	cniConfig := cluster.Spec.ClusterNetwork.CNIConfig
+	switch {
+	case cniConfig == nil:
+		return cluster.Spec.ClusterNetwork.CNI
+	case cniConfig.Cilium != nil:
+		return anywherev1.Cilium
+	case cniConfig.Kindnetd != nil:
+		return anywherev1.Kindnetd
+	default:
+		return ""
+	}
+}
+
+func updateReadyForUpgradeStatus(cluster *anywherev1.Cluster) {
+	switch {
+	case cluster.IsSelfManaged():
+		// Self-managed clusters are reconciled by the CLI, the controller can't tell whether it applied their latest spec.
+		conditions.Delete(cluster, anywherev1.ReadyForUpgradeCondition)
+	case cluster.Status.FailureMessage != nil:
+		conditions.MarkFalse(cluster, anywherev1.ReadyForUpgradeCondition, anywherev1.ReconciliationFailedReason, clusterv1.ConditionSeverityWarning, "%s", *cluster.Status.FailureMessage)
+	case cluster.Status.ObservedGeneration < cluster.Generation:
+		conditions.MarkFalse(cluster, anywherev1.ReadyForUpgradeCondition, anywherev1.ReconciliationInProgressReason, clusterv1.ConditionSeverityInfo, "")
+	case !conditions.IsTrue(cluster, clusterv1.ReadyCondition):
+		conditions.MarkFalse(cluster, anywherev1.ReadyForUpgradeCondition, anywherev1.ClusterNotReadyReason, clusterv1.ConditionSeverityInfo, "%s", conditions.GetMessage(cluster, clusterv1.ReadyCondition))
+	default:
+		conditions.MarkTrue(cluster, anywherev1.ReadyForUpgradeCondition)
+		// All the machines are up to date with the latest spec, so they run the components of its bundles.
+		cluster.Status.BundlesRef = cluster.Spec.BundlesRef.DeepCopy()
+	}
+}
+
+func specForCluster(c *anywherev1.Cluster) *cluster.Spec {
+	return &cluster.Spec{Config: &cluster.Config{Cluster: c}}
+}
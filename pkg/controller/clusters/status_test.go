@@ -0,0 +1,345 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+)
+
+func givenCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: anywherev1.ClusterSpec{
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{Cilium: &anywherev1.CiliumConfig{}},
+			},
+			ManagementCluster: anywherev1.ManagementCluster{
+				Name: "my-management-cluster",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Count: 3,
+			},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{
+					Name:  "md-0",
+					Count: 2,
+				},
+			},
+			BundlesRef: &anywherev1.BundlesRef{
+				APIVersion: "anywhere.eks.amazonaws.com/v1alpha1",
+				Name:       "bundles-1",
+				Namespace:  "default",
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ObservedGeneration: 2,
+		},
+	}
+}
+
+func givenKubeadmControlPlane() *controlplanev1.KubeadmControlPlane {
+	version := "v1.21.5-eks-1-21-9"
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster",
+			Namespace:  constants.EksaSystemNamespace,
+			Generation: 1,
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			ObservedGeneration: 1,
+			Replicas:           3,
+			UpdatedReplicas:    3,
+			ReadyReplicas:      3,
+			Version:            &version,
+		},
+	}
+}
+
+func givenMachineDeployment() *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-cluster-md-0",
+			Namespace:  constants.EksaSystemNamespace,
+			Generation: 1,
+		},
+		Status: clusterv1.MachineDeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+		},
+	}
+}
+
+func givenCiliumDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cilium",
+			Namespace:  constants.KubeSystemNamespace,
+			Generation: 1,
+		},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     1,
+			DesiredNumberScheduled: 5,
+			UpdatedNumberScheduled: 5,
+			NumberReady:            5,
+		},
+	}
+}
+
+func newClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestUpdateClusterStatusReady(t *testing.T) {
+	g := NewWithT(t)
+	cluster := givenCluster()
+	client := newClient(givenKubeadmControlPlane(), givenMachineDeployment(), givenCiliumDaemonSet())
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, client, cluster)).To(Succeed())
+
+	for _, c := range []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+		anywherev1.ControlPlaneReadyCondition,
+		anywherev1.WorkersReadyCondition,
+		anywherev1.CNIReadyCondition,
+		anywherev1.DefaultCNIConfiguredCondition,
+		anywherev1.ReadyForUpgradeCondition,
+	} {
+		g.Expect(conditions.IsTrue(cluster, c)).To(BeTrue(), "condition %s should be true", c)
+	}
+	g.Expect(cluster.Status.ControlPlane).To(Equal(&anywherev1.NodeGroupStatus{Replicas: 3, ReadyReplicas: 3}))
+	g.Expect(cluster.Status.WorkerNodeGroups).To(Equal([]anywherev1.NodeGroupStatus{{Name: "md-0", Replicas: 2, ReadyReplicas: 2}}))
+	g.Expect(cluster.Status.KubernetesVersion).To(Equal("v1.21.5-eks-1-21-9"))
+	g.Expect(cluster.Status.BundlesRef).To(Equal(cluster.Spec.BundlesRef))
+}
+
+func TestUpdateClusterStatusControlPlaneNotFound(t *testing.T) {
+	g := NewWithT(t)
+	cluster := givenCluster()
+	client := newClient()
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, nil, cluster)).To(Succeed())
+
+	g.Expect(conditions.GetReason(cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(anywherev1.ControlPlaneInitializingReason))
+	g.Expect(conditions.GetReason(cluster, anywherev1.WorkersReadyCondition)).To(Equal(anywherev1.WorkersInitializingReason))
+	g.Expect(conditions.GetReason(cluster, anywherev1.CNIReadyCondition)).To(Equal(anywherev1.ControlPlaneInitializingReason))
+	g.Expect(conditions.IsFalse(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(cluster, anywherev1.ReadyForUpgradeCondition)).To(Equal(anywherev1.ClusterNotReadyReason))
+	g.Expect(cluster.Status.ControlPlane).To(BeNil())
+	g.Expect(cluster.Status.WorkerNodeGroups).To(BeEmpty())
+	g.Expect(cluster.Status.BundlesRef).To(BeNil())
+}
+
+func TestUpdateClusterStatusNodeGroupsNotReady(t *testing.T) {
+	tests := []struct {
+		name                string
+		kcp                 func(*controlplanev1.KubeadmControlPlane)
+		md                  func(*clusterv1.MachineDeployment)
+		wantControlPlane    string
+		wantWorkers         string
+		wantControlPlaneMsg string
+		wantWorkersMsg      string
+	}{
+		{
+			name:                "control plane scaling up",
+			kcp:                 func(k *controlplanev1.KubeadmControlPlane) { k.Status.Replicas = 1 },
+			md:                  func(*clusterv1.MachineDeployment) {},
+			wantControlPlane:    anywherev1.ScalingUpReason,
+			wantControlPlaneMsg: "control plane: scaling up to 3 replicas (actual 1)",
+		},
+		{
+			name:           "workers scaling down",
+			kcp:            func(*controlplanev1.KubeadmControlPlane) {},
+			md:             func(m *clusterv1.MachineDeployment) { m.Status.Replicas = 3 },
+			wantWorkers:    anywherev1.ScalingDownReason,
+			wantWorkersMsg: "md-0: scaling down to 2 replicas (actual 3)",
+		},
+		{
+			name:                "control plane rolling upgrade",
+			kcp:                 func(k *controlplanev1.KubeadmControlPlane) { k.Status.UpdatedReplicas = 1 },
+			md:                  func(*clusterv1.MachineDeployment) {},
+			wantControlPlane:    anywherev1.RollingUpgradeInProgressReason,
+			wantControlPlaneMsg: "control plane: 1 of 3 replicas up to date",
+		},
+		{
+			name:           "workers spec not observed",
+			kcp:            func(*controlplanev1.KubeadmControlPlane) {},
+			md:             func(m *clusterv1.MachineDeployment) { m.Generation = 2 },
+			wantWorkers:    anywherev1.RollingUpgradeInProgressReason,
+			wantWorkersMsg: "md-0: 2 of 2 replicas up to date",
+		},
+		{
+			name:           "worker nodes not ready",
+			kcp:            func(*controlplanev1.KubeadmControlPlane) {},
+			md:             func(m *clusterv1.MachineDeployment) { m.Status.ReadyReplicas = 0 },
+			wantWorkers:    anywherev1.NodesNotReadyReason,
+			wantWorkersMsg: "md-0: 0 of 2 replicas ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := givenCluster()
+			kcp := givenKubeadmControlPlane()
+			tt.kcp(kcp)
+			md := givenMachineDeployment()
+			tt.md(md)
+			client := newClient(kcp, md, givenCiliumDaemonSet())
+
+			g.Expect(clusters.UpdateClusterStatus(context.Background(), client, client, cluster)).To(Succeed())
+
+			g.Expect(conditions.GetReason(cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(tt.wantControlPlane))
+			g.Expect(conditions.GetMessage(cluster, anywherev1.ControlPlaneReadyCondition)).To(Equal(tt.wantControlPlaneMsg))
+			g.Expect(conditions.GetReason(cluster, anywherev1.WorkersReadyCondition)).To(Equal(tt.wantWorkers))
+			g.Expect(conditions.GetMessage(cluster, anywherev1.WorkersReadyCondition)).To(Equal(tt.wantWorkersMsg))
+			g.Expect(conditions.IsFalse(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+			g.Expect(conditions.IsFalse(cluster, anywherev1.ReadyForUpgradeCondition)).To(BeTrue())
+		})
+	}
+}
+
+func TestUpdateClusterStatusReadyForUpgrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		cluster    func(*anywherev1.Cluster)
+		wantReason string
+	}{
+		{
+			name: "reconciliation failed",
+			cluster: func(c *anywherev1.Cluster) {
+				msg := "failed"
+				c.Status.FailureMessage = &msg
+			},
+			wantReason: anywherev1.ReconciliationFailedReason,
+		},
+		{
+			name:       "spec not reconciled",
+			cluster:    func(c *anywherev1.Cluster) { c.Generation = 3 },
+			wantReason: anywherev1.ReconciliationInProgressReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := givenCluster()
+			tt.cluster(cluster)
+			client := newClient(givenKubeadmControlPlane(), givenMachineDeployment(), givenCiliumDaemonSet())
+
+			g.Expect(clusters.UpdateClusterStatus(context.Background(), client, client, cluster)).To(Succeed())
+
+			g.Expect(conditions.IsTrue(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(cluster, anywherev1.ReadyForUpgradeCondition)).To(Equal(tt.wantReason))
+			g.Expect(cluster.Status.BundlesRef).To(BeNil())
+		})
+	}
+}
+
+func TestUpdateClusterStatusUserManagedCNI(t *testing.T) {
+	g := NewWithT(t)
+	cluster := givenCluster()
+	cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{None: &anywherev1.NoneCNIConfig{}}
+	client := newClient(givenKubeadmControlPlane(), givenMachineDeployment(), givenCiliumDaemonSet())
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, client, cluster)).To(Succeed())
+
+	g.Expect(conditions.Get(cluster, anywherev1.DefaultCNIConfiguredCondition).Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(conditions.GetReason(cluster, anywherev1.DefaultCNIConfiguredCondition)).To(Equal(anywherev1.DefaultCNIDisabledReason))
+	g.Expect(conditions.Has(cluster, anywherev1.CNIReadyCondition)).To(BeFalse())
+	g.Expect(conditions.IsTrue(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+}
+
+func TestUpdateClusterStatusCNINotReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		ds         func(*appsv1.DaemonSet)
+		cni        *anywherev1.CNIConfig
+		wantReason string
+		wantMsg    string
+	}{
+		{
+			name:       "pods not ready",
+			ds:         func(d *appsv1.DaemonSet) { d.Status.NumberReady = 3 },
+			wantReason: anywherev1.CNINotReadyReason,
+			wantMsg:    "cilium: 3 of 5 pods ready",
+		},
+		{
+			name:       "no pods scheduled",
+			ds:         func(d *appsv1.DaemonSet) { d.Status = appsv1.DaemonSetStatus{ObservedGeneration: 1} },
+			wantReason: anywherev1.CNINotReadyReason,
+			wantMsg:    "cilium: 0 of 0 pods ready",
+		},
+		{
+			name:       "rolling upgrade",
+			ds:         func(d *appsv1.DaemonSet) { d.Status.UpdatedNumberScheduled = 2 },
+			wantReason: anywherev1.RollingUpgradeInProgressReason,
+			wantMsg:    "cilium: 2 of 5 pods up to date",
+		},
+		{
+			name:       "spec not observed",
+			ds:         func(d *appsv1.DaemonSet) { d.Generation = 2 },
+			wantReason: anywherev1.RollingUpgradeInProgressReason,
+			wantMsg:    "cilium: 5 of 5 pods up to date",
+		},
+		{
+			name:       "not installed",
+			ds:         func(d *appsv1.DaemonSet) {},
+			cni:        &anywherev1.CNIConfig{Kindnetd: &anywherev1.KindnetdConfig{}},
+			wantReason: anywherev1.CNINotInstalledReason,
+			wantMsg:    "kindnet daemonset not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := givenCluster()
+			if tt.cni != nil {
+				cluster.Spec.ClusterNetwork.CNIConfig = tt.cni
+			}
+			ds := givenCiliumDaemonSet()
+			tt.ds(ds)
+			client := newClient(givenKubeadmControlPlane(), givenMachineDeployment(), ds)
+
+			g.Expect(clusters.UpdateClusterStatus(context.Background(), client, client, cluster)).To(Succeed())
+
+			g.Expect(conditions.GetReason(cluster, anywherev1.CNIReadyCondition)).To(Equal(tt.wantReason))
+			g.Expect(conditions.GetMessage(cluster, anywherev1.CNIReadyCondition)).To(Equal(tt.wantMsg))
+			g.Expect(conditions.IsFalse(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+		})
+	}
+}
+
+func TestUpdateClusterStatusSelfManaged(t *testing.T) {
+	g := NewWithT(t)
+	cluster := givenCluster()
+	cluster.Spec.ManagementCluster.Name = cluster.Name
+	client := newClient(givenKubeadmControlPlane(), givenMachineDeployment(), givenCiliumDaemonSet())
+
+	g.Expect(clusters.UpdateClusterStatus(context.Background(), client, client, cluster)).To(Succeed())
+
+	g.Expect(conditions.IsTrue(cluster, clusterv1.ReadyCondition)).To(BeTrue())
+	g.Expect(conditions.Has(cluster, anywherev1.ReadyForUpgradeCondition)).To(BeFalse())
+	g.Expect(cluster.Status.BundlesRef).To(BeNil())
+}
@@ -0,0 +1,135 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// DefaultDedupWindow is the time during which an event identical to the last one
+// recorded for the same object and reason is dropped.
+const DefaultDedupWindow = 10 * time.Minute
+
+type eventKey struct {
+	uid       types.UID
+	eventtype string
+	reason    string
+}
+
+type lastEvent struct {
+	message string
+	time    time.Time
+}
+
+// DedupRecorder is a record.EventRecorder that drops events identical to the last one
+// recorded for the same object, type and reason during the dedup window.
+// Reconcilers requeue often while waiting on the same condition, so this prevents them
+// from flooding the object events with the same message.
+type DedupRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	last      map[eventKey]lastEvent
+	lastPrune time.Time
+}
+
+var _ record.EventRecorder = &DedupRecorder{}
+
+// DedupRecorderOpt allows to customize a DedupRecorder on construction.
+type DedupRecorderOpt func(*DedupRecorder)
+
+// WithDedupWindow sets the time during which identical events are dropped.
+func WithDedupWindow(window time.Duration) DedupRecorderOpt {
+	return func(r *DedupRecorder) {
+		r.window = window
+	}
+}
+
+// WithNow sets the function used to get the current time.
+func WithNow(now func() time.Time) DedupRecorderOpt {
+	return func(r *DedupRecorder) {
+		r.now = now
+	}
+}
+
+// NewDedupRecorder builds a DedupRecorder that records events with recorder.
+func NewDedupRecorder(recorder record.EventRecorder, opts ...DedupRecorderOpt) *DedupRecorder {
+	r := &DedupRecorder{
+		recorder: recorder,
+		window:   DefaultDedupWindow,
+		now:      time.Now,
+		last:     map[eventKey]lastEvent{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Event implements record.EventRecorder.
+func (r *DedupRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.Event(object, eventtype, reason, message)
+}
+
+// Eventf implements record.EventRecorder.
+func (r *DedupRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf implements record.EventRecorder.
+func (r *DedupRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+func (r *DedupRecorder) isDuplicate(object runtime.Object, eventtype, reason, message string) bool {
+	accessor, err := meta.Accessor(object)
+	if err != nil || accessor.GetUID() == "" {
+		// Without a UID the object can't be told apart from others, so don't dedup it
+		return false
+	}
+
+	key := eventKey{uid: accessor.GetUID(), eventtype: eventtype, reason: reason}
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if last, ok := r.last[key]; ok && last.message == message && now.Sub(last.time) < r.window {
+		return true
+	}
+
+	r.last[key] = lastEvent{message: message, time: now}
+	if now.Sub(r.lastPrune) >= r.window {
+		r.pruneLocked(now)
+		r.lastPrune = now
+	}
+
+	return false
+}
+
+// pruneLocked removes the events out of the dedup window so the cache doesn't grow
+// with deleted objects. It runs at most once per window, so events never expire later than
+// twice the window. It must be called with the lock held.
+func (r *DedupRecorder) pruneLocked(now time.Time) {
+	for key, last := range r.last {
+		if now.Sub(last.time) >= r.window {
+			delete(r.last, key)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/controller/events"
+)
+
+func newCluster(uid string) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+			UID:       types.UID("uid-" + uid),
+		},
+	}
+}
+
+func TestDedupRecorderDropsDuplicates(t *testing.T) {
+	g := NewWithT(t)
+	fake := record.NewFakeRecorder(10)
+	r := events.NewDedupRecorder(fake)
+	cluster := newCluster("1")
+
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForControlPlane", "Waiting for control plane to be ready")
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForControlPlane", "Waiting for control plane to be ready")
+	r.Eventf(cluster, corev1.EventTypeNormal, "WaitingForControlPlane", "Waiting for %s to be ready", "control plane")
+
+	g.Expect(fake.Events).To(HaveLen(1))
+	g.Expect(<-fake.Events).To(Equal("Normal WaitingForControlPlane Waiting for control plane to be ready"))
+}
+
+func TestDedupRecorderRecordsChanges(t *testing.T) {
+	g := NewWithT(t)
+	fake := record.NewFakeRecorder(10)
+	r := events.NewDedupRecorder(fake)
+	cluster := newCluster("1")
+	otherCluster := newCluster("2")
+
+	r.Event(cluster, corev1.EventTypeWarning, "ReconcileFailed", "error 1")
+	r.Event(cluster, corev1.EventTypeWarning, "ReconcileFailed", "error 2")
+	r.Event(cluster, corev1.EventTypeWarning, "ReconcileFailed", "error 1")
+	r.Event(cluster, corev1.EventTypeNormal, "ClusterReady", "error 1")
+	r.Event(otherCluster, corev1.EventTypeWarning, "ReconcileFailed", "error 1")
+
+	g.Expect(fake.Events).To(HaveLen(5))
+}
+
+func TestDedupRecorderRecordsAfterWindow(t *testing.T) {
+	g := NewWithT(t)
+	fake := record.NewFakeRecorder(10)
+	now := time.Now()
+	r := events.NewDedupRecorder(fake,
+		events.WithDedupWindow(time.Minute),
+		events.WithNow(func() time.Time { return now }),
+	)
+	cluster := newCluster("1")
+
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+	now = now.Add(30 * time.Second)
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+	now = now.Add(time.Minute)
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+
+	g.Expect(fake.Events).To(HaveLen(2))
+}
+
+func TestDedupRecorderWithoutUID(t *testing.T) {
+	g := NewWithT(t)
+	fake := record.NewFakeRecorder(10)
+	r := events.NewDedupRecorder(fake)
+	cluster := &anywherev1.Cluster{}
+
+	r.Event(cluster, corev1.EventTypeNormal, "ClusterReady", "Cluster is ready")
+	r.AnnotatedEventf(cluster, map[string]string{"a": "b"}, corev1.EventTypeNormal, "ClusterReady", "Cluster is %s", "ready")
+
+	g.Expect(fake.Events).To(HaveLen(2))
+}
+
+func TestDedupRecorderPruneKeepsEventsInWindow(t *testing.T) {
+	g := NewWithT(t)
+	fake := record.NewFakeRecorder(10)
+	now := time.Now()
+	r := events.NewDedupRecorder(fake,
+		events.WithDedupWindow(time.Minute),
+		events.WithNow(func() time.Time { return now }),
+	)
+	cluster := newCluster("1")
+	otherCluster := newCluster("2")
+
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+	now = now.Add(45 * time.Second)
+	r.Event(otherCluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+	now = now.Add(30 * time.Second)
+	// Prunes the first cluster event but keeps the second one, which is still in the window
+	r.Event(cluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+	r.Event(otherCluster, corev1.EventTypeNormal, "WaitingForEtcd", "Waiting for etcd to be ready")
+
+	g.Expect(fake.Events).To(HaveLen(3))
+}
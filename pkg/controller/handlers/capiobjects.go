@@ -1,27 +1,31 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 )
 
 // CAPIObjectToCluster returns a request handler that enqueues an EKS-A Cluster
-// reconcile request for CAPI objects that contain the cluster name and namespace labels
-func CAPIObjectToCluster(log logr.Logger) handler.MapFunc {
+// reconcile request for CAPI objects that contain the cluster name and namespace labels.
+// Not all providers set those labels, so objects without them are mapped through the CAPI
+// Cluster they belong to, which has the same name as its EKS-A Cluster.
+func CAPIObjectToCluster(reader client.Reader, log logr.Logger) handler.MapFunc {
 	return func(o client.Object) []reconcile.Request {
 		labels := o.GetLabels()
 		clusterName, ok := labels[clusterapi.EKSAClusterLabelName]
 		if !ok {
-			// Object not managed by an eks-a Cluster, don't enqueue
-			log.V(6).Info("Object not managed by an eks-a Cluster, ignoring", "type", fmt.Sprintf("%T", o), "name", o.GetName())
-			return nil
+			return capiClusterToClusters(reader, log, o)
 		}
 
 		clusterNamespace := labels[clusterapi.EKSAClusterLabelNamespace]
@@ -39,3 +43,48 @@ func CAPIObjectToCluster(log logr.Logger) handler.MapFunc {
 		}}
 	}
 }
+
+func capiClusterToClusters(reader client.Reader, log logr.Logger, o client.Object) []reconcile.Request {
+	capiClusterName := capiClusterNameForObject(o)
+	if capiClusterName == "" {
+		// Object not managed by an eks-a Cluster, don't enqueue
+		log.V(6).Info("Object not managed by an eks-a Cluster, ignoring", "type", fmt.Sprintf("%T", o), "name", o.GetName())
+		return nil
+	}
+
+	clusters := &anywherev1.ClusterList{}
+	if err := reader.List(context.Background(), clusters); err != nil {
+		log.Error(err, "Listing clusters for CAPI object", "type", fmt.Sprintf("%T", o), "name", o.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, c := range clusters.Items {
+		if c.Name == capiClusterName {
+			log.Info("Enqueuing Cluster request coming from CAPI object", "type", fmt.Sprintf("%T", o), "name", o.GetName(), "cluster", c.Name)
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: c.Namespace, Name: c.Name}})
+		}
+	}
+
+	return requests
+}
+
+// capiClusterNameForObject returns the name of the CAPI Cluster an object belongs to, from the CAPI
+// cluster name label or its owner references.
+func capiClusterNameForObject(o client.Object) string {
+	if name := o.GetLabels()[clusterv1.ClusterLabelName]; name != "" {
+		return name
+	}
+
+	for _, owner := range o.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			continue
+		}
+		if owner.Kind == "Cluster" && gv.Group == clusterv1.GroupVersion.Group {
+			return owner.Name
+		}
+	}
+
+	return ""
+}
@@ -6,12 +6,16 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller/handlers"
 )
@@ -57,12 +61,75 @@ func TestCAPIObjectToCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "capi cluster name label",
+			obj: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						clusterv1.ClusterLabelName: "my-cluster",
+					},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "my-cluster",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+		},
+		{
+			testName: "capi cluster owner",
+			obj: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: clusterv1.GroupVersion.String(),
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+			},
+			wantRequests: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "my-cluster",
+						Namespace: "my-namespace",
+					},
+				},
+			},
+		},
+		{
+			testName: "capi cluster without eksa cluster",
+			obj: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						clusterv1.ClusterLabelName: "other-cluster",
+					},
+				},
+			},
+			wantRequests: nil,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := anywherev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	eksaCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "my-namespace",
+		},
 	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(eksaCluster).Build()
 
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
 			g := NewWithT(t)
-			handle := handlers.CAPIObjectToCluster(logr.New(logf.NullLogSink{}))
+			handle := handlers.CAPIObjectToCluster(reader, logr.New(logf.NullLogSink{}))
 			requests := handle(tt.obj)
 			g.Expect(requests).To(Equal(tt.wantRequests))
 		})
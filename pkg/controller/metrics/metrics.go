@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// Outcomes of a cluster reconciliation.
+const (
+	ResultSuccess = "success"
+	ResultRequeue = "requeue"
+	ResultError   = "error"
+)
+
+// Phases of a cluster reconciliation. The spec, CNI and extra objects phases are part of the provider reconciliation.
+const (
+	PhaseControlPlaneSpec   = "control_plane_spec"
+	PhaseWorkersSpec        = "workers_spec"
+	PhaseCNI                = "cni"
+	PhaseExtraObjects       = "extra_objects"
+	PhaseOwnerReferences    = "owner_references"
+	PhaseAWSIamAuthMappings = "aws_iam_auth_mappings"
+	PhaseProviderReconcile  = "provider_reconcile"
+	PhaseStatus             = "status"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eksa_cluster_reconcile_total",
+			Help: "Total number of cluster reconciliations per provider and result.",
+		},
+		[]string{"provider", "result"},
+	)
+
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "eksa_cluster_reconcile_duration_seconds",
+			Help:    "Duration of cluster reconciliations per provider.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"provider"},
+	)
+
+	reconcilePhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "eksa_cluster_reconcile_phase_duration_seconds",
+			Help:    "Duration of each phase of cluster reconciliations per provider.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"provider", "phase"},
+	)
+
+	clusterReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "eksa_cluster_ready",
+			Help: "Whether the cluster Ready condition is true (1) or not (0).",
+		},
+		[]string{"name", "namespace", "provider"},
+	)
+
+	clusterInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "eksa_cluster_info",
+			Help: "Versions of the cluster, always 1.",
+		},
+		[]string{"name", "namespace", "provider", "kubernetes_version", "running_kubernetes_version", "bundles"},
+	)
+
+	// infoLabels holds the last clusterInfo labels of each cluster, so the series is replaced
+	// instead of duplicated when a version changes.
+	infoLabels   = map[types.NamespacedName]prometheus.Labels{}
+	infoLabelsMu sync.Mutex
+)
+
+// Collectors returns the Prometheus collectors of the cluster reconciliations, to be registered by the manager.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		reconcileTotal,
+		reconcileDuration,
+		reconcilePhaseDuration,
+		clusterReady,
+		clusterInfo,
+	}
+}
+
+// ObserveReconcile records the result and duration of a cluster reconciliation started at start.
+func ObserveReconcile(cluster *anywherev1.Cluster, start time.Time, result ctrl.Result, err error) {
+	provider := Provider(cluster)
+	outcome := ResultSuccess
+	switch {
+	case err != nil:
+		outcome = ResultError
+	case result.Requeue || result.RequeueAfter > 0:
+		outcome = ResultRequeue
+	}
+
+	reconcileTotal.WithLabelValues(provider, outcome).Inc()
+	reconcileDuration.WithLabelValues(provider).Observe(time.Since(start).Seconds())
+}
+
+// ObservePhase records the duration of a phase of a cluster reconciliation started at start.
+// It's meant to be deferred at the beginning of the phase.
+func ObservePhase(provider, phase string, start time.Time) {
+	reconcilePhaseDuration.WithLabelValues(provider, phase).Observe(time.Since(start).Seconds())
+}
+
+// RecordClusterStatus updates the readiness and version gauges of a cluster from its status.
+func RecordClusterStatus(cluster *anywherev1.Cluster) {
+	provider := Provider(cluster)
+	ready := 0.0
+	if conditions.IsTrue(cluster, clusterv1.ReadyCondition) {
+		ready = 1
+	}
+	clusterReady.WithLabelValues(cluster.Name, cluster.Namespace, provider).Set(ready)
+
+	bundles := ""
+	if cluster.Status.BundlesRef != nil {
+		bundles = cluster.Status.BundlesRef.Name
+	}
+	labels := prometheus.Labels{
+		"name":                       cluster.Name,
+		"namespace":                  cluster.Namespace,
+		"provider":                   provider,
+		"kubernetes_version":         string(cluster.Spec.KubernetesVersion),
+		"running_kubernetes_version": cluster.Status.KubernetesVersion,
+		"bundles":                    bundles,
+	}
+
+	infoLabelsMu.Lock()
+	defer infoLabelsMu.Unlock()
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	if old, ok := infoLabels[key]; ok {
+		clusterInfo.Delete(old)
+	}
+	clusterInfo.With(labels).Set(1)
+	infoLabels[key] = labels
+}
+
+// DeleteClusterStatus removes the readiness and version gauges of a deleted cluster.
+func DeleteClusterStatus(cluster *anywherev1.Cluster) {
+	clusterReady.DeleteLabelValues(cluster.Name, cluster.Namespace, Provider(cluster))
+
+	infoLabelsMu.Lock()
+	defer infoLabelsMu.Unlock()
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	if old, ok := infoLabels[key]; ok {
+		clusterInfo.Delete(old)
+		delete(infoLabels, key)
+	}
+}
+
+// Provider returns the name of the provider of a cluster used as metrics label.
+func Provider(cluster *anywherev1.Cluster) string {
+	switch cluster.Spec.DatacenterRef.Kind {
+	case anywherev1.VSphereDatacenterKind:
+		return constants.VSphereProviderName
+	case anywherev1.DockerDatacenterKind:
+		return constants.DockerProviderName
+	case anywherev1.SnowDatacenterKind:
+		return constants.SnowProviderName
+	case anywherev1.TinkerbellDatacenterKind:
+		return constants.TinkerbellProviderName
+	case anywherev1.CloudStackDatacenterKind:
+		return constants.CloudStackProviderName
+	default:
+		return cluster.Spec.DatacenterRef.Kind
+	}
+}
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func givenCluster() *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube122,
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.VSphereDatacenterKind,
+				Name: "my-cluster",
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			KubernetesVersion: "1.21",
+		},
+	}
+}
+
+func TestProvider(t *testing.T) {
+	tests := []struct {
+		kind string
+		want string
+	}{
+		{kind: anywherev1.VSphereDatacenterKind, want: constants.VSphereProviderName},
+		{kind: anywherev1.DockerDatacenterKind, want: constants.DockerProviderName},
+		{kind: anywherev1.SnowDatacenterKind, want: constants.SnowProviderName},
+		{kind: anywherev1.TinkerbellDatacenterKind, want: constants.TinkerbellProviderName},
+		{kind: anywherev1.CloudStackDatacenterKind, want: constants.CloudStackProviderName},
+		{kind: "OtherDatacenterConfig", want: "OtherDatacenterConfig"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := givenCluster()
+			cluster.Spec.DatacenterRef.Kind = tt.kind
+			g.Expect(Provider(cluster)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestObserveReconcile(t *testing.T) {
+	g := NewWithT(t)
+	reconcileTotal.Reset()
+	reconcileDuration.Reset()
+	cluster := givenCluster()
+
+	ObserveReconcile(cluster, time.Now(), ctrl.Result{}, nil)
+	ObserveReconcile(cluster, time.Now(), ctrl.Result{RequeueAfter: time.Second}, nil)
+	ObserveReconcile(cluster, time.Now(), ctrl.Result{Requeue: true}, nil)
+	ObserveReconcile(cluster, time.Now(), ctrl.Result{}, errors.New("failed"))
+
+	g.Expect(testutil.ToFloat64(reconcileTotal.WithLabelValues(constants.VSphereProviderName, ResultSuccess))).To(Equal(1.0))
+	g.Expect(testutil.ToFloat64(reconcileTotal.WithLabelValues(constants.VSphereProviderName, ResultRequeue))).To(Equal(2.0))
+	g.Expect(testutil.ToFloat64(reconcileTotal.WithLabelValues(constants.VSphereProviderName, ResultError))).To(Equal(1.0))
+	g.Expect(testutil.CollectAndCount(reconcileDuration)).To(Equal(1))
+}
+
+func TestObservePhase(t *testing.T) {
+	g := NewWithT(t)
+	reconcilePhaseDuration.Reset()
+
+	func() {
+		defer ObservePhase(constants.VSphereProviderName, PhaseCNI, time.Now())
+	}()
+	ObservePhase(constants.VSphereProviderName, PhaseWorkersSpec, time.Now())
+
+	g.Expect(testutil.CollectAndCount(reconcilePhaseDuration)).To(Equal(2))
+}
+
+func TestRecordClusterStatus(t *testing.T) {
+	g := NewWithT(t)
+	clusterReady.Reset()
+	clusterInfo.Reset()
+	cluster := givenCluster()
+
+	RecordClusterStatus(cluster)
+	g.Expect(testutil.ToFloat64(clusterReady.WithLabelValues("my-cluster", "default", constants.VSphereProviderName))).To(Equal(0.0))
+
+	conditions.MarkTrue(cluster, clusterv1.ReadyCondition)
+	cluster.Status.KubernetesVersion = "1.22"
+	RecordClusterStatus(cluster)
+	g.Expect(testutil.ToFloat64(clusterReady.WithLabelValues("my-cluster", "default", constants.VSphereProviderName))).To(Equal(1.0))
+	g.Expect(testutil.CollectAndCount(clusterInfo)).To(Equal(1), "version change should replace the info series")
+	g.Expect(testutil.ToFloat64(clusterInfo.WithLabelValues("my-cluster", "default", constants.VSphereProviderName, "1.22", "1.22", ""))).To(Equal(1.0))
+}
+
+func TestDeleteClusterStatus(t *testing.T) {
+	g := NewWithT(t)
+	clusterReady.Reset()
+	clusterInfo.Reset()
+	cluster := givenCluster()
+
+	RecordClusterStatus(cluster)
+	g.Expect(testutil.CollectAndCount(clusterReady)).To(Equal(1))
+	g.Expect(testutil.CollectAndCount(clusterInfo)).To(Equal(1))
+
+	DeleteClusterStatus(cluster)
+	g.Expect(testutil.CollectAndCount(clusterReady)).To(Equal(0))
+	g.Expect(testutil.CollectAndCount(clusterInfo)).To(Equal(0))
+}
+
+func TestCollectorsRegister(t *testing.T) {
+	g := NewWithT(t)
+	registry := prometheus.NewRegistry()
+	for _, c := range Collectors() {
+		g.Expect(registry.Register(c)).To(Succeed())
+	}
+}
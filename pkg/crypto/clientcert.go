@@ -0,0 +1,123 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// ClientCertificateRequest describes a client certificate to sign with a cluster CA.
+type ClientCertificateRequest struct {
+	// User is the certificate common name, which Kubernetes uses as the user name.
+	User string
+	// Groups are the certificate organizations, which Kubernetes uses as the user groups.
+	Groups []string
+	// TTL is how long the certificate is valid for.
+	TTL time.Duration
+}
+
+// ClientCertificate is a client certificate and its private key.
+type ClientCertificate struct {
+	CertPEM     []byte
+	KeyPEM      []byte
+	Certificate *x509.Certificate
+}
+
+// SignClientCertificate generates a private key and a client certificate for req signed by the
+// CA in caCertPEM and caKeyPEM. The certificate is valid from now and never outlives the CA.
+func SignClientCertificate(caCertPEM, caKeyPEM []byte, req ClientCertificateRequest, now time.Time) (*ClientCertificate, error) {
+	if req.User == "" {
+		return nil, fmt.Errorf("client certificate user is required")
+	}
+	if req.TTL <= 0 {
+		return nil, fmt.Errorf("client certificate ttl must be positive")
+	}
+
+	caCert, err := parseCertificate(caCertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CA certificate: %v", err)
+	}
+	caKey, err := parsePrivateKey(caKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CA key: %v", err)
+	}
+
+	notAfter := now.Add(req.TTL)
+	if notAfter.After(caCert.NotAfter) {
+		return nil, fmt.Errorf("client certificate would expire at %s, after its CA at %s", notAfter.UTC().Format(time.RFC3339), caCert.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	cg := &certificategenerator{}
+	privateKey, err := cg.generatePrivateKey(2048)
+	if err != nil {
+		return nil, fmt.Errorf("generating private key for client certificate: %v", err)
+	}
+	serialNumber, err := cg.generateCertSerialNumber()
+	if err != nil {
+		return nil, fmt.Errorf("generating serial number for client certificate: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   req.User,
+			Organization: req.Groups,
+		},
+		NotBefore:             now,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, &privateKey.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("signing client certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing client certificate: %v", err)
+	}
+
+	return &ClientCertificate{
+		CertPEM:     cg.encodeToPEM(certBytes, "CERTIFICATE"),
+		KeyPEM:      cg.encodeToPEM(cg.encodePrivateKey(privateKey), "RSA PRIVATE KEY"),
+		Certificate: cert,
+	}, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported private key format")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,129 @@
+package crypto_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/crypto"
+)
+
+func givenCA(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("generating CA certificate: %v", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM
+}
+
+func TestSignClientCertificate(t *testing.T) {
+	g := NewWithT(t)
+	caCert, caKey := givenCA(t)
+	now := time.Now().Truncate(time.Second)
+
+	cert, err := crypto.SignClientCertificate(caCert, caKey, crypto.ClientCertificateRequest{
+		User:   "jane",
+		Groups: []string{"dev", "ops"},
+		TTL:    8 * time.Hour,
+	}, now)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cert.Certificate.Subject.CommonName).To(Equal("jane"))
+	g.Expect(cert.Certificate.Subject.Organization).To(Equal([]string{"dev", "ops"}))
+	g.Expect(cert.Certificate.NotAfter).To(BeTemporally("==", now.Add(8*time.Hour)))
+	g.Expect(cert.Certificate.ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}))
+	g.Expect(cert.Certificate.IsCA).To(BeFalse())
+
+	block, _ := pem.Decode(caCert)
+	ca, err := x509.ParseCertificate(block.Bytes)
+	g.Expect(err).NotTo(HaveOccurred())
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	_, err = cert.Certificate.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now.Add(time.Hour),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	keyBlock, _ := pem.Decode(cert.KeyPEM)
+	g.Expect(keyBlock.Type).To(Equal("RSA PRIVATE KEY"))
+}
+
+func TestSignClientCertificateErrors(t *testing.T) {
+	caCert, caKey := givenCA(t)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		caCert  []byte
+		caKey   []byte
+		req     crypto.ClientCertificateRequest
+		wantErr string
+	}{
+		{
+			name:    "no user",
+			caCert:  caCert,
+			caKey:   caKey,
+			req:     crypto.ClientCertificateRequest{TTL: time.Hour},
+			wantErr: "client certificate user is required",
+		},
+		{
+			name:    "no ttl",
+			caCert:  caCert,
+			caKey:   caKey,
+			req:     crypto.ClientCertificateRequest{User: "jane"},
+			wantErr: "client certificate ttl must be positive",
+		},
+		{
+			name:    "invalid ca cert",
+			caCert:  []byte("invalid"),
+			caKey:   caKey,
+			req:     crypto.ClientCertificateRequest{User: "jane", TTL: time.Hour},
+			wantErr: "parsing CA certificate: no PEM data found",
+		},
+		{
+			name:    "invalid ca key",
+			caCert:  caCert,
+			caKey:   []byte("invalid"),
+			req:     crypto.ClientCertificateRequest{User: "jane", TTL: time.Hour},
+			wantErr: "parsing CA key: no PEM data found",
+		},
+		{
+			name:    "outlives ca",
+			caCert:  caCert,
+			caKey:   caKey,
+			req:     crypto.ClientCertificateRequest{User: "jane", TTL: 2 * 365 * 24 * time.Hour},
+			wantErr: "after its CA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := crypto.SignClientCertificate(tt.caCert, tt.caKey, tt.req, now)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
@@ -26,10 +26,12 @@ import (
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	gitfactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig/issuer"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networking/kindnetd"
+	"github.com/aws/eks-anywhere/pkg/networking/none"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
@@ -76,6 +78,7 @@ type Dependencies struct {
 	ResourceSetManager        *clusterapi.ResourceSetManager
 	FileReader                *files.Reader
 	ManifestReader            *manifests.Reader
+	KubeconfigIssuer          *issuer.Issuer
 	closers                   []types.Closer
 	CliConfig                 *config.CliConfig
 	PackageInstaller          interfaces.PackageInstaller
@@ -617,7 +620,11 @@ func (f *Factory) WithHelmInsecure() *Factory {
 
 func (f *Factory) WithNetworking(clusterConfig *v1alpha1.Cluster) *Factory {
 	var networkingBuilder func() clustermanager.Networking
-	if clusterConfig.Spec.ClusterNetwork.CNIConfig.Kindnetd != nil {
+	if clusterConfig.Spec.ClusterNetwork.CNIConfig.IsUserManaged() {
+		networkingBuilder = func() clustermanager.Networking {
+			return none.NewNone()
+		}
+	} else if clusterConfig.Spec.ClusterNetwork.CNIConfig.Kindnetd != nil {
 		f.WithKubectl()
 		networkingBuilder = func() clustermanager.Networking {
 			return kindnetd.NewKindnetd(f.dependencies.Kubectl)
@@ -982,6 +989,21 @@ func (f *Factory) WithManifestReader() *Factory {
 	return f
 }
 
+func (f *Factory) WithKubeconfigIssuer() *Factory {
+	f.WithKubectl().WithWriter()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.dependencies.KubeconfigIssuer != nil {
+			return nil
+		}
+
+		f.dependencies.KubeconfigIssuer = issuer.New(f.dependencies.Kubectl, f.dependencies.Writer)
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) WithUnAuthKubeClient() *Factory {
 	f.WithKubectl()
 
@@ -216,3 +216,14 @@ func TestFactoryBuildWithCuratedPackagesDefaultRegistry(t *testing.T) {
 	tt.Expect(err).To(BeNil())
 	tt.Expect(deps.BundleRegistry).NotTo(BeNil())
 }
+
+func TestFactoryBuildWithKubeconfigIssuer(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		UseExecutableImage("image:1").
+		WithKubeconfigIssuer().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.KubeconfigIssuer).NotTo(BeNil())
+}
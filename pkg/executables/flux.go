@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"sigs.k8s.io/yaml"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
@@ -169,6 +171,35 @@ func (f *Flux) ResumeKustomization(ctx context.Context, cluster *types.Cluster,
 	return err
 }
 
+// KustomizationSuspended returns true if the reconciliation of the Kustomization is suspended.
+func (f *Flux) KustomizationSuspended(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) (bool, error) {
+	c := fluxConfig.Spec
+	if c.SystemNamespace == "" {
+		return false, fmt.Errorf("executing flux export kustomization: namespace empty")
+	}
+	params := []string{"export", "ks", c.SystemNamespace, "--namespace", c.SystemNamespace}
+
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+
+	stdOut, err := f.Execute(ctx, params...)
+	if err != nil {
+		return false, fmt.Errorf("executing flux export kustomization: %v", err)
+	}
+
+	kustomization := &struct {
+		Spec struct {
+			Suspend bool `json:"suspend"`
+		} `json:"spec"`
+	}{}
+	if err = yaml.Unmarshal(stdOut.Bytes(), kustomization); err != nil {
+		return false, fmt.Errorf("parsing flux export kustomization response: %v", err)
+	}
+
+	return kustomization.Spec.Suspend, nil
+}
+
 func (f *Flux) Reconcile(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
 	c := fluxConfig.Spec
 	params := []string{"reconcile", "source", "git"}
@@ -228,6 +228,49 @@ func TestFluxUninstallGitOpsToolkitsComponents(t *testing.T) {
 	}
 }
 
+func TestFluxKustomizationSuspended(t *testing.T) {
+	tests := []struct {
+		testName string
+		cluster  *types.Cluster
+		output   string
+		want     bool
+		wantArgs []interface{}
+	}{
+		{
+			testName: "suspended",
+			cluster:  &types.Cluster{KubeconfigFile: "f.kubeconfig"},
+			output:   "apiVersion: kustomize.toolkit.fluxcd.io/v1beta2\nkind: Kustomization\nspec:\n  suspend: true\n",
+			want:     true,
+			wantArgs: []interface{}{"export", "ks", "flux-system", "--namespace", "flux-system", "--kubeconfig", "f.kubeconfig"},
+		},
+		{
+			testName: "not suspended",
+			cluster:  &types.Cluster{},
+			output:   "apiVersion: kustomize.toolkit.fluxcd.io/v1beta2\nkind: Kustomization\nspec:\n  prune: true\n",
+			want:     false,
+			wantArgs: []interface{}{"export", "ks", "flux-system", "--namespace", "flux-system"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+			executable.EXPECT().Execute(ctx, tt.wantArgs...).Return(*bytes.NewBufferString(tt.output), nil)
+
+			f := executables.NewFlux(executable)
+			fluxConfig := &v1alpha1.FluxConfig{Spec: v1alpha1.FluxConfigSpec{SystemNamespace: "flux-system"}}
+			got, err := f.KustomizationSuspended(ctx, tt.cluster, fluxConfig)
+			if err != nil {
+				t.Fatalf("flux.KustomizationSuspended() error = %v, want nil", err)
+			}
+			if got != tt.want {
+				t.Errorf("flux.KustomizationSuspended() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFluxPauseKustomization(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
@@ -681,6 +681,16 @@ func (g *Govc) NetworkExists(ctx context.Context, network string) (bool, error)
 }
 
 func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, _ *bool) error {
+	return g.validateVCenterSetupMachineConfig(ctx, datacenterConfig, machineConfig, true)
+}
+
+// CheckVCenterSetupMachineConfig validates the datastore, folder and resource pool of a machine config
+// like ValidateVCenterSetupMachineConfig, but it fails when the folder doesn't exist instead of creating it.
+func (g *Govc) CheckVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig) error {
+	return g.validateVCenterSetupMachineConfig(ctx, datacenterConfig, machineConfig, false)
+}
+
+func (g *Govc) validateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, createFolder bool) error {
 	envMap, err := g.validateAndSetupCreds()
 	if err != nil {
 		return fmt.Errorf("failed govc validations: %v", err)
@@ -717,6 +727,9 @@ func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenter
 		params = []string{"folder.info", machineConfig.Spec.Folder}
 		err = g.Retry(func() error {
 			_, err := g.ExecuteWithEnv(ctx, envMap, params...)
+			if err != nil && !createFolder {
+				return fmt.Errorf("folder %s does not exist: %v", machineConfig.Spec.Folder, err)
+			}
 			if err != nil {
 				err = g.createFolder(ctx, envMap, machineConfig)
 				if err != nil {
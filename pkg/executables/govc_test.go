@@ -512,6 +512,48 @@ func TestGovcValidateVCenterSetupMachineConfig(t *testing.T) {
 	}
 }
 
+func TestGovcCheckVCenterSetupMachineConfigMissingFolder(t *testing.T) {
+	ctx := context.Background()
+	ts := newHTTPSServer(t)
+	datacenterConfig := v1alpha1.VSphereDatacenterConfig{
+		Spec: v1alpha1.VSphereDatacenterConfigSpec{
+			Datacenter: "SDDC Datacenter",
+			Network:    "/SDDC Datacenter/network/test network",
+			Server:     strings.TrimPrefix(ts.URL, "https://"),
+			Insecure:   true,
+		},
+	}
+	machineConfig := v1alpha1.VSphereMachineConfig{
+		Spec: v1alpha1.VSphereMachineConfigSpec{
+			Datastore:    "/SDDC Datacenter/datastore/testDatastore",
+			Folder:       "/SDDC Datacenter/vm/test",
+			ResourcePool: "*/Resources/Compute ResourcePool",
+		},
+	}
+	env := govcEnvironment
+	mockCtrl := gomock.NewController(t)
+	_, writer := test.NewWriter(t)
+
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+
+	params := []string{"datastore.info", machineConfig.Spec.Datastore}
+	executable.EXPECT().ExecuteWithEnv(ctx, env, params).Return(bytes.Buffer{}, nil)
+
+	params = []string{"folder.info", machineConfig.Spec.Folder}
+	executable.EXPECT().ExecuteWithEnv(ctx, env, params).Return(bytes.Buffer{}, errors.New("folder not found")).MinTimes(1)
+
+	g := executables.NewGovc(executable, writer)
+	g.Retrier = retrier.NewWithMaxRetries(1, 0)
+
+	if err := g.CheckVCenterSetupMachineConfig(ctx, &datacenterConfig, &machineConfig); err == nil {
+		t.Fatal("Govc.CheckVCenterSetupMachineConfig() error = nil, want error for missing folder")
+	}
+}
+
 func newHTTPSServer(t *testing.T) *httptest.Server {
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if _, err := w.Write([]byte("ready")); err != nil {
@@ -8,11 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -47,6 +49,7 @@ var (
 	eksaTinkerbellDatacenterResourceType = fmt.Sprintf("tinkerbelldatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaTinkerbellMachineResourceType    = fmt.Sprintf("tinkerbellmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	TinkerbellHardwareResourceType       = fmt.Sprintf("hardware.%s", tinkv1alpha1.GroupVersion.Group)
+	rufioBaseboardManagementResourceType = fmt.Sprintf("baseboardmanagements.%s", rufiov1alpha1.GroupVersion.Group)
 	eksaCloudStackDatacenterResourceType = fmt.Sprintf("cloudstackdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaCloudStackMachineResourceType    = fmt.Sprintf("cloudstackmachineconfigs.%s", v1alpha1.GroupVersion.Group)
 	eksaAwsResourceType                  = fmt.Sprintf("awsdatacenterconfigs.%s", v1alpha1.GroupVersion.Group)
@@ -257,6 +260,19 @@ func (k *Kubectl) DeleteKubeSpecFromBytes(ctx context.Context, cluster *types.Cl
 	return nil
 }
 
+// DeleteKubeSpecFromBytesIgnoreNotFound deletes the objects in data, skipping the ones that don't exist.
+func (k *Kubectl) DeleteKubeSpecFromBytesIgnoreNotFound(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	params := []string{"delete", "-f", "-", "--ignore-not-found"}
+	if cluster.KubeconfigFile != "" {
+		params = append(params, "--kubeconfig", cluster.KubeconfigFile)
+	}
+	_, err := k.ExecuteWithStdin(ctx, data, params...)
+	if err != nil {
+		return fmt.Errorf("executing delete: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) WaitForControlPlaneReady(ctx context.Context, cluster *types.Cluster, timeout string, newClusterName string) error {
 	return k.Wait(ctx, cluster.KubeconfigFile, timeout, "ControlPlaneReady", fmt.Sprintf("%s/%s", capiClustersResourceType, newClusterName), constants.EksaSystemNamespace)
 }
@@ -321,6 +337,16 @@ func (k *Kubectl) WaitForDeployment(ctx context.Context, cluster *types.Cluster,
 	return k.Wait(ctx, cluster.KubeconfigFile, timeout, condition, "deployments/"+target, namespace)
 }
 
+// WaitForNodesReady blocks until all the nodes in the cluster have the Ready condition.
+func (k *Kubectl) WaitForNodesReady(ctx context.Context, cluster *types.Cluster, timeout string) error {
+	_, err := k.Execute(ctx, "wait", "--timeout", timeout,
+		"--for=condition=Ready", "nodes", "--all", "--kubeconfig", cluster.KubeconfigFile)
+	if err != nil {
+		return fmt.Errorf("waiting for nodes to be ready: %v", err)
+	}
+	return nil
+}
+
 func (k *Kubectl) Wait(ctx context.Context, kubeconfig string, timeout string, forCondition string, property string, namespace string) error {
 	_, err := k.Execute(ctx, "wait", "--timeout", timeout,
 		"--for=condition="+forCondition, property, "--kubeconfig", kubeconfig, "-n", namespace)
@@ -411,6 +437,20 @@ func (k *Kubectl) DeleteCluster(ctx context.Context, managementCluster, clusterT
 	return nil
 }
 
+// SetCAPIClusterPaused sets spec.paused in the CAPI Cluster, which pauses or resumes the reconciliation
+// of the cluster and all its CAPI objects.
+func (k *Kubectl) SetCAPIClusterPaused(ctx context.Context, managementCluster *types.Cluster, clusterName string, paused bool) error {
+	params := []string{
+		"patch", capiClustersResourceType, clusterName, "--type=merge", fmt.Sprintf("-p={\"spec\":{\"paused\":%t}}", paused),
+		"--kubeconfig", managementCluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+	}
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("setting paused to %t in capi cluster %s: %v", paused, clusterName, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) ListCluster(ctx context.Context) error {
 	params := []string{"get", "pods", "-A", "-o", "jsonpath={..image}"}
 	output, err := k.Execute(ctx, params...)
@@ -760,6 +800,19 @@ func (k *Kubectl) RolloutRestartDaemonSet(ctx context.Context, dsName, dsNamespa
 	return nil
 }
 
+// RolloutRestartDeployment restarts the pods of deployment name in namespace.
+func (k *Kubectl) RolloutRestartDeployment(ctx context.Context, name, namespace, kubeconfig string) error {
+	params := []string{
+		"rollout", "restart", "deployment", name,
+		"--kubeconfig", kubeconfig, "--namespace", namespace,
+	}
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("restarting %s deployment in namespace %s: %v", name, namespace, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) SetEksaControllerEnvVar(ctx context.Context, envVar, envVarVal, kubeconfig string) error {
 	params := []string{
 		"set", "env", "deployment/eksa-controller-manager", fmt.Sprintf("%s=%s", envVar, envVarVal),
@@ -991,6 +1044,19 @@ func (k *Kubectl) GetMachineDeployments(ctx context.Context, opts ...KubectlOpt)
 	return response.Items, nil
 }
 
+func (k *Kubectl) ScaleMachineDeployment(ctx context.Context, machineDeploymentName string, replicas int, cluster *types.Cluster, namespace string) error {
+	params := []string{
+		"scale", fmt.Sprintf("machinedeployments.%s", clusterv1.GroupVersion.Group), machineDeploymentName,
+		"--replicas", strconv.Itoa(replicas),
+	}
+	applyOpts(&params, WithCluster(cluster), WithNamespace(namespace))
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("scaling machine deployment %s: %v", machineDeploymentName, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) UpdateEnvironmentVariables(ctx context.Context, resourceType, resourceName string, envMap map[string]string, opts ...KubectlOpt) error {
 	params := []string{"set", "env", resourceType, resourceName}
 	for k, v := range envMap {
@@ -1060,6 +1126,23 @@ func (k *Kubectl) GetEksaCluster(ctx context.Context, cluster *types.Cluster, cl
 	return response, nil
 }
 
+// GetEksaClusters returns all the EKS-A clusters in the cluster, in any namespace.
+func (k *Kubectl) GetEksaClusters(ctx context.Context, cluster *types.Cluster) ([]v1alpha1.Cluster, error) {
+	params := []string{"get", eksaClusterResourceType, "-A", "-o", "json", "--kubeconfig", cluster.KubeconfigFile}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting eksa clusters: %v", err)
+	}
+
+	response := &v1alpha1.ClusterList{}
+	err = json.Unmarshal(stdOut.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("parsing get eksa clusters response: %v", err)
+	}
+
+	return response.Items, nil
+}
+
 func (k *Kubectl) SearchVsphereMachineConfig(ctx context.Context, name string, kubeconfigFile string, namespace string) ([]*v1alpha1.VSphereMachineConfig, error) {
 	params := []string{
 		"get", eksaVSphereMachineResourceType, "-o", "json", "--kubeconfig",
@@ -1286,6 +1369,85 @@ func (k *Kubectl) GetUnprovisionedTinkerbellHardware(ctx context.Context, kubeco
 	return list.Items, nil
 }
 
+// GetAllTinkerbellHardware retrieves all Tinkerbell Hardware objects in namespace, provisioned or not.
+func (k *Kubectl) GetAllTinkerbellHardware(ctx context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error) {
+	params := []string{
+		"get", TinkerbellHardwareResourceType,
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", namespace,
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting tinkerbell hardware: %v", err)
+	}
+
+	var list tinkv1alpha1.HardwareList
+	if err := json.Unmarshal(stdOut.Bytes(), &list); err != nil {
+		return nil, fmt.Errorf("parsing get tinkerbell hardware response: %v", err)
+	}
+
+	return list.Items, nil
+}
+
+// GetAllBaseboardManagements retrieves all Rufio BaseboardManagement objects in namespace.
+func (k *Kubectl) GetAllBaseboardManagements(ctx context.Context, kubeconfig, namespace string) ([]rufiov1alpha1.BaseboardManagement, error) {
+	params := []string{
+		"get", rufioBaseboardManagementResourceType,
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", namespace,
+	}
+	stdOut, err := k.Execute(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting baseboard managements: %v", err)
+	}
+
+	var list rufiov1alpha1.BaseboardManagementList
+	if err := json.Unmarshal(stdOut.Bytes(), &list); err != nil {
+		return nil, fmt.Errorf("parsing get baseboard managements response: %v", err)
+	}
+
+	return list.Items, nil
+}
+
+func (k *Kubectl) DeleteTinkerbellHardware(ctx context.Context, cluster *types.Cluster, name, namespace string) error {
+	params := []string{"delete", TinkerbellHardwareResourceType, name, "--kubeconfig", cluster.KubeconfigFile, "--namespace", namespace}
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("deleting tinkerbell hardware %s in namespace %s: %v", name, namespace, err)
+	}
+	return nil
+}
+
+func (k *Kubectl) DeleteBaseboardManagement(ctx context.Context, cluster *types.Cluster, name, namespace string) error {
+	params := []string{"delete", rufioBaseboardManagementResourceType, name, "--kubeconfig", cluster.KubeconfigFile, "--namespace", namespace}
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("deleting baseboard management %s in namespace %s: %v", name, namespace, err)
+	}
+	return nil
+}
+
+func (k *Kubectl) UpdateTinkerbellHardwareLabels(ctx context.Context, cluster *types.Cluster, name, namespace string, labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := []string{"label", TinkerbellHardwareResourceType, name}
+	for _, key := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", key, labels[key]))
+	}
+	applyOpts(&params, WithOverwrite(), WithCluster(cluster), WithNamespace(namespace))
+	_, err := k.Execute(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("updating tinkerbell hardware %s labels: %v", name, err)
+	}
+	return nil
+}
+
 func (k *Kubectl) GetEksaVSphereMachineConfig(ctx context.Context, vsphereMachineConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.VSphereMachineConfig, error) {
 	params := []string{"get", eksaVSphereMachineResourceType, vsphereMachineConfigName, "-o", "json", "--kubeconfig", kubeconfigFile, "--namespace", namespace}
 	stdOut, err := k.Execute(ctx, params...)
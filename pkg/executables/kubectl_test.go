@@ -141,6 +141,17 @@ func TestKubectlDeleteSpecFromBytesError(t *testing.T) {
 	}
 }
 
+func TestKubectlDeleteKubeSpecFromBytesIgnoreNotFoundSuccess(t *testing.T) {
+	var data []byte
+
+	k, ctx, cluster, e := newKubectl(t)
+	expectedParam := []string{"delete", "-f", "-", "--ignore-not-found", "--kubeconfig", cluster.KubeconfigFile}
+	e.EXPECT().ExecuteWithStdin(ctx, data, gomock.Eq(expectedParam)).Return(bytes.Buffer{}, nil)
+	if err := k.DeleteKubeSpecFromBytesIgnoreNotFound(ctx, cluster, data); err != nil {
+		t.Errorf("Kubectl.DeleteKubeSpecFromBytesIgnoreNotFound() error = %v, want nil", err)
+	}
+}
+
 func TestKubectlApplyKubeSpecFromBytesWithNamespaceSuccess(t *testing.T) {
 	var data []byte
 	var namespace string
@@ -253,6 +264,26 @@ func TestKubectlWaitSuccess(t *testing.T) {
 	}
 }
 
+func TestKubectlWaitForNodesReadySuccess(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"wait", "--timeout", "60m", "--for=condition=Ready", "nodes", "--all", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.k.WaitForNodesReady(tt.ctx, tt.cluster, "60m")).To(Succeed())
+}
+
+func TestKubectlWaitForNodesReadyError(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(
+		tt.ctx,
+		"wait", "--timeout", "60m", "--for=condition=Ready", "nodes", "--all", "--kubeconfig", tt.cluster.KubeconfigFile,
+	).Return(bytes.Buffer{}, errors.New("timed out"))
+
+	tt.Expect(tt.k.WaitForNodesReady(tt.ctx, tt.cluster, "60m")).To(MatchError(ContainSubstring("waiting for nodes to be ready")))
+}
+
 func TestKubectlWaitForService(t *testing.T) {
 	testSvc := &corev1.Service{
 		Spec: corev1.ServiceSpec{
@@ -455,6 +486,45 @@ func TestKubectlDeleteClusterError(t *testing.T) {
 	}
 }
 
+func TestKubectlSetCAPIClusterPaused(t *testing.T) {
+	tests := []struct {
+		testName string
+		paused   bool
+		patch    string
+	}{
+		{
+			testName: "pause",
+			paused:   true,
+			patch:    "-p={\"spec\":{\"paused\":true}}",
+		},
+		{
+			testName: "resume",
+			paused:   false,
+			patch:    "-p={\"spec\":{\"paused\":false}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			k, ctx, cluster, e := newKubectl(t)
+			e.EXPECT().Execute(ctx,
+				"patch", capiClustersResourceType, "w01", "--type=merge", tt.patch,
+				"--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.EksaSystemNamespace,
+			).Return(bytes.Buffer{}, nil)
+
+			g.Expect(k.SetCAPIClusterPaused(ctx, cluster, "w01", tt.paused)).To(Succeed())
+		})
+	}
+}
+
+func TestKubectlSetCAPIClusterPausedError(t *testing.T) {
+	g := NewWithT(t)
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("error from execute"))
+
+	g.Expect(k.SetCAPIClusterPaused(ctx, cluster, "w01", true)).To(MatchError(ContainSubstring("setting paused to true in capi cluster w01")))
+}
+
 func TestKubectlGetMachines(t *testing.T) {
 	tests := []struct {
 		testName         string
@@ -1106,6 +1176,21 @@ func TestKubectlRolloutRestartDaemonSetSuccess(t *testing.T) {
 	}
 }
 
+func TestKubectlRolloutRestartDeploymentSuccess(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(
+		ctx,
+		[]string{
+			"rollout", "restart", "deployment", "capc-controller-manager",
+			"--kubeconfig", cluster.KubeconfigFile, "--namespace", constants.CapcSystemNamespace,
+		},
+	).Return(bytes.Buffer{}, nil)
+
+	if err := k.RolloutRestartDeployment(ctx, "capc-controller-manager", constants.CapcSystemNamespace, cluster.KubeconfigFile); err != nil {
+		t.Fatalf("Kubectl.RolloutRestartDeployment() error = %v, want nil", err)
+	}
+}
+
 func TestKubectlRolloutRestartDaemonSetError(t *testing.T) {
 	k, ctx, cluster, e := newKubectl(t)
 	e.EXPECT().Execute(
@@ -1678,6 +1763,20 @@ func TestKubectlUpdateAnnotation(t *testing.T) {
 	}
 }
 
+func TestKubectlScaleMachineDeployment(t *testing.T) {
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(ctx, []string{
+		"scale", "machinedeployments.cluster.x-k8s.io", "test-cluster-md-0",
+		"--replicas", "5",
+		"--kubeconfig", cluster.KubeconfigFile, "--namespace", "eksa-system",
+	})
+
+	err := k.ScaleMachineDeployment(ctx, "test-cluster-md-0", 5, cluster, "eksa-system")
+	if err != nil {
+		t.Fatalf("Kubectl.ScaleMachineDeployment() error = %v, want nil", err)
+	}
+}
+
 func TestKubectlRemoveAnnotation(t *testing.T) {
 	k, ctx, cluster, e := newKubectl(t)
 	e.EXPECT().Execute(ctx, []string{
@@ -2191,6 +2290,78 @@ func TestGetUnprovisionedTinkerbellHardware_ExecutableErrors(t *testing.T) {
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestGetAllTinkerbellHardware(t *testing.T) {
+	tt := newKubectlTest(t)
+	hardwareJSON := test.ReadFile(t, "testdata/kubectl_tinkerbellhardware.json")
+	kubeconfig := "foo/bar"
+
+	params := []string{
+		"get", executables.TinkerbellHardwareResourceType,
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", tt.namespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(*bytes.NewBufferString(hardwareJSON), nil)
+
+	hardware, err := tt.k.GetAllTinkerbellHardware(tt.ctx, kubeconfig, tt.namespace)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(hardware).To(HaveLen(2))
+	tt.Expect(hardware[0].Name).To(Equal("hw1"))
+}
+
+func TestGetAllBaseboardManagements(t *testing.T) {
+	tt := newKubectlTest(t)
+	bmcJSON := test.ReadFile(t, "testdata/kubectl_baseboardmanagements.json")
+	kubeconfig := "foo/bar"
+
+	params := []string{
+		"get", "baseboardmanagements.bmc.tinkerbell.org",
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", tt.namespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(*bytes.NewBufferString(bmcJSON), nil)
+
+	bmcs, err := tt.k.GetAllBaseboardManagements(tt.ctx, kubeconfig, tt.namespace)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(bmcs).To(HaveLen(1))
+	tt.Expect(bmcs[0].Spec.Connection.Host).To(Equal("10.10.10.10"))
+}
+
+func TestGetAllBaseboardManagementsExecutableError(t *testing.T) {
+	tt := newKubectlTest(t)
+	kubeconfig := "foo/bar"
+
+	params := []string{
+		"get", "baseboardmanagements.bmc.tinkerbell.org",
+		"--kubeconfig", kubeconfig,
+		"-o", "json",
+		"--namespace", tt.namespace,
+	}
+	tt.e.EXPECT().Execute(tt.ctx, gomock.Eq(params)).Return(bytes.Buffer{}, errors.New("error from execute"))
+
+	_, err := tt.k.GetAllBaseboardManagements(tt.ctx, kubeconfig, tt.namespace)
+	tt.Expect(err).To(MatchError(ContainSubstring("error from execute")))
+}
+
+func TestKubectlDeleteTinkerbellHardware(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "delete", executables.TinkerbellHardwareResourceType, "hw1", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace)
+	tt.Expect(tt.k.DeleteTinkerbellHardware(tt.ctx, tt.cluster, "hw1", tt.namespace)).To(Succeed())
+}
+
+func TestKubectlDeleteBaseboardManagement(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "delete", "baseboardmanagements.bmc.tinkerbell.org", "bmc-hw1", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace)
+	tt.Expect(tt.k.DeleteBaseboardManagement(tt.ctx, tt.cluster, "bmc-hw1", tt.namespace)).To(Succeed())
+}
+
+func TestKubectlUpdateTinkerbellHardwareLabels(t *testing.T) {
+	tt := newKubectlTest(t)
+	tt.e.EXPECT().Execute(tt.ctx, "label", executables.TinkerbellHardwareResourceType, "hw1", "type=cp", "--overwrite", "--kubeconfig", tt.cluster.KubeconfigFile, "--namespace", tt.namespace)
+	tt.Expect(tt.k.UpdateTinkerbellHardwareLabels(tt.ctx, tt.cluster, "hw1", tt.namespace, map[string]string{"type": "cp"})).To(Succeed())
+}
+
 func TestKubectlDelete(t *testing.T) {
 	tt := newKubectlTest(t)
 	name := "my-cluster"
@@ -2212,3 +2383,26 @@ func TestKubectlWaitForManagedExternalEtcdNotReady(t *testing.T) {
 
 	tt.Expect(tt.k.WaitForManagedExternalEtcdNotReady(tt.ctx, tt.cluster, "5m", "test")).To(Succeed())
 }
+
+func TestKubectlListEksaClusters(t *testing.T) {
+	g := NewWithT(t)
+	fileContent := test.ReadFile(t, "testdata/kubectl_eksa_clusters.json")
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(ctx, []string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "-o", "json", "--kubeconfig", cluster.KubeconfigFile}).Return(*bytes.NewBufferString(fileContent), nil)
+
+	clusters, err := k.GetEksaClusters(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(clusters).To(HaveLen(2))
+	g.Expect(clusters[0].Name).To(Equal("mgmt"))
+	g.Expect(clusters[1].Name).To(Equal("workload-1"))
+	g.Expect(clusters[1].ManagedBy()).To(Equal("mgmt"))
+}
+
+func TestKubectlListEksaClustersError(t *testing.T) {
+	g := NewWithT(t)
+	k, ctx, cluster, e := newKubectl(t)
+	e.EXPECT().Execute(ctx, []string{"get", "clusters.anywhere.eks.amazonaws.com", "-A", "-o", "json", "--kubeconfig", cluster.KubeconfigFile}).Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	_, err := k.GetEksaClusters(ctx, cluster)
+	g.Expect(err).To(MatchError(ContainSubstring("getting eksa clusters: connection refused")))
+}
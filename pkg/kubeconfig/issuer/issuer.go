@@ -0,0 +1,295 @@
+package issuer
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	// ClusterNameLabel is set on the records of issued certificates with the name of the cluster they grant access to.
+	ClusterNameLabel = "anywhere.eks.amazonaws.com/cluster-name"
+	// IssuedCertificateLabel identifies the ConfigMaps recording issued client certificates.
+	IssuedCertificateLabel = "anywhere.eks.amazonaws.com/issued-client-certificate"
+
+	apiVersionExecCredential = "client.authentication.k8s.io/v1beta1"
+
+	systemPrefix = "system:"
+	// maxCertificateTTL bounds client certificates since they can't be revoked before they expire.
+	maxCertificateTTL = 7 * 24 * time.Hour
+)
+
+var (
+	invalidFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+	// privilegedGroups are bound to cluster-admin by kubeadm.
+	privilegedGroups = map[string]struct{}{
+		"kubeadm:cluster-admins": {},
+	}
+)
+
+// KubectlClient retrieves and records cluster credentials.
+type KubectlClient interface {
+	GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error)
+	GetConfigMap(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.ConfigMap, error)
+	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
+	GetEksaOIDCConfig(ctx context.Context, oidcConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.OIDCConfig, error)
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+}
+
+// Issuer generates kubeconfigs for EKS Anywhere clusters that don't grant cluster-admin access.
+// Client certificates are never issued for the system: users and groups nor for the groups kubeadm
+// binds to cluster-admin, so their access only comes from the RBAC bindings of their user and groups.
+type Issuer struct {
+	kubectl KubectlClient
+	writer  filewriter.FileWriter
+	now     func() time.Time
+}
+
+// IssuerOpt configures an Issuer.
+type IssuerOpt func(*Issuer)
+
+// WithNow sets the clock used to compute certificate validity.
+func WithNow(now func() time.Time) IssuerOpt {
+	return func(i *Issuer) {
+		i.now = now
+	}
+}
+
+// New creates an Issuer that writes the kubeconfigs it generates with writer.
+func New(kubectl KubectlClient, writer filewriter.FileWriter, opts ...IssuerOpt) *Issuer {
+	i := &Issuer{
+		kubectl: kubectl,
+		writer:  writer,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// CertificateRequest describes a client certificate kubeconfig.
+type CertificateRequest struct {
+	User   string
+	Groups []string
+	TTL    time.Duration
+}
+
+// ValidateCertificateRequest checks req can be issued: it can't impersonate Kubernetes components
+// nor grant cluster-admin through its groups, and its TTL must be positive and at most maxCertificateTTL.
+func ValidateCertificateRequest(req CertificateRequest) error {
+	if req.User == "" {
+		return fmt.Errorf("user is required")
+	}
+	if strings.HasPrefix(req.User, systemPrefix) {
+		return fmt.Errorf("user %s is reserved for Kubernetes components", req.User)
+	}
+	for _, group := range req.Groups {
+		if strings.HasPrefix(group, systemPrefix) {
+			return fmt.Errorf("group %s is reserved for Kubernetes components", group)
+		}
+		if _, ok := privilegedGroups[group]; ok {
+			return fmt.Errorf("group %s grants cluster-admin access", group)
+		}
+	}
+	if req.TTL <= 0 {
+		return fmt.Errorf("ttl must be positive")
+	}
+	if req.TTL > maxCertificateTTL {
+		return fmt.Errorf("ttl must be at most %s", maxCertificateTTL)
+	}
+
+	return nil
+}
+
+// IssueCertificate signs a client certificate for req with the CA of clusterName that CAPI stores
+// in managementCluster and writes a kubeconfig using it. Each issued certificate is recorded in a
+// ConfigMap in managementCluster so it can be reviewed. It returns the path of the kubeconfig.
+func (i *Issuer) IssueCertificate(ctx context.Context, managementCluster *types.Cluster, clusterName string, req CertificateRequest) (string, error) {
+	if err := ValidateCertificateRequest(req); err != nil {
+		return "", err
+	}
+
+	server, caData, err := i.clusterEndpoint(ctx, managementCluster, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	caSecret, err := i.kubectl.GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, fmt.Sprintf("%s-ca", clusterName), constants.EksaSystemNamespace)
+	if err != nil {
+		return "", fmt.Errorf("retrieving cluster %s CA: %v", clusterName, err)
+	}
+
+	cert, err := crypto.SignClientCertificate(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey], crypto.ClientCertificateRequest{
+		User:   req.User,
+		Groups: req.Groups,
+		TTL:    req.TTL,
+	}, i.now())
+	if err != nil {
+		return "", err
+	}
+
+	if err := i.recordCertificate(ctx, managementCluster, clusterName, req, cert.Certificate); err != nil {
+		return "", err
+	}
+
+	content, err := kubeconfig.NewClientCertificateConfig(clusterName, server, caData, req.User, cert.CertPEM, cert.KeyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	return i.write(clusterName, req.User, content)
+}
+
+// IssueOIDC writes a kubeconfig for clusterName that gets user credentials from the OIDC provider
+// configured in the cluster, using the kubectl oidc-login plugin.
+func (i *Issuer) IssueOIDC(ctx context.Context, managementCluster *types.Cluster, clusterName, user string) (string, error) {
+	cluster, err := i.kubectl.GetEksaCluster(ctx, managementCluster, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	var oidcConfig *v1alpha1.OIDCConfig
+	for _, ref := range cluster.Spec.IdentityProviderRefs {
+		if ref.Kind == v1alpha1.OIDCConfigKind {
+			oidcConfig, err = i.kubectl.GetEksaOIDCConfig(ctx, ref.Name, managementCluster.KubeconfigFile, cluster.Namespace)
+			if err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+	if oidcConfig == nil {
+		return "", fmt.Errorf("cluster %s doesn't reference an OIDCConfig", clusterName)
+	}
+
+	server, caData, err := i.clusterEndpoint(ctx, managementCluster, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := kubeconfig.NewExecConfig(clusterName, server, caData, user, &clientcmdapi.ExecConfig{
+		APIVersion: apiVersionExecCredential,
+		Command:    "kubectl",
+		Args: []string{
+			"oidc-login",
+			"get-token",
+			"--oidc-issuer-url=" + oidcConfig.Spec.IssuerUrl,
+			"--oidc-client-id=" + oidcConfig.Spec.ClientId,
+		},
+		InstallHint:     "Install the kubectl oidc-login plugin from https://github.com/int128/kubelogin",
+		InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return i.write(clusterName, user, content)
+}
+
+// IssueAWSIam writes a kubeconfig for workloadCluster that gets user credentials from
+// aws-iam-authenticator. The cluster ID is read from the aws-iam-authenticator config running in
+// workloadCluster.
+func (i *Issuer) IssueAWSIam(ctx context.Context, managementCluster, workloadCluster *types.Cluster, user string) (string, error) {
+	cm, err := i.kubectl.GetConfigMap(ctx, workloadCluster.KubeconfigFile, awsiamauth.ConfigMapName, constants.KubeSystemNamespace)
+	if err != nil {
+		return "", fmt.Errorf("retrieving aws-iam-authenticator config: %v", err)
+	}
+	clusterID, err := awsiamauth.ParseClusterID(cm)
+	if err != nil {
+		return "", err
+	}
+
+	server, caData, err := i.clusterEndpoint(ctx, managementCluster, workloadCluster.Name)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := kubeconfig.NewExecConfig(workloadCluster.Name, server, caData, user, &clientcmdapi.ExecConfig{
+		APIVersion:      apiVersionExecCredential,
+		Command:         "aws-iam-authenticator",
+		Args:            []string{"token", "-i", clusterID.String()},
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return i.write(workloadCluster.Name, user, content)
+}
+
+// clusterEndpoint reads the API server url and CA of clusterName from the kubeconfig secret CAPI
+// stores in managementCluster.
+func (i *Issuer) clusterEndpoint(ctx context.Context, managementCluster *types.Cluster, clusterName string) (server string, caData []byte, err error) {
+	secret, err := i.kubectl.GetSecretFromNamespace(ctx, managementCluster.KubeconfigFile, fmt.Sprintf("%s-kubeconfig", clusterName), constants.EksaSystemNamespace)
+	if err != nil {
+		return "", nil, fmt.Errorf("retrieving cluster %s kubeconfig: %v", clusterName, err)
+	}
+
+	return kubeconfig.ClusterEndpoint(secret.Data["value"])
+}
+
+func (i *Issuer) recordCertificate(ctx context.Context, managementCluster *types.Cluster, clusterName string, req CertificateRequest, cert *x509.Certificate) error {
+	serial := cert.SerialNumber.Text(16)
+	record := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-client-cert-%s", clusterName, serial),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				ClusterNameLabel:       clusterName,
+				IssuedCertificateLabel: "true",
+			},
+		},
+		Data: map[string]string{
+			"user":         req.User,
+			"groups":       strings.Join(req.Groups, ","),
+			"serialNumber": serial,
+			"fingerprint":  fmt.Sprintf("%x", sha256.Sum256(cert.Raw)),
+			"notBefore":    cert.NotBefore.UTC().Format(time.RFC3339),
+			"notAfter":     cert.NotAfter.UTC().Format(time.RFC3339),
+		},
+	}
+
+	content, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshalling client certificate record: %v", err)
+	}
+	if err := i.kubectl.ApplyKubeSpecFromBytes(ctx, managementCluster, content); err != nil {
+		return fmt.Errorf("recording client certificate: %v", err)
+	}
+	logger.V(3).Info("Recorded client certificate", "configMap", record.Name, "user", req.User)
+
+	return nil
+}
+
+func (i *Issuer) write(clusterName, user string, content []byte) (string, error) {
+	fileName := fmt.Sprintf("%s-%s.kubeconfig", clusterName, invalidFileNameChars.ReplaceAllString(user, "-"))
+	writtenFile, err := i.writer.Write(fileName, content, filewriter.PersistentFile, filewriter.Permission0600)
+	if err != nil {
+		return "", fmt.Errorf("writing kubeconfig: %v", err)
+	}
+	return writtenFile, nil
+}
@@ -0,0 +1,318 @@
+package issuer_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig/issuer"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig/issuer/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type issuerTest struct {
+	*WithT
+	ctx               context.Context
+	kubectl           *mocks.MockKubectlClient
+	writer            *writermocks.MockFileWriter
+	issuer            *issuer.Issuer
+	managementCluster *types.Cluster
+	now               time.Time
+	caCert            []byte
+	caKey             []byte
+	workloadConfig    []byte
+}
+
+func newIssuerTest(t *testing.T) *issuerTest {
+	ctrl := gomock.NewController(t)
+	now := time.Now().Truncate(time.Second)
+	kubectl := mocks.NewMockKubectlClient(ctrl)
+	writer := writermocks.NewMockFileWriter(ctrl)
+	caCert, caKey := givenCA(t, now)
+	workloadConfig, err := kubeconfig.NewClientCertificateConfig("my-cluster", "https://1.2.3.4:6443", caCert, "admin", []byte("cert"), []byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &issuerTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		kubectl:           kubectl,
+		writer:            writer,
+		issuer:            issuer.New(kubectl, writer, issuer.WithNow(func() time.Time { return now })),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+		now:               now,
+		caCert:            caCert,
+		caKey:             caKey,
+		workloadConfig:    workloadConfig,
+	}
+}
+
+func givenCA(t *testing.T, now time.Time) (certPEM, keyPEM []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func (tt *issuerTest) expectWorkloadKubeconfigSecret() {
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, "mgmt.kubeconfig", "my-cluster-kubeconfig", "eksa-system").Return(
+		&corev1.Secret{Data: map[string][]byte{"value": tt.workloadConfig}}, nil,
+	)
+}
+
+func (tt *issuerTest) expectCASecret() {
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, "mgmt.kubeconfig", "my-cluster-ca", "eksa-system").Return(
+		&corev1.Secret{Data: map[string][]byte{"tls.crt": tt.caCert, "tls.key": tt.caKey}}, nil,
+	)
+}
+
+func TestIssueCertificate(t *testing.T) {
+	tt := newIssuerTest(t)
+	tt.expectWorkloadKubeconfigSecret()
+	tt.expectCASecret()
+
+	var record corev1.ConfigMap
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.managementCluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			return yaml.Unmarshal(data, &record)
+		},
+	)
+
+	var content []byte
+	tt.writer.EXPECT().Write("my-cluster-jane-example.com.kubeconfig", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(name string, data []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			content = data
+			return "my-cluster/" + name, nil
+		},
+	)
+
+	path, err := tt.issuer.IssueCertificate(tt.ctx, tt.managementCluster, "my-cluster", issuer.CertificateRequest{
+		User:   "jane@example.com",
+		Groups: []string{"dev", "ops"},
+		TTL:    8 * time.Hour,
+	})
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(path).To(Equal("my-cluster/my-cluster-jane-example.com.kubeconfig"))
+
+	config, err := clientcmd.Load(content)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.Clusters["my-cluster"].Server).To(Equal("https://1.2.3.4:6443"))
+	tt.Expect(config.Clusters["my-cluster"].CertificateAuthorityData).To(Equal(tt.caCert))
+	authInfo := config.AuthInfos["jane@example.com"]
+	block, _ := pem.Decode(authInfo.ClientCertificateData)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(cert.Subject.CommonName).To(Equal("jane@example.com"))
+	tt.Expect(cert.Subject.Organization).To(Equal([]string{"dev", "ops"}))
+	keyBlock, _ := pem.Decode(authInfo.ClientKeyData)
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(cert.PublicKey.(*rsa.PublicKey).Equal(&key.PublicKey)).To(BeTrue())
+
+	tt.Expect(record.Namespace).To(Equal("eksa-system"))
+	tt.Expect(record.Name).To(Equal("my-cluster-client-cert-" + cert.SerialNumber.Text(16)))
+	tt.Expect(record.Labels).To(Equal(map[string]string{
+		issuer.ClusterNameLabel:       "my-cluster",
+		issuer.IssuedCertificateLabel: "true",
+	}))
+	tt.Expect(record.Data).To(HaveKeyWithValue("user", "jane@example.com"))
+	tt.Expect(record.Data).To(HaveKeyWithValue("groups", "dev,ops"))
+	tt.Expect(record.Data).To(HaveKeyWithValue("notAfter", tt.now.Add(8*time.Hour).UTC().Format(time.RFC3339)))
+}
+
+func TestIssueCertificateInvalidRequest(t *testing.T) {
+	tt := newIssuerTest(t)
+
+	_, err := tt.issuer.IssueCertificate(tt.ctx, tt.managementCluster, "my-cluster", issuer.CertificateRequest{
+		User: "jane",
+		TTL:  30 * 24 * time.Hour,
+	})
+	tt.Expect(err).To(MatchError("ttl must be at most 168h0m0s"))
+}
+
+func TestIssueCertificateRecordError(t *testing.T) {
+	tt := newIssuerTest(t)
+	tt.expectWorkloadKubeconfigSecret()
+	tt.expectCASecret()
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.managementCluster, gomock.Any()).Return(errors.New("forbidden"))
+
+	_, err := tt.issuer.IssueCertificate(tt.ctx, tt.managementCluster, "my-cluster", issuer.CertificateRequest{
+		User: "jane",
+		TTL:  time.Hour,
+	})
+	tt.Expect(err).To(MatchError("recording client certificate: forbidden"))
+}
+
+func TestIssueCertificateCAError(t *testing.T) {
+	tt := newIssuerTest(t)
+	tt.expectWorkloadKubeconfigSecret()
+	tt.kubectl.EXPECT().GetSecretFromNamespace(tt.ctx, "mgmt.kubeconfig", "my-cluster-ca", "eksa-system").Return(nil, errors.New("not found"))
+
+	_, err := tt.issuer.IssueCertificate(tt.ctx, tt.managementCluster, "my-cluster", issuer.CertificateRequest{
+		User: "jane",
+		TTL:  time.Hour,
+	})
+	tt.Expect(err).To(MatchError("retrieving cluster my-cluster CA: not found"))
+}
+
+func TestValidateCertificateRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     issuer.CertificateRequest
+		wantErr string
+	}{
+		{
+			name: "valid",
+			req:  issuer.CertificateRequest{User: "jane", Groups: []string{"dev"}, TTL: time.Hour},
+		},
+		{
+			name:    "no user",
+			req:     issuer.CertificateRequest{TTL: time.Hour},
+			wantErr: "user is required",
+		},
+		{
+			name:    "system user",
+			req:     issuer.CertificateRequest{User: "system:kube-scheduler", TTL: time.Hour},
+			wantErr: "user system:kube-scheduler is reserved for Kubernetes components",
+		},
+		{
+			name:    "system masters",
+			req:     issuer.CertificateRequest{User: "jane", Groups: []string{"dev", "system:masters"}, TTL: time.Hour},
+			wantErr: "group system:masters is reserved for Kubernetes components",
+		},
+		{
+			name:    "kubeadm cluster admins",
+			req:     issuer.CertificateRequest{User: "jane", Groups: []string{"kubeadm:cluster-admins"}, TTL: time.Hour},
+			wantErr: "group kubeadm:cluster-admins grants cluster-admin access",
+		},
+		{
+			name:    "no ttl",
+			req:     issuer.CertificateRequest{User: "jane"},
+			wantErr: "ttl must be positive",
+		},
+		{
+			name:    "long ttl",
+			req:     issuer.CertificateRequest{User: "jane", TTL: 8 * 24 * time.Hour},
+			wantErr: "ttl must be at most 168h0m0s",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := issuer.ValidateCertificateRequest(tc.req)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(tc.wantErr))
+		})
+	}
+}
+
+func TestIssueOIDC(t *testing.T) {
+	tt := newIssuerTest(t)
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			IdentityProviderRefs: []v1alpha1.Ref{{Kind: v1alpha1.OIDCConfigKind, Name: "my-oidc"}},
+		},
+	}
+	oidc := &v1alpha1.OIDCConfig{
+		Spec: v1alpha1.OIDCConfigSpec{
+			IssuerUrl: "https://issuer.example.com",
+			ClientId:  "client-id",
+		},
+	}
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, "my-cluster").Return(cluster, nil)
+	tt.kubectl.EXPECT().GetEksaOIDCConfig(tt.ctx, "my-oidc", "mgmt.kubeconfig", "default").Return(oidc, nil)
+	tt.expectWorkloadKubeconfigSecret()
+
+	var content []byte
+	tt.writer.EXPECT().Write("my-cluster-jane.kubeconfig", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(name string, data []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			content = data
+			return name, nil
+		},
+	)
+
+	_, err := tt.issuer.IssueOIDC(tt.ctx, tt.managementCluster, "my-cluster", "jane")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(content)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.AuthInfos["jane"].Exec.Command).To(Equal("kubectl"))
+	tt.Expect(config.AuthInfos["jane"].Exec.Args).To(Equal([]string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=https://issuer.example.com",
+		"--oidc-client-id=client-id",
+	}))
+}
+
+func TestIssueOIDCNoOIDCConfig(t *testing.T) {
+	tt := newIssuerTest(t)
+	tt.kubectl.EXPECT().GetEksaCluster(tt.ctx, tt.managementCluster, "my-cluster").Return(&v1alpha1.Cluster{}, nil)
+
+	_, err := tt.issuer.IssueOIDC(tt.ctx, tt.managementCluster, "my-cluster", "jane")
+	tt.Expect(err).To(MatchError("cluster my-cluster doesn't reference an OIDCConfig"))
+}
+
+func TestIssueAWSIam(t *testing.T) {
+	tt := newIssuerTest(t)
+	workloadCluster := &types.Cluster{Name: "my-cluster", KubeconfigFile: "my-cluster.kubeconfig"}
+	tt.kubectl.EXPECT().GetConfigMap(tt.ctx, "my-cluster.kubeconfig", "aws-iam-authenticator", "kube-system").Return(
+		&corev1.ConfigMap{Data: map[string]string{"config.yaml": "clusterID: 36db102f-9e1e-4ca4-8300-271d30b14161"}}, nil,
+	)
+	tt.expectWorkloadKubeconfigSecret()
+
+	var content []byte
+	tt.writer.EXPECT().Write("my-cluster-jane.kubeconfig", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(name string, data []byte, _ ...filewriter.FileOptionsFunc) (string, error) {
+			content = data
+			return name, nil
+		},
+	)
+
+	_, err := tt.issuer.IssueAWSIam(tt.ctx, tt.managementCluster, workloadCluster, "jane")
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(content)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.AuthInfos["jane"].Exec.Command).To(Equal("aws-iam-authenticator"))
+	tt.Expect(config.AuthInfos["jane"].Exec.Args).To(Equal([]string{"token", "-i", "36db102f-9e1e-4ca4-8300-271d30b14161"}))
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/kubeconfig/issuer (interfaces: KubectlClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MockKubectlClient is a mock of KubectlClient interface.
+type MockKubectlClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubectlClientMockRecorder
+}
+
+// MockKubectlClientMockRecorder is the mock recorder for MockKubectlClient.
+type MockKubectlClientMockRecorder struct {
+	mock *MockKubectlClient
+}
+
+// NewMockKubectlClient creates a new mock instance.
+func NewMockKubectlClient(ctrl *gomock.Controller) *MockKubectlClient {
+	mock := &MockKubectlClient{ctrl: ctrl}
+	mock.recorder = &MockKubectlClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKubectlClient) EXPECT() *MockKubectlClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockKubectlClient) ApplyKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockKubectlClientMockRecorder) ApplyKubeSpecFromBytes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockKubectlClient)(nil).ApplyKubeSpecFromBytes), arg0, arg1, arg2)
+}
+
+// GetConfigMap mocks base method.
+func (m *MockKubectlClient) GetConfigMap(arg0 context.Context, arg1, arg2, arg3 string) (*v1.ConfigMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap.
+func (mr *MockKubectlClientMockRecorder) GetConfigMap(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockKubectlClient)(nil).GetConfigMap), arg0, arg1, arg2, arg3)
+}
+
+// GetEksaCluster mocks base method.
+func (m *MockKubectlClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEksaCluster", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*v1alpha1.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEksaCluster indicates an expected call of GetEksaCluster.
+func (mr *MockKubectlClientMockRecorder) GetEksaCluster(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaCluster", reflect.TypeOf((*MockKubectlClient)(nil).GetEksaCluster), arg0, arg1, arg2)
+}
+
+// GetEksaOIDCConfig mocks base method.
+func (m *MockKubectlClient) GetEksaOIDCConfig(arg0 context.Context, arg1, arg2, arg3 string) (*v1alpha1.OIDCConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEksaOIDCConfig", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha1.OIDCConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEksaOIDCConfig indicates an expected call of GetEksaOIDCConfig.
+func (mr *MockKubectlClientMockRecorder) GetEksaOIDCConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEksaOIDCConfig", reflect.TypeOf((*MockKubectlClient)(nil).GetEksaOIDCConfig), arg0, arg1, arg2, arg3)
+}
+
+// GetSecretFromNamespace mocks base method.
+func (m *MockKubectlClient) GetSecretFromNamespace(arg0 context.Context, arg1, arg2, arg3 string) (*v1.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretFromNamespace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretFromNamespace indicates an expected call of GetSecretFromNamespace.
+func (mr *MockKubectlClientMockRecorder) GetSecretFromNamespace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretFromNamespace", reflect.TypeOf((*MockKubectlClient)(nil).GetSecretFromNamespace), arg0, arg1, arg2, arg3)
+}
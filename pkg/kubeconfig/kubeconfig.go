@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 // FromClusterFormat defines the format of the kubeconfig of the
@@ -37,3 +40,66 @@ func NewMissingFileError(path string) error {
 func (m missingFileError) Error() string {
 	return fmt.Sprintf("kubeconfig file missing: path=%v", m.Path)
 }
+
+// ClusterEndpoint reads the server url and certificate authority data of the current cluster
+// in the kubeconfig content.
+func ClusterEndpoint(content []byte) (server string, caData []byte, err error) {
+	config, err := clientcmd.Load(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing kubeconfig: %v", err)
+	}
+
+	clusterName := ""
+	if c, ok := config.Contexts[config.CurrentContext]; ok {
+		clusterName = c.Cluster
+	}
+	cluster, ok := config.Clusters[clusterName]
+	if !ok {
+		for _, c := range config.Clusters {
+			cluster = c
+			break
+		}
+	}
+	if cluster == nil {
+		return "", nil, fmt.Errorf("kubeconfig doesn't contain any cluster")
+	}
+
+	return cluster.Server, cluster.CertificateAuthorityData, nil
+}
+
+// NewClientCertificateConfig builds a kubeconfig for the cluster at server that authenticates
+// as user with a client certificate.
+func NewClientCertificateConfig(clusterName, server string, caData []byte, user string, certData, keyData []byte) ([]byte, error) {
+	return write(clusterName, server, caData, user, &clientcmdapi.AuthInfo{
+		ClientCertificateData: certData,
+		ClientKeyData:         keyData,
+	})
+}
+
+// NewExecConfig builds a kubeconfig for the cluster at server that gets the credentials of user
+// from an exec credential plugin.
+func NewExecConfig(clusterName, server string, caData []byte, user string, exec *clientcmdapi.ExecConfig) ([]byte, error) {
+	return write(clusterName, server, caData, user, &clientcmdapi.AuthInfo{Exec: exec})
+}
+
+func write(clusterName, server string, caData []byte, user string, authInfo *clientcmdapi.AuthInfo) ([]byte, error) {
+	contextName := fmt.Sprintf("%s@%s", user, clusterName)
+	config := clientcmdapi.NewConfig()
+	config.Clusters[clusterName] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: caData,
+	}
+	config.AuthInfos[user] = authInfo
+	config.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:  clusterName,
+		AuthInfo: user,
+	}
+	config.CurrentContext = contextName
+
+	content, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, fmt.Errorf("writing kubeconfig: %v", err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,56 @@
+package kubeconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+func TestNewClientCertificateConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	content, err := kubeconfig.NewClientCertificateConfig("my-cluster", "https://1.2.3.4:6443", []byte("ca"), "jane", []byte("cert"), []byte("key"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.CurrentContext).To(Equal("jane@my-cluster"))
+	g.Expect(config.Contexts["jane@my-cluster"].Cluster).To(Equal("my-cluster"))
+	g.Expect(config.Contexts["jane@my-cluster"].AuthInfo).To(Equal("jane"))
+	g.Expect(config.AuthInfos["jane"].ClientCertificateData).To(Equal([]byte("cert")))
+	g.Expect(config.AuthInfos["jane"].ClientKeyData).To(Equal([]byte("key")))
+
+	server, caData, err := kubeconfig.ClusterEndpoint(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(server).To(Equal("https://1.2.3.4:6443"))
+	g.Expect(caData).To(Equal([]byte("ca")))
+}
+
+func TestNewExecConfig(t *testing.T) {
+	g := NewWithT(t)
+	exec := &clientcmdapi.ExecConfig{
+		APIVersion:      "client.authentication.k8s.io/v1beta1",
+		Command:         "aws-iam-authenticator",
+		Args:            []string{"token", "-i", "id"},
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	}
+
+	content, err := kubeconfig.NewExecConfig("my-cluster", "https://1.2.3.4:6443", []byte("ca"), "jane", exec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(content)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.AuthInfos["jane"].Exec.Command).To(Equal("aws-iam-authenticator"))
+	g.Expect(config.AuthInfos["jane"].Exec.Args).To(Equal([]string{"token", "-i", "id"}))
+}
+
+func TestClusterEndpointNoClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	_, _, err := kubeconfig.ClusterEndpoint([]byte("apiVersion: v1\nkind: Config\n"))
+	g.Expect(err).To(MatchError("kubeconfig doesn't contain any cluster"))
+}
@@ -0,0 +1,137 @@
+package kubevip
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config/kube-vip-services.yaml
+var kubeVipServicesTemplate string
+
+const (
+	globalPoolName = "global"
+	// ipPoolsConfigMapName is the config map with the IP pools of the kube-vip-cloud-provider.
+	ipPoolsConfigMapName = "kubevip"
+	// ManagedLabel marks the kube-vip services objects created by EKS-A.
+	// Only the objects with this label are deleted when the load balancer is removed from the cluster spec.
+	ManagedLabel = "anywhere.eks.amazonaws.com/kube-vip-services"
+)
+
+// GenerateServicesManifest returns the manifest to run kube-vip as the load balancer for services of type LoadBalancer.
+// It includes the kube-vip-cloud-provider, which assigns the service IPs from the pools in lbConfig.
+func GenerateServicesManifest(lbConfig *v1alpha1.LoadBalancerConfiguration, kubeVipImage, cloudProviderImage string) ([]byte, error) {
+	if cloudProviderImage == "" {
+		return nil, errors.New("generating kube-vip services manifest: bundle doesn't include the kube-vip-cloud-provider image")
+	}
+
+	return generateServicesManifest(kubeVipImage, cloudProviderImage, ipPoolsConfig(lbConfig))
+}
+
+func generateServicesManifest(kubeVipImage, cloudProviderImage string, ipPools map[string]string) ([]byte, error) {
+	data := map[string]interface{}{
+		"kubeVipImage":       kubeVipImage,
+		"cloudProviderImage": cloudProviderImage,
+		"ipPools":            ipPools,
+		"managedLabel":       ManagedLabel,
+	}
+
+	manifest, err := templater.Execute(kubeVipServicesTemplate, data)
+	if err != nil {
+		return nil, fmt.Errorf("generating kube-vip services manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// ReconcileServicesLoadBalancer makes the kube-vip services load balancer in the cluster c points to match lbConfig.
+// It server-side applies the manifest when lbConfig is set, and deletes a previous install when it's not.
+func ReconcileServicesLoadBalancer(ctx context.Context, c client.Client, lbConfig *v1alpha1.LoadBalancerConfiguration, kubeVipImage, cloudProviderImage string) error {
+	if lbConfig == nil {
+		return DeleteServicesLoadBalancer(ctx, c)
+	}
+
+	manifest, err := GenerateServicesManifest(lbConfig, kubeVipImage, cloudProviderImage)
+	if err != nil {
+		return err
+	}
+	if err := serverside.ReconcileYaml(ctx, c, manifest); err != nil {
+		return fmt.Errorf("applying kube-vip services manifest: %v", err)
+	}
+	return nil
+}
+
+// DeleteServicesLoadBalancer deletes the objects of the kube-vip services load balancer created by EKS-A
+// from the cluster c points to. Objects without the ManagedLabel, like a kube-vip installed by the user, are kept.
+// The IP pools config map is deleted last, so a failed deletion is retried on the next call.
+func DeleteServicesLoadBalancer(ctx context.Context, c client.Client) error {
+	ipPools := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{Name: ipPoolsConfigMapName, Namespace: constants.KubeSystemNamespace}, ipPools)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading kube-vip IP pools config map: %v", err)
+	}
+	if !managedByEKSA(ipPools) {
+		return nil
+	}
+
+	manifest, err := generateServicesManifest("", "", nil)
+	if err != nil {
+		return err
+	}
+	objs, err := clientutil.YamlToClientObjects(manifest)
+	if err != nil {
+		return fmt.Errorf("parsing kube-vip services manifest: %v", err)
+	}
+	for _, obj := range objs {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading kube-vip services %s %s: %v", kind, obj.GetName(), err)
+		}
+		if !managedByEKSA(obj) {
+			continue
+		}
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting kube-vip services %s %s: %v", kind, obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func managedByEKSA(obj client.Object) bool {
+	return obj.GetLabels()[ManagedLabel] == "true"
+}
+
+// ipPoolsConfig converts the IP pools to the kube-vip-cloud-provider config map format,
+// where keys are cidr-<namespace> or range-<namespace>.
+func ipPoolsConfig(lbConfig *v1alpha1.LoadBalancerConfiguration) map[string]string {
+	config := make(map[string]string, len(lbConfig.IPPools))
+	for _, pool := range lbConfig.IPPools {
+		namespace := pool.Namespace
+		if namespace == "" {
+			namespace = globalPoolName
+		}
+		if pool.CIDR != "" {
+			config["cidr-"+namespace] = pool.CIDR
+		} else {
+			config["range-"+namespace] = pool.Range
+		}
+	}
+	return config
+}
@@ -0,0 +1,106 @@
+package kubevip_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/kubevip"
+)
+
+func TestGenerateServicesManifest(t *testing.T) {
+	g := NewWithT(t)
+	lbConfig := &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{
+			{CIDR: "10.0.2.0/28"},
+			{Namespace: "apps", Range: "10.0.1.10-10.0.1.20"},
+		},
+	}
+
+	manifest, err := kubevip.GenerateServicesManifest(lbConfig, "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.4.2", "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip-cloud-provider:v0.0.2")
+	g.Expect(err).To(BeNil())
+	test.AssertContentToFile(t, string(manifest), "testdata/want-kube-vip-services.yaml")
+}
+
+func TestGenerateServicesManifestMissingCloudProviderImage(t *testing.T) {
+	g := NewWithT(t)
+	lbConfig := &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+
+	_, err := kubevip.GenerateServicesManifest(lbConfig, "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.4.2", "")
+	g.Expect(err).To(MatchError(ContainSubstring("bundle doesn't include the kube-vip-cloud-provider image")))
+}
+
+func TestReconcileServicesLoadBalancerMissingCloudProviderImage(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().Build()
+	lbConfig := &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+
+	err := kubevip.ReconcileServicesLoadBalancer(context.Background(), c, lbConfig, "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip:v0.4.2", "")
+	g.Expect(err).To(MatchError(ContainSubstring("bundle doesn't include the kube-vip-cloud-provider image")))
+}
+
+func TestReconcileServicesLoadBalancerDeletesWhenRemoved(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	managed := map[string]string{kubevip.ManagedLabel: "true"}
+	ipPools := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kubevip", Namespace: "kube-system", Labels: managed}}
+	daemonSet := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kube-vip-ds", Namespace: "kube-system", Labels: managed}}
+	cloudProvider := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "kube-vip-cloud-provider", Namespace: "kube-system", Labels: managed}}
+	c := fake.NewClientBuilder().WithObjects(ipPools, daemonSet, cloudProvider).Build()
+
+	g.Expect(kubevip.ReconcileServicesLoadBalancer(ctx, c, nil, "", "")).To(Succeed())
+
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(ipPools), &corev1.ConfigMap{}))).To(BeTrue())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(daemonSet), &appsv1.DaemonSet{}))).To(BeTrue())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(cloudProvider), &appsv1.Deployment{}))).To(BeTrue())
+}
+
+func TestReconcileServicesLoadBalancerNotInstalled(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	// kube-vip in the kube-system namespace without the IP pools config map isn't the services load balancer
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "kube-vip", Namespace: "kube-system"}}
+	c := fake.NewClientBuilder().WithObjects(serviceAccount).Build()
+
+	g.Expect(kubevip.ReconcileServicesLoadBalancer(ctx, c, nil, "", "")).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(serviceAccount), &corev1.ServiceAccount{})).To(Succeed())
+}
+
+func TestReconcileServicesLoadBalancerKeepsUserInstall(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ipPools := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kubevip", Namespace: "kube-system"}}
+	daemonSet := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kube-vip-ds", Namespace: "kube-system"}}
+	c := fake.NewClientBuilder().WithObjects(ipPools, daemonSet).Build()
+
+	g.Expect(kubevip.ReconcileServicesLoadBalancer(ctx, c, nil, "", "")).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(ipPools), &corev1.ConfigMap{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(daemonSet), &appsv1.DaemonSet{})).To(Succeed())
+}
+
+func TestReconcileServicesLoadBalancerKeepsUnlabeledObjects(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ipPools := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kubevip", Namespace: "kube-system", Labels: map[string]string{kubevip.ManagedLabel: "true"}}}
+	daemonSet := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kube-vip-ds", Namespace: "kube-system"}}
+	c := fake.NewClientBuilder().WithObjects(ipPools, daemonSet).Build()
+
+	g.Expect(kubevip.ReconcileServicesLoadBalancer(ctx, c, nil, "", "")).To(Succeed())
+
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(ipPools), &corev1.ConfigMap{}))).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(daemonSet), &appsv1.DaemonSet{})).To(Succeed())
+}
@@ -172,6 +172,17 @@ func templateValues(spec *cluster.Spec) values {
 	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode != "" {
 		val["policyEnforcementMode"] = spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.PolicyEnforcementMode
 	}
+
+	if spec.Cluster.Spec.ClusterNetwork.IsIPv6Enabled() {
+		val["ipv6"] = values{
+			"enabled": true,
+		}
+		if !spec.Cluster.Spec.ClusterNetwork.IsDualStack() {
+			val["ipv4"] = values{
+				"enabled": false,
+			}
+		}
+	}
 	return val
 }
 
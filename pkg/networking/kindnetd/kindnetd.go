@@ -70,7 +70,7 @@ func updatePodSubnet(clusterSpec *cluster.Spec, unstructured *unstructured.Unstr
 	}
 	for idx, env := range daemonSet.Spec.Template.Spec.Containers[0].Env {
 		if env.Name == "POD_SUBNET" {
-			daemonSet.Spec.Template.Spec.Containers[0].Env[idx].Value = clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks[0]
+			daemonSet.Spec.Template.Spec.Containers[0].Env[idx].Value = strings.Join(clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks, ",")
 		}
 	}
 	return yaml.Marshal(daemonSet)
@@ -0,0 +1,30 @@
+package none
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// None is the networking implementation for clusters where the CNI plugin is
+// installed and upgraded by the user. All its operations are no-ops.
+type None struct{}
+
+func NewNone() *None {
+	return &None{}
+}
+
+func (n *None) GenerateManifest(_ context.Context, _ *cluster.Spec, _ []string) ([]byte, error) {
+	return nil, nil
+}
+
+func (n *None) Upgrade(_ context.Context, _ *types.Cluster, _, _ *cluster.Spec, _ []string) (*types.ChangeDiff, error) {
+	logger.V(1).Info("CNI is user managed, skipping CNI upgrade")
+	return nil, nil
+}
+
+func (n *None) RunPostControlPlaneUpgradeSetup(_ context.Context, _ *types.Cluster) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package none_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/networking/none"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+func TestNoneGenerateManifest(t *testing.T) {
+	g := NewWithT(t)
+	n := none.NewNone()
+
+	manifest, err := n.GenerateManifest(context.Background(), test.NewClusterSpec(), []string{})
+	g.Expect(err).To(Succeed())
+	g.Expect(manifest).To(BeEmpty())
+}
+
+func TestNoneUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	n := none.NewNone()
+	clusterSpec := test.NewClusterSpec()
+
+	diff, err := n.Upgrade(context.Background(), &types.Cluster{}, clusterSpec, clusterSpec, []string{})
+	g.Expect(err).To(Succeed())
+	g.Expect(diff).To(BeNil())
+}
+
+func TestNoneRunPostControlPlaneUpgradeSetup(t *testing.T) {
+	g := NewWithT(t)
+	n := none.NewNone()
+
+	g.Expect(n.RunPostControlPlaneUpgradeSetup(context.Background(), &types.Cluster{})).To(Succeed())
+}
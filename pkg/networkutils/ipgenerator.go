@@ -40,7 +40,7 @@ func (ipgen IPGenerator) GenerateUniqueIP(cidrBlock string) (string, error) {
 // generates a random ip within the specified cidr block
 func (ipgen IPGenerator) randIp(cidr *net.IPNet) (net.IP, error) {
 	newIp := *new(net.IP)
-	for i := 0; i < 4; i++ {
+	for i := 0; i < len(cidr.Mask); i++ {
 		newIp = append(newIp, byte(ipgen.rand.Intn(255))&^cidr.Mask[i]|cidr.IP[i])
 	}
 	if !cidr.Contains(newIp) {
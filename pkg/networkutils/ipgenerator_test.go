@@ -28,3 +28,17 @@ func TestGenerateUniqueIP(t *testing.T) {
 		t.Fatalf("GenerateUniqueIP() ip = %v error: %v", ip, err)
 	}
 }
+
+func TestGenerateUniqueIPv6(t *testing.T) {
+	cidrBlock := "fd00:1::/64"
+	_, cidr, _ := net.ParseCIDR(cidrBlock)
+
+	ipgen := networkutils.NewIPGenerator(&DummyNetClient{})
+	ip, err := ipgen.GenerateUniqueIP(cidrBlock)
+	if err != nil {
+		t.Fatalf("GenerateUniqueIP() ip = %v error: %v", ip, err)
+	}
+	if !cidr.Contains(net.ParseIP(ip)) {
+		t.Fatalf("GenerateUniqueIP() ip = %v is not in %s", ip, cidrBlock)
+	}
+}
@@ -0,0 +1,60 @@
+package networkutils
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IPRange is an inclusive range of IP addresses.
+type IPRange struct {
+	Start net.IP
+	End   net.IP
+}
+
+// ParseIPRange parses a range of IPs in the form <start ip>-<end ip>.
+func ParseIPRange(ipRange string) (*IPRange, error) {
+	ips := strings.Split(ipRange, "-")
+	if len(ips) != 2 {
+		return nil, fmt.Errorf("invalid ip range %s, it must be in the form <start ip>-<end ip>", ipRange)
+	}
+	start := net.ParseIP(strings.TrimSpace(ips[0]))
+	end := net.ParseIP(strings.TrimSpace(ips[1]))
+	if start == nil || end == nil {
+		return nil, fmt.Errorf("invalid ip range %s, it must be in the form <start ip>-<end ip>", ipRange)
+	}
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return nil, fmt.Errorf("invalid ip range %s, start and end ips must be in the same IP family", ipRange)
+	}
+	r := &IPRange{Start: start.To16(), End: end.To16()}
+	if bytes.Compare(r.Start, r.End) > 0 {
+		return nil, fmt.Errorf("invalid ip range %s, start ip must not be greater than end ip", ipRange)
+	}
+	return r, nil
+}
+
+// ParseCIDRRange parses a CIDR block into the range of IPs it contains.
+func ParseCIDRRange(cidr string) (*IPRange, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	start := ipNet.IP
+	end := make(net.IP, len(start))
+	for i := range start {
+		end[i] = start[i] | ^ipNet.Mask[i]
+	}
+	return &IPRange{Start: start.To16(), End: end.To16()}, nil
+}
+
+// Contains returns true if ip is in the range.
+func (r *IPRange) Contains(ip net.IP) bool {
+	ip = ip.To16()
+	return ip != nil && bytes.Compare(r.Start, ip) <= 0 && bytes.Compare(ip, r.End) <= 0
+}
+
+// Overlaps returns true if both ranges have at least one IP in common.
+func (r *IPRange) Overlaps(o *IPRange) bool {
+	return bytes.Compare(r.Start, o.End) <= 0 && bytes.Compare(o.Start, r.End) <= 0
+}
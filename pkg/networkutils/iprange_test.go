@@ -0,0 +1,87 @@
+package networkutils_test
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+func TestParseIPRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		ipRange string
+		wantErr string
+	}{
+		{
+			name:    "valid ipv4 range",
+			ipRange: "10.0.0.10-10.0.0.20",
+		},
+		{
+			name:    "valid ipv6 range",
+			ipRange: "fd00::10-fd00::20",
+		},
+		{
+			name:    "missing end",
+			ipRange: "10.0.0.10",
+			wantErr: "it must be in the form <start ip>-<end ip>",
+		},
+		{
+			name:    "invalid ip",
+			ipRange: "10.0.0.10-10.0.0",
+			wantErr: "it must be in the form <start ip>-<end ip>",
+		},
+		{
+			name:    "mixed ip families",
+			ipRange: "10.0.0.10-fd00::20",
+			wantErr: "start and end ips must be in the same IP family",
+		},
+		{
+			name:    "start greater than end",
+			ipRange: "10.0.0.20-10.0.0.10",
+			wantErr: "start ip must not be greater than end ip",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := networkutils.ParseIPRange(tt.ipRange)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestParseCIDRRange(t *testing.T) {
+	g := NewWithT(t)
+	r, err := networkutils.ParseCIDRRange("192.168.0.0/16")
+	g.Expect(err).To(BeNil())
+	g.Expect(r.Start.Equal(net.ParseIP("192.168.0.0"))).To(BeTrue())
+	g.Expect(r.End.Equal(net.ParseIP("192.168.255.255"))).To(BeTrue())
+}
+
+func TestIPRangeContains(t *testing.T) {
+	g := NewWithT(t)
+	r, err := networkutils.ParseIPRange("10.0.0.10-10.0.0.20")
+	g.Expect(err).To(BeNil())
+	g.Expect(r.Contains(net.ParseIP("10.0.0.15"))).To(BeTrue())
+	g.Expect(r.Contains(net.ParseIP("10.0.0.21"))).To(BeFalse())
+	g.Expect(r.Contains(net.ParseIP("fd00::15"))).To(BeFalse())
+}
+
+func TestIPRangeOverlaps(t *testing.T) {
+	g := NewWithT(t)
+	r, err := networkutils.ParseIPRange("10.0.0.10-10.0.0.20")
+	g.Expect(err).To(BeNil())
+	cidr, err := networkutils.ParseCIDRRange("10.0.0.16/28")
+	g.Expect(err).To(BeNil())
+	g.Expect(r.Overlaps(cidr)).To(BeTrue())
+	other, err := networkutils.ParseCIDRRange("10.0.1.0/24")
+	g.Expect(err).To(BeNil())
+	g.Expect(r.Overlaps(other)).To(BeFalse())
+}
@@ -24,6 +24,18 @@ func ValidateIP(ip string) error {
 	return nil
 }
 
+// IsIPv6 returns true if ip is a valid IPv6 address.
+func IsIPv6(ip string) bool {
+	parsedIp := net.ParseIP(ip)
+	return parsedIp != nil && parsedIp.To4() == nil
+}
+
+// IsIPv6CIDR returns true if cidr is a valid IPv6 CIDR block.
+func IsIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
 // IsIPInUse performs a soft check to see if there are any services listening on a selection of common ports at
 // ip by trying to establish a TCP connection. Ports checked include: 22, 23, 80, 443 and 6443 (Kubernetes API Server).
 // Each connection attempt allows up-to 500ms for a response.
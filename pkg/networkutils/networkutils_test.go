@@ -35,6 +35,41 @@ func TestIsPortValidExpectInvalid(t *testing.T) {
 	}
 }
 
+func TestIsIPv6(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "1.2.3.4", want: false},
+		{ip: "fd00::1", want: true},
+		{ip: "::ffff:1.2.3.4", want: false},
+		{ip: "invalid", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(networkutils.IsIPv6(tt.ip)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestIsIPv6CIDR(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{cidr: "192.168.0.0/16", want: false},
+		{cidr: "fd00::/48", want: true},
+		{cidr: "fd00::1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(networkutils.IsIPv6CIDR(tt.cidr)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func TestIsIPInUsePass(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	g := gomega.NewWithT(t)
@@ -1,15 +1,19 @@
 package cloudstack
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
 	"strconv"
+	"text/template"
 
+	"github.com/Masterminds/sprig"
 	etcdv1beta1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -25,6 +29,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
@@ -47,6 +52,20 @@ var defaultClusterConfigMD string
 //go:embed config/machine-health-check-template.yaml
 var mhcTemplate []byte
 
+//go:embed config/secret.yaml
+var defaultSecretObject string
+
+//go:embed config/capc-secret.yaml
+var capcSecretObject string
+
+const (
+	// capcCredentialsSecretName is the Secret CAPC reads its cloud-config from, created when CAPC
+	// is installed.
+	capcCredentialsSecretName = "capc-manager-bootstrap-credentials"
+	capcCloudConfigKey        = "cloud-config"
+	capcControllerManagerName = "capc-controller-manager"
+)
+
 var requiredEnvs = []string{decoder.CloudStackCloudConfigB64SecretKey}
 
 var (
@@ -64,6 +83,7 @@ type cloudstackProvider struct {
 	templateBuilder       *CloudStackTemplateBuilder
 	skipIpCheck           bool
 	validator             *Validator
+	netClient             networkutils.NetClient
 }
 
 func (p *cloudstackProvider) PreBootstrapSetup(ctx context.Context, cluster *types.Cluster) error {
@@ -87,6 +107,120 @@ func (p *cloudstackProvider) PostWorkloadInit(ctx context.Context, cluster *type
 }
 
 func (p *cloudstackProvider) UpdateSecrets(ctx context.Context, cluster *types.Cluster) error {
+	var contents bytes.Buffer
+	if err := p.createSecret(ctx, cluster, &contents); err != nil {
+		return err
+	}
+
+	if err := p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, contents.Bytes()); err != nil {
+		return fmt.Errorf("loading secrets object: %v", err)
+	}
+
+	// CAPC on a management cluster reconciles all its workload clusters with the credentials it was
+	// installed with, so only a self-managed cluster rotates them.
+	if p.clusterConfig.IsManaged() {
+		return nil
+	}
+	return p.rotateCAPCCredentials(ctx, cluster)
+}
+
+// rotateCAPCCredentials updates the credentials CAPC was installed with if they differ from the
+// ones in the environment. CAPC only reads them on start so its controller is restarted.
+func (p *cloudstackProvider) rotateCAPCCredentials(ctx context.Context, cluster *types.Cluster) error {
+	encoded := os.Getenv(decoder.EksacloudStackCloudConfigB64SecretKey)
+	cloudConfig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("decoding value for %s with base64: %v", decoder.EksacloudStackCloudConfigB64SecretKey, err)
+	}
+
+	secret, err := p.providerKubectlClient.GetSecretFromNamespace(ctx, cluster.KubeconfigFile, capcCredentialsSecretName, constants.CapcSystemNamespace)
+	if err != nil {
+		return fmt.Errorf("getting capc credentials: %v", err)
+	}
+	if bytes.Equal(secret.Data[capcCloudConfigKey], cloudConfig) {
+		return nil
+	}
+
+	logger.V(3).Info("Rotating CAPC credentials")
+	t, err := template.New("tmpl").Parse(capcSecretObject)
+	if err != nil {
+		return fmt.Errorf("creating capc secret object template: %v", err)
+	}
+	var contents bytes.Buffer
+	values := map[string]string{
+		"name":        capcCredentialsSecretName,
+		"namespace":   constants.CapcSystemNamespace,
+		"cloudConfig": encoded,
+	}
+	if err := t.Execute(&contents, values); err != nil {
+		return fmt.Errorf("substituting values for capc secret object template: %v", err)
+	}
+	if err := p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, contents.Bytes()); err != nil {
+		return fmt.Errorf("updating capc credentials: %v", err)
+	}
+
+	if err := p.providerKubectlClient.RolloutRestartDeployment(ctx, capcControllerManagerName, constants.CapcSystemNamespace, cluster.KubeconfigFile); err != nil {
+		return fmt.Errorf("restarting capc after rotating its credentials: %v", err)
+	}
+	return nil
+}
+
+// createSecret renders a credentials Secret for every profile referenced by the availability zones
+// of the datacenter config. Credentials are read from the environment so applying the Secrets
+// rotates the credentials stored on the cluster.
+func (p *cloudstackProvider) createSecret(ctx context.Context, cluster *types.Cluster, contents *bytes.Buffer) error {
+	if err := p.providerKubectlClient.GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace); err != nil {
+		if err := p.providerKubectlClient.CreateNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace); err != nil {
+			return err
+		}
+	}
+
+	execConfig, err := decoder.ParseCloudStackSecret()
+	if err != nil {
+		return fmt.Errorf("failed to parse environment variable exec config: %v", err)
+	}
+	execProfiles := make(map[string]decoder.CloudStackProfileConfig, len(execConfig.Profiles))
+	for _, profile := range execConfig.Profiles {
+		execProfiles[profile.Name] = profile
+	}
+
+	availabilityZones, err := generateLocalAvailabilityZones(ctx, p.datacenterConfig)
+	if err != nil {
+		return err
+	}
+
+	profiles := []map[string]string{}
+	added := map[string]bool{}
+	for _, az := range availabilityZones {
+		if added[az.CredentialsRef] {
+			continue
+		}
+		profile, ok := execProfiles[az.CredentialsRef]
+		if !ok {
+			return fmt.Errorf("availability zone %s credentialsRef %s not found in %s", az.Name, az.CredentialsRef, decoder.EksacloudStackCloudConfigB64SecretKey)
+		}
+		profiles = append(profiles, map[string]string{
+			"secretName": decoder.CredentialsSecretName(p.clusterConfig.Name, profile.Name),
+			"apiKey":     profile.ApiKey,
+			"secretKey":  profile.SecretKey,
+			"apiUrl":     profile.ManagementUrl,
+			"verifySsl":  profile.VerifySsl,
+		})
+		added[az.CredentialsRef] = true
+	}
+
+	t, err := template.New("tmpl").Funcs(sprig.TxtFuncMap()).Parse(defaultSecretObject)
+	if err != nil {
+		return fmt.Errorf("creating secret object template: %v", err)
+	}
+
+	values := map[string]interface{}{
+		"eksaSystemNamespace": constants.EksaSystemNamespace,
+		"profiles":            profiles,
+	}
+	if err := t.Execute(contents, values); err != nil {
+		return fmt.Errorf("substituting values for secret object template: %v", err)
+	}
 	return nil
 }
 
@@ -185,6 +319,9 @@ func (p *cloudstackProvider) RunPostControlPlaneUpgrade(ctx context.Context, old
 type ProviderKubectlClient interface {
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	GetNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	GetSecretFromNamespace(ctx context.Context, kubeconfigFile, name, namespace string) (*corev1.Secret, error)
+	RolloutRestartDeployment(ctx context.Context, name, namespace, kubeconfig string) error
 	LoadSecret(ctx context.Context, secretObject string, secretObjType string, secretObjectName string, kubeConfFile string) error
 	GetEksaCluster(ctx context.Context, cluster *types.Cluster, clusterName string) (*v1alpha1.Cluster, error)
 	GetEksaCloudStackDatacenterConfig(ctx context.Context, cloudstackDatacenterConfigName string, kubeconfigFile string, namespace string) (*v1alpha1.CloudStackDatacenterConfig, error)
@@ -202,6 +339,20 @@ type ProviderKubectlClient interface {
 }
 
 func NewProvider(datacenterConfig *v1alpha1.CloudStackDatacenterConfig, machineConfigs map[string]*v1alpha1.CloudStackMachineConfig, clusterConfig *v1alpha1.Cluster, providerKubectlClient ProviderKubectlClient, providerCmkClient ProviderCmkClient, writer filewriter.FileWriter, now types.NowFunc, skipIpCheck bool) *cloudstackProvider {
+	return NewProviderCustomNet(
+		datacenterConfig,
+		machineConfigs,
+		clusterConfig,
+		providerKubectlClient,
+		providerCmkClient,
+		writer,
+		&networkutils.DefaultNetClient{},
+		now,
+		skipIpCheck,
+	)
+}
+
+func NewProviderCustomNet(datacenterConfig *v1alpha1.CloudStackDatacenterConfig, machineConfigs map[string]*v1alpha1.CloudStackMachineConfig, clusterConfig *v1alpha1.Cluster, providerKubectlClient ProviderKubectlClient, providerCmkClient ProviderCmkClient, writer filewriter.FileWriter, netClient networkutils.NetClient, now types.NowFunc, skipIpCheck bool) *cloudstackProvider {
 	var controlPlaneMachineSpec, etcdMachineSpec *v1alpha1.CloudStackMachineConfigSpec
 	workerNodeGroupMachineSpecs := make(map[string]v1alpha1.CloudStackMachineConfigSpec, len(machineConfigs))
 	if clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && machineConfigs[clusterConfig.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
@@ -228,6 +379,7 @@ func NewProvider(datacenterConfig *v1alpha1.CloudStackDatacenterConfig, machineC
 		},
 		skipIpCheck: skipIpCheck,
 		validator:   NewValidator(providerCmkClient),
+		netClient:   netClient,
 	}
 }
 
@@ -374,6 +526,9 @@ func (p *cloudstackProvider) validateClusterSpec(ctx context.Context, clusterSpe
 	if err := p.validator.ValidateCloudStackDatacenterConfig(ctx, p.datacenterConfig); err != nil {
 		return err
 	}
+	if err := p.validator.ValidateCredentialsSecretNames(ctx, clusterSpec.Cluster.Name, p.datacenterConfig); err != nil {
+		return err
+	}
 	if err := p.validator.ValidateClusterMachineConfigs(ctx, NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig)); err != nil {
 		return err
 	}
@@ -416,6 +571,16 @@ func (p *cloudstackProvider) SetupAndValidateCreateCluster(ctx context.Context,
 		return nil
 	}
 
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.ExternalLoadBalancer {
+		host, _, err := net.SplitHostPort(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host)
+		if err != nil {
+			return fmt.Errorf("parsing control plane endpoint: %v", err)
+		}
+		if !networkutils.IsIPInUse(p.netClient, host) {
+			return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is not reachable, the external load balancer must be serving it before creating the cluster", host)
+		}
+	}
+
 	return nil
 }
 
@@ -635,7 +800,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterConfigSpec v1alpha1
 		"externalProvisionerImage":                     bundle.KubeDistro.ExternalProvisioner.VersionedImage(),
 		"managerImage":                                 bundle.CloudStack.ClusterAPIController.VersionedImage(),
 		"kubeVipImage":                                 bundle.CloudStack.KubeVip.VersionedImage(),
-		"cloudstackKubeVip":                            !features.IsActive(features.CloudStackKubeVipDisabled()),
+		"cloudstackKubeVip":                            !features.IsActive(features.CloudStackKubeVipDisabled()) && clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.KubeVipLoadBalancer,
 		"cloudstackDomain":                             datacenterConfigSpec.Domain,
 		"cloudstackZones":                              datacenterConfigSpec.Zones,
 		"cloudstackAccount":                            datacenterConfigSpec.Account,
@@ -690,6 +855,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterConfigSpec v1alpha1
 	values["cloudstackControlPlaneAnnotations"] = values["cloudstackControlPlaneDiskOfferingProvided"].(bool) || len(controlPlaneMachineSpec.Symlinks) > 0
 	values["cloudstackEtcdAnnotations"] = values["cloudstackEtcdDiskOfferingProvided"].(bool) || len(etcdMachineSpec.Symlinks) > 0
 
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.HAProxyStaticPodLoadBalancer {
+		values["haproxyStaticPod"] = true
+		values["haproxyImage"] = bundle.Haproxy.Image.VersionedImage()
+		values["haproxyConfig"] = common.GetHAProxyConfig(port)
+		values["apiServerBindPort"] = common.HAProxyAPIServerBindPort
+	}
+
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		values["registryMirrorConfiguration"] = clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
@@ -3,6 +3,8 @@ package cloudstack
 import (
 	"context"
 	_ "embed"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -13,6 +15,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
+	networkutilsmocks "github.com/aws/eks-anywhere/pkg/networkutils/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -422,6 +426,27 @@ func TestProviderGenerateCAPISpecForCreateWithMirrorAndCertConfig(t *testing.T)
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_mirror_config_with_cert_md.yaml")
 }
 
+func TestBuildTemplateMapCPHAProxyStaticPodLoadBalancer(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec := givenEmptyClusterSpec()
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint = &v1alpha1.Endpoint{
+		Host:         "1.2.3.4:6443",
+		LoadBalancer: v1alpha1.HAProxyStaticPodLoadBalancer,
+	}
+	clusterSpec.VersionsBundle.Haproxy.Image.URI = "public.ecr.aws/l0g8r8j6/haproxy:v0.0.1"
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	controlPlaneMachineSpec := machineConfigs["test-cp"].Spec
+
+	values := buildTemplateMapCP(clusterSpec, datacenterConfig.Spec, controlPlaneMachineSpec, v1alpha1.CloudStackMachineConfigSpec{})
+
+	g.Expect(values["haproxyStaticPod"]).To(BeTrue())
+	g.Expect(values["haproxyImage"]).To(Equal("public.ecr.aws/l0g8r8j6/haproxy:v0.0.1"))
+	g.Expect(values["haproxyConfig"]).To(ContainSubstring("bind :::6443 v4v6"))
+	g.Expect(values["apiServerBindPort"]).To(Equal("6444"))
+}
+
 func TestProviderGenerateCAPISpecForCreateWithProxyConfig(t *testing.T) {
 	clusterSpecManifest := "cluster_minimal_proxy.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -522,6 +547,34 @@ func TestSetupAndValidateCreateCluster(t *testing.T) {
 	}
 }
 
+func TestSetupAndValidateCreateClusterExternalLoadBalancerNotReachable(t *testing.T) {
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancer = v1alpha1.ExternalLoadBalancer
+	var tctx testContext
+	tctx.SaveContext()
+
+	netClient := networkutilsmocks.NewMockNetClient(mockCtrl)
+	netClient.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to connect")).AnyTimes()
+	_, writer := test.NewWriter(t)
+	provider := NewProviderCustomNet(
+		givenDatacenterConfig(t, testClusterConfigMainFilename),
+		givenMachineConfigs(t, testClusterConfigMainFilename),
+		clusterSpec.Cluster,
+		nil,
+		givenWildcardCmk(mockCtrl),
+		writer,
+		netClient,
+		test.FakeNow,
+		false,
+	)
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host <1.2.3.4> is not reachable, the external load balancer must be serving it before creating the cluster", err)
+}
+
 func TestSetupAndValidateCreateWorkloadClusterSuccess(t *testing.T) {
 	ctx := context.Background()
 	provider := givenProvider(t)
@@ -1665,3 +1718,150 @@ func TestInstallCustomProviderComponentsKubeVipEnabled(t *testing.T) {
 		t.Fatalf("unexpected failure %v", err)
 	}
 }
+
+func givenUpdateSecretsProvider(t *testing.T, kubectl ProviderKubectlClient, iniFile string) *cloudstackProvider {
+	iniConfig, err := os.ReadFile(path.Join(testDataDir, iniFile))
+	if err != nil {
+		t.Fatalf("unable to read cloudstack config: %v", err)
+	}
+	t.Setenv(decoder.EksacloudStackCloudConfigB64SecretKey, base64.StdEncoding.EncodeToString(iniConfig))
+
+	datacenterConfig := givenDatacenterConfig(t, "cluster_main_with_availability_zones.yaml")
+	clusterConfig := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	return newProvider(t, datacenterConfig, nil, clusterConfig, kubectl, nil)
+}
+
+func TestUpdateSecretsAvailabilityZones(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_availability_zones.ini")
+
+	var secrets []byte
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *types.Cluster, data []byte) error {
+			secrets = data
+			return nil
+		},
+	)
+	kubectl.EXPECT().GetSecretFromNamespace(ctx, cluster.KubeconfigFile, "capc-manager-bootstrap-credentials", constants.CapcSystemNamespace).
+		Return(givenCAPCCredentialsSecret(t, "cloudstack_config_availability_zones.ini"), nil)
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(Succeed())
+	test.AssertContentToFile(t, string(secrets), "testdata/expected_results_availability_zones_secrets.yaml")
+}
+
+func TestUpdateSecretsCreatesNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_availability_zones.ini")
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(errors.New("not found"))
+	kubectl.EXPECT().CreateNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(nil)
+	kubectl.EXPECT().GetSecretFromNamespace(ctx, cluster.KubeconfigFile, "capc-manager-bootstrap-credentials", constants.CapcSystemNamespace).
+		Return(givenCAPCCredentialsSecret(t, "cloudstack_config_availability_zones.ini"), nil)
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(Succeed())
+}
+
+func TestUpdateSecretsRotatesCAPCCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_availability_zones.ini")
+
+	var capcSecret []byte
+	gomock.InOrder(
+		kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil),
+		kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(nil),
+		kubectl.EXPECT().GetSecretFromNamespace(ctx, cluster.KubeconfigFile, "capc-manager-bootstrap-credentials", constants.CapcSystemNamespace).
+			Return(givenCAPCCredentialsSecret(t, "cloudstack_config_valid.ini"), nil),
+		kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *types.Cluster, data []byte) error {
+				capcSecret = data
+				return nil
+			},
+		),
+		kubectl.EXPECT().RolloutRestartDeployment(ctx, "capc-controller-manager", constants.CapcSystemNamespace, cluster.KubeconfigFile).Return(nil),
+	)
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(Succeed())
+	g.Expect(string(capcSecret)).To(ContainSubstring("name: capc-manager-bootstrap-credentials"))
+	g.Expect(string(capcSecret)).To(ContainSubstring("cloud-config: " + os.Getenv(decoder.EksacloudStackCloudConfigB64SecretKey)))
+}
+
+func TestUpdateSecretsManagedClusterSkipsCAPCCredentials(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "management", KubeconfigFile: "management.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_availability_zones.ini")
+	provider.clusterConfig.SetManagedBy("management")
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(nil)
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(Succeed())
+}
+
+func TestUpdateSecretsGetCAPCCredentialsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_availability_zones.ini")
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(nil)
+	kubectl.EXPECT().GetSecretFromNamespace(ctx, cluster.KubeconfigFile, "capc-manager-bootstrap-credentials", constants.CapcSystemNamespace).
+		Return(nil, errors.New("not found"))
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(MatchError("getting capc credentials: not found"))
+}
+
+// givenCAPCCredentialsSecret returns the CAPC credentials Secret holding the contents of iniFile.
+func givenCAPCCredentialsSecret(t *testing.T, iniFile string) *v1.Secret {
+	iniConfig, err := os.ReadFile(path.Join(testDataDir, iniFile))
+	if err != nil {
+		t.Fatalf("unable to read cloudstack config: %v", err)
+	}
+	return &v1.Secret{Data: map[string][]byte{"cloud-config": iniConfig}}
+}
+
+func TestUpdateSecretsMissingProfile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_valid.ini")
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil)
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(MatchError(ContainSubstring("credentialsRef zone2 not found")))
+}
+
+func TestUpdateSecretsApplyError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	provider := givenUpdateSecretsProvider(t, kubectl, "cloudstack_config_availability_zones.ini")
+
+	kubectl.EXPECT().GetNamespace(ctx, cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any()).Return(errors.New("apply failed"))
+
+	g.Expect(provider.UpdateSecrets(ctx, cluster)).To(MatchError(ContainSubstring("loading secrets object")))
+}
@@ -0,0 +1,78 @@
+package cloudstack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+// newCloudStackAPIServer starts a server that only accepts requests signed with apiKey and secretKey.
+func newCloudStackAPIServer(t *testing.T, apiKey, secretKey string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.RawQuery
+		i := strings.Index(query, "&signature=")
+		if i < 0 || r.URL.Query().Get("apiKey") != apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mac := hmac.New(sha1.New, []byte(secretKey))
+		mac.Write([]byte(strings.ToLower(query[:i])))
+		if r.URL.Query().Get("signature") != base64.StdEncoding.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"listcapabilitiesresponse":{}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAPIAuthenticatorAuthenticate(t *testing.T) {
+	g := NewWithT(t)
+	server := newCloudStackAPIServer(t, "test-key", "test-secret")
+
+	err := NewAPIAuthenticator().Authenticate(context.Background(), decoder.CloudStackProfileConfig{
+		Name:          "Global",
+		ApiKey:        "test-key",
+		SecretKey:     "test-secret",
+		ManagementUrl: server.URL + "/client/api",
+		VerifySsl:     "true",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestAPIAuthenticatorAuthenticateInvalidCredentials(t *testing.T) {
+	g := NewWithT(t)
+	server := newCloudStackAPIServer(t, "test-key", "test-secret")
+
+	err := NewAPIAuthenticator().Authenticate(context.Background(), decoder.CloudStackProfileConfig{
+		Name:          "Global",
+		ApiKey:        "test-key",
+		SecretKey:     "wrong-secret",
+		ManagementUrl: server.URL + "/client/api",
+		VerifySsl:     "true",
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("authenticating profile Global")))
+	g.Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+}
+
+func TestAPIAuthenticatorAuthenticateInvalidVerifySsl(t *testing.T) {
+	g := NewWithT(t)
+
+	err := NewAPIAuthenticator().Authenticate(context.Background(), decoder.CloudStackProfileConfig{
+		Name:      "Global",
+		VerifySsl: "maybe",
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("'verify-ssl' has invalid boolean string maybe")))
+}
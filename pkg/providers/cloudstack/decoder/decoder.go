@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"gopkg.in/ini.v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -15,6 +17,12 @@ const (
 	EksaCloudStackHostPathToMount         = "EKSA_CLOUDSTACK_HOST_PATHS_TO_MOUNT"
 	CloudStackGlobalAZ                    = "Global"
 	defaultVerifySslValue                 = "true"
+
+	// Keys of the credentials in a profile, used both in the ini blob and in the credentials Secrets.
+	APIKeyKey    = "api-key"
+	SecretKeyKey = "secret-key"
+	APIUrlKey    = "api-url"
+	VerifySslKey = "verify-ssl"
 )
 
 // ParseCloudStackSecret parses the input b64 string into the ini object to extract out the api key, secret key, and url
@@ -39,20 +47,20 @@ func ParseCloudStackSecret() (*CloudStackExecConfig, error) {
 			continue
 		}
 
-		apiKey, err := section.GetKey("api-key")
+		apiKey, err := section.GetKey(APIKeyKey)
 		if err != nil {
 			return nil, fmt.Errorf("extracting value of 'api-key' from %s: %v", section.Name(), err)
 		}
-		secretKey, err := section.GetKey("secret-key")
+		secretKey, err := section.GetKey(SecretKeyKey)
 		if err != nil {
 			return nil, fmt.Errorf("extracting value of 'secret-key' from %s: %v", EksacloudStackCloudConfigB64SecretKey, err)
 		}
-		apiUrl, err := section.GetKey("api-url")
+		apiUrl, err := section.GetKey(APIUrlKey)
 		if err != nil {
 			return nil, fmt.Errorf("extracting value of 'api-url' from %s: %v", EksacloudStackCloudConfigB64SecretKey, err)
 		}
 		verifySslValue := defaultVerifySslValue
-		if verifySsl, err := section.GetKey("verify-ssl"); err == nil {
+		if verifySsl, err := section.GetKey(VerifySslKey); err == nil {
 			verifySslValue = verifySsl.Value()
 			if _, err := strconv.ParseBool(verifySslValue); err != nil {
 				return nil, fmt.Errorf("'verify-ssl' has invalid boolean string %s: %v", verifySslValue, err)
@@ -76,6 +84,42 @@ func ParseCloudStackSecret() (*CloudStackExecConfig, error) {
 	}, nil
 }
 
+// CredentialsSecretName returns the name of the Secret in the eksa-system namespace holding the
+// credentials of the profile an availability zone's credentialsRef points to. Names are prefixed with
+// the cluster name, since clusters managed by the same cluster can use different credentials under
+// the same credentialsRef, and lowercased, so the Global profile of cluster test is stored in the
+// "test-global" Secret.
+func CredentialsSecretName(clusterName, credentialsRef string) string {
+	return fmt.Sprintf("%s-%s", clusterName, strings.ToLower(credentialsRef))
+}
+
+// ParseCloudStackProfileFromSecret extracts the api key, secret key, and url of profile name from
+// a credentials Secret.
+func ParseCloudStackProfileFromSecret(name string, secret *corev1.Secret) (*CloudStackProfileConfig, error) {
+	profile := &CloudStackProfileConfig{Name: name}
+	for key, value := range map[string]*string{
+		APIKeyKey:    &profile.ApiKey,
+		SecretKeyKey: &profile.SecretKey,
+		APIUrlKey:    &profile.ManagementUrl,
+	} {
+		data, ok := secret.Data[key]
+		if !ok || len(data) == 0 {
+			return nil, fmt.Errorf("extracting value of '%s' from secret %s: key is not set or is empty", key, secret.Name)
+		}
+		*value = string(data)
+	}
+
+	profile.VerifySsl = defaultVerifySslValue
+	if verifySsl, ok := secret.Data[VerifySslKey]; ok {
+		profile.VerifySsl = string(verifySsl)
+		if _, err := strconv.ParseBool(profile.VerifySsl); err != nil {
+			return nil, fmt.Errorf("'verify-ssl' has invalid boolean string %s: %v", profile.VerifySsl, err)
+		}
+	}
+
+	return profile, nil
+}
+
 type CloudStackExecConfig struct {
 	Profiles []CloudStackProfileConfig
 }
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
@@ -164,3 +166,85 @@ func TestCloudStackConfigDecoderNoEnvVariable(t *testing.T) {
 	g.Expect(err).NotTo(BeNil())
 	tctx.restoreContext()
 }
+
+func TestCredentialsSecretName(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(decoder.CredentialsSecretName("test", decoder.CloudStackGlobalAZ)).To(Equal("test-global"))
+	g.Expect(decoder.CredentialsSecretName("test", "zone-2")).To(Equal("test-zone-2"))
+}
+
+func TestParseCloudStackProfileFromSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        map[string][]byte
+		wantProfile *decoder.CloudStackProfileConfig
+		wantErr     string
+	}{
+		{
+			name: "valid secret",
+			data: map[string][]byte{
+				"api-key":    []byte("test-key1"),
+				"secret-key": []byte("test-secret1"),
+				"api-url":    []byte("http://127.16.0.1:8080/client/api"),
+				"verify-ssl": []byte("false"),
+			},
+			wantProfile: &decoder.CloudStackProfileConfig{
+				Name:          "zone1",
+				ApiKey:        "test-key1",
+				SecretKey:     "test-secret1",
+				ManagementUrl: "http://127.16.0.1:8080/client/api",
+				VerifySsl:     "false",
+			},
+		},
+		{
+			name: "default verify-ssl",
+			data: map[string][]byte{
+				"api-key":    []byte("test-key1"),
+				"secret-key": []byte("test-secret1"),
+				"api-url":    []byte("http://127.16.0.1:8080/client/api"),
+			},
+			wantProfile: &decoder.CloudStackProfileConfig{
+				Name:          "zone1",
+				ApiKey:        "test-key1",
+				SecretKey:     "test-secret1",
+				ManagementUrl: "http://127.16.0.1:8080/client/api",
+				VerifySsl:     "true",
+			},
+		},
+		{
+			name: "missing api-url",
+			data: map[string][]byte{
+				"api-key":    []byte("test-key1"),
+				"secret-key": []byte("test-secret1"),
+			},
+			wantErr: "extracting value of 'api-url' from secret zone1",
+		},
+		{
+			name: "invalid verify-ssl",
+			data: map[string][]byte{
+				"api-key":    []byte("test-key1"),
+				"secret-key": []byte("test-secret1"),
+				"api-url":    []byte("http://127.16.0.1:8080/client/api"),
+				"verify-ssl": []byte("maybe"),
+			},
+			wantErr: "'verify-ssl' has invalid boolean string maybe",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "zone1"},
+				Data:       tc.data,
+			}
+
+			profile, err := decoder.ParseCloudStackProfileFromSecret("zone1", secret)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(profile).To(Equal(tc.wantProfile))
+		})
+	}
+}
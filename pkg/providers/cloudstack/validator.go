@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
+
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
@@ -73,6 +75,30 @@ func (v *Validator) validateCloudStackAccess(ctx context.Context, datacenterConf
 	return nil
 }
 
+// ValidateCredentialsSecretNames checks the credentials of every availability zone can be stored in
+// the Secret named after the cluster and the credentialsRef. Names are lowercased, so different
+// credentialsRefs can't map to the same Secret.
+func (v *Validator) ValidateCredentialsSecretNames(ctx context.Context, clusterName string, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error {
+	localAvailabilityZones, err := generateLocalAvailabilityZones(ctx, datacenterConfig)
+	if err != nil {
+		return err
+	}
+
+	credentialsRefsBySecret := map[string]string{}
+	for _, az := range localAvailabilityZones {
+		secretName := decoder.CredentialsSecretName(clusterName, az.CredentialsRef)
+		if errs := validation.IsDNS1123Subdomain(secretName); len(errs) > 0 {
+			return fmt.Errorf("availability zone %s credentialsRef %s can't be stored in secret %s: %s", az.Name, az.CredentialsRef, secretName, strings.Join(errs, ", "))
+		}
+		if ref, ok := credentialsRefsBySecret[secretName]; ok && ref != az.CredentialsRef {
+			return fmt.Errorf("credentialsRefs %s and %s would both be stored in secret %s, they must differ by more than case", ref, az.CredentialsRef, secretName)
+		}
+		credentialsRefsBySecret[secretName] = az.CredentialsRef
+	}
+
+	return nil
+}
+
 func (v *Validator) ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error {
 	localAvailabilityZones, err := generateLocalAvailabilityZones(ctx, datacenterConfig)
 	if err != nil {
@@ -143,6 +169,7 @@ func generateLocalAvailabilityZones(ctx context.Context, datacenterConfig *anywh
 		}
 	}
 	for _, az := range datacenterConfig.Spec.AvailabilityZones {
+		az := az
 		availabilityZone := localAvailabilityZone{
 			CloudStackAvailabilityZone: &az,
 		}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"errors"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -82,6 +83,46 @@ func TestValidateCloudStackDatacenterConfigWithAZ(t *testing.T) {
 	}
 }
 
+func TestValidateCredentialsSecretNamesInvalidCredentialsRef(t *testing.T) {
+	ctx := context.Background()
+	setupContext()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	validator := NewValidator(cmk)
+
+	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainWithAZsFilename))
+	if err != nil {
+		t.Fatalf("unable to get datacenter config from file")
+	}
+	datacenterConfig.Spec.AvailabilityZones[0].CredentialsRef = "zone_2"
+
+	err = validator.ValidateCredentialsSecretNames(ctx, "test", datacenterConfig)
+	if err == nil || !strings.Contains(err.Error(), "credentialsRef zone_2 can't be stored in secret test-zone_2") {
+		t.Fatalf("expected invalid credentialsRef error, got: %v", err)
+	}
+}
+
+func TestValidateCredentialsSecretNamesCollidingCredentialsRefs(t *testing.T) {
+	ctx := context.Background()
+	setupContext()
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	validator := NewValidator(cmk)
+
+	datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(path.Join(testDataDir, testClusterConfigMainWithAZsFilename))
+	if err != nil {
+		t.Fatalf("unable to get datacenter config from file")
+	}
+	az := datacenterConfig.Spec.AvailabilityZones[0]
+	az.Name = "zone-2"
+	az.CredentialsRef = "zone2"
+	datacenterConfig.Spec.AvailabilityZones[0].CredentialsRef = "Zone2"
+	datacenterConfig.Spec.AvailabilityZones = append(datacenterConfig.Spec.AvailabilityZones, az)
+
+	err = validator.ValidateCredentialsSecretNames(ctx, "test", datacenterConfig)
+	if err == nil || !strings.Contains(err.Error(), "credentialsRefs Zone2 and zone2 would both be stored in secret test-zone2") {
+		t.Fatalf("expected colliding credentialsRefs error, got: %v", err)
+	}
+}
+
 func TestValidateCloudStackConnection(t *testing.T) {
 	ctx := context.Background()
 	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
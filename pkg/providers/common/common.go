@@ -19,17 +19,29 @@ import (
 //go:embed config/audit-policy.yaml
 var auditPolicy string
 
+//go:embed config/haproxy.cfg
+var haproxyConfig string
+
 // TODO: Split out common into separate packages to avoid becoming a dumping ground
 
 const (
 	privateKeyFileName = "eks-a-id_rsa"
 	publicKeyFileName  = "eks-a-id_rsa.pub"
+	// HAProxyAPIServerBindPort is the port the kube-apiserver binds to in the control plane nodes
+	// when HAProxy runs as a static pod in front of it.
+	HAProxyAPIServerBindPort = "6444"
 )
 
 func GetAuditPolicy() string {
 	return auditPolicy
 }
 
+// GetHAProxyConfig returns the HAProxy configuration for the control plane static pod, which
+// listens on frontendPort and forwards to the kube-apiserver of the node.
+func GetHAProxyConfig(frontendPort string) string {
+	return fmt.Sprintf(haproxyConfig, frontendPort, HAProxyAPIServerBindPort)
+}
+
 func BootstrapClusterOpts(serverEndpoint string, clusterConfig *v1alpha1.Cluster) ([]bootstrapper.BootstrapClusterOption, error) {
 	env := map[string]string{}
 	if clusterConfig.Spec.ProxyConfiguration != nil {
@@ -45,6 +45,23 @@ type Provider interface {
 	PostClusterDeleteValidate(ctx context.Context, managementCluster *types.Cluster) error
 }
 
+// PreflightCheck is a single provider validation that doesn't make changes to the infrastructure.
+// It only runs after all the checks it depends on, identified by name, have passed.
+type PreflightCheck struct {
+	Name      string
+	DependsOn []string
+	Run       func(ctx context.Context) error
+}
+
+// PreflightChecker is implemented by providers that can validate a cluster spec as a set of
+// independent checks without changing the infrastructure, as opposed to the SetupAndValidate methods,
+// which can create the resources the cluster needs. The checks set the same defaults in the cluster spec
+// and only depend on checks returned before them.
+type PreflightChecker interface {
+	CreatePreflightChecks(clusterSpec *cluster.Spec) []PreflightCheck
+	UpgradePreflightChecks(cluster *types.Cluster, clusterSpec *cluster.Spec) []PreflightCheck
+}
+
 type DatacenterConfig interface {
 	Kind() string
 	PauseReconcile()
@@ -17,6 +17,7 @@ limitations under the License.
 package snow
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
@@ -148,6 +149,10 @@ type AWSSnowMachineSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	Devices []string `json:"devices,omitempty"`
 
+	// Network is the network configuration of the direct network interfaces (DNI) of the machine.
+	// +optional
+	Network *AWSSnowNetwork `json:"network,omitempty"`
+
 	// SpotMarketOptions allows users to configure instances to be run using AWS Spot instances.
 	// TODO: Evaluate the need or remove completely.
 	// +optional
@@ -285,6 +290,37 @@ func (r *AWSSnowMachine) IsControlPlane() bool {
 	return keyExists
 }
 
+// AWSSnowNetwork includes the direct network interfaces (DNI) configuration.
+type AWSSnowNetwork struct {
+	// DirectNetworkInterfaces is a list of DNI configurations.
+	// +kubebuilder:validation:MaxItems=8
+	DirectNetworkInterfaces []AWSSnowDirectNetworkInterface `json:"directNetworkInterfaces,omitempty"`
+}
+
+// AWSSnowDirectNetworkInterface defines the configuration of a direct network interface (DNI).
+type AWSSnowDirectNetworkInterface struct {
+	// Index is the index number of DNI, usually starts from 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8
+	Index int `json:"index,omitempty"`
+
+	// VlanID is the vlan id assigned by the user for the DNI.
+	// +optional
+	VlanID *int32 `json:"vlanID,omitempty"`
+
+	// DHCP defines whether DHCP is used to assign ip for the DNI.
+	// +optional
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// IPPool is a reference to the AWSSnowIPPool the ip of the DNI is allocated from.
+	// +optional
+	IPPool *corev1.ObjectReference `json:"ipPool,omitempty"`
+
+	// Primary indicates whether the DNI is the primary one of the machine.
+	// +optional
+	Primary bool `json:"primary,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&AWSSnowMachine{}, &AWSSnowMachineList{})
 }
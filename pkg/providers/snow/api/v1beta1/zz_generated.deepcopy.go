@@ -19,6 +19,7 @@
 package snow
 
 import (
+	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
@@ -170,6 +171,127 @@ func (in *AWSSnowClusterStatus) DeepCopy() *AWSSnowClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSnowDirectNetworkInterface) DeepCopyInto(out *AWSSnowDirectNetworkInterface) {
+	*out = *in
+	if in.VlanID != nil {
+		in, out := &in.VlanID, &out.VlanID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IPPool != nil {
+		in, out := &in.IPPool, &out.IPPool
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowDirectNetworkInterface.
+func (in *AWSSnowDirectNetworkInterface) DeepCopy() *AWSSnowDirectNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSnowDirectNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSnowIPPool) DeepCopyInto(out *AWSSnowIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowIPPool.
+func (in *AWSSnowIPPool) DeepCopy() *AWSSnowIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSnowIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSSnowIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSnowIPPoolList) DeepCopyInto(out *AWSSnowIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AWSSnowIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowIPPoolList.
+func (in *AWSSnowIPPoolList) DeepCopy() *AWSSnowIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSnowIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AWSSnowIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSnowIPPoolSpec) DeepCopyInto(out *AWSSnowIPPoolSpec) {
+	*out = *in
+	if in.IPPools != nil {
+		in, out := &in.IPPools, &out.IPPools
+		*out = make([]IPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowIPPoolSpec.
+func (in *AWSSnowIPPoolSpec) DeepCopy() *AWSSnowIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSnowIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSnowIPPoolStatus) DeepCopyInto(out *AWSSnowIPPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowIPPoolStatus.
+func (in *AWSSnowIPPoolStatus) DeepCopy() *AWSSnowIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSnowIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSSnowMachine) DeepCopyInto(out *AWSSnowMachine) {
 	*out = *in
@@ -307,6 +429,11 @@ func (in *AWSSnowMachineSpec) DeepCopyInto(out *AWSSnowMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(AWSSnowNetwork)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowMachineSpec.
@@ -451,6 +578,28 @@ func (in *AWSSnowMachineTemplateSpec) DeepCopy() *AWSSnowMachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSnowNetwork) DeepCopyInto(out *AWSSnowNetwork) {
+	*out = *in
+	if in.DirectNetworkInterfaces != nil {
+		in, out := &in.DirectNetworkInterfaces, &out.DirectNetworkInterfaces
+		*out = make([]AWSSnowDirectNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSnowNetwork.
+func (in *AWSSnowNetwork) DeepCopy() *AWSSnowNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSnowNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
@@ -518,6 +667,41 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPool) DeepCopyInto(out *IPPool) {
+	*out = *in
+	if in.IPStart != nil {
+		in, out := &in.IPStart, &out.IPStart
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPEnd != nil {
+		in, out := &in.IPEnd, &out.IPEnd
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
+func (in *IPPool) DeepCopy() *IPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -2,7 +2,9 @@ package snow
 
 import (
 	"fmt"
+	"sort"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -18,6 +20,7 @@ import (
 const (
 	SnowClusterKind         = "AWSSnowCluster"
 	SnowMachineTemplateKind = "AWSSnowMachineTemplate"
+	SnowIPPoolKind          = "AWSSnowIPPool"
 )
 
 func CAPICluster(clusterSpec *cluster.Spec, snowCluster *snowv1.AWSSnowCluster, kubeadmControlPlane *controlplanev1.KubeadmControlPlane) *clusterv1.Cluster {
@@ -130,9 +133,11 @@ func SnowCluster(clusterSpec *cluster.Spec) *snowv1.AWSSnowCluster {
 	return cluster
 }
 
-func SnowMachineTemplate(name string, machineConfig *v1alpha1.SnowMachineConfig) *snowv1.AWSSnowMachineTemplate {
+// SnowMachineTemplate builds the machine template for machineConfig. Its devices are the ones the
+// machineConfig placement policy allows machines on.
+func SnowMachineTemplate(clusterName, name string, machineConfig *v1alpha1.SnowMachineConfig) *snowv1.AWSSnowMachineTemplate {
 	networkConnector := string(machineConfig.Spec.PhysicalNetworkConnector)
-	return &snowv1.AWSSnowMachineTemplate{
+	m := &snowv1.AWSSnowMachineTemplate{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: clusterapi.InfrastructureAPIVersion(),
 			Kind:       SnowMachineTemplateKind,
@@ -154,9 +159,88 @@ func SnowMachineTemplate(name string, machineConfig *v1alpha1.SnowMachineConfig)
 						InsecureSkipSecretsManager: true,
 					},
 					PhysicalNetworkConnectorType: &networkConnector,
-					Devices:                      machineConfig.Spec.Devices,
+					Devices:                      placementDevices(machineConfig),
 				},
 			},
 		},
 	}
+
+	if machineConfig.Spec.Network != nil {
+		m.Spec.Template.Spec.Network = snowNetwork(clusterName, machineConfig.Spec.Network)
+	}
+
+	return m
+}
+
+func snowNetwork(clusterName string, network *v1alpha1.SnowNetwork) *snowv1.AWSSnowNetwork {
+	dnis := make([]snowv1.AWSSnowDirectNetworkInterface, 0, len(network.DirectNetworkInterfaces))
+	for _, dni := range network.DirectNetworkInterfaces {
+		d := snowv1.AWSSnowDirectNetworkInterface{
+			Index:   dni.Index,
+			VlanID:  dni.VlanID,
+			DHCP:    dni.DHCP,
+			Primary: dni.Primary,
+		}
+		if dni.IPPoolRef != nil {
+			d.IPPool = &v1.ObjectReference{
+				APIVersion: clusterapi.InfrastructureAPIVersion(),
+				Kind:       SnowIPPoolKind,
+				Name:       snowIPPoolName(clusterName, dni.IPPoolRef.Name),
+				Namespace:  constants.EksaSystemNamespace,
+			}
+		}
+		dnis = append(dnis, d)
+	}
+
+	return &snowv1.AWSSnowNetwork{
+		DirectNetworkInterfaces: dnis,
+	}
+}
+
+// SnowIPPools builds the AWSSnowIPPools for all the SnowIPPools in the cluster spec.
+func SnowIPPools(clusterSpec *cluster.Spec) []*snowv1.AWSSnowIPPool {
+	names := make([]string, 0, len(clusterSpec.SnowIPPools))
+	for name := range clusterSpec.SnowIPPools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pools := make([]*snowv1.AWSSnowIPPool, 0, len(names))
+	for _, name := range names {
+		pools = append(pools, SnowIPPool(clusterSpec.Cluster.Name, clusterSpec.SnowIPPools[name]))
+	}
+	return pools
+}
+
+// SnowIPPool builds an AWSSnowIPPool from a SnowIPPool. Its name is prefixed with the cluster name
+// so pools with the same name in different clusters don't collide in the eksa-system namespace.
+func SnowIPPool(clusterName string, pool *v1alpha1.SnowIPPool) *snowv1.AWSSnowIPPool {
+	ipPools := make([]snowv1.IPPool, 0, len(pool.Spec.Pools))
+	for _, p := range pool.Spec.Pools {
+		p := p
+		ipPools = append(ipPools, snowv1.IPPool{
+			IPStart: &p.IPStart,
+			IPEnd:   &p.IPEnd,
+			Subnet:  &p.Subnet,
+			Gateway: &p.Gateway,
+		})
+	}
+
+	return &snowv1.AWSSnowIPPool{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clusterapi.InfrastructureAPIVersion(),
+			Kind:       SnowIPPoolKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snowIPPoolName(clusterName, pool.GetName()),
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: snowv1.AWSSnowIPPoolSpec{
+			IPPools: ipPools,
+		},
+	}
+}
+
+func snowIPPoolName(clusterName, poolName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, poolName)
 }
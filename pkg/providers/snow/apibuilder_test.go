@@ -75,7 +75,7 @@ func wantCAPICluster() *clusterv1.Cluster {
 func TestCAPICluster(t *testing.T) {
 	tt := newApiBuilerTest(t)
 	snowCluster := snow.SnowCluster(tt.clusterSpec)
-	controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test-control-plane-1", tt.machineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+	controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", tt.machineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
 	kubeadmControlPlane, err := snow.KubeadmControlPlane(tt.clusterSpec, controlPlaneMachineTemplate)
 	tt.Expect(err).To(Succeed())
 
@@ -173,7 +173,7 @@ func wantRegistryMirrorCommands() []string {
 
 func TestKubeadmControlPlane(t *testing.T) {
 	tt := newApiBuilerTest(t)
-	controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test-control-plane-1", tt.machineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+	controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", tt.machineConfigs[tt.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
 	got, err := snow.KubeadmControlPlane(tt.clusterSpec, controlPlaneMachineTemplate)
 	tt.Expect(err).To(Succeed())
 
@@ -278,7 +278,7 @@ func TestKubeadmControlPlaneWithRegistryMirror(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := newApiBuilerTest(t)
 			g.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration = tt.registryMirrorConfig
-			controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test-control-plane-1", g.machineConfigs[g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+			controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", g.machineConfigs[g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
 			got, err := snow.KubeadmControlPlane(g.clusterSpec, controlPlaneMachineTemplate)
 			g.Expect(err).To(Succeed())
 			want := wantKubeadmControlPlane()
@@ -329,7 +329,7 @@ func TestKubeadmControlPlaneWithProxyConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := newApiBuilerTest(t)
 			g.clusterSpec.Cluster.Spec.ProxyConfiguration = tt.proxy
-			controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test-control-plane-1", g.machineConfigs[g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+			controlPlaneMachineTemplate := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", g.machineConfigs[g.clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
 			got, err := snow.KubeadmControlPlane(g.clusterSpec, controlPlaneMachineTemplate)
 			g.Expect(err).To(Succeed())
 			want := wantKubeadmControlPlane()
@@ -495,13 +495,134 @@ func wantSnowMachineTemplate() *snowv1.AWSSnowMachineTemplate {
 
 func TestSnowMachineTemplate(t *testing.T) {
 	tt := newApiBuilerTest(t)
-	got := snow.SnowMachineTemplate("snow-test-control-plane-1", tt.machineConfigs["test-cp"])
+	got := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", tt.machineConfigs["test-cp"])
 	want := wantSnowMachineTemplate()
 	want.SetName("snow-test-control-plane-1")
 	want.Spec.Template.Spec.InstanceType = "sbe-c.large"
 	tt.Expect(got).To(Equal(want))
 }
 
+func TestSnowMachineTemplatePackPlacement(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	machineConfig := tt.machineConfigs["test-cp"]
+	machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	got := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", machineConfig)
+	tt.Expect(got.Spec.Template.Spec.Devices).To(Equal([]string{"1.2.3.4"}))
+}
+
+func TestSnowMachineTemplateSpreadPlacement(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	machineConfig := tt.machineConfigs["test-cp"]
+	machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementSpread
+	got := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", machineConfig)
+	tt.Expect(got.Spec.Template.Spec.Devices).To(Equal(machineConfig.Spec.Devices))
+}
+
+func TestSnowMachineTemplateWithNetwork(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	vlanID := int32(10)
+	machineConfig := tt.machineConfigs["test-cp"]
+	machineConfig.Spec.Network = &v1alpha1.SnowNetwork{
+		DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+			{
+				Index:   1,
+				DHCP:    true,
+				Primary: true,
+			},
+			{
+				Index:  2,
+				VlanID: &vlanID,
+				IPPoolRef: &v1alpha1.Ref{
+					Kind: v1alpha1.SnowIPPoolKind,
+					Name: "ip-pool-1",
+				},
+			},
+		},
+	}
+	got := snow.SnowMachineTemplate("snow-test", "snow-test-control-plane-1", machineConfig)
+	tt.Expect(got.Spec.Template.Spec.Network).To(Equal(&snowv1.AWSSnowNetwork{
+		DirectNetworkInterfaces: []snowv1.AWSSnowDirectNetworkInterface{
+			{
+				Index:   1,
+				DHCP:    true,
+				Primary: true,
+			},
+			{
+				Index:  2,
+				VlanID: &vlanID,
+				IPPool: &v1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+					Kind:       "AWSSnowIPPool",
+					Name:       "snow-test-ip-pool-1",
+					Namespace:  "eksa-system",
+				},
+			},
+		},
+	}))
+}
+
+func givenSnowIPPool(name string) *v1alpha1.SnowIPPool {
+	return &v1alpha1.SnowIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-namespace",
+		},
+		Spec: v1alpha1.SnowIPPoolSpec{
+			Pools: []v1alpha1.IPPool{
+				{
+					IPStart: "10.0.0.10",
+					IPEnd:   "10.0.0.20",
+					Subnet:  "10.0.0.0/24",
+					Gateway: "10.0.0.1",
+				},
+			},
+		},
+	}
+}
+
+func wantSnowIPPool(name string) *snowv1.AWSSnowIPPool {
+	ipStart := "10.0.0.10"
+	ipEnd := "10.0.0.20"
+	subnet := "10.0.0.0/24"
+	gateway := "10.0.0.1"
+	return &snowv1.AWSSnowIPPool{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+			Kind:       "AWSSnowIPPool",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "eksa-system",
+		},
+		Spec: snowv1.AWSSnowIPPoolSpec{
+			IPPools: []snowv1.IPPool{
+				{
+					IPStart: &ipStart,
+					IPEnd:   &ipEnd,
+					Subnet:  &subnet,
+					Gateway: &gateway,
+				},
+			},
+		},
+	}
+}
+
+func TestSnowIPPool(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	got := snow.SnowIPPool("snow-test", givenSnowIPPool("ip-pool-1"))
+	tt.Expect(got).To(Equal(wantSnowIPPool("snow-test-ip-pool-1")))
+}
+
+func TestSnowIPPools(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.SnowIPPools = map[string]*v1alpha1.SnowIPPool{
+		"ip-pool-2": givenSnowIPPool("ip-pool-2"),
+		"ip-pool-1": givenSnowIPPool("ip-pool-1"),
+	}
+	got := snow.SnowIPPools(tt.clusterSpec)
+	tt.Expect(got).To(Equal([]*snowv1.AWSSnowIPPool{wantSnowIPPool("snow-test-ip-pool-1"), wantSnowIPPool("snow-test-ip-pool-2")}))
+}
+
 func tlsCipherSuitesArgs() map[string]string {
 	return map[string]string{"tls-cipher-suites": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}
 }
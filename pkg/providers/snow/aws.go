@@ -10,6 +10,8 @@ type AwsClient interface {
 	EC2ImageExists(ctx context.Context, imageID string) (bool, error)
 	EC2KeyNameExists(ctx context.Context, keyName string) (bool, error)
 	EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error
+	EC2InstanceTypes(ctx context.Context) ([]aws.EC2InstanceType, error)
+	EC2RunningInstanceTypes(ctx context.Context) ([]string, error)
 }
 
 type AwsClientMap map[string]AwsClient
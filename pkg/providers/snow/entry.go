@@ -35,6 +35,18 @@ func (cm *ConfigManager) SetDefaultsAndValidate(ctx context.Context, config *clu
 	return nil
 }
 
+// ValidatePlacement checks the control plane and etcd machines in config are placed on more than one device.
+// Existing clusters with all their control plane on one device can't be moved, so it's only enforced for new clusters.
+func (cm *ConfigManager) ValidatePlacement(ctx context.Context, config *cluster.Config) error {
+	return cm.validator.ValidateControlPlanePlacement(ctx, config)
+}
+
+// ValidateCapacity checks the devices have enough capacity left to provision all the machines in config.
+// It's only meaningful for new clusters, since the machines of an existing cluster already run on the devices.
+func (cm *ConfigManager) ValidateCapacity(ctx context.Context, config *cluster.Config) error {
+	return cm.validator.ValidateDeviceCapacity(ctx, config)
+}
+
 func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEntry {
 	return &cluster.ConfigManagerEntry{
 		Defaulters: []cluster.Defaulter{
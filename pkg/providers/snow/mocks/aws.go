@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	aws "github.com/aws/eks-anywhere/pkg/aws"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -63,6 +64,21 @@ func (mr *MockAwsClientMockRecorder) EC2ImportKeyPair(ctx, keyName, keyMaterial
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImportKeyPair", reflect.TypeOf((*MockAwsClient)(nil).EC2ImportKeyPair), ctx, keyName, keyMaterial)
 }
 
+// EC2InstanceTypes mocks base method.
+func (m *MockAwsClient) EC2InstanceTypes(ctx context.Context) ([]aws.EC2InstanceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2InstanceTypes", ctx)
+	ret0, _ := ret[0].([]aws.EC2InstanceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2InstanceTypes indicates an expected call of EC2InstanceTypes.
+func (mr *MockAwsClientMockRecorder) EC2InstanceTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2InstanceTypes", reflect.TypeOf((*MockAwsClient)(nil).EC2InstanceTypes), ctx)
+}
+
 // EC2KeyNameExists mocks base method.
 func (m *MockAwsClient) EC2KeyNameExists(ctx context.Context, keyName string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -77,3 +93,18 @@ func (mr *MockAwsClientMockRecorder) EC2KeyNameExists(ctx, keyName interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2KeyNameExists", reflect.TypeOf((*MockAwsClient)(nil).EC2KeyNameExists), ctx, keyName)
 }
+
+// EC2RunningInstanceTypes mocks base method.
+func (m *MockAwsClient) EC2RunningInstanceTypes(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2RunningInstanceTypes", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2RunningInstanceTypes indicates an expected call of EC2RunningInstanceTypes.
+func (mr *MockAwsClientMockRecorder) EC2RunningInstanceTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2RunningInstanceTypes", reflect.TypeOf((*MockAwsClient)(nil).EC2RunningInstanceTypes), ctx)
+}
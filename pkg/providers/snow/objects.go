@@ -17,7 +17,7 @@ import (
 
 func ControlPlaneObjects(ctx context.Context, clusterSpec *cluster.Spec, kubeClient kubernetes.Client) ([]runtime.Object, error) {
 	snowCluster := SnowCluster(clusterSpec)
-	new := SnowMachineTemplate(clusterapi.ControlPlaneMachineTemplateName(clusterSpec), clusterSpec.SnowMachineConfigs[clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
+	new := SnowMachineTemplate(clusterSpec.Cluster.Name, clusterapi.ControlPlaneMachineTemplateName(clusterSpec), clusterSpec.SnowMachineConfigs[clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name])
 
 	old, err := oldControlPlaneMachineTemplate(ctx, kubeClient, clusterSpec)
 	if err != nil {
@@ -35,7 +35,12 @@ func ControlPlaneObjects(ctx context.Context, clusterSpec *cluster.Spec, kubeCli
 	}
 	capiCluster := CAPICluster(clusterSpec, snowCluster, kubeadmControlPlane)
 
-	return []runtime.Object{capiCluster, snowCluster, kubeadmControlPlane, new}, nil
+	objects := []runtime.Object{capiCluster, snowCluster, kubeadmControlPlane, new}
+	for _, pool := range SnowIPPools(clusterSpec) {
+		objects = append(objects, pool)
+	}
+
+	return objects, nil
 }
 
 func WorkersObjects(ctx context.Context, clusterSpec *cluster.Spec, kubeClient kubernetes.Client) ([]runtime.Object, error) {
@@ -77,7 +82,7 @@ func WorkersMachineAndConfigTemplate(ctx context.Context, kubeClient kubernetes.
 		}
 
 		// build worker machineTemplate with new clusterSpec
-		newMachineTemplate := SnowMachineTemplate(clusterapi.WorkerMachineTemplateName(clusterSpec, workerNodeGroupConfig), clusterSpec.SnowMachineConfigs[workerNodeGroupConfig.MachineGroupRef.Name])
+		newMachineTemplate := SnowMachineTemplate(clusterSpec.Cluster.Name, clusterapi.WorkerMachineTemplateName(clusterSpec, workerNodeGroupConfig), clusterSpec.SnowMachineConfigs[workerNodeGroupConfig.MachineGroupRef.Name])
 
 		// build worker kubeadmConfigTemplate with new clusterSpec
 		newConfigTemplate, err := kubeadmConfigTemplate(clusterSpec, workerNodeGroupConfig)
@@ -0,0 +1,34 @@
+package snow
+
+import (
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// DevicePlacement estimates how count machines of machineConfig are assigned to its devices, in the
+// order the devices are listed. With the spread policy machines are assumed to be assigned round robin,
+// with the pack policy all machines are assigned to the first device. It returns the device of each machine.
+// CAPAS picks the device of each machine from the machine template devices itself, so this is only used
+// to estimate the capacity each device needs.
+func DevicePlacement(machineConfig *v1alpha1.SnowMachineConfig, count int) []string {
+	devices := placementDevices(machineConfig)
+	if len(devices) == 0 {
+		return nil
+	}
+
+	placement := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		placement = append(placement, devices[i%len(devices)])
+	}
+	return placement
+}
+
+// placementDevices returns the devices machines of machineConfig can be provisioned on. These are the
+// devices set in the machine template, so they don't depend on the number of machines and scaling
+// doesn't change the template.
+func placementDevices(machineConfig *v1alpha1.SnowMachineConfig) []string {
+	devices := machineConfig.Spec.Devices
+	if machineConfig.Spec.PlacementPolicy == v1alpha1.SnowPlacementPack && len(devices) > 0 {
+		return devices[:1]
+	}
+	return devices
+}
@@ -0,0 +1,59 @@
+package snow_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+)
+
+func TestDevicePlacement(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy v1alpha1.SnowPlacementPolicy
+		count  int
+		want   []string
+	}{
+		{
+			name:  "default spread",
+			count: 4,
+			want:  []string{"device-2", "device-1", "device-3", "device-2"},
+		},
+		{
+			name:   "spread fewer machines than devices",
+			policy: v1alpha1.SnowPlacementSpread,
+			count:  2,
+			want:   []string{"device-2", "device-1"},
+		},
+		{
+			name:   "pack",
+			policy: v1alpha1.SnowPlacementPack,
+			count:  3,
+			want:   []string{"device-2", "device-2", "device-2"},
+		},
+		{
+			name:  "no machines",
+			count: 0,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			m := &v1alpha1.SnowMachineConfig{
+				Spec: v1alpha1.SnowMachineConfigSpec{
+					Devices:         []string{"device-2", "device-1", "device-3"},
+					PlacementPolicy: tt.policy,
+				},
+			}
+			g.Expect(snow.DevicePlacement(m, tt.count)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDevicePlacementNoDevices(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(snow.DevicePlacement(&v1alpha1.SnowMachineConfig{}, 3)).To(BeNil())
+}
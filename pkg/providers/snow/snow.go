@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -30,6 +32,9 @@ const (
 	snowCertsKey               = "AWS_B64ENCODED_CA_BUNDLES"
 	maxRetries                 = 30
 	backOffPeriod              = 5 * time.Second
+	// snowIPPoolCRDName is the CRD of AWSSnowIPPools. Only the CAPAS releases that ship it support
+	// direct network interfaces and static ips.
+	snowIPPoolCRDName = "awssnowippools.infrastructure.cluster.x-k8s.io"
 )
 
 var (
@@ -71,6 +76,12 @@ func (p *SnowProvider) SetupAndValidateCreateCluster(ctx context.Context, cluste
 	if err := p.configManager.SetDefaultsAndValidate(ctx, clusterSpec.Config); err != nil {
 		return fmt.Errorf("setting defaults and validate snow config: %v", err)
 	}
+	if err := p.configManager.ValidatePlacement(ctx, clusterSpec.Config); err != nil {
+		return fmt.Errorf("validating snow device placement: %v", err)
+	}
+	if err := p.configManager.ValidateCapacity(ctx, clusterSpec.Config); err != nil {
+		return fmt.Errorf("validating snow device capacity: %v", err)
+	}
 	if !p.skipIpCheck {
 		if err := providerValidator.ValidateControlPlaneIpUniqueness(clusterSpec.Cluster, &networkutils.DefaultNetClient{}); err != nil {
 			return err
@@ -88,6 +99,9 @@ func (p *SnowProvider) SetupAndValidateUpgradeCluster(ctx context.Context, clust
 	if err := p.configManager.SetDefaultsAndValidate(ctx, clusterSpec.Config); err != nil {
 		return fmt.Errorf("setting defaults and validate snow config: %v", err)
 	}
+	if err := p.configManager.ValidatePlacement(ctx, clusterSpec.Config); err != nil {
+		logger.Info("Warning: the control plane has no protection against a snow device failure", "reason", err)
+	}
 	return nil
 }
 
@@ -128,9 +142,42 @@ func CAPIObjects(ctx context.Context, clusterSpec *cluster.Spec, kubeClient kube
 
 func (p *SnowProvider) generateCAPISpec(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	kubeconfigClient := p.kubeUnAuthClient.KubeconfigClient(cluster.KubeconfigFile)
+	if err := validateNetworkSupported(ctx, kubeconfigClient, clusterSpec); err != nil {
+		return nil, nil, err
+	}
 	return CAPIObjects(ctx, clusterSpec, kubeconfigClient)
 }
 
+// validateNetworkSupported checks the CAPAS version installed in the cluster supports direct network interfaces
+// when the cluster uses them. Older versions would silently drop the machine network configuration.
+func validateNetworkSupported(ctx context.Context, kubeClient kubernetes.Client, clusterSpec *cluster.Spec) error {
+	if !usesDirectNetworkInterfaces(clusterSpec) {
+		return nil
+	}
+
+	err := kubeClient.Get(ctx, snowIPPoolCRDName, "", &apiextensionsv1.CustomResourceDefinition{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("snow provider version %s doesn't support direct network interfaces, %s is not installed", clusterSpec.VersionsBundle.Snow.Version, snowIPPoolCRDName)
+	}
+	if err != nil {
+		return fmt.Errorf("checking snow provider support for direct network interfaces: %v", err)
+	}
+
+	return nil
+}
+
+func usesDirectNetworkInterfaces(clusterSpec *cluster.Spec) bool {
+	if len(clusterSpec.SnowIPPools) > 0 {
+		return true
+	}
+	for _, m := range clusterSpec.SnowMachineConfigs {
+		if m.Spec.Network != nil && len(m.Spec.Network.DirectNetworkInterfaces) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *SnowProvider) GenerateCAPISpecForCreate(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	return p.generateCAPISpec(ctx, cluster, clusterSpec)
 }
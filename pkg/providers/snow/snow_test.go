@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -269,10 +270,33 @@ func TestSetupAndValidateCreateClusterSuccess(t *testing.T) {
 	setupContext(t)
 	tt.aws.EXPECT().EC2ImageExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
 	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	tt.aws.EXPECT().EC2InstanceTypes(tt.ctx).Return(deviceInstanceTypes(), nil).Times(2)
+	tt.aws.EXPECT().EC2RunningInstanceTypes(tt.ctx).Return(nil, nil).Times(2)
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 	tt.Expect(err).To(Succeed())
 }
 
+func TestSetupAndValidateCreateClusterNotEnoughCapacity(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.aws.EXPECT().EC2ImageExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	tt.aws.EXPECT().EC2InstanceTypes(tt.ctx).Return(deviceInstanceTypes(), nil)
+	tt.aws.EXPECT().EC2RunningInstanceTypes(tt.ctx).Return(fullDevice(), nil)
+	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("validating snow device capacity: not enough vCPU on snow device")))
+}
+
+func TestSetupAndValidateCreateClusterControlPlaneOnSingleDevice(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.clusterSpec.SnowMachineConfigs["test-cp"].Spec.Devices = []string{"1.2.3.4"}
+	tt.aws.EXPECT().EC2ImageExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("validating snow device placement: control plane SnowMachineConfig [test-cp] places all its 3 machines on a single snow device")))
+}
+
 func TestSetupAndValidateCreateClusterNoCredsEnv(t *testing.T) {
 	tt := newSnowTest(t)
 	setupContext(t)
@@ -298,6 +322,16 @@ func TestSetupAndValidateUpgradeClusterSuccess(t *testing.T) {
 	tt.Expect(err).To(Succeed())
 }
 
+func TestSetupAndValidateUpgradeClusterControlPlaneOnSingleDevice(t *testing.T) {
+	tt := newSnowTest(t)
+	setupContext(t)
+	tt.clusterSpec.SnowMachineConfigs["test-cp"].Spec.Devices = []string{"1.2.3.4"}
+	tt.aws.EXPECT().EC2ImageExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
+	err := tt.provider.SetupAndValidateUpgradeCluster(tt.ctx, tt.cluster, tt.clusterSpec)
+	tt.Expect(err).To(Succeed())
+}
+
 func TestSetupAndValidateUpgradeClusterNoCredsEnv(t *testing.T) {
 	tt := newSnowTest(t)
 	setupContext(t)
@@ -365,6 +399,47 @@ func TestGenerateCAPISpecForCreate(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_main_md.yaml")
 }
 
+func TestGenerateCAPISpecForCreateWithDirectNetworkInterfaces(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.clusterSpec.SnowMachineConfigs["test-cp"].Spec.Network = &v1alpha1.SnowNetwork{
+		DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+			{Index: 1, DHCP: true, Primary: true},
+		},
+	}
+	tt.kubeUnAuthClient.EXPECT().KubeconfigClient(tt.cluster.KubeconfigFile).Return(tt.kubeconfigClient)
+	tt.kubeconfigClient.EXPECT().
+		Get(tt.ctx, "awssnowippools.infrastructure.cluster.x-k8s.io", "", &apiextensionsv1.CustomResourceDefinition{}).
+		Return(nil)
+	tt.kubeconfigClient.EXPECT().
+		Get(tt.ctx, "snow-test", constants.EksaSystemNamespace, &controlplanev1.KubeadmControlPlane{}).
+		Return(apierrors.NewNotFound(schema.GroupResource{Group: "", Resource: ""}, ""))
+	tt.kubeconfigClient.EXPECT().
+		Get(tt.ctx, "snow-test-md-0", constants.EksaSystemNamespace, &clusterv1.MachineDeployment{}).
+		Return(apierrors.NewNotFound(schema.GroupResource{Group: "", Resource: ""}, ""))
+
+	cp, _, err := tt.provider.GenerateCAPISpecForCreate(tt.ctx, tt.cluster, tt.clusterSpec)
+
+	tt.Expect(err).To(Succeed())
+	tt.Expect(string(cp)).To(ContainSubstring("directNetworkInterfaces"))
+}
+
+func TestGenerateCAPISpecForCreateDirectNetworkInterfacesNotSupported(t *testing.T) {
+	tt := newSnowTest(t)
+	tt.clusterSpec.SnowMachineConfigs["test-cp"].Spec.Network = &v1alpha1.SnowNetwork{
+		DirectNetworkInterfaces: []v1alpha1.SnowDirectNetworkInterface{
+			{Index: 1, DHCP: true, Primary: true},
+		},
+	}
+	tt.kubeUnAuthClient.EXPECT().KubeconfigClient(tt.cluster.KubeconfigFile).Return(tt.kubeconfigClient)
+	tt.kubeconfigClient.EXPECT().
+		Get(tt.ctx, "awssnowippools.infrastructure.cluster.x-k8s.io", "", &apiextensionsv1.CustomResourceDefinition{}).
+		Return(apierrors.NewNotFound(schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}, ""))
+
+	_, _, err := tt.provider.GenerateCAPISpecForCreate(tt.ctx, tt.cluster, tt.clusterSpec)
+
+	tt.Expect(err).To(MatchError("snow provider version v1.0.2 doesn't support direct network interfaces, awssnowippools.infrastructure.cluster.x-k8s.io is not installed"))
+}
+
 func TestGenerateCAPISpecForUpgrade(t *testing.T) {
 	tt := newSnowTest(t)
 	mt := wantSnowMachineTemplate()
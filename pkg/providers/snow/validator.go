@@ -3,14 +3,30 @@ package snow
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
-const (
-	defaultAwsSshKeyName = "eksa-default"
-)
+const defaultAwsSshKeyName = "eksa-default"
+
+// deviceFamily is a snow device model, identified by the instance types the device offers.
+type deviceFamily struct {
+	instanceTypePrefix string
+	capacity           aws.EC2InstanceType
+}
+
+// deviceFamilies lists the compute capacity of each snow device model. Devices don't expose their
+// total capacity through the ec2 api, only the instance types they offer, which differ per model.
+// Families offering a superset of the instance types of another are listed first.
+var deviceFamilies = []deviceFamily{
+	{instanceTypePrefix: "sbe-g.", capacity: aws.EC2InstanceType{Name: "Snowball Edge Compute Optimized with GPU", VCPU: 52, MemoryMiB: 208 * 1024}},
+	{instanceTypePrefix: "sbe-c.", capacity: aws.EC2InstanceType{Name: "Snowball Edge Compute Optimized", VCPU: 104, MemoryMiB: 416 * 1024}},
+	{instanceTypePrefix: "sbe1.", capacity: aws.EC2InstanceType{Name: "Snowball Edge Storage Optimized", VCPU: 40, MemoryMiB: 80 * 1024}},
+}
 
 type Validator struct {
 	awsClientMap AwsClientMap
@@ -69,3 +85,133 @@ func (v *Validator) ValidateMachineDeviceIPs(ctx context.Context, m *v1alpha1.Sn
 
 	return nil
 }
+
+// ValidateControlPlanePlacement checks control plane and etcd machines are placed on more than one
+// device so a single device failure doesn't take down the whole control plane.
+func (v *Validator) ValidateControlPlanePlacement(ctx context.Context, c *cluster.Config) error {
+	cp := c.Cluster.Spec.ControlPlaneConfiguration
+	if err := validateMultiDevicePlacement(c, "control plane", cp.MachineGroupRef, cp.Count); err != nil {
+		return err
+	}
+
+	if etcd := c.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		if err := validateMultiDevicePlacement(c, "etcd", etcd.MachineGroupRef, etcd.Count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateMultiDevicePlacement(c *cluster.Config, role string, ref *v1alpha1.Ref, count int) error {
+	if ref == nil || count <= 1 {
+		return nil
+	}
+
+	m, ok := c.SnowMachineConfigs[ref.Name]
+	if !ok {
+		return nil
+	}
+
+	if devices := placementDevices(m); len(devices) < 2 {
+		return fmt.Errorf("%s SnowMachineConfig [%s] places all its %d machines on a single snow device, use the %s placementPolicy with at least 2 devices", role, m.Name, count, v1alpha1.SnowPlacementSpread)
+	}
+
+	return nil
+}
+
+// ValidateDeviceCapacity places all the cluster machines on their devices and checks each device
+// has enough vCPU and memory left for them, on top of the instances already running on it.
+func (v *Validator) ValidateDeviceCapacity(ctx context.Context, c *cluster.Config) error {
+	requested := map[string][]string{}
+	place := func(ref *v1alpha1.Ref, count int) {
+		if ref == nil {
+			return
+		}
+		m, ok := c.SnowMachineConfigs[ref.Name]
+		if !ok {
+			return
+		}
+		for _, device := range DevicePlacement(m, count) {
+			requested[device] = append(requested[device], string(m.Spec.InstanceType))
+		}
+	}
+
+	place(c.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef, c.Cluster.Spec.ControlPlaneConfiguration.Count)
+	if c.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		place(c.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef, c.Cluster.Spec.ExternalEtcdConfiguration.Count)
+	}
+	for _, w := range c.Cluster.Spec.WorkerNodeGroupConfigurations {
+		place(w.MachineGroupRef, w.Count)
+	}
+
+	for ip, instanceTypes := range requested {
+		client, ok := v.awsClientMap[ip]
+		if !ok {
+			return fmt.Errorf("credentials not found for device [%s]", ip)
+		}
+		if err := validateDeviceCapacity(ctx, ip, client, instanceTypes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDeviceCapacity(ctx context.Context, ip string, client AwsClient, requested []string) error {
+	instanceTypes, err := client.EC2InstanceTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("describe instance types on snow device [%s]: %v", ip, err)
+	}
+	capacity := make(map[string]aws.EC2InstanceType, len(instanceTypes))
+	for _, t := range instanceTypes {
+		capacity[t.Name] = t
+	}
+	for _, name := range requested {
+		if _, ok := capacity[name]; !ok {
+			return fmt.Errorf("instance type [%s] is not available on snow device [%s]", name, ip)
+		}
+	}
+
+	total, ok := deviceCapacity(instanceTypes)
+	if !ok {
+		logger.Info("Warning: unknown snow device model, skipping capacity validation", "device", ip)
+		return nil
+	}
+
+	running, err := client.EC2RunningInstanceTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("describe instances on snow device [%s]: %v", ip, err)
+	}
+
+	var used, needed aws.EC2InstanceType
+	for _, name := range running {
+		used.VCPU += capacity[name].VCPU
+		used.MemoryMiB += capacity[name].MemoryMiB
+	}
+	for _, name := range requested {
+		needed.VCPU += capacity[name].VCPU
+		needed.MemoryMiB += capacity[name].MemoryMiB
+	}
+
+	if available := total.VCPU - used.VCPU; needed.VCPU > available {
+		return fmt.Errorf("not enough vCPU on snow device [%s]: %d machines require %d vCPU but only %d are available", ip, len(requested), needed.VCPU, available)
+	}
+	if available := total.MemoryMiB - used.MemoryMiB; needed.MemoryMiB > available {
+		return fmt.Errorf("not enough memory on snow device [%s]: %d machines require %d MiB but only %d MiB are available", ip, len(requested), needed.MemoryMiB, available)
+	}
+
+	return nil
+}
+
+// deviceCapacity returns the total compute capacity of a device from the instance types it offers.
+func deviceCapacity(instanceTypes []aws.EC2InstanceType) (aws.EC2InstanceType, bool) {
+	for _, family := range deviceFamilies {
+		for _, t := range instanceTypes {
+			if strings.HasPrefix(t.Name, family.instanceTypePrefix) {
+				return family.capacity, true
+			}
+		}
+	}
+	return aws.EC2InstanceType{}, false
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
@@ -42,8 +44,9 @@ func newConfigManagerTest(t *testing.T) *configManagerTest {
 			Name: "cp-machine",
 		},
 		Spec: v1alpha1.SnowMachineConfigSpec{
-			AMIID:      "ami-1",
-			SshKeyName: "default",
+			AMIID:        "ami-1",
+			SshKeyName:   "default",
+			InstanceType: v1alpha1.SbeCLarge,
 			Devices: []string{
 				"device-1",
 				"device-2",
@@ -122,3 +125,147 @@ func TestValidateMachineDeviceIPsNotValid(t *testing.T) {
 	err := g.validator.ValidateMachineDeviceIPs(g.ctx, g.machineConfig)
 	g.Expect(err).NotTo(Succeed())
 }
+
+func deviceInstanceTypes() []aws.EC2InstanceType {
+	return []aws.EC2InstanceType{
+		{Name: "sbe-c.large", VCPU: 2, MemoryMiB: 8192},
+		{Name: "sbe-c.xlarge", VCPU: 4, MemoryMiB: 16384},
+		{Name: "sbe-c.2xlarge", VCPU: 8, MemoryMiB: 32768},
+		{Name: "sbe-c.4xlarge", VCPU: 16, MemoryMiB: 65536},
+	}
+}
+
+// fullDevice returns the instances running on a device with 100 of its 104 vCPU in use.
+func fullDevice() []string {
+	return []string{
+		"sbe-c.4xlarge", "sbe-c.4xlarge", "sbe-c.4xlarge",
+		"sbe-c.4xlarge", "sbe-c.4xlarge", "sbe-c.4xlarge",
+		"sbe-c.xlarge",
+	}
+}
+
+func (g *configManagerTest) givenConfig(cpCount, workerCount int) *cluster.Config {
+	return &cluster.Config{
+		Cluster: &v1alpha1.Cluster{
+			Spec: v1alpha1.ClusterSpec{
+				ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+					Count:           cpCount,
+					MachineGroupRef: &v1alpha1.Ref{Name: g.machineConfig.Name},
+				},
+				WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+					{
+						Count:           workerCount,
+						MachineGroupRef: &v1alpha1.Ref{Name: g.machineConfig.Name},
+					},
+				},
+			},
+		},
+		SnowMachineConfigs: map[string]*v1alpha1.SnowMachineConfig{
+			g.machineConfig.Name: g.machineConfig,
+		},
+	}
+}
+
+func TestValidateDeviceCapacity(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.InstanceType = v1alpha1.SbeC4XLarge
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return(deviceInstanceTypes(), nil).Times(2)
+	g.aws.EXPECT().EC2RunningInstanceTypes(g.ctx).Return([]string{"sbe-c.large"}, nil).Times(2)
+	err := g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(3, 7))
+	g.Expect(err).To(Succeed())
+}
+
+func TestValidateDeviceCapacityNotEnoughVCPU(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return(deviceInstanceTypes(), nil)
+	g.aws.EXPECT().EC2RunningInstanceTypes(g.ctx).Return(fullDevice(), nil)
+	err := g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(1, 2))
+	g.Expect(err).To(MatchError("not enough vCPU on snow device [device-1]: 3 machines require 6 vCPU but only 4 are available"))
+}
+
+func TestValidateDeviceCapacityNotEnoughMemory(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return([]aws.EC2InstanceType{
+		{Name: "sbe-c.large", VCPU: 2, MemoryMiB: 200 * 1024},
+	}, nil)
+	g.aws.EXPECT().EC2RunningInstanceTypes(g.ctx).Return(nil, nil)
+	err := g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(1, 2))
+	g.Expect(err).To(MatchError("not enough memory on snow device [device-1]: 3 machines require 614400 MiB but only 425984 MiB are available"))
+}
+
+func TestValidateDeviceCapacityInstanceTypeNotAvailable(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return(nil, nil)
+	err := g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(1, 0))
+	g.Expect(err).To(MatchError("instance type [sbe-c.large] is not available on snow device [device-1]"))
+}
+
+func TestValidateDeviceCapacityError(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return(nil, errors.New("error"))
+	err := g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(1, 0))
+	g.Expect(err).To(MatchError("describe instance types on snow device [device-1]: error"))
+}
+
+func TestValidateControlPlanePlacement(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.Expect(g.validator.ValidateControlPlanePlacement(g.ctx, g.givenConfig(3, 1))).To(Succeed())
+}
+
+func TestValidateControlPlanePlacementPack(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.Expect(g.validator.ValidateControlPlanePlacement(g.ctx, g.givenConfig(1, 1))).To(Succeed())
+	g.Expect(g.validator.ValidateControlPlanePlacement(g.ctx, g.givenConfig(3, 1))).To(
+		MatchError("control plane SnowMachineConfig [cp-machine] places all its 3 machines on a single snow device, use the spread placementPolicy with at least 2 devices"),
+	)
+}
+
+func TestValidateControlPlanePlacementSpreadSingleDevice(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.Devices = []string{"device-1"}
+	g.Expect(g.validator.ValidateControlPlanePlacement(g.ctx, g.givenConfig(3, 1))).To(
+		MatchError("control plane SnowMachineConfig [cp-machine] places all its 3 machines on a single snow device, use the spread placementPolicy with at least 2 devices"),
+	)
+}
+
+func TestValidateControlPlanePlacementExternalEtcd(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.Devices = []string{"device-1"}
+	c := g.givenConfig(1, 1)
+	c.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:           3,
+		MachineGroupRef: &v1alpha1.Ref{Name: g.machineConfig.Name},
+	}
+	g.Expect(g.validator.ValidateControlPlanePlacement(g.ctx, c)).To(
+		MatchError(ContainSubstring("etcd SnowMachineConfig [cp-machine] places all its 3 machines on a single snow device")),
+	)
+}
+
+func TestValidateDeviceCapacityUnknownDevice(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.machineConfig.Spec.InstanceType = "snc1.medium"
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return([]aws.EC2InstanceType{
+		{Name: "snc1.medium", VCPU: 2, MemoryMiB: 4096},
+	}, nil)
+	g.aws.EXPECT().EC2RunningInstanceTypes(g.ctx).Return(nil, nil).Times(0)
+	g.Expect(g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(1, 20))).To(Succeed())
+}
+
+func TestValidateDeviceCapacityStorageOptimized(t *testing.T) {
+	g := newConfigManagerTest(t)
+	g.machineConfig.Spec.PlacementPolicy = v1alpha1.SnowPlacementPack
+	g.machineConfig.Spec.InstanceType = "sbe1.xlarge"
+	g.aws.EXPECT().EC2InstanceTypes(g.ctx).Return([]aws.EC2InstanceType{
+		{Name: "sbe1.xlarge", VCPU: 4, MemoryMiB: 16384},
+	}, nil)
+	g.aws.EXPECT().EC2RunningInstanceTypes(g.ctx).Return(nil, nil)
+	g.Expect(g.validator.ValidateDeviceCapacity(g.ctx, g.givenConfig(1, 10))).To(
+		MatchError("not enough vCPU on snow device [device-1]: 11 machines require 44 vCPU but only 40 are available"),
+	)
+}
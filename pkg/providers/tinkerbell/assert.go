@@ -3,6 +3,7 @@ package tinkerbell
 import (
 	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
@@ -82,6 +83,18 @@ func NewIPNotInUseAssertion(client networkutils.NetClient) ClusterSpecAssertion
 	}
 }
 
+// NewIPReachableAssertion ensures the endpoint host for the control plane is already served. It's used when the
+// control plane endpoint is load balanced outside of EKS Anywhere. The check may be unreliable due to its implementation.
+func NewIPReachableAssertion(client networkutils.NetClient) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		ip := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+		if !networkutils.IsIPInUse(client, ip) {
+			return fmt.Errorf("control plane endpoint ip not reachable: %v", ip)
+		}
+		return nil
+	}
+}
+
 // HardwareSatisfiesOnlyOneSelectorAssertion ensures hardware in catalogue only satisfies 1
 // of the MachineConfig's HardwareSelector's from the spec.
 func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
@@ -95,6 +108,56 @@ func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) Cl
 	}
 }
 
+// NewBMCsReachableAssertion ensures the BMCs of all hardware in catalogue that satisfies the
+// MachineConfig's HardwareSelector's from spec are reachable. Rufio needs to contact them to
+// power cycle and netboot the hardware. The check may be unreliable due to its implementation.
+func NewBMCsReachableAssertion(catalogue *hardware.Catalogue, client networkutils.NetClient) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		selectors, err := selectorsFromClusterSpec(spec)
+		if err != nil {
+			return err
+		}
+
+		bmcs, err := getBMCsForSelectedHardware(catalogue, selectors, nil)
+		if err != nil {
+			return err
+		}
+
+		return validateBMCsReachable(client, bmcs)
+	}
+}
+
+// NewLibraryTemplatesRenderAssertion ensures the TinkerbellTemplateConfigs referencing a template
+// library render for the hardware selected by the MachineConfig's using them.
+func NewLibraryTemplatesRenderAssertion(builder *TemplateBuilder) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		machineConfigs := []*v1alpha1.TinkerbellMachineConfig{spec.ControlPlaneMachineConfig()}
+		for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
+			machineConfigs = append(machineConfigs, spec.WorkerNodeGroupMachineConfig(nodeGroup))
+		}
+		if spec.HasExternalEtcd() {
+			machineConfigs = append(machineConfigs, spec.ExternalEtcdMachineConfig())
+		}
+
+		for _, machineConfig := range machineConfigs {
+			templateConfig := spec.TinkerbellTemplateConfigs[machineConfig.Spec.TemplateRef.Name]
+			if templateConfig == nil || templateConfig.Spec.Library == nil {
+				continue
+			}
+
+			if len(templateConfig.Spec.Template.Tasks) > 0 {
+				return fmt.Errorf("TinkerbellTemplateConfig %s: template and library are mutually exclusive", templateConfig.Name)
+			}
+
+			if _, err := builder.renderLibraryTemplate(spec.Spec, *templateConfig.Spec.Library, machineConfig.Spec); err != nil {
+				return fmt.Errorf("TinkerbellTemplateConfig %s for TinkerbellMachineConfig %s: %v", templateConfig.Name, machineConfig.Name, err)
+			}
+		}
+
+		return nil
+	}
+}
+
 // selectorsFromClusterSpec extracts all selectors specified on MachineConfig's from spec.
 func selectorsFromClusterSpec(spec *ClusterSpec) (selectorSet, error) {
 	selectors := selectorSet{}
@@ -4,13 +4,18 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	"github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	tinkerbellworkflow "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/networkutils/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
@@ -164,6 +169,40 @@ func TestNewIPNotInUseAssertion_InUseFails(t *testing.T) {
 	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
 }
 
+func TestNewIPReachableAssertion_ReachableSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	server, client := net.Pipe()
+	defer server.Close()
+
+	netClient := mocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(client, nil)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	assertion := tinkerbell.NewIPReachableAssertion(netClient)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestNewIPReachableAssertion_NotReachableFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	netClient := mocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(5).
+		Return(nil, errors.New("failed to connect"))
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	assertion := tinkerbell.NewIPReachableAssertion(netClient)
+	g.Expect(assertion(clusterSpec)).ToNot(gomega.Succeed())
+}
+
 func TestMinimumHardwareAvailableAssertionForCreate_SufficientSucceeds(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -354,6 +393,185 @@ func TestHardwareSatisfiesOnlyOneSelectorAssertion_NoLabelsMeetsNothing(t *testi
 	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
 }
 
+func TestNewBMCsReachableAssertion_ReachableSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	server, client := net.Pipe()
+	defer server.Close()
+
+	netClient := mocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout("tcp", "10.10.10.10:22", gomock.Any()).
+		Return(client, nil)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := newCatalogueWithBMC(g, clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector)
+
+	assertion := tinkerbell.NewBMCsReachableAssertion(catalogue, netClient)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestNewBMCsReachableAssertion_NotReachableFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	netClient := mocks.NewMockNetClient(ctrl)
+	netClient.EXPECT().
+		DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(5).
+		Return(nil, errors.New("failed to connect"))
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := newCatalogueWithBMC(g, clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector)
+
+	assertion := tinkerbell.NewBMCsReachableAssertion(catalogue, netClient)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("bmc not reachable")))
+}
+
+func TestNewBMCsReachableAssertion_UnselectedHardwareIgnored(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+
+	netClient := mocks.NewMockNetClient(ctrl)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	catalogue := newCatalogueWithBMC(g, map[string]string{"unselected": "true"})
+
+	assertion := tinkerbell.NewBMCsReachableAssertion(catalogue, netClient)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestNewLibraryTemplatesRenderAssertion_RendersSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec, builder := newLibraryTemplateClusterSpec(g)
+	clusterSpec.TinkerbellTemplateConfigs["firmware"].Spec.Library.Parameters = map[string]string{
+		"firmwareURL": "https://firmware/update.cab",
+	}
+
+	assertion := tinkerbell.NewLibraryTemplatesRenderAssertion(builder)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestNewLibraryTemplatesRenderAssertion_MissingParameterFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec, builder := newLibraryTemplateClusterSpec(g)
+
+	assertion := tinkerbell.NewLibraryTemplatesRenderAssertion(builder)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring(
+		"TinkerbellTemplateConfig firmware for TinkerbellMachineConfig control-plane: template ubuntu-firmware-update parameter firmwareURL is required",
+	)))
+}
+
+func TestNewLibraryTemplatesRenderAssertion_TemplateAndLibraryFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec, builder := newLibraryTemplateClusterSpec(g)
+	clusterSpec.TinkerbellTemplateConfigs["firmware"].Spec.Template.Tasks = []tinkerbellworkflow.Task{{Name: "task"}}
+
+	assertion := tinkerbell.NewLibraryTemplatesRenderAssertion(builder)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError("TinkerbellTemplateConfig firmware: template and library are mutually exclusive"))
+}
+
+func TestNewLibraryTemplatesRenderAssertion_HeterogeneousLabelsFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec, builder := newLibraryTemplateClusterSpec(g,
+		hardware.Machine{Disk: "/dev/sda", Labels: map[string]string{"type": "cp", "data-disk": "sdb"}},
+		hardware.Machine{Disk: "/dev/sda", Labels: map[string]string{"type": "cp", "data-disk": "sdc"}},
+	)
+	clusterSpec.TinkerbellTemplateConfigs["firmware"].Spec.Library.Template = "ubuntu-multi-disk"
+
+	assertion := tinkerbell.NewLibraryTemplatesRenderAssertion(builder)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring(
+		"template ubuntu-multi-disk parameter dataDisk: hardware matching the selector has different values for label data-disk",
+	)))
+}
+
+func TestNewLibraryTemplatesRenderAssertion_HeterogeneousLabelsWithParameterSucceeds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec, builder := newLibraryTemplateClusterSpec(g,
+		hardware.Machine{Disk: "/dev/sda", Labels: map[string]string{"type": "cp", "data-disk": "sdb"}},
+		hardware.Machine{Disk: "/dev/sda", Labels: map[string]string{"type": "cp"}},
+	)
+	library := clusterSpec.TinkerbellTemplateConfigs["firmware"].Spec.Library
+	library.Template = "ubuntu-multi-disk"
+	library.Parameters = map[string]string{"dataDisk": "sdb"}
+
+	assertion := tinkerbell.NewLibraryTemplatesRenderAssertion(builder)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+// newLibraryTemplateClusterSpec creates a ClusterSpec whose control plane uses the
+// ubuntu-firmware-update library template and a TemplateBuilder whose disk extractor has seen
+// machines or, if none are given, a single control plane machine.
+func newLibraryTemplateClusterSpec(g *gomega.WithT, machines ...hardware.Machine) (*tinkerbell.ClusterSpec, *tinkerbell.TemplateBuilder) {
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Name = "test"
+	bundle := newTemplateLibraryBundle()
+	clusterSpec.VersionsBundle = &cluster.VersionsBundle{VersionsBundle: &bundle}
+	clusterSpec.TinkerbellTemplateConfigs = map[string]*eksav1alpha1.TinkerbellTemplateConfig{
+		"firmware": {
+			ObjectMeta: v1.ObjectMeta{Name: "firmware"},
+			Spec: eksav1alpha1.TinkerbellTemplateConfigSpec{
+				Library: &eksav1alpha1.TinkerbellTemplateLibraryRef{
+					Name:     tinkerbell.DefaultTemplateLibraryName,
+					Version:  "v1",
+					Template: "ubuntu-firmware-update",
+				},
+			},
+		},
+	}
+
+	controlPlane := clusterSpec.ControlPlaneMachineConfig()
+	controlPlane.Spec.TemplateRef = eksav1alpha1.Ref{Kind: eksav1alpha1.TinkerbellTemplateConfigKind, Name: "firmware"}
+
+	diskExtractor := hardware.NewDiskExtractor()
+	g.Expect(diskExtractor.Register(controlPlane.Spec.HardwareSelector)).To(gomega.Succeed())
+	if len(machines) == 0 {
+		machines = []hardware.Machine{{Disk: "/dev/sda", Labels: map[string]string{"type": "cp"}}}
+	}
+	for _, m := range machines {
+		g.Expect(diskExtractor.Write(m)).To(gomega.Succeed())
+	}
+
+	builder := tinkerbell.NewTemplateBuilder(
+		&clusterSpec.DatacenterConfig.Spec,
+		&controlPlane.Spec,
+		nil,
+		diskExtractor,
+		nil,
+		"1.1.1.1",
+		time.Now,
+	).(*tinkerbell.TemplateBuilder)
+
+	return clusterSpec, builder
+}
+
+// newCatalogueWithBMC creates a catalogue with a single hardware labeled with labels and its BMC.
+func newCatalogueWithBMC(g *gomega.WithT, labels map[string]string) *hardware.Catalogue {
+	catalogue := hardware.NewCatalogue(hardware.WithBMCNameIndex())
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "test",
+			Labels: labels,
+		},
+		Spec: v1alpha1.HardwareSpec{
+			BMCRef: &corev1.TypedLocalObjectReference{Name: "bmc-test"},
+		},
+	})).To(gomega.Succeed())
+	g.Expect(catalogue.InsertBMC(&rufiov1alpha1.BaseboardManagement{
+		ObjectMeta: v1.ObjectMeta{Name: "bmc-test"},
+		Spec: rufiov1alpha1.BaseboardManagementSpec{
+			Connection: rufiov1alpha1.Connection{Host: "10.10.10.10"},
+		},
+	})).To(gomega.Succeed())
+	return catalogue
+}
+
 // mergeHardwareSelectors merges m1 with m2. Values already in m1 will be overwritten by m2.
 func mergeHardwareSelectors(m1, m2 map[string]string) map[string]string {
 	for name, value := range m2 {
@@ -6,9 +6,13 @@ import (
 	"net/url"
 	"strings"
 
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/kubevip"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
@@ -70,9 +74,56 @@ func (p *Provider) PostBootstrapSetup(ctx context.Context, clusterConfig *v1alph
 	if err != nil {
 		return fmt.Errorf("applying hardware yaml: %v", err)
 	}
+	return p.netbootSelectedHardware(ctx, cluster)
+}
+
+// netbootSelectedHardware creates Rufio BMCJobs for the hardware in the catalogue that may be
+// selected for provisioning so it's powered on and netboots into the Tinkerbell stack. Hardware
+// already provisioned for a cluster is skipped, so running nodes aren't power cycled.
+func (p *Provider) netbootSelectedHardware(ctx context.Context, cluster *types.Cluster) error {
+	selectors := selectorSet{}
+	for _, selector := range selectorsFromMachineConfigs(p.machineConfigs) {
+		if err := selectors.Add(selector); err != nil {
+			return err
+		}
+	}
+
+	bmcs, err := getBMCsForSelectedHardware(p.catalogue, selectors, p.isProvisioned)
+	if err != nil {
+		return err
+	}
+	if len(bmcs) == 0 {
+		return nil
+	}
+
+	now := p.now()
+	jobs := make([]*rufiov1alpha1.BMCJob, 0, len(bmcs))
+	for _, bmc := range bmcs {
+		jobs = append(jobs, hardware.NewNetbootBMCJob(bmc, now))
+	}
+
+	jobsSpec, err := hardware.MarshalBMCJobs(jobs)
+	if err != nil {
+		return err
+	}
+
+	logger.V(4).Info("Netbooting hardware selected for provisioning", "count", len(jobs))
+	if err := p.providerKubectlClient.ApplyKubeSpecFromBytesForce(ctx, cluster, jobsSpec); err != nil {
+		return fmt.Errorf("applying bmc jobs yaml: %v", err)
+	}
 	return nil
 }
 
+// isProvisioned returns true when hw is owned by a machine, either because it has the CAPT owner
+// label or because it was provisioned in the cluster being upgraded.
+func (p *Provider) isProvisioned(hw *tinkv1alpha1.Hardware) bool {
+	if _, ok := hw.Labels[hardware.OwnerNameLabel]; ok {
+		return true
+	}
+	_, ok := p.provisionedHardware[hw.Name]
+	return ok
+}
+
 func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	logger.V(4).Info("Installing Tinkerbell stack on workload cluster")
 
@@ -95,6 +146,55 @@ func (p *Provider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster,
 		return err
 	}
 
+	return p.applyKubeVipServicesManifest(ctx, cluster, clusterSpec)
+}
+
+// reconcileKubeVipServicesManifest applies the kube-vip services manifest of newSpec, or deletes the one of
+// currentSpec when the load balancer configuration was removed.
+func (p *Provider) reconcileKubeVipServicesManifest(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error {
+	if newSpec.Cluster.Spec.LoadBalancerConfiguration != nil || currentSpec.Cluster.Spec.LoadBalancerConfiguration == nil {
+		return p.applyKubeVipServicesManifest(ctx, cluster, newSpec)
+	}
+
+	bundle := currentSpec.VersionsBundle.Tinkerbell
+	manifest, err := kubevip.GenerateServicesManifest(currentSpec.Cluster.Spec.LoadBalancerConfiguration, bundle.KubeVip.VersionedImage(), bundle.KubeVipCloudProvider.VersionedImage())
+	if err != nil {
+		return err
+	}
+
+	logger.V(4).Info("Deleting kube-vip service load balancer manifest")
+	err = p.retrier.Retry(
+		func() error {
+			return p.providerKubectlClient.DeleteKubeSpecFromBytesIgnoreNotFound(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("deleting kube-vip service load balancer manifest: %v", err)
+	}
+	return nil
+}
+
+func (p *Provider) applyKubeVipServicesManifest(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	lbConfig := clusterSpec.Cluster.Spec.LoadBalancerConfiguration
+	if lbConfig == nil {
+		return nil
+	}
+
+	bundle := clusterSpec.VersionsBundle.Tinkerbell
+	manifest, err := kubevip.GenerateServicesManifest(lbConfig, bundle.KubeVip.VersionedImage(), bundle.KubeVipCloudProvider.VersionedImage())
+	if err != nil {
+		return err
+	}
+
+	logger.V(4).Info("Applying kube-vip service load balancer manifest")
+	err = p.retrier.Retry(
+		func() error {
+			return p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("applying kube-vip service load balancer manifest: %v", err)
+	}
 	return nil
 }
 
@@ -124,10 +224,16 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 	clusterSpecValidator := NewClusterSpecValidator(
 		MinimumHardwareAvailableAssertionForCreate(p.catalogue),
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		NewBMCsReachableAssertion(p.catalogue, p.netClient),
+		NewLibraryTemplatesRenderAssertion(p.templateBuilder),
 	)
 
 	if !p.skipIpCheck {
-		clusterSpecValidator.Register(NewIPNotInUseAssertion(p.netClient))
+		if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.ExternalLoadBalancer {
+			clusterSpecValidator.Register(NewIPReachableAssertion(p.netClient))
+		} else {
+			clusterSpecValidator.Register(NewIPNotInUseAssertion(p.netClient))
+		}
 	}
 
 	if p.datacenterConfig.Spec.OSImageURL != "" {
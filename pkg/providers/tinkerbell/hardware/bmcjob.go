@@ -0,0 +1,62 @@
+package hardware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tinkerbell/rufio/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// NewNetbootBMCJob creates a Rufio BMCJob that powers off the machine managed by bmc, sets its
+// one-time boot device to PXE and powers it back on so it netboots into the Tinkerbell stack.
+// Rufio doesn't rerun completed jobs, so the job name is suffixed with now to netboot the machine
+// again on every create or upgrade.
+func NewNetbootBMCJob(bmc *v1alpha1.BaseboardManagement, now time.Time) *v1alpha1.BMCJob {
+	powerOff := v1alpha1.HardPowerOff
+	powerOn := v1alpha1.PowerOn
+
+	return &v1alpha1.BMCJob{
+		TypeMeta: newBMCJobTypeMeta(),
+		ObjectMeta: v1.ObjectMeta{
+			Name:      formatNetbootBMCJobName(bmc.Name, now),
+			Namespace: bmc.Namespace,
+		},
+		Spec: v1alpha1.BMCJobSpec{
+			BaseboardManagementRef: v1alpha1.BaseboardManagementRef{
+				Name:      bmc.Name,
+				Namespace: bmc.Namespace,
+			},
+			Tasks: []v1alpha1.Task{
+				{PowerAction: &powerOff},
+				{
+					OneTimeBootDeviceAction: &v1alpha1.OneTimeBootDeviceAction{
+						Devices: []v1alpha1.BootDevice{v1alpha1.PXE},
+						EFIBoot: true,
+					},
+				},
+				{PowerAction: &powerOn},
+			},
+		},
+	}
+}
+
+// MarshalBMCJobs marshals jobs into YAML that can be submitted to a Kubernetes cluster.
+func MarshalBMCJobs(jobs []*v1alpha1.BMCJob) ([]byte, error) {
+	resources := make([][]byte, 0, len(jobs))
+	for _, job := range jobs {
+		resource, err := yaml.Marshal(job)
+		if err != nil {
+			return nil, fmt.Errorf("failed marshalling bmc job %s: %v", job.Name, err)
+		}
+		resources = append(resources, resource)
+	}
+	return templater.AppendYamlResources(resources...), nil
+}
+
+func formatNetbootBMCJobName(bmcName string, now time.Time) string {
+	return fmt.Sprintf("%s-netboot-%d", bmcName, now.Unix())
+}
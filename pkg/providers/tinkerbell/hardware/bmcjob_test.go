@@ -0,0 +1,46 @@
+package hardware_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/tinkerbell/rufio/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestNewNetbootBMCJob(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	bmc := &v1alpha1.BaseboardManagement{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-worker1", Namespace: "eksa-system"},
+	}
+
+	job := hardware.NewNetbootBMCJob(bmc, time.Unix(1660000000, 0))
+	g.Expect(job.Name).To(gomega.Equal("bmc-worker1-netboot-1660000000"))
+	g.Expect(job.Namespace).To(gomega.Equal("eksa-system"))
+	g.Expect(job.Kind).To(gomega.Equal("BMCJob"))
+	g.Expect(job.Spec.BaseboardManagementRef).To(gomega.Equal(v1alpha1.BaseboardManagementRef{Name: "bmc-worker1", Namespace: "eksa-system"}))
+	g.Expect(job.Spec.Tasks).To(gomega.HaveLen(3))
+	g.Expect(*job.Spec.Tasks[0].PowerAction).To(gomega.Equal(v1alpha1.HardPowerOff))
+	g.Expect(job.Spec.Tasks[1].OneTimeBootDeviceAction.Devices).To(gomega.Equal([]v1alpha1.BootDevice{v1alpha1.PXE}))
+	g.Expect(*job.Spec.Tasks[2].PowerAction).To(gomega.Equal(v1alpha1.PowerOn))
+}
+
+func TestMarshalBMCJobs(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	now := time.Unix(1660000000, 0)
+	jobs := []*v1alpha1.BMCJob{
+		hardware.NewNetbootBMCJob(&v1alpha1.BaseboardManagement{ObjectMeta: metav1.ObjectMeta{Name: "bmc-worker1"}}, now),
+		hardware.NewNetbootBMCJob(&v1alpha1.BaseboardManagement{ObjectMeta: metav1.ObjectMeta{Name: "bmc-worker2"}}, now),
+	}
+
+	manifest, err := hardware.MarshalBMCJobs(jobs)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(string(manifest)).To(gomega.ContainSubstring("name: bmc-worker1-netboot-1660000000"))
+	g.Expect(string(manifest)).To(gomega.ContainSubstring("name: bmc-worker2-netboot-1660000000"))
+	g.Expect(string(manifest)).To(gomega.ContainSubstring("---"))
+}
@@ -16,29 +16,70 @@ import (
 type DiskExtractor struct {
 	selector map[string]eksav1alpha1.HardwareSelector
 	disks    map[string]string
+	labels   map[string]Labels
+	// conflicts holds, per selector, the label keys that are missing or have different values
+	// across the machines matching the selector.
+	conflicts map[string]map[string]struct{}
 }
 
 // NewDiskExtractor creates a DiskExtractor instance.
 func NewDiskExtractor() *DiskExtractor {
 	return &DiskExtractor{
-		selector: make(map[string]eksav1alpha1.HardwareSelector),
-		disks:    make(map[string]string),
+		selector:  make(map[string]eksav1alpha1.HardwareSelector),
+		disks:     make(map[string]string),
+		labels:    make(map[string]Labels),
+		conflicts: make(map[string]map[string]struct{}),
 	}
 }
 
-// Write matches m to registered hardware selectors and caches the disk for a given selector.
-// If subsequent Machine matches are made for a given selector, the disk from the first Machine
-// match is used.
+// Write matches m to registered hardware selectors and caches the disk and labels for a given
+// selector. The disk is taken from the first Machine match, all matches are expected to have the
+// same disk (see MatchingDisksForSelectors). Only the labels shared with the same value by all
+// matches are cached, the others are recorded as conflicting.
 func (d *DiskExtractor) Write(m Machine) error {
 	for key, selector := range d.selector {
-		if _, ok := d.disks[key]; !ok && LabelsMatchSelector(selector, m.Labels) {
+		if !LabelsMatchSelector(selector, m.Labels) {
+			continue
+		}
+
+		cached, ok := d.labels[key]
+		if !ok {
 			d.disks[key] = m.Disk
+			d.labels[key] = copyLabels(m.Labels)
+			continue
+		}
+
+		for label, value := range cached {
+			if other, ok := m.Labels[label]; !ok || other != value {
+				d.addConflict(key, label)
+				delete(cached, label)
+			}
+		}
+		for label := range m.Labels {
+			if _, ok := cached[label]; !ok {
+				d.addConflict(key, label)
+			}
 		}
 	}
 
 	return nil
 }
 
+func (d *DiskExtractor) addConflict(key, label string) {
+	if _, ok := d.conflicts[key]; !ok {
+		d.conflicts[key] = make(map[string]struct{})
+	}
+	d.conflicts[key][label] = struct{}{}
+}
+
+func copyLabels(labels Labels) Labels {
+	c := make(Labels, len(labels))
+	for k, v := range labels {
+		c[k] = v
+	}
+	return c
+}
+
 // Register registers selector with d such that a disk can be cached when
 // machines are written to Write().
 func (d *DiskExtractor) Register(selector eksav1alpha1.HardwareSelector) error {
@@ -69,6 +110,33 @@ func (d *DiskExtractor) GetDisk(selector eksav1alpha1.HardwareSelector) (string,
 	return d.disks[key], nil
 }
 
+// GetLabels returns the labels shared with the same value by all machines matching selector. If
+// selector has no disk cached ErrDiskNotFound is returned.
+func (d *DiskExtractor) GetLabels(selector eksav1alpha1.HardwareSelector) (Labels, error) {
+	key, err := serializeHardwareSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := d.disks[key]; !ok {
+		return nil, ErrDiskNotFound{key}
+	}
+
+	return d.labels[key], nil
+}
+
+// HasConflictingLabel returns true if the machines matching selector don't all have label with
+// the same value.
+func (d *DiskExtractor) HasConflictingLabel(selector eksav1alpha1.HardwareSelector, label string) (bool, error) {
+	key, err := serializeHardwareSelector(selector)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := d.conflicts[key][label]
+	return ok, nil
+}
+
 // serializeHardwareSelector returns a key for use in a map unique selector.
 func serializeHardwareSelector(selector eksav1alpha1.HardwareSelector) (string, error) {
 	return selector.ToString()
@@ -162,6 +162,49 @@ func TestDiskExtractor_MachineHasMultipleLabels(t *testing.T) {
 	disk, err := diskExtractor.GetDisk(hardwareSelector)
 	g.Expect(err).To(gomega.Succeed())
 	g.Expect(disk).To(gomega.Equal(machine.Disk))
+
+	labels, err := diskExtractor.GetLabels(hardwareSelector)
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(labels).To(gomega.BeEquivalentTo(machine.Labels))
+}
+
+func TestDiskExtractorGetLabelsOnlySharedLabels(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	diskExtractor := hardware.NewDiskExtractor()
+	hardwareSelector := eksav1alpha1.HardwareSelector{"type": "cp"}
+	g.Expect(diskExtractor.Register(hardwareSelector)).To(gomega.Succeed())
+
+	machine1 := NewValidMachine()
+	machine1.Labels = map[string]string{"type": "cp", "rack": "a", "gpu": "true"}
+	machine2 := NewValidMachine()
+	machine2.Labels = map[string]string{"type": "cp", "rack": "b", "zone": "1"}
+	g.Expect(diskExtractor.Write(machine1)).To(gomega.Succeed())
+	g.Expect(diskExtractor.Write(machine2)).To(gomega.Succeed())
+
+	labels, err := diskExtractor.GetLabels(hardwareSelector)
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(labels).To(gomega.BeEquivalentTo(map[string]string{"type": "cp"}))
+
+	for _, label := range []string{"rack", "gpu", "zone"} {
+		conflicting, err := diskExtractor.HasConflictingLabel(hardwareSelector, label)
+		g.Expect(err).To(gomega.Succeed())
+		g.Expect(conflicting).To(gomega.BeTrue(), label)
+	}
+	conflicting, err := diskExtractor.HasConflictingLabel(hardwareSelector, "type")
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(conflicting).To(gomega.BeFalse())
+}
+
+func TestDiskExtractorGetLabelsNoMachineFound(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	diskExtractor := hardware.NewDiskExtractor()
+	hardwareSelector := eksav1alpha1.HardwareSelector{"type": "cp"}
+	g.Expect(diskExtractor.Register(hardwareSelector)).To(gomega.Succeed())
+
+	_, err := diskExtractor.GetLabels(hardwareSelector)
+	g.Expect(err).To(gomega.MatchError(hardware.ErrDiskNotFound{}))
 }
 
 func TestDiskExtractor_MultipleSelectors(t *testing.T) {
@@ -87,3 +87,34 @@ func ensureRequiredColumnsInCSV(unmatched []string) error {
 
 	return nil
 }
+
+// CSVWriter is a MachineWriter that writes machines as CSV rows readable by CSVReader.
+type CSVWriter struct {
+	writer        io.Writer
+	headerWritten bool
+}
+
+var _ MachineWriter = &CSVWriter{}
+
+// NewCSVWriter creates a CSVWriter that writes to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{writer: w}
+}
+
+// Write writes m as a CSV row. The header is written before the first row.
+func (cw *CSVWriter) Write(m Machine) error {
+	csvWriter := csv.NewSafeCSVWriter(stdcsv.NewWriter(cw.writer))
+
+	var err error
+	if cw.headerWritten {
+		err = csv.MarshalCSVWithoutHeaders([]Machine{m}, csvWriter)
+	} else {
+		err = csv.MarshalCSV([]Machine{m}, csvWriter)
+	}
+	if err != nil {
+		return fmt.Errorf("writing csv (mac=%v): %v", m.MACAddress, err)
+	}
+
+	cw.headerWritten = true
+	return nil
+}
@@ -167,3 +167,27 @@ func (b *BufferedCSV) Write(record []string) error {
 	b.Flush()
 	return nil
 }
+
+func TestCSVWriterWritesReadableRows(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	first := NewValidMachine()
+	second := NewValidMachine()
+	second.Hostname = "worker2"
+
+	var buf bytes.Buffer
+	writer := hardware.NewCSVWriter(&buf)
+	g.Expect(writer.Write(first)).To(gomega.Succeed())
+	g.Expect(writer.Write(second)).To(gomega.Succeed())
+
+	reader, err := hardware.NewCSVReader(&buf)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.Equal(first))
+
+	machine, err = reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.Equal(second))
+}
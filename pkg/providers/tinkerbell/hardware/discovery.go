@@ -0,0 +1,277 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	csv "github.com/gocarina/gocsv"
+
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+)
+
+// IPPlanEntry is the network configuration planned for the machine managed by the BMC at
+// BMCIPAddress. MACAddress and Disk are optional and override the discovered values. MACAddress
+// is required when the machine has more than one ethernet interface.
+type IPPlanEntry struct {
+	BMCIPAddress string      `csv:"bmc_ip"`
+	Hostname     string      `csv:"hostname"`
+	IPAddress    string      `csv:"ip_address"`
+	Netmask      string      `csv:"netmask"`
+	Gateway      string      `csv:"gateway"`
+	Nameservers  Nameservers `csv:"nameservers"`
+	Labels       Labels      `csv:"labels"`
+	MACAddress   string      `csv:"mac,omitempty"`
+	Disk         string      `csv:"disk,omitempty"`
+	BMCUsername  string      `csv:"bmc_username,omitempty"`
+	BMCPassword  string      `csv:"bmc_password,omitempty"`
+}
+
+// IPPlan maps BMC IP addresses to their IPPlanEntry.
+type IPPlan map[string]IPPlanEntry
+
+// ParseIPPlanFromFile parses an IPPlan from the CSV file at path.
+func ParseIPPlanFromFile(path string) (IPPlan, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	return ParseIPPlan(fh)
+}
+
+// ParseIPPlan parses an IPPlan from CSV data in r. Each entry must specify a unique bmc_ip.
+func ParseIPPlan(r io.Reader) (IPPlan, error) {
+	var entries []IPPlanEntry
+	if err := csv.UnmarshalCSV(csv.LazyCSVReader(r), &entries); err != nil {
+		return nil, fmt.Errorf("parsing ip plan: %v", err)
+	}
+
+	plan := make(IPPlan, len(entries))
+	for _, entry := range entries {
+		if entry.BMCIPAddress == "" {
+			return nil, fmt.Errorf("ip plan entry for hostname %s is missing bmc_ip", entry.Hostname)
+		}
+		if _, seen := plan[entry.BMCIPAddress]; seen {
+			return nil, fmt.Errorf("duplicate bmc_ip in ip plan: %v", entry.BMCIPAddress)
+		}
+		plan[entry.BMCIPAddress] = entry
+	}
+
+	return plan, nil
+}
+
+// RedfishSystemDiscoverer retrieves the system managed by a BMC.
+type RedfishSystemDiscoverer interface {
+	// Probe returns true if a Redfish service responds at bmcIP.
+	Probe(ctx context.Context, bmcIP string) bool
+	Discover(ctx context.Context, bmcIP, username, password string) (*RedfishSystem, error)
+}
+
+const (
+	// maxDiscoveryRangeSize is the maximum number of IPs scanned for BMCs.
+	maxDiscoveryRangeSize = 4096
+	// discoveryProbeWorkers is the number of IPs probed concurrently.
+	discoveryProbeWorkers = 64
+)
+
+// RedfishMachineReader is a MachineReader that discovers machines through the Redfish API of
+// BMCs in a range of IPs and merges them with an IPPlan. Every IP in the range is probed and
+// BMCs responding without an IPPlan entry are reported as an error.
+type RedfishMachineReader struct {
+	ctx        context.Context
+	discoverer RedfishSystemDiscoverer
+	bmcRange   *networkutils.IPRange
+	plan       IPPlan
+	bmcIPs     []string
+	username   string
+	password   string
+	scanned    bool
+	next       int
+}
+
+// NewRedfishMachineReader creates a RedfishMachineReader for the BMCs in bmcRange. username and
+// password are used for BMCs whose IPPlan entry doesn't specify credentials.
+func NewRedfishMachineReader(
+	ctx context.Context,
+	discoverer RedfishSystemDiscoverer,
+	bmcRange *networkutils.IPRange,
+	plan IPPlan,
+	username, password string,
+) *RedfishMachineReader {
+	return &RedfishMachineReader{
+		ctx:        ctx,
+		discoverer: discoverer,
+		bmcRange:   bmcRange,
+		plan:       plan,
+		username:   username,
+		password:   password,
+	}
+}
+
+// scan probes every IP in the range and builds the list of BMCs to discover. It fails if a BMC
+// responds without an IPPlan entry so no machine is silently left out.
+func (r *RedfishMachineReader) scan() error {
+	ips, err := rangeIPs(r.bmcRange, maxDiscoveryRangeSize)
+	if err != nil {
+		return err
+	}
+
+	responding := make([]bool, len(ips))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < discoveryProbeWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				responding[i] = r.discoverer.Probe(r.ctx, ips[i].String())
+			}
+		}()
+	}
+	for i := range ips {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var unplanned []string
+	for i, ip := range ips {
+		bmcIP, planned := r.planEntryFor(ip)
+		switch {
+		case planned:
+			// Planned BMCs are always discovered so an unreachable one fails the read.
+			r.bmcIPs = append(r.bmcIPs, bmcIP)
+		case responding[i]:
+			unplanned = append(unplanned, bmcIP)
+		}
+	}
+
+	if len(unplanned) > 0 {
+		return fmt.Errorf("bmcs found without an ip plan entry: %s", strings.Join(unplanned, ", "))
+	}
+
+	return nil
+}
+
+// planEntryFor returns the IPPlan key for ip and whether it has an entry. Keys are compared as
+// IPs so equivalent representations match.
+func (r *RedfishMachineReader) planEntryFor(ip net.IP) (string, bool) {
+	for bmcIP := range r.plan {
+		if planIP := net.ParseIP(bmcIP); planIP != nil && planIP.Equal(ip) {
+			return bmcIP, true
+		}
+	}
+	return ip.String(), false
+}
+
+// rangeIPs returns all the IPs in ipRange. It fails if the range contains more than max IPs.
+func rangeIPs(ipRange *networkutils.IPRange, max int) ([]net.IP, error) {
+	start := new(big.Int).SetBytes(ipRange.Start.To16())
+	end := new(big.Int).SetBytes(ipRange.End.To16())
+	size := new(big.Int).Sub(end, start)
+	size.Add(size, big.NewInt(1))
+	if size.Cmp(big.NewInt(int64(max))) > 0 {
+		return nil, fmt.Errorf("bmc range %s-%s has more than %d ips", ipRange.Start, ipRange.End, max)
+	}
+
+	ips := make([]net.IP, 0, size.Int64())
+	for current := start; current.Cmp(end) <= 0; current = new(big.Int).Add(current, big.NewInt(1)) {
+		ip := make(net.IP, net.IPv6len)
+		current.FillBytes(ip)
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// Read discovers the next BMC and returns its Machine. When all BMCs have been read, it returns io.EOF.
+func (r *RedfishMachineReader) Read() (Machine, error) {
+	if !r.scanned {
+		r.scanned = true
+		if err := r.scan(); err != nil {
+			return Machine{}, err
+		}
+	}
+
+	if r.next >= len(r.bmcIPs) {
+		return Machine{}, io.EOF
+	}
+	bmcIP := r.bmcIPs[r.next]
+	r.next++
+
+	entry := r.plan[bmcIP]
+
+	username, password := entry.BMCUsername, entry.BMCPassword
+	if username == "" {
+		username, password = r.username, r.password
+	}
+
+	system, err := r.discoverer.Discover(r.ctx, bmcIP, username, password)
+	if err != nil {
+		return Machine{}, fmt.Errorf("discovering bmc %s: %v", bmcIP, err)
+	}
+
+	m := Machine{
+		Hostname:     entry.Hostname,
+		IPAddress:    entry.IPAddress,
+		Netmask:      entry.Netmask,
+		Gateway:      entry.Gateway,
+		Nameservers:  entry.Nameservers,
+		Labels:       entry.Labels,
+		MACAddress:   strings.ToLower(entry.MACAddress),
+		Disk:         entry.Disk,
+		BMCIPAddress: bmcIP,
+		BMCUsername:  username,
+		BMCPassword:  password,
+	}
+
+	if m.Hostname == "" {
+		m.Hostname = strings.ToLower(system.HostName)
+	}
+
+	if m.Disk == "" {
+		if system.Disk == "" {
+			return Machine{}, fmt.Errorf(
+				"bmc %s reported %d drives, the disk must be set in the ip plan as its device path can't be discovered",
+				bmcIP,
+				system.Drives,
+			)
+		}
+		m.Disk = system.Disk
+	}
+
+	if len(system.MACAddresses) == 0 {
+		return Machine{}, fmt.Errorf("bmc %s didn't report any ethernet interfaces", bmcIP)
+	}
+
+	switch {
+	case m.MACAddress == "" && len(system.MACAddresses) > 1:
+		return Machine{}, fmt.Errorf(
+			"bmc %s reported %d ethernet interfaces (%s), the mac to provision must be set in the ip plan",
+			bmcIP,
+			len(system.MACAddresses),
+			strings.Join(system.MACAddresses, ", "),
+		)
+	case m.MACAddress == "":
+		m.MACAddress = system.MACAddresses[0]
+	case !containsString(system.MACAddresses, m.MACAddress):
+		return Machine{}, fmt.Errorf("ip plan mac %s not found on the system managed by bmc %s", m.MACAddress, bmcIP)
+	}
+
+	return m, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package hardware_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const ipPlanHeader = "bmc_ip,hostname,ip_address,netmask,gateway,nameservers,labels,mac,disk\n"
+
+func TestParseIPPlan(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		"127.0.0.1,worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1|8.8.8.8,type=cp,,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(plan).To(gomega.HaveKeyWithValue("127.0.0.1", hardware.IPPlanEntry{
+		BMCIPAddress: "127.0.0.1",
+		Hostname:     "worker1",
+		IPAddress:    "10.10.10.10",
+		Netmask:      "255.255.255.0",
+		Gateway:      "10.10.10.1",
+		Nameservers:  hardware.Nameservers{"1.1.1.1", "8.8.8.8"},
+		Labels:       hardware.Labels{"type": "cp"},
+	}))
+}
+
+func TestParseIPPlanDuplicateBMC(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	_, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		"127.0.0.1,worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,,\n" +
+		"127.0.0.1,worker2,10.10.10.11,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,,\n",
+	))
+	g.Expect(err).To(gomega.MatchError("duplicate bmc_ip in ip plan: 127.0.0.1"))
+}
+
+func TestRedfishMachineReaderMergesIPPlan(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		host + ",,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,AA:BB:CC:DD:EE:02,\n" +
+		"192.168.0.10,outside,10.10.10.11,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	bmcRange, err := networkutils.ParseCIDRRange(host + "/32")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, plan, "admin", "password")
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.Equal(hardware.Machine{
+		Hostname:     "worker1",
+		IPAddress:    "10.10.10.10",
+		Netmask:      "255.255.255.0",
+		Gateway:      "10.10.10.1",
+		Nameservers:  hardware.Nameservers{"1.1.1.1"},
+		MACAddress:   "aa:bb:cc:dd:ee:02",
+		Disk:         "/dev/sda",
+		Labels:       hardware.Labels{"type": "cp"},
+		BMCIPAddress: host,
+		BMCUsername:  "admin",
+		BMCPassword:  "password",
+	}))
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.Equal(io.EOF))
+}
+
+func TestRedfishMachineReaderUnknownPlanMAC(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		host + ",worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,00:00:00:00:00:01,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	bmcRange, err := networkutils.ParseIPRange(host + "-" + host)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, plan, "admin", "password")
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("ip plan mac 00:00:00:00:00:01 not found")))
+}
+
+func TestRedfishMachineReaderMultipleMACsRequirePlanMAC(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		host + ",worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	bmcRange, err := networkutils.ParseIPRange(host + "-" + host)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, plan, "admin", "password")
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(
+		"reported 2 ethernet interfaces (aa:bb:cc:dd:ee:01, aa:bb:cc:dd:ee:02), the mac to provision must be set in the ip plan",
+	)))
+}
+
+func TestRedfishMachineReaderOutputReadableByParsers(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		host + ",worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,aa:bb:cc:dd:ee:01,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	bmcRange, err := networkutils.ParseCIDRRange(host + "/32")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	var yamlBuffer, csvBuffer bytes.Buffer
+	writer := hardware.MultiMachineWriter(
+		hardware.NewTinkerbellManifestYAML(&yamlBuffer),
+		hardware.NewCSVWriter(&csvBuffer),
+	)
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, plan, "admin", "password")
+	g.Expect(hardware.TranslateAll(reader, writer, hardware.NewDefaultMachineValidator())).To(gomega.Succeed())
+
+	catalogue := hardware.NewCatalogue()
+	g.Expect(hardware.ParseYAMLCatalogue(catalogue, &yamlBuffer)).To(gomega.Succeed())
+	g.Expect(catalogue.TotalHardware()).To(gomega.Equal(1))
+	g.Expect(catalogue.TotalBMCs()).To(gomega.Equal(1))
+
+	csvReader, err := hardware.NewCSVReader(&csvBuffer)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	machine, err := csvReader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine.MACAddress).To(gomega.Equal("aa:bb:cc:dd:ee:01"))
+	g.Expect(machine.BMCIPAddress).To(gomega.Equal(host))
+}
+
+func TestRedfishMachineReaderUnplannedBMC(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		"192.168.0.10,outside,10.10.10.11,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	bmcRange, err := networkutils.ParseCIDRRange(host + "/32")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, plan, "admin", "password")
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.MatchError("bmcs found without an ip plan entry: " + host))
+}
+
+func TestRedfishMachineReaderRangeTooLarge(t *testing.T) {
+	g := gomega.NewWithT(t)
+	discoverer := hardware.NewRedfishDiscoverer()
+
+	bmcRange, err := networkutils.ParseCIDRRange("10.0.0.0/16")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, hardware.IPPlan{}, "admin", "password")
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("has more than 4096 ips")))
+}
+
+func TestRedfishMachineReaderUndiscoverableDisk(t *testing.T) {
+	g := gomega.NewWithT(t)
+	resources := defaultRedfishResources("SATA")
+	resources["/redfish/v1/Systems/1/Storage"] = redfishCollection()
+	server := newRedfishMockServer(t, "admin", "password", resources)
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	plan, err := hardware.ParseIPPlan(strings.NewReader(ipPlanHeader +
+		host + ",worker1,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,,\n",
+	))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	bmcRange, err := networkutils.ParseCIDRRange(host + "/32")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	reader := hardware.NewRedfishMachineReader(context.Background(), discoverer, bmcRange, plan, "admin", "password")
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("the disk must be set in the ip plan")))
+}
@@ -0,0 +1,282 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// OwnerNameLabel is the label applied by CAPT to Hardware it has acquired for provisioning.
+const OwnerNameLabel = "v1alpha1.tinkerbell.org/ownerName"
+
+// MachineStatus describes the state of a machine in the inventory.
+type MachineStatus string
+
+const (
+	// MachineProvisioned is a machine that has been acquired by a cluster.
+	MachineProvisioned MachineStatus = "Provisioned"
+	// MachineFree is a machine available for provisioning.
+	MachineFree MachineStatus = "Free"
+	// MachineError is a machine that is in an error state or whose BMC can't be contacted.
+	MachineError MachineStatus = "Error"
+)
+
+// InventoryClient is a Kubernetes client used to manage the Tinkerbell hardware inventory in a cluster.
+type InventoryClient interface {
+	GetAllTinkerbellHardware(ctx context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error)
+	GetAllBaseboardManagements(ctx context.Context, kubeconfig, namespace string) ([]rufiov1alpha1.BaseboardManagement, error)
+	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteTinkerbellHardware(ctx context.Context, cluster *types.Cluster, name, namespace string) error
+	DeleteBaseboardManagement(ctx context.Context, cluster *types.Cluster, name, namespace string) error
+	DeleteSecret(ctx context.Context, cluster *types.Cluster, name, namespace string) error
+	UpdateTinkerbellHardwareLabels(ctx context.Context, cluster *types.Cluster, name, namespace string, labels map[string]string) error
+}
+
+// InventoryEntry is a Hardware registered in a cluster along with its optional BaseboardManagement.
+type InventoryEntry struct {
+	Hardware *tinkv1alpha1.Hardware
+	BMC      *rufiov1alpha1.BaseboardManagement
+	Status   MachineStatus
+}
+
+// Machine converts e to a Machine. BMC credentials aren't populated.
+func (e InventoryEntry) Machine() Machine {
+	return MachineFromHardware(e.Hardware, e.BMC)
+}
+
+// Inventory manages the Tinkerbell Hardware, BaseboardManagement and Secret objects in a cluster.
+type Inventory struct {
+	client    InventoryClient
+	cluster   *types.Cluster
+	namespace string
+}
+
+// NewInventory creates a new Inventory instance for the hardware registered in cluster.
+func NewInventory(client InventoryClient, cluster *types.Cluster) *Inventory {
+	return &Inventory{
+		client:    client,
+		cluster:   cluster,
+		namespace: constants.EksaSystemNamespace,
+	}
+}
+
+// Catalogue builds a Catalogue with all the Hardware and BaseboardManagement objects in the cluster.
+func (i *Inventory) Catalogue(ctx context.Context) (*Catalogue, error) {
+	hardware, err := i.client.GetAllTinkerbellHardware(ctx, i.cluster.KubeconfigFile, i.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	bmcs, err := i.client.GetAllBaseboardManagements(ctx, i.cluster.KubeconfigFile, i.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue := NewCatalogue(WithBMCNameIndex())
+	for idx := range hardware {
+		if err := catalogue.InsertHardware(&hardware[idx]); err != nil {
+			return nil, err
+		}
+	}
+	for idx := range bmcs {
+		if err := catalogue.InsertBMC(&bmcs[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return catalogue, nil
+}
+
+// List returns all the machines in the cluster along with their status.
+func (i *Inventory) List(ctx context.Context) ([]InventoryEntry, error) {
+	catalogue, err := i.Catalogue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]InventoryEntry, 0, catalogue.TotalHardware())
+	for _, hw := range catalogue.AllHardware() {
+		entry := InventoryEntry{Hardware: hw}
+
+		if hw.Spec.BMCRef != nil {
+			bmcs, err := catalogue.LookupBMC(BMCNameIndex, hw.Spec.BMCRef.Name)
+			if err != nil {
+				return nil, err
+			}
+			if len(bmcs) > 0 {
+				entry.BMC = bmcs[0]
+			}
+		}
+
+		entry.Status = machineStatus(entry.Hardware, entry.BMC)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Add reads all machines from reader, validates them against the machines already registered
+// in the cluster and applies the resulting Hardware, BaseboardManagement and Secret objects.
+// It returns the number of machines added.
+func (i *Inventory) Add(ctx context.Context, reader MachineReader) (int, error) {
+	entries, err := i.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	existing := make([]Machine, 0, len(entries))
+	for _, entry := range entries {
+		existing = append(existing, entry.Machine())
+	}
+
+	catalogue := NewCatalogue()
+	validator := NewDefaultMachineValidatorWithExisting(existing)
+	if err := TranslateAll(reader, NewMachineCatalogueWriter(catalogue), validator); err != nil {
+		return 0, err
+	}
+
+	if catalogue.TotalHardware() == 0 {
+		return 0, nil
+	}
+
+	manifest, err := MarshalCatalogue(catalogue)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := i.client.ApplyKubeSpecFromBytesForce(ctx, i.cluster, manifest); err != nil {
+		return 0, fmt.Errorf("applying hardware: %v", err)
+	}
+
+	return catalogue.TotalHardware(), nil
+}
+
+// Remove deletes the Hardware named hostname along with its BaseboardManagement and Secret.
+// Hardware that has been provisioned can't be removed.
+func (i *Inventory) Remove(ctx context.Context, hostname string) error {
+	entry, err := i.lookup(ctx, hostname)
+	if err != nil {
+		return err
+	}
+
+	if entry.Status == MachineProvisioned {
+		return fmt.Errorf("hardware %s is provisioned by %s and can't be removed", hostname, entry.Hardware.Labels[OwnerNameLabel])
+	}
+
+	if err := i.client.DeleteTinkerbellHardware(ctx, i.cluster, entry.Hardware.Name, i.namespace); err != nil {
+		return err
+	}
+
+	if entry.BMC == nil {
+		return nil
+	}
+
+	if err := i.client.DeleteBaseboardManagement(ctx, i.cluster, entry.BMC.Name, i.namespace); err != nil {
+		return err
+	}
+
+	secret := entry.BMC.Spec.Connection.AuthSecretRef
+	if secret.Name == "" {
+		return nil
+	}
+
+	namespace := secret.Namespace
+	if namespace == "" {
+		namespace = i.namespace
+	}
+
+	return i.client.DeleteSecret(ctx, i.cluster, secret.Name, namespace)
+}
+
+// Label applies labels to the Hardware named hostname, overwriting existing values for the same keys.
+func (i *Inventory) Label(ctx context.Context, hostname string, labels map[string]string) error {
+	for key, value := range labels {
+		if err := validateLabelKey(key); err != nil {
+			return fmt.Errorf("invalid label key %s: %v", key, err)
+		}
+		if err := validateLabelValue(value); err != nil {
+			return fmt.Errorf("invalid label value %s: %v", value, err)
+		}
+	}
+
+	entry, err := i.lookup(ctx, hostname)
+	if err != nil {
+		return err
+	}
+
+	return i.client.UpdateTinkerbellHardwareLabels(ctx, i.cluster, entry.Hardware.Name, i.namespace, labels)
+}
+
+func (i *Inventory) lookup(ctx context.Context, hostname string) (InventoryEntry, error) {
+	entries, err := i.List(ctx)
+	if err != nil {
+		return InventoryEntry{}, err
+	}
+
+	for _, entry := range entries {
+		if entry.Hardware.Name == hostname {
+			return entry, nil
+		}
+	}
+
+	return InventoryEntry{}, fmt.Errorf("hardware %s not found", hostname)
+}
+
+func machineStatus(hw *tinkv1alpha1.Hardware, bmc *rufiov1alpha1.BaseboardManagement) MachineStatus {
+	if _, ok := hw.Labels[OwnerNameLabel]; ok {
+		return MachineProvisioned
+	}
+
+	if hw.Status.State == tinkv1alpha1.HardwareError {
+		return MachineError
+	}
+
+	if bmc != nil {
+		for _, condition := range bmc.Status.Conditions {
+			if condition.Type == rufiov1alpha1.Contactable && condition.Status == rufiov1alpha1.ConditionFalse {
+				return MachineError
+			}
+		}
+	}
+
+	return MachineFree
+}
+
+// MachineFromHardware converts hw and its optional bmc to a Machine. BMC credentials are stored
+// in a Secret so they aren't populated.
+func MachineFromHardware(hw *tinkv1alpha1.Hardware, bmc *rufiov1alpha1.BaseboardManagement) Machine {
+	m := Machine{
+		Hostname: hw.Name,
+		Labels:   Labels{},
+	}
+
+	for k, v := range hw.Labels {
+		m.Labels[k] = v
+	}
+
+	if len(hw.Spec.Disks) > 0 {
+		m.Disk = hw.Spec.Disks[0].Device
+	}
+
+	if len(hw.Spec.Interfaces) > 0 && hw.Spec.Interfaces[0].DHCP != nil {
+		dhcp := hw.Spec.Interfaces[0].DHCP
+		m.MACAddress = dhcp.MAC
+		m.Nameservers = Nameservers(dhcp.NameServers)
+		if dhcp.IP != nil {
+			m.IPAddress = dhcp.IP.Address
+			m.Netmask = dhcp.IP.Netmask
+			m.Gateway = dhcp.IP.Gateway
+		}
+	}
+
+	if bmc != nil {
+		m.BMCIPAddress = bmc.Spec.Connection.Host
+	}
+
+	return m
+}
@@ -0,0 +1,179 @@
+package hardware_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type inventoryTest struct {
+	*gomega.WithT
+	ctx       context.Context
+	client    *mocks.MockInventoryClient
+	cluster   *types.Cluster
+	inventory *hardware.Inventory
+	hardware  []tinkv1alpha1.Hardware
+	bmcs      []rufiov1alpha1.BaseboardManagement
+}
+
+func newInventoryTest(t *testing.T, machines ...hardware.Machine) *inventoryTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockInventoryClient(ctrl)
+	cluster := &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"}
+
+	g := gomega.NewWithT(t)
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewMachineCatalogueWriter(catalogue)
+	for _, m := range machines {
+		g.Expect(writer.Write(m)).To(gomega.Succeed())
+	}
+
+	tt := &inventoryTest{
+		WithT:     g,
+		ctx:       context.Background(),
+		client:    client,
+		cluster:   cluster,
+		inventory: hardware.NewInventory(client, cluster),
+	}
+	for _, hw := range catalogue.AllHardware() {
+		tt.hardware = append(tt.hardware, *hw)
+	}
+	for _, bmc := range catalogue.AllBMCs() {
+		tt.bmcs = append(tt.bmcs, *bmc)
+	}
+
+	return tt
+}
+
+func (tt *inventoryTest) expectGetInventory() {
+	tt.client.EXPECT().GetAllTinkerbellHardware(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(tt.hardware, nil)
+	tt.client.EXPECT().GetAllBaseboardManagements(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(tt.bmcs, nil)
+}
+
+func TestInventoryListStatus(t *testing.T) {
+	free := NewValidMachine()
+	free.Hostname = "free"
+
+	provisioned := NewValidMachine()
+	provisioned.Hostname = "provisioned"
+	provisioned.IPAddress = "10.10.10.20"
+
+	errored := NewValidMachine()
+	errored.Hostname = "errored"
+	errored.IPAddress = "10.10.10.30"
+
+	tt := newInventoryTest(t, free, provisioned, errored)
+	tt.hardware[1].Labels[hardware.OwnerNameLabel] = "machine-1"
+	tt.bmcs[2].Status.Conditions = []rufiov1alpha1.BaseboardManagementCondition{
+		{Type: rufiov1alpha1.Contactable, Status: rufiov1alpha1.ConditionFalse},
+	}
+	tt.expectGetInventory()
+
+	entries, err := tt.inventory.List(tt.ctx)
+	tt.Expect(err).To(gomega.Succeed())
+	tt.Expect(entries).To(gomega.HaveLen(3))
+	tt.Expect(entries[0].Status).To(gomega.Equal(hardware.MachineFree))
+	tt.Expect(entries[1].Status).To(gomega.Equal(hardware.MachineProvisioned))
+	tt.Expect(entries[2].Status).To(gomega.Equal(hardware.MachineError))
+	tt.Expect(entries[0].Machine().IPAddress).To(gomega.Equal(free.IPAddress))
+	tt.Expect(entries[0].Machine().BMCIPAddress).To(gomega.Equal(free.BMCIPAddress))
+}
+
+func TestInventoryListHardwareInErrorState(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	tt.hardware[0].Status.State = tinkv1alpha1.HardwareError
+	tt.expectGetInventory()
+
+	entries, err := tt.inventory.List(tt.ctx)
+	tt.Expect(err).To(gomega.Succeed())
+	tt.Expect(entries[0].Status).To(gomega.Equal(hardware.MachineError))
+}
+
+func TestInventoryAddSuccess(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	tt.expectGetInventory()
+	tt.client.EXPECT().ApplyKubeSpecFromBytesForce(tt.ctx, tt.cluster, gomock.Any()).Return(nil)
+
+	reader, err := hardware.NewCSVReader(strings.NewReader(
+		"hostname,bmc_ip,bmc_username,bmc_password,mac,ip_address,netmask,gateway,nameservers,labels,disk\n" +
+			"worker1,192.168.0.10,Admin,admin,00:00:00:00:00:01,10.10.10.20,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,/dev/sda\n",
+	))
+	tt.Expect(err).To(gomega.Succeed())
+
+	added, err := tt.inventory.Add(tt.ctx, reader)
+	tt.Expect(err).To(gomega.Succeed())
+	tt.Expect(added).To(gomega.Equal(1))
+}
+
+func TestInventoryAddDuplicateOfExisting(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	tt.expectGetInventory()
+
+	reader, err := hardware.NewCSVReader(strings.NewReader(
+		"hostname,bmc_ip,bmc_username,bmc_password,mac,ip_address,netmask,gateway,nameservers,labels,disk\n" +
+			"worker1,192.168.0.10,Admin,admin,00:00:00:00:00:01,10.10.10.10,255.255.255.0,10.10.10.1,1.1.1.1,type=cp,/dev/sda\n",
+	))
+	tt.Expect(err).To(gomega.Succeed())
+
+	_, err = tt.inventory.Add(tt.ctx, reader)
+	tt.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("duplicate IPAddress: 10.10.10.10")))
+}
+
+func TestInventoryRemoveSuccess(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	tt.expectGetInventory()
+	tt.client.EXPECT().DeleteTinkerbellHardware(tt.ctx, tt.cluster, "localhost", constants.EksaSystemNamespace).Return(nil)
+	tt.client.EXPECT().DeleteBaseboardManagement(tt.ctx, tt.cluster, "bmc-localhost", constants.EksaSystemNamespace).Return(nil)
+	tt.client.EXPECT().DeleteSecret(tt.ctx, tt.cluster, "bmc-localhost-auth", constants.EksaSystemNamespace).Return(nil)
+
+	tt.Expect(tt.inventory.Remove(tt.ctx, "localhost")).To(gomega.Succeed())
+}
+
+func TestInventoryRemoveProvisioned(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	tt.hardware[0].Labels[hardware.OwnerNameLabel] = "machine-1"
+	tt.expectGetInventory()
+
+	tt.Expect(tt.inventory.Remove(tt.ctx, "localhost")).To(gomega.MatchError(gomega.ContainSubstring("is provisioned by machine-1")))
+}
+
+func TestInventoryRemoveNotFound(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	tt.expectGetInventory()
+
+	tt.Expect(tt.inventory.Remove(tt.ctx, "other")).To(gomega.MatchError("hardware other not found"))
+}
+
+func TestInventoryLabelSuccess(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+	labels := map[string]string{"type": "worker"}
+	tt.expectGetInventory()
+	tt.client.EXPECT().UpdateTinkerbellHardwareLabels(tt.ctx, tt.cluster, "localhost", constants.EksaSystemNamespace, labels).Return(nil)
+
+	tt.Expect(tt.inventory.Label(tt.ctx, "localhost", labels)).To(gomega.Succeed())
+}
+
+func TestInventoryLabelInvalidKey(t *testing.T) {
+	tt := newInventoryTest(t, NewValidMachine())
+
+	tt.Expect(tt.inventory.Label(tt.ctx, "localhost", map[string]string{"in valid": "worker"})).To(gomega.MatchError(gomega.ContainSubstring("invalid label key")))
+}
+
+func TestInventoryGetHardwareError(t *testing.T) {
+	tt := newInventoryTest(t)
+	tt.client.EXPECT().GetAllTinkerbellHardware(tt.ctx, tt.cluster.KubeconfigFile, constants.EksaSystemNamespace).Return(nil, errors.New("error getting hardware"))
+
+	_, err := tt.inventory.List(tt.ctx)
+	tt.Expect(err).To(gomega.MatchError("error getting hardware"))
+}
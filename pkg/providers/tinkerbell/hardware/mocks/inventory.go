@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/providers/tinkerbell/hardware/inventory.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/eks-anywhere/pkg/types"
+	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
+	v1alpha10 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+)
+
+// MockInventoryClient is a mock of InventoryClient interface.
+type MockInventoryClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryClientMockRecorder
+}
+
+// MockInventoryClientMockRecorder is the mock recorder for MockInventoryClient.
+type MockInventoryClientMockRecorder struct {
+	mock *MockInventoryClient
+}
+
+// NewMockInventoryClient creates a new mock instance.
+func NewMockInventoryClient(ctrl *gomock.Controller) *MockInventoryClient {
+	mock := &MockInventoryClient{ctrl: ctrl}
+	mock.recorder = &MockInventoryClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryClient) EXPECT() *MockInventoryClientMockRecorder {
+	return m.recorder
+}
+
+// ApplyKubeSpecFromBytesForce mocks base method.
+func (m *MockInventoryClient) ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytesForce", ctx, cluster, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytesForce indicates an expected call of ApplyKubeSpecFromBytesForce.
+func (mr *MockInventoryClientMockRecorder) ApplyKubeSpecFromBytesForce(ctx, cluster, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytesForce", reflect.TypeOf((*MockInventoryClient)(nil).ApplyKubeSpecFromBytesForce), ctx, cluster, data)
+}
+
+// DeleteBaseboardManagement mocks base method.
+func (m *MockInventoryClient) DeleteBaseboardManagement(ctx context.Context, cluster *types.Cluster, name, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBaseboardManagement", ctx, cluster, name, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBaseboardManagement indicates an expected call of DeleteBaseboardManagement.
+func (mr *MockInventoryClientMockRecorder) DeleteBaseboardManagement(ctx, cluster, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBaseboardManagement", reflect.TypeOf((*MockInventoryClient)(nil).DeleteBaseboardManagement), ctx, cluster, name, namespace)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockInventoryClient) DeleteSecret(ctx context.Context, cluster *types.Cluster, name, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, cluster, name, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockInventoryClientMockRecorder) DeleteSecret(ctx, cluster, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockInventoryClient)(nil).DeleteSecret), ctx, cluster, name, namespace)
+}
+
+// DeleteTinkerbellHardware mocks base method.
+func (m *MockInventoryClient) DeleteTinkerbellHardware(ctx context.Context, cluster *types.Cluster, name, namespace string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTinkerbellHardware", ctx, cluster, name, namespace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTinkerbellHardware indicates an expected call of DeleteTinkerbellHardware.
+func (mr *MockInventoryClientMockRecorder) DeleteTinkerbellHardware(ctx, cluster, name, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTinkerbellHardware", reflect.TypeOf((*MockInventoryClient)(nil).DeleteTinkerbellHardware), ctx, cluster, name, namespace)
+}
+
+// GetAllBaseboardManagements mocks base method.
+func (m *MockInventoryClient) GetAllBaseboardManagements(ctx context.Context, kubeconfig, namespace string) ([]v1alpha1.BaseboardManagement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllBaseboardManagements", ctx, kubeconfig, namespace)
+	ret0, _ := ret[0].([]v1alpha1.BaseboardManagement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllBaseboardManagements indicates an expected call of GetAllBaseboardManagements.
+func (mr *MockInventoryClientMockRecorder) GetAllBaseboardManagements(ctx, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBaseboardManagements", reflect.TypeOf((*MockInventoryClient)(nil).GetAllBaseboardManagements), ctx, kubeconfig, namespace)
+}
+
+// GetAllTinkerbellHardware mocks base method.
+func (m *MockInventoryClient) GetAllTinkerbellHardware(ctx context.Context, kubeconfig, namespace string) ([]v1alpha10.Hardware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTinkerbellHardware", ctx, kubeconfig, namespace)
+	ret0, _ := ret[0].([]v1alpha10.Hardware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTinkerbellHardware indicates an expected call of GetAllTinkerbellHardware.
+func (mr *MockInventoryClientMockRecorder) GetAllTinkerbellHardware(ctx, kubeconfig, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTinkerbellHardware", reflect.TypeOf((*MockInventoryClient)(nil).GetAllTinkerbellHardware), ctx, kubeconfig, namespace)
+}
+
+// UpdateTinkerbellHardwareLabels mocks base method.
+func (m *MockInventoryClient) UpdateTinkerbellHardwareLabels(ctx context.Context, cluster *types.Cluster, name, namespace string, labels map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTinkerbellHardwareLabels", ctx, cluster, name, namespace, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTinkerbellHardwareLabels indicates an expected call of UpdateTinkerbellHardwareLabels.
+func (mr *MockInventoryClientMockRecorder) UpdateTinkerbellHardwareLabels(ctx, cluster, name, namespace, labels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTinkerbellHardwareLabels", reflect.TypeOf((*MockInventoryClient)(nil).UpdateTinkerbellHardwareLabels), ctx, cluster, name, namespace, labels)
+}
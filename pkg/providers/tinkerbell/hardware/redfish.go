@@ -0,0 +1,280 @@
+package hardware
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	redfishServiceRootPath = "/redfish/v1/"
+	redfishSystemsPath     = "/redfish/v1/Systems"
+	redfishDefaultTimeout  = 30 * time.Second
+	redfishProbeTimeout    = 5 * time.Second
+	redfishDefaultPort     = 443
+	redfishNVMeProtocol    = "NVMe"
+	nvmeDisk               = "/dev/nvme0n1"
+	scsiDisk               = "/dev/sda"
+	redfishContentTypeJSON = "application/json"
+)
+
+// RedfishSystem is the subset of a Redfish ComputerSystem used to build a Machine.
+type RedfishSystem struct {
+	HostName     string
+	Manufacturer string
+	Model        string
+	SerialNumber string
+	// MACAddresses of the system's ethernet interfaces in the order reported by the BMC.
+	MACAddresses []string
+	// Drives is the number of drives reported across all the system's storage controllers.
+	Drives int
+	// Disk is the linux device path of the system's drive. It's only set when the system has a
+	// single drive as Redfish doesn't expose device paths and the kernel enumeration order of
+	// multiple drives can't be derived from it.
+	Disk string
+}
+
+// RedfishDiscoverer retrieves system information from BMCs through their Redfish API.
+type RedfishDiscoverer struct {
+	client *http.Client
+	port   int
+}
+
+// RedfishDiscovererOpt configures a RedfishDiscoverer.
+type RedfishDiscovererOpt func(*RedfishDiscoverer)
+
+// WithRedfishHTTPClient configures the RedfishDiscoverer to use client for all requests.
+func WithRedfishHTTPClient(client *http.Client) RedfishDiscovererOpt {
+	return func(d *RedfishDiscoverer) {
+		d.client = client
+	}
+}
+
+// WithRedfishPort configures the port the RedfishDiscoverer connects to on each BMC.
+func WithRedfishPort(port int) RedfishDiscovererOpt {
+	return func(d *RedfishDiscoverer) {
+		d.port = port
+	}
+}
+
+// NewRedfishDiscoverer creates a new RedfishDiscoverer. By default, BMC certificates aren't verified
+// as they are usually self-signed.
+func NewRedfishDiscoverer(opts ...RedfishDiscovererOpt) *RedfishDiscoverer {
+	d := &RedfishDiscoverer{
+		client: &http.Client{
+			Timeout: redfishDefaultTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+		port: redfishDefaultPort,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+type redfishLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+type redfishCollection struct {
+	Members []redfishLink `json:"Members"`
+}
+
+type redfishComputerSystem struct {
+	HostName           string      `json:"HostName"`
+	Manufacturer       string      `json:"Manufacturer"`
+	Model              string      `json:"Model"`
+	SerialNumber       string      `json:"SerialNumber"`
+	EthernetInterfaces redfishLink `json:"EthernetInterfaces"`
+	Storage            redfishLink `json:"Storage"`
+}
+
+type redfishEthernetInterface struct {
+	MACAddress          string `json:"MACAddress"`
+	PermanentMACAddress string `json:"PermanentMACAddress"`
+}
+
+type redfishStorage struct {
+	Drives []redfishLink `json:"Drives"`
+}
+
+type redfishDrive struct {
+	Protocol string `json:"Protocol"`
+}
+
+// Discover retrieves the first system managed by the BMC at bmcIP.
+func (d *RedfishDiscoverer) Discover(ctx context.Context, bmcIP, username, password string) (*RedfishSystem, error) {
+	address := net.JoinHostPort(bmcIP, strconv.Itoa(d.port))
+	session := redfishSession{
+		client:   d.client,
+		baseURL:  fmt.Sprintf("https://%s", address),
+		username: username,
+		password: password,
+	}
+
+	systems := &redfishCollection{}
+	if err := session.get(ctx, redfishSystemsPath, systems); err != nil {
+		return nil, err
+	}
+	if len(systems.Members) == 0 {
+		return nil, fmt.Errorf("bmc %s doesn't manage any systems", address)
+	}
+
+	system := &redfishComputerSystem{}
+	if err := session.get(ctx, systems.Members[0].ODataID, system); err != nil {
+		return nil, err
+	}
+
+	macs, err := session.macAddresses(ctx, system.EthernetInterfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	drives, err := session.drives(ctx, system.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	var disk string
+	if len(drives) == 1 {
+		disk = scsiDisk
+		if drives[0].Protocol == redfishNVMeProtocol {
+			disk = nvmeDisk
+		}
+	}
+
+	return &RedfishSystem{
+		HostName:     system.HostName,
+		Manufacturer: system.Manufacturer,
+		Model:        system.Model,
+		SerialNumber: system.SerialNumber,
+		MACAddresses: macs,
+		Drives:       len(drives),
+		Disk:         disk,
+	}, nil
+}
+
+// Probe returns true if a Redfish service responds at the BMC at bmcIP. The Redfish service root
+// doesn't require authentication so no credentials are needed.
+func (d *RedfishDiscoverer) Probe(ctx context.Context, bmcIP string) bool {
+	ctx, cancel := context.WithTimeout(ctx, redfishProbeTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("https://%s%s", net.JoinHostPort(bmcIP, strconv.Itoa(d.port)), redfishServiceRootPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", redfishContentTypeJSON)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+type redfishSession struct {
+	client   *http.Client
+	baseURL  string
+	username string
+	password string
+}
+
+func (s redfishSession) macAddresses(ctx context.Context, link redfishLink) ([]string, error) {
+	if link.ODataID == "" {
+		return nil, nil
+	}
+
+	interfaces := &redfishCollection{}
+	if err := s.get(ctx, link.ODataID, interfaces); err != nil {
+		return nil, err
+	}
+
+	macs := make([]string, 0, len(interfaces.Members))
+	for _, member := range interfaces.Members {
+		nic := &redfishEthernetInterface{}
+		if err := s.get(ctx, member.ODataID, nic); err != nil {
+			return nil, err
+		}
+
+		mac := nic.PermanentMACAddress
+		if mac == "" {
+			mac = nic.MACAddress
+		}
+		if mac != "" {
+			macs = append(macs, strings.ToLower(mac))
+		}
+	}
+
+	return macs, nil
+}
+
+// drives returns the drives of all the system's storage controllers.
+func (s redfishSession) drives(ctx context.Context, link redfishLink) ([]redfishDrive, error) {
+	if link.ODataID == "" {
+		return nil, nil
+	}
+
+	storages := &redfishCollection{}
+	if err := s.get(ctx, link.ODataID, storages); err != nil {
+		return nil, err
+	}
+
+	var drives []redfishDrive
+	for _, member := range storages.Members {
+		storage := &redfishStorage{}
+		if err := s.get(ctx, member.ODataID, storage); err != nil {
+			return nil, err
+		}
+
+		for _, driveLink := range storage.Drives {
+			drive := redfishDrive{}
+			if err := s.get(ctx, driveLink.ODataID, &drive); err != nil {
+				return nil, err
+			}
+			drives = append(drives, drive)
+		}
+	}
+
+	return drives, nil
+}
+
+func (s redfishSession) get(ctx context.Context, path string, out interface{}) error {
+	url := s.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating redfish request for %s: %v", url, err)
+	}
+	req.SetBasicAuth(s.username, s.password)
+	req.Header.Set("Accept", redfishContentTypeJSON)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("redfish request to %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("redfish request to %s: unexpected status %s", url, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding redfish response from %s: %v", url, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package hardware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// newRedfishMockServer starts a TLS server serving resources as Redfish JSON documents keyed by
+// path. Requests other than the service root must authenticate with username and password.
+func newRedfishMockServer(t *testing.T, username, password string, resources map[string]interface{}) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redfish/v1/" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("{}"))
+			return
+		}
+
+		u, p, ok := r.BasicAuth()
+		if !ok || u != username || p != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		resource, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resource); err != nil {
+			t.Errorf("encoding redfish mock response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func redfishLink(path string) map[string]string {
+	return map[string]string{"@odata.id": path}
+}
+
+func redfishCollection(paths ...string) map[string]interface{} {
+	members := make([]map[string]string, 0, len(paths))
+	for _, p := range paths {
+		members = append(members, redfishLink(p))
+	}
+	return map[string]interface{}{"Members": members}
+}
+
+func defaultRedfishResources(protocol string) map[string]interface{} {
+	return map[string]interface{}{
+		"/redfish/v1/Systems": redfishCollection("/redfish/v1/Systems/1"),
+		"/redfish/v1/Systems/1": map[string]interface{}{
+			"HostName":           "Worker1",
+			"Manufacturer":       "Dell Inc.",
+			"Model":              "PowerEdge R640",
+			"SerialNumber":       "ABC123",
+			"EthernetInterfaces": redfishLink("/redfish/v1/Systems/1/EthernetInterfaces"),
+			"Storage":            redfishLink("/redfish/v1/Systems/1/Storage"),
+		},
+		"/redfish/v1/Systems/1/EthernetInterfaces": redfishCollection(
+			"/redfish/v1/Systems/1/EthernetInterfaces/1",
+			"/redfish/v1/Systems/1/EthernetInterfaces/2",
+		),
+		"/redfish/v1/Systems/1/EthernetInterfaces/1": map[string]string{"PermanentMACAddress": "AA:BB:CC:DD:EE:01"},
+		"/redfish/v1/Systems/1/EthernetInterfaces/2": map[string]string{"MACAddress": "AA:BB:CC:DD:EE:02"},
+		"/redfish/v1/Systems/1/Storage":              redfishCollection("/redfish/v1/Systems/1/Storage/1"),
+		"/redfish/v1/Systems/1/Storage/1": map[string]interface{}{
+			"Drives": []map[string]string{redfishLink("/redfish/v1/Systems/1/Storage/1/Drives/1")},
+		},
+		"/redfish/v1/Systems/1/Storage/1/Drives/1": map[string]string{"Protocol": protocol},
+	}
+}
+
+func newRedfishDiscovererForServer(t *testing.T, server *httptest.Server) (*hardware.RedfishDiscoverer, string) {
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hardware.NewRedfishDiscoverer(
+		hardware.WithRedfishHTTPClient(server.Client()),
+		hardware.WithRedfishPort(portNumber),
+	), host
+}
+
+func TestRedfishDiscovererDiscover(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	system, err := discoverer.Discover(context.Background(), host, "admin", "password")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(system).To(gomega.Equal(&hardware.RedfishSystem{
+		HostName:     "Worker1",
+		Manufacturer: "Dell Inc.",
+		Model:        "PowerEdge R640",
+		SerialNumber: "ABC123",
+		MACAddresses: []string{"aa:bb:cc:dd:ee:01", "aa:bb:cc:dd:ee:02"},
+		Drives:       1,
+		Disk:         "/dev/sda",
+	}))
+}
+
+func TestRedfishDiscovererDiscoverNVMe(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("NVMe"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	system, err := discoverer.Discover(context.Background(), host, "admin", "password")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(system.Disk).To(gomega.Equal("/dev/nvme0n1"))
+}
+
+func TestRedfishDiscovererDiscoverUnauthorized(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	_, err := discoverer.Discover(context.Background(), host, "admin", "wrong")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("401 Unauthorized")))
+}
+
+func TestRedfishDiscovererDiscoverNoSystems(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", map[string]interface{}{
+		"/redfish/v1/Systems": redfishCollection(),
+	})
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	_, err := discoverer.Discover(context.Background(), host, "admin", "password")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("doesn't manage any systems")))
+}
+
+func TestRedfishDiscovererDiscoverMultipleDrives(t *testing.T) {
+	g := gomega.NewWithT(t)
+	resources := defaultRedfishResources("SATA")
+	resources["/redfish/v1/Systems/1/Storage/1"] = map[string]interface{}{
+		"Drives": []map[string]string{
+			redfishLink("/redfish/v1/Systems/1/Storage/1/Drives/1"),
+			redfishLink("/redfish/v1/Systems/1/Storage/1/Drives/2"),
+		},
+	}
+	resources["/redfish/v1/Systems/1/Storage/1/Drives/2"] = map[string]string{"Protocol": "NVMe"}
+	server := newRedfishMockServer(t, "admin", "password", resources)
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	system, err := discoverer.Discover(context.Background(), host, "admin", "password")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(system.Drives).To(gomega.Equal(2))
+	g.Expect(system.Disk).To(gomega.BeEmpty())
+}
+
+func TestRedfishDiscovererProbe(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishMockServer(t, "admin", "password", defaultRedfishResources("SATA"))
+	discoverer, host := newRedfishDiscovererForServer(t, server)
+
+	g.Expect(discoverer.Probe(context.Background(), host)).To(gomega.BeTrue())
+
+	server.Close()
+	g.Expect(discoverer.Probe(context.Background(), host)).To(gomega.BeFalse())
+}
@@ -9,6 +9,7 @@ const (
 	tinkerbellAPIVersion   = "tinkerbell.org/v1alpha1"
 	tinkerbellHardwareKind = "Hardware"
 	tinkerbellBMCKind      = "BaseboardManagement"
+	rufioBMCJobKind        = "BMCJob"
 
 	secretKind       = "Secret"
 	secretAPIVersion = "v1"
@@ -28,6 +29,13 @@ func newBaseboardManagementTypeMeta() v1.TypeMeta {
 	}
 }
 
+func newBMCJobTypeMeta() v1.TypeMeta {
+	return v1.TypeMeta{
+		Kind:       rufioBMCJobKind,
+		APIVersion: rufioAPIVersion,
+	}
+}
+
 func newSecretTypeMeta() v1.TypeMeta {
 	return v1.TypeMeta{
 		Kind:       secretKind,
@@ -210,13 +210,37 @@ func UniqueBMCIPAddress() MachineAssertion {
 // RegisterDefaultAssertions applies a set of default assertions to validator. The default assertions
 // include UniqueHostnames and UniqueIDs.
 func RegisterDefaultAssertions(validator *DefaultMachineValidator) {
-	validator.Register([]MachineAssertion{
-		StaticMachineAssertions(),
+	validator.Register(StaticMachineAssertions())
+	validator.Register(uniquenessAssertions()...)
+}
+
+// NewDefaultMachineValidatorWithExisting creates a DefaultMachineValidator with the default assertions
+// registered where the uniqueness assertions have already observed existing. It is used to validate
+// new machines against those already known, for example, the hardware already present in a cluster.
+// The existing machines are not validated.
+func NewDefaultMachineValidatorWithExisting(existing []Machine) *DefaultMachineValidator {
+	uniqueness := uniquenessAssertions()
+	for _, m := range existing {
+		for _, fn := range uniqueness {
+			// Existing machines are recorded regardless of duplicates between them as we're only
+			// interested in whether new machines collide with them.
+			_ = fn(m)
+		}
+	}
+
+	validator := &DefaultMachineValidator{}
+	validator.Register(StaticMachineAssertions())
+	validator.Register(uniqueness...)
+	return validator
+}
+
+func uniquenessAssertions() []MachineAssertion {
+	return []MachineAssertion{
 		UniqueIPAddress(),
 		UniqueMACAddress(),
 		UniqueHostnames(),
 		UniqueBMCIPAddress(),
-	}...)
+	}
 }
 
 func validateLabelKey(k string) error {
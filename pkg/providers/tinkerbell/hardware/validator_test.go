@@ -136,6 +136,41 @@ func TestUniquenessAssertionsWithDupes(t *testing.T) {
 	}
 }
 
+func TestNewDefaultMachineValidatorWithExisting(t *testing.T) {
+	existing := NewValidMachine()
+
+	cases := map[string]func(*hardware.Machine){
+		"IPAddress":    func(m *hardware.Machine) { m.IPAddress = existing.IPAddress },
+		"MACAddress":   func(m *hardware.Machine) { m.MACAddress = existing.MACAddress },
+		"Hostname":     func(m *hardware.Machine) { m.Hostname = existing.Hostname },
+		"BMCIPAddress": func(m *hardware.Machine) { m.BMCIPAddress = existing.BMCIPAddress },
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			validator := hardware.NewDefaultMachineValidatorWithExisting([]hardware.Machine{existing})
+
+			machine := hardware.Machine{
+				IPAddress:    "10.10.10.20",
+				Gateway:      "10.10.10.1",
+				Nameservers:  []string{"ns1"},
+				MACAddress:   "00:00:00:00:00:01",
+				Netmask:      "255.255.255.255",
+				Hostname:     "other",
+				Disk:         "/dev/sda",
+				BMCIPAddress: "10.10.10.21",
+				BMCUsername:  "username",
+				BMCPassword:  "password",
+			}
+			g.Expect(hardware.NewDefaultMachineValidatorWithExisting([]hardware.Machine{existing}).Validate(machine)).To(gomega.Succeed())
+
+			mutate(&machine)
+			g.Expect(validator.Validate(machine)).To(gomega.MatchError(gomega.ContainSubstring("duplicate")))
+		})
+	}
+}
+
 func TestStaticMachineAssertions_ValidMachine(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -57,6 +57,20 @@ func (mr *MockProviderKubectlClientMockRecorder) ApplyKubeSpec(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpec", reflect.TypeOf((*MockProviderKubectlClient)(nil).ApplyKubeSpec), arg0, arg1, arg2)
 }
 
+// ApplyKubeSpecFromBytes mocks base method.
+func (m *MockProviderKubectlClient) ApplyKubeSpecFromBytes(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyKubeSpecFromBytes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyKubeSpecFromBytes indicates an expected call of ApplyKubeSpecFromBytes.
+func (mr *MockProviderKubectlClientMockRecorder) ApplyKubeSpecFromBytes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyKubeSpecFromBytes", reflect.TypeOf((*MockProviderKubectlClient)(nil).ApplyKubeSpecFromBytes), arg0, arg1, arg2)
+}
+
 // ApplyKubeSpecFromBytesForce mocks base method.
 func (m *MockProviderKubectlClient) ApplyKubeSpecFromBytesForce(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
 	m.ctrl.T.Helper()
@@ -99,6 +113,35 @@ func (mr *MockProviderKubectlClientMockRecorder) DeleteEksaMachineConfig(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEksaMachineConfig", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteEksaMachineConfig), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DeleteKubeSpecFromBytesIgnoreNotFound mocks base method.
+func (m *MockProviderKubectlClient) DeleteKubeSpecFromBytesIgnoreNotFound(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeSpecFromBytesIgnoreNotFound", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeSpecFromBytesIgnoreNotFound indicates an expected call of DeleteKubeSpecFromBytesIgnoreNotFound.
+func (mr *MockProviderKubectlClientMockRecorder) DeleteKubeSpecFromBytesIgnoreNotFound(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeSpecFromBytesIgnoreNotFound", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteKubeSpecFromBytesIgnoreNotFound), arg0, arg1, arg2)
+}
+
+// GetAllTinkerbellHardware mocks base method.
+func (m *MockProviderKubectlClient) GetAllTinkerbellHardware(arg0 context.Context, arg1, arg2 string) ([]v1alpha10.Hardware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTinkerbellHardware", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]v1alpha10.Hardware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTinkerbellHardware indicates an expected call of GetAllTinkerbellHardware.
+func (mr *MockProviderKubectlClientMockRecorder) GetAllTinkerbellHardware(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTinkerbellHardware", reflect.TypeOf((*MockProviderKubectlClient)(nil).GetAllTinkerbellHardware), arg0, arg1, arg2)
+}
+
 // GetEksaCluster mocks base method.
 func (m *MockProviderKubectlClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -6,13 +6,16 @@ import (
 	"fmt"
 
 	etcdv1 "github.com/mrajashree/etcdadm-controller/api/v1beta1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
@@ -36,6 +39,7 @@ type TemplateBuilder struct {
 	WorkerNodeGroupMachineSpecs map[string]v1alpha1.TinkerbellMachineConfigSpec
 	etcdMachineSpec             *v1alpha1.TinkerbellMachineConfigSpec
 	diskExtractor               *hardware.DiskExtractor
+	templateLibraries           *TemplateLibraryLoader
 	tinkerbellIp                string
 	now                         types.NowFunc
 }
@@ -47,6 +51,7 @@ func NewTemplateBuilder(datacenterSpec *v1alpha1.TinkerbellDatacenterConfigSpec,
 		WorkerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
 		etcdMachineSpec:             etcdMachineSpec,
 		diskExtractor:               diskExtractor,
+		templateLibraries:           NewTemplateLibraryLoader(files.NewReader()),
 		tinkerbellIp:                tinkerbellIp,
 		now:                         now,
 	}
@@ -66,7 +71,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 		cpTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.controlPlaneMachineSpec.OSFamily)
 	}
 
-	cpTemplateString, err := cpTemplateConfig.ToTemplateString()
+	cpTemplateString, err := tb.templateString(clusterSpec, cpTemplateConfig, *tb.controlPlaneMachineSpec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Control Plane TinkerbellTemplateConfig: %v", err)
 	}
@@ -87,7 +92,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecControlPlane(clusterSpec *cluster.Spe
 			}
 			etcdTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, tb.etcdMachineSpec.OSFamily)
 		}
-		etcdTemplateString, err = etcdTemplateConfig.ToTemplateString()
+		etcdTemplateString, err = tb.templateString(clusterSpec, etcdTemplateConfig, *tb.etcdMachineSpec)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get ETCD TinkerbellTemplateConfig: %v", err)
 		}
@@ -121,7 +126,7 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 			wTemplateConfig = v1alpha1.NewDefaultTinkerbellTemplateConfigCreate(clusterSpec.Cluster.Name, *versionBundle, disk, tb.datacenterSpec.OSImageURL, tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP, workerNodeMachineSpec.OSFamily)
 		}
 
-		wTemplateString, err := wTemplateConfig.ToTemplateString()
+		wTemplateString, err := tb.templateString(clusterSpec, wTemplateConfig, workerNodeMachineSpec)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get worker TinkerbellTemplateConfig: %v", err)
 		}
@@ -150,6 +155,75 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 	return templater.AppendYamlResources(workerSpecs...), nil
 }
 
+// templateString returns the workflow template defined by config for machines selected by machineSpec.
+func (tb *TemplateBuilder) templateString(clusterSpec *cluster.Spec, config *v1alpha1.TinkerbellTemplateConfig, machineSpec v1alpha1.TinkerbellMachineConfigSpec) (string, error) {
+	if config.Spec.Library == nil {
+		return config.ToTemplateString()
+	}
+
+	workflow, err := tb.renderLibraryTemplate(clusterSpec, *config.Spec.Library, machineSpec)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := yaml.Marshal(workflow)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert library template %s to string: %v", config.Spec.Library.Template, err)
+	}
+	return string(b), nil
+}
+
+// renderLibraryTemplate renders the library template referenced by ref using the disk and labels of
+// the hardware selected by machineSpec. All the selected hardware must have the same value for the
+// labels the template parameters are read from.
+func (tb *TemplateBuilder) renderLibraryTemplate(clusterSpec *cluster.Spec, ref v1alpha1.TinkerbellTemplateLibraryRef, machineSpec v1alpha1.TinkerbellMachineConfigSpec) (*tinkerbell.Workflow, error) {
+	bundle := clusterSpec.VersionsBundle.VersionsBundle
+	library, err := tb.templateLibraries.Load(ref, bundle.Tinkerbell.TinkerbellStack)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := tb.diskExtractor.GetDisk(machineSpec.HardwareSelector)
+	if err != nil {
+		return nil, fmt.Errorf("getting disk type of the hardware selector: %v", err)
+	}
+
+	labels, err := tb.diskExtractor.GetLabels(machineSpec.HardwareSelector)
+	if err != nil {
+		return nil, fmt.Errorf("getting labels of the hardware selector: %v", err)
+	}
+
+	libraryTemplate, err := library.Template(ref.Template)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range libraryTemplate.Parameters {
+		if _, set := ref.Parameters[param.Name]; set || param.Label == "" {
+			continue
+		}
+		conflicting, err := tb.diskExtractor.HasConflictingLabel(machineSpec.HardwareSelector, param.Label)
+		if err != nil {
+			return nil, err
+		}
+		if conflicting {
+			return nil, fmt.Errorf(
+				"template %s parameter %s: hardware matching the selector has different values for label %s",
+				ref.Template, param.Name, param.Label,
+			)
+		}
+	}
+
+	return library.Render(ref.Template, *bundle, LibraryTemplateValues{
+		Name:         clusterSpec.Cluster.Name,
+		Disk:         disk,
+		OSFamily:     machineSpec.OSFamily,
+		OSImageURL:   tb.datacenterSpec.OSImageURL,
+		MetadataURLs: fmt.Sprintf("http://%s:50061,http://%s:50061", tb.tinkerbellIp, tb.datacenterSpec.TinkerbellIP),
+		Labels:       labels,
+		Parameters:   ref.Parameters,
+	})
+}
+
 func (p *Provider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.Cluster.Name
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
@@ -380,6 +454,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"format":                       format,
 		"kubernetesVersion":            bundle.KubeDistro.Kubernetes.Tag,
 		"kubeVipImage":                 bundle.Tinkerbell.KubeVip.VersionedImage(),
+		"kubeVip":                      clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.KubeVipLoadBalancer,
 		"podCidrs":                     clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
 		"serviceCidrs":                 clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"apiserverExtraArgs":           apiServerExtraArgs.ToPartialYaml(),
@@ -398,6 +473,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, controlPlaneMachineSpec, etcd
 		"hardwareSelector":             controlPlaneMachineSpec.HardwareSelector,
 		"controlPlaneTaints":           clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 	}
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.HAProxyStaticPodLoadBalancer {
+		values["haproxyStaticPod"] = true
+		values["haproxyImage"] = bundle.Haproxy.Image.VersionedImage()
+		values["haproxyConfig"] = common.GetHAProxyConfig("6443")
+		values["apiServerBindPort"] = common.HAProxyAPIServerBindPort
+	}
+
 	if clusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
 		values["externalEtcd"] = true
 		values["externalEtcdReplicas"] = clusterSpec.Cluster.Spec.ExternalEtcdConfiguration.Count
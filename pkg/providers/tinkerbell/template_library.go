@@ -0,0 +1,315 @@
+package tinkerbell
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// defaultTemplateLibrary is used when the bundle doesn't ship a template library.
+//
+//go:embed config/template-library.yaml
+var defaultTemplateLibrary []byte
+
+const (
+	// DefaultTemplateLibraryName is the name of the template library shipped in the bundle.
+	DefaultTemplateLibraryName = "eks-anywhere"
+
+	// Library templates use [[ ]] delimiters so they can contain Tinkerbell's own {{ }} expressions.
+	libraryTemplateLeftDelim  = "[["
+	libraryTemplateRightDelim = "]]"
+)
+
+var templateParameterNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+// TemplateLibrary is a named and versioned collection of Tinkerbell workflow templates.
+type TemplateLibrary struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Partials are template definitions shared by all templates in the library.
+	Partials  string            `json:"partials,omitempty"`
+	Templates []LibraryTemplate `json:"templates"`
+}
+
+// LibraryTemplate is a Tinkerbell workflow template in a TemplateLibrary.
+type LibraryTemplate struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// OSFamily restricts the template to machines of an OS family. Any OS family is accepted when empty.
+	OSFamily   v1alpha1.OSFamily   `json:"osFamily,omitempty"`
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+	// Template is the workflow template rendered with LibraryTemplateValues.
+	Template string `json:"template"`
+}
+
+// TemplateParameter is a value used to parameterise a LibraryTemplate.
+type TemplateParameter struct {
+	Name string `json:"name"`
+	// Label is the hardware label the parameter value is read from. Label values can't contain
+	// characters such as '/' so parameters holding paths or URLs shouldn't set it.
+	Label string `json:"label,omitempty"`
+	// Default is used when the parameter isn't set and the hardware doesn't have Label.
+	// Parameters without a default are required.
+	Default string `json:"default,omitempty"`
+}
+
+// LibraryTemplateValues are the values a LibraryTemplate is rendered with.
+type LibraryTemplateValues struct {
+	// Name of the workflow and its task.
+	Name string
+	// Disk is the device path of the disk the OS is installed on.
+	Disk     string
+	OSFamily v1alpha1.OSFamily
+	// OSImageURL overrides the OS image from the bundle.
+	OSImageURL   string
+	MetadataURLs string
+	// Labels of the hardware the template is rendered for.
+	Labels hardware.Labels
+	// Parameters explicitly set by the user. They take precedence over Labels.
+	Parameters map[string]string
+}
+
+// ParseTemplateLibrary parses and validates a TemplateLibrary from YAML.
+func ParseTemplateLibrary(data []byte) (*TemplateLibrary, error) {
+	library := &TemplateLibrary{}
+	if err := yaml.UnmarshalStrict(data, library); err != nil {
+		return nil, fmt.Errorf("parsing template library: %v", err)
+	}
+
+	if library.Name == "" || library.Version == "" {
+		return nil, fmt.Errorf("template library name and version are required")
+	}
+
+	templates := make(map[string]struct{}, len(library.Templates))
+	for _, t := range library.Templates {
+		if _, ok := templates[t.Name]; ok {
+			return nil, fmt.Errorf("duplicate template %s in template library %s", t.Name, library.Name)
+		}
+		templates[t.Name] = struct{}{}
+
+		parameters := make(map[string]struct{}, len(t.Parameters))
+		for _, p := range t.Parameters {
+			if !templateParameterNameRegex.MatchString(p.Name) {
+				return nil, fmt.Errorf("template %s parameter name %q must be alphanumeric and start with a letter", t.Name, p.Name)
+			}
+			if _, ok := parameters[p.Name]; ok {
+				return nil, fmt.Errorf("duplicate parameter %s in template %s", p.Name, t.Name)
+			}
+			parameters[p.Name] = struct{}{}
+		}
+	}
+
+	return library, nil
+}
+
+// Template retrieves the template called name.
+func (l *TemplateLibrary) Template(name string) (*LibraryTemplate, error) {
+	for i := range l.Templates {
+		if l.Templates[i].Name == name {
+			return &l.Templates[i], nil
+		}
+	}
+	return nil, fmt.Errorf("template %s not found in template library %s version %s", name, l.Name, l.Version)
+}
+
+// Render renders the template called name with values and the images in bundle. Parameters are
+// resolved from values.Parameters, then values.Labels and finally the parameter defaults. The
+// rendered workflow may only use action and hook images from bundle.
+func (l *TemplateLibrary) Render(name string, bundle releasev1alpha1.VersionsBundle, values LibraryTemplateValues) (*tinkerbell.Workflow, error) {
+	t, err := l.Template(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.OSFamily != "" && t.OSFamily != values.OSFamily {
+		return nil, fmt.Errorf("template %s only supports osFamily %s", t.Name, t.OSFamily)
+	}
+
+	params, err := t.resolveParameters(values)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := template.New(t.Name).
+		Delims(libraryTemplateLeftDelim, libraryTemplateRightDelim).
+		Option("missingkey=error")
+	if _, err := tmpl.Parse(l.Partials); err != nil {
+		return nil, fmt.Errorf("parsing template library %s partials: %v", l.Name, err)
+	}
+	if _, err := tmpl.Parse(t.Template); err != nil {
+		return nil, fmt.Errorf("parsing template %s: %v", t.Name, err)
+	}
+
+	actions := bundle.Tinkerbell.TinkerbellStack.Actions
+	osImageURL := values.OSImageURL
+	if osImageURL == "" {
+		osImageURL = bundle.EksD.Raw.Ubuntu.URI
+		if values.OSFamily == v1alpha1.Bottlerocket {
+			osImageURL = bundle.EksD.Raw.Bottlerocket.URI
+		}
+	}
+
+	rootPartition := "2"
+	if values.OSFamily == v1alpha1.Bottlerocket {
+		rootPartition = "12"
+	}
+
+	data := map[string]interface{}{
+		"name":            values.Name,
+		"disk":            values.Disk,
+		"partitionPrefix": v1alpha1.GetDiskPart(values.Disk),
+		"rootPartition":   v1alpha1.GetDiskPart(values.Disk) + rootPartition,
+		"osImageURL":      osImageURL,
+		"metadataURLs":    values.MetadataURLs,
+		"actions": map[string]string{
+			"cexec":       actions.Cexec.URI,
+			"kexec":       actions.Kexec.URI,
+			"imageToDisk": actions.ImageToDisk.URI,
+			"ociToDisk":   actions.OciToDisk.URI,
+			"writeFile":   actions.WriteFile.URI,
+			"reboot":      actions.Reboot.URI,
+		},
+		"params": params,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering template %s: %v", t.Name, err)
+	}
+
+	workflow := &tinkerbell.Workflow{}
+	if err := yaml.UnmarshalStrict(buf.Bytes(), workflow); err != nil {
+		return nil, fmt.Errorf("template %s didn't render a valid workflow: %v", t.Name, err)
+	}
+
+	if err := validateWorkflowImages(workflow, bundle.Tinkerbell.TinkerbellStack); err != nil {
+		return nil, fmt.Errorf("template %s: %v", t.Name, err)
+	}
+
+	return workflow, nil
+}
+
+func (t *LibraryTemplate) resolveParameters(values LibraryTemplateValues) (map[string]string, error) {
+	params := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if v, ok := values.Parameters[p.Name]; ok {
+			params[p.Name] = v
+			continue
+		}
+		if v, ok := values.Labels[p.Label]; ok && p.Label != "" {
+			params[p.Name] = v
+			continue
+		}
+		if p.Default == "" {
+			return nil, fmt.Errorf("template %s parameter %s is required", t.Name, p.Name)
+		}
+		params[p.Name] = p.Default
+	}
+
+	for name := range values.Parameters {
+		if _, ok := params[name]; !ok {
+			return nil, fmt.Errorf("template %s doesn't have parameter %s", t.Name, name)
+		}
+	}
+
+	return params, nil
+}
+
+// validateWorkflowImages ensures all actions in workflow use action or hook images from stack.
+func validateWorkflowImages(workflow *tinkerbell.Workflow, stack releasev1alpha1.TinkerbellStackBundle) error {
+	allowed := make(map[string]struct{})
+	for _, image := range []releasev1alpha1.Image{
+		stack.Actions.Cexec,
+		stack.Actions.Kexec,
+		stack.Actions.ImageToDisk,
+		stack.Actions.OciToDisk,
+		stack.Actions.WriteFile,
+		stack.Actions.Reboot,
+		stack.Hook.Bootkit,
+		stack.Hook.Docker,
+		stack.Hook.Kernel,
+	} {
+		if image.URI != "" {
+			allowed[image.URI] = struct{}{}
+		}
+	}
+
+	for _, task := range workflow.Tasks {
+		for _, action := range task.Actions {
+			if _, ok := allowed[action.Image]; !ok {
+				return fmt.Errorf("action %s image %s isn't a Tinkerbell action or hook image from the bundle", action.Name, action.Image)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FileReader reads files from https URLs or local paths.
+type FileReader interface {
+	ReadFile(uri string) ([]byte, error)
+}
+
+// TemplateLibraryLoader loads the template libraries referenced by TinkerbellTemplateConfigs.
+type TemplateLibraryLoader struct {
+	reader    FileReader
+	libraries map[string]*TemplateLibrary
+}
+
+// NewTemplateLibraryLoader creates a TemplateLibraryLoader that reads libraries with reader.
+func NewTemplateLibraryLoader(reader FileReader) *TemplateLibraryLoader {
+	return &TemplateLibraryLoader{
+		reader:    reader,
+		libraries: make(map[string]*TemplateLibrary),
+	}
+}
+
+// Load retrieves the library referenced by ref. Libraries are read from the ref source or, when the
+// ref has no source, from the library shipped in stack. If stack doesn't ship a library, the library
+// embedded in the CLI is used. The library name and version must match the ref.
+func (l *TemplateLibraryLoader) Load(ref v1alpha1.TinkerbellTemplateLibraryRef, stack releasev1alpha1.TinkerbellStackBundle) (*TemplateLibrary, error) {
+	source := ref.Source
+	if source == "" {
+		source = stack.TemplateLibrary.URI
+	}
+
+	library, ok := l.libraries[source]
+	if !ok {
+		var err error
+		if library, err = l.read(source); err != nil {
+			return nil, err
+		}
+		l.libraries[source] = library
+	}
+
+	if library.Name != ref.Name || library.Version != ref.Version {
+		return nil, fmt.Errorf(
+			"template library %s version %s not found, source provides %s version %s",
+			ref.Name, ref.Version, library.Name, library.Version,
+		)
+	}
+
+	return library, nil
+}
+
+func (l *TemplateLibraryLoader) read(source string) (*TemplateLibrary, error) {
+	if source == "" {
+		return ParseTemplateLibrary(defaultTemplateLibrary)
+	}
+
+	data, err := l.reader.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("reading template library: %v", err)
+	}
+
+	return ParseTemplateLibrary(data)
+}
@@ -0,0 +1,278 @@
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	releasev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func newTemplateLibraryBundle() releasev1alpha1.VersionsBundle {
+	return releasev1alpha1.VersionsBundle{
+		EksD: releasev1alpha1.EksDRelease{
+			Raw: releasev1alpha1.OSImageBundle{
+				Ubuntu:       releasev1alpha1.OSImage{Archive: releasev1alpha1.Archive{URI: "https://images/ubuntu.gz"}},
+				Bottlerocket: releasev1alpha1.OSImage{Archive: releasev1alpha1.Archive{URI: "https://images/bottlerocket.gz"}},
+			},
+		},
+		Tinkerbell: releasev1alpha1.TinkerbellBundle{
+			TinkerbellStack: releasev1alpha1.TinkerbellStackBundle{
+				Actions: releasev1alpha1.ActionsBundle{
+					Cexec:       releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/cexec:v1"},
+					Kexec:       releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/kexec:v1"},
+					ImageToDisk: releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/image2disk:v1"},
+					OciToDisk:   releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/oci2disk:v1"},
+					WriteFile:   releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/writefile:v1"},
+					Reboot:      releasev1alpha1.Image{URI: "public.ecr.aws/eks-anywhere/reboot:v1"},
+				},
+			},
+		},
+	}
+}
+
+func newTemplateLibraryValues() tinkerbell.LibraryTemplateValues {
+	return tinkerbell.LibraryTemplateValues{
+		Name:         "test",
+		Disk:         "/dev/sda",
+		OSFamily:     v1alpha1.Ubuntu,
+		MetadataURLs: "http://1.1.1.1:50061,http://2.2.2.2:50061",
+		Labels:       hardware.Labels{"type": "cp"},
+	}
+}
+
+func loadDefaultTemplateLibrary(t *testing.T) *tinkerbell.TemplateLibrary {
+	loader := tinkerbell.NewTemplateLibraryLoader(files.NewReader())
+	library, err := loader.Load(v1alpha1.TinkerbellTemplateLibraryRef{
+		Name:    tinkerbell.DefaultTemplateLibraryName,
+		Version: "v1",
+	}, releasev1alpha1.TinkerbellStackBundle{})
+	if err != nil {
+		t.Fatalf("loading default template library: %v", err)
+	}
+	return library
+}
+
+func TestDefaultTemplateLibraryTemplatesRender(t *testing.T) {
+	library := loadDefaultTemplateLibrary(t)
+
+	for _, tmpl := range library.Templates {
+		t.Run(tmpl.Name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			values := newTemplateLibraryValues()
+			if tmpl.Name == "ubuntu-firmware-update" {
+				values.Parameters = map[string]string{"firmwareURL": "https://firmware/update.cab"}
+			}
+
+			workflow, err := library.Render(tmpl.Name, newTemplateLibraryBundle(), values)
+			g.Expect(err).To(gomega.Succeed())
+			g.Expect(workflow.Name).To(gomega.Equal("test"))
+			g.Expect(workflow.Tasks).To(gomega.HaveLen(1))
+			g.Expect(workflow.Tasks[0].WorkerAddr).To(gomega.Equal("{{.device_1}}"))
+
+			actions := workflow.Tasks[0].Actions
+			g.Expect(actions[0].Environment).To(gomega.HaveKeyWithValue("IMG_URL", "https://images/ubuntu.gz"))
+			g.Expect(actions[2].Environment["CONTENTS"]).To(gomega.Equal("network: {config: disabled}"))
+			g.Expect(actions[3].Environment["CONTENTS"]).To(gomega.ContainSubstring("metadata_urls: [ http://1.1.1.1:50061,http://2.2.2.2:50061 ]"))
+			g.Expect(actions[len(actions)-1].Name).To(gomega.Equal("reboot-image"))
+		})
+	}
+}
+
+func TestTemplateLibraryRenderParametersFromLabels(t *testing.T) {
+	g := gomega.NewWithT(t)
+	library := loadDefaultTemplateLibrary(t)
+	values := newTemplateLibraryValues()
+	values.Labels["raid-disk-1"] = "nvme1n1"
+	values.Labels["raid-disk-2"] = "nvme2n1"
+
+	workflow, err := library.Render("ubuntu-raid1", newTemplateLibraryBundle(), values)
+	g.Expect(err).To(gomega.Succeed())
+
+	raid := workflow.Tasks[0].Actions[5]
+	g.Expect(raid.Image).To(gomega.Equal("public.ecr.aws/eks-anywhere/cexec:v1"))
+	g.Expect(raid.Environment["BLOCK_DEVICE"]).To(gomega.Equal("/dev/sda2"))
+	g.Expect(raid.Environment["CMD_LINE"]).To(gomega.ContainSubstring("--raid-devices=2 /dev/nvme1n1 /dev/nvme2n1"))
+	g.Expect(raid.Environment["CMD_LINE"]).To(gomega.ContainSubstring("/dev/md0 /var/lib/data ext4"))
+}
+
+func TestTemplateLibraryRenderParametersOverrideLabels(t *testing.T) {
+	g := gomega.NewWithT(t)
+	library := loadDefaultTemplateLibrary(t)
+	values := newTemplateLibraryValues()
+	values.Labels["data-disk"] = "sdb"
+	values.Parameters = map[string]string{"dataDisk": "sdc"}
+
+	workflow, err := library.Render("ubuntu-multi-disk", newTemplateLibraryBundle(), values)
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(workflow.Tasks[0].Actions[5].Environment["CMD_LINE"]).To(gomega.HavePrefix("mkfs.ext4 -F /dev/sdc"))
+}
+
+func TestTemplateLibraryRenderNVMePartitions(t *testing.T) {
+	g := gomega.NewWithT(t)
+	library := loadDefaultTemplateLibrary(t)
+	values := newTemplateLibraryValues()
+	values.Disk = "/dev/nvme0n1"
+
+	workflow, err := library.Render("ubuntu-custom-partitions", newTemplateLibraryBundle(), values)
+	g.Expect(err).To(gomega.Succeed())
+
+	actions := workflow.Tasks[0].Actions
+	g.Expect(actions[0].Environment["DEST_DISK"]).To(gomega.Equal("/dev/nvme0n1"))
+	g.Expect(actions[1].Environment["DEST_DISK"]).To(gomega.Equal("/dev/nvme0n1p2"))
+	g.Expect(actions[5].Environment["CMD_LINE"]).To(gomega.ContainSubstring("mkfs.ext4 -F /dev/nvme0n1p3"))
+}
+
+func TestTemplateLibraryRenderOSImageOverride(t *testing.T) {
+	g := gomega.NewWithT(t)
+	library := loadDefaultTemplateLibrary(t)
+	values := newTemplateLibraryValues()
+	values.OSImageURL = "https://custom/ubuntu.gz"
+
+	workflow, err := library.Render("ubuntu-multi-disk", newTemplateLibraryBundle(), values)
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(workflow.Tasks[0].Actions[0].Environment["IMG_URL"]).To(gomega.Equal("https://custom/ubuntu.gz"))
+}
+
+func TestTemplateLibraryRenderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		mutate   func(*tinkerbell.LibraryTemplateValues)
+		wantErr  string
+	}{
+		{
+			name:     "missing template",
+			template: "ubuntu-unknown",
+			wantErr:  "template ubuntu-unknown not found in template library eks-anywhere version v1",
+		},
+		{
+			name:     "os family mismatch",
+			template: "ubuntu-raid1",
+			mutate:   func(v *tinkerbell.LibraryTemplateValues) { v.OSFamily = v1alpha1.Bottlerocket },
+			wantErr:  "template ubuntu-raid1 only supports osFamily ubuntu",
+		},
+		{
+			name:     "missing required parameter",
+			template: "ubuntu-firmware-update",
+			wantErr:  "template ubuntu-firmware-update parameter firmwareURL is required",
+		},
+		{
+			name:     "unknown parameter",
+			template: "ubuntu-raid1",
+			mutate:   func(v *tinkerbell.LibraryTemplateValues) { v.Parameters = map[string]string{"raidDisk3": "sdd"} },
+			wantErr:  "template ubuntu-raid1 doesn't have parameter raidDisk3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			values := newTemplateLibraryValues()
+			if tt.mutate != nil {
+				tt.mutate(&values)
+			}
+
+			_, err := loadDefaultTemplateLibrary(t).Render(tt.template, newTemplateLibraryBundle(), values)
+			g.Expect(err).To(gomega.MatchError(tt.wantErr))
+		})
+	}
+}
+
+func TestTemplateLibraryLoaderFromSource(t *testing.T) {
+	g := gomega.NewWithT(t)
+	loader := tinkerbell.NewTemplateLibraryLoader(files.NewReader())
+	ref := v1alpha1.TinkerbellTemplateLibraryRef{
+		Name:    "custom",
+		Version: "v2",
+		Source:  "testdata/template_library.yaml",
+	}
+
+	library, err := loader.Load(ref, releasev1alpha1.TinkerbellStackBundle{})
+	g.Expect(err).To(gomega.Succeed())
+
+	workflow, err := library.Render("ubuntu-oci", newTemplateLibraryBundle(), newTemplateLibraryValues())
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(workflow.Tasks[0].Actions[0].Image).To(gomega.Equal("public.ecr.aws/eks-anywhere/oci2disk:v1"))
+	g.Expect(workflow.Tasks[0].Actions[0].Environment["IMG_URL"]).To(gomega.Equal("public.ecr.aws/ubuntu:latest"))
+}
+
+func TestTemplateLibraryLoaderFromBundle(t *testing.T) {
+	g := gomega.NewWithT(t)
+	loader := tinkerbell.NewTemplateLibraryLoader(files.NewReader())
+	stack := releasev1alpha1.TinkerbellStackBundle{
+		TemplateLibrary: releasev1alpha1.Manifest{URI: "testdata/template_library.yaml"},
+	}
+
+	library, err := loader.Load(v1alpha1.TinkerbellTemplateLibraryRef{Name: "custom", Version: "v2"}, stack)
+	g.Expect(err).To(gomega.Succeed())
+	g.Expect(library.Templates).To(gomega.HaveLen(2))
+}
+
+func TestTemplateLibraryLoaderVersionMismatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+	loader := tinkerbell.NewTemplateLibraryLoader(files.NewReader())
+	ref := v1alpha1.TinkerbellTemplateLibraryRef{
+		Name:    "custom",
+		Version: "v1",
+		Source:  "testdata/template_library.yaml",
+	}
+
+	_, err := loader.Load(ref, releasev1alpha1.TinkerbellStackBundle{})
+	g.Expect(err).To(gomega.MatchError("template library custom version v1 not found, source provides custom version v2"))
+}
+
+func TestTemplateLibraryRenderImageNotInBundle(t *testing.T) {
+	g := gomega.NewWithT(t)
+	loader := tinkerbell.NewTemplateLibraryLoader(files.NewReader())
+	library, err := loader.Load(v1alpha1.TinkerbellTemplateLibraryRef{
+		Name:    "custom",
+		Version: "v2",
+		Source:  "testdata/template_library.yaml",
+	}, releasev1alpha1.TinkerbellStackBundle{})
+	g.Expect(err).To(gomega.Succeed())
+
+	_, err = library.Render("unknown-image", newTemplateLibraryBundle(), newTemplateLibraryValues())
+	g.Expect(err).To(gomega.MatchError("template unknown-image: action custom image quay.io/custom/action:latest isn't a Tinkerbell action or hook image from the bundle"))
+}
+
+func TestParseTemplateLibraryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		library string
+		wantErr string
+	}{
+		{
+			name:    "missing version",
+			library: "name: custom\n",
+			wantErr: "template library name and version are required",
+		},
+		{
+			name:    "duplicate template",
+			library: "name: custom\nversion: v1\ntemplates:\n- name: a\n  template: x\n- name: a\n  template: y\n",
+			wantErr: "duplicate template a in template library custom",
+		},
+		{
+			name:    "invalid parameter name",
+			library: "name: custom\nversion: v1\ntemplates:\n- name: a\n  template: x\n  parameters:\n  - name: raid-disk\n",
+			wantErr: "template a parameter name \"raid-disk\" must be alphanumeric and start with a letter",
+		},
+		{
+			name:    "unknown field",
+			library: "name: custom\nversion: v1\nunknown: true\n",
+			wantErr: "parsing template library: error unmarshaling JSON: while decoding JSON: json: unknown field \"unknown\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			_, err := tinkerbell.ParseTemplateLibrary([]byte(tt.library))
+			g.Expect(err).To(gomega.MatchError(tt.wantErr))
+		})
+	}
+}
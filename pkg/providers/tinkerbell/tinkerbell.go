@@ -16,6 +16,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -54,8 +55,12 @@ type Provider struct {
 
 	hardwareCSVFile string
 	catalogue       *hardware.Catalogue
-	diskExtractor   hardware.DiskExtractor
-	tinkerbellIp    string
+	// provisionedHardware holds the names of the hardware already provisioned in the cluster
+	// being upgraded.
+	provisionedHardware map[string]struct{}
+	diskExtractor       hardware.DiskExtractor
+	tinkerbellIp        string
+	now                 types.NowFunc
 
 	// TODO(chrisdoheryt4) Temporarily depend on the netclient until the validator can be injected.
 	// This is already a dependency, just uncached, because we require it during the initializing
@@ -69,7 +74,9 @@ type Provider struct {
 
 type ProviderKubectlClient interface {
 	ApplyKubeSpec(ctx context.Context, cluster *types.Cluster, spec string) error
+	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
 	ApplyKubeSpecFromBytesForce(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytesIgnoreNotFound(ctx context.Context, cluster *types.Cluster, data []byte) error
 	DeleteEksaDatacenterConfig(ctx context.Context, eksaTinkerbellDatacenterResourceType string, tinkerbellDatacenterConfigName string, kubeconfigFile string, namespace string) error
 	DeleteEksaMachineConfig(ctx context.Context, eksaTinkerbellMachineResourceType string, tinkerbellMachineConfigName string, kubeconfigFile string, namespace string) error
 	GetMachineDeployment(ctx context.Context, machineDeploymentName string, opts ...executables.KubectlOpt) (*clusterv1.MachineDeployment, error)
@@ -82,6 +89,7 @@ type ProviderKubectlClient interface {
 	UpdateAnnotation(ctx context.Context, resourceType, objectName string, annotations map[string]string, opts ...executables.KubectlOpt) error
 	WaitForDeployment(ctx context.Context, cluster *types.Cluster, timeout string, condition string, target string, namespace string) error
 	GetUnprovisionedTinkerbellHardware(_ context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error)
+	GetAllTinkerbellHardware(ctx context.Context, kubeconfig, namespace string) ([]tinkv1alpha1.Hardware, error)
 }
 
 // KeyGenerator generates ssh keys and writes them to a FileWriter.
@@ -145,6 +153,7 @@ func NewProvider(
 			WorkerNodeGroupMachineSpecs: workerNodeGroupMachineSpecs,
 			etcdMachineSpec:             etcdMachineSpec,
 			diskExtractor:               diskExtractor,
+			templateLibraries:           NewTemplateLibraryLoader(files.NewReader()),
 			tinkerbellIp:                tinkerbellIp,
 			now:                         now,
 		},
@@ -160,6 +169,7 @@ func NewProvider(
 		),
 		diskExtractor: *diskExtractor,
 		tinkerbellIp:  tinkerbellIp,
+		now:           now,
 		netClient:     &networkutils.DefaultNetClient{},
 		retrier:       retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
 		// (chrisdoherty4) We're hard coding the dependency and monkey patching in testing because the provider
@@ -3,15 +3,20 @@ package tinkerbell
 import (
 	"context"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	filewritermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack"
 	stackmocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/stack/mocks"
@@ -351,6 +356,218 @@ func TestPostWorkloadInitSuccess(t *testing.T) {
 	}
 }
 
+func TestPostWorkloadInitWithLoadBalancerConfiguration(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	clusterSpec.Cluster.Spec.LoadBalancerConfiguration = &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+	clusterSpec.VersionsBundle.Tinkerbell.KubeVipCloudProvider.URI = "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip-cloud-provider:v0.0.2"
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, forceCleanup)
+	provider.stackInstaller = stackInstaller
+
+	stackInstaller.EXPECT().Install(ctx, clusterSpec.VersionsBundle.Tinkerbell, testIP, "test.kubeconfig", "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	stackInstaller.EXPECT().UninstallLocal(ctx)
+	kubectl.EXPECT().ApplyKubeSpecFromBytes(ctx, cluster, gomock.Any())
+
+	err := provider.PostWorkloadInit(ctx, cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed PostWorkloadInit: %v", err)
+	}
+}
+
+func TestRunPostControlPlaneUpgradeLoadBalancerConfigurationRemoved(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	oldClusterSpec := clusterSpec.DeepCopy()
+	oldClusterSpec.Cluster.Spec.LoadBalancerConfiguration = &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+	oldClusterSpec.VersionsBundle.Tinkerbell.KubeVipCloudProvider.URI = "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip-cloud-provider:v0.0.2"
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl, false)
+
+	kubectl.EXPECT().DeleteKubeSpecFromBytesIgnoreNotFound(ctx, cluster, gomock.Any())
+
+	if err := provider.RunPostControlPlaneUpgrade(ctx, oldClusterSpec, clusterSpec, cluster, cluster); err != nil {
+		t.Fatalf("failed RunPostControlPlaneUpgrade: %v", err)
+	}
+}
+
+func TestPostBootstrapSetupNetbootsSelectedHardware(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterConfig, writer, docker, helm, kubectl, forceCleanup)
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed reading hardware csv: %v", err)
+	}
+
+	var jobsSpec string
+	gomock.InOrder(
+		kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any()),
+		kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any()).Do(
+			func(_ context.Context, _ *types.Cluster, data []byte) { jobsSpec = string(data) },
+		),
+	)
+
+	if err := provider.PostBootstrapSetup(ctx, clusterConfig, cluster); err != nil {
+		t.Fatalf("failed PostBootstrapSetup: %v", err)
+	}
+
+	// worker3 is labeled for etcd which isn't selected by any machine config in a stacked etcd cluster.
+	for _, name := range []string{"bmc-worker1-netboot", "bmc-worker2-netboot", "bmc-worker4-netboot"} {
+		if !strings.Contains(jobsSpec, name) {
+			t.Errorf("bmc jobs spec missing %s:\n%s", name, jobsSpec)
+		}
+	}
+	if strings.Contains(jobsSpec, "bmc-worker3-netboot") {
+		t.Errorf("bmc jobs spec includes unselected hardware worker3:\n%s", jobsSpec)
+	}
+}
+
+func TestPostBootstrapSetupUpgradeSkipsProvisionedHardware(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	cluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterConfig, writer, docker, helm, kubectl, forceCleanup)
+	if err := provider.readCSVToCatalogue(); err != nil {
+		t.Fatalf("failed reading hardware csv: %v", err)
+	}
+	provider.provisionedHardware = map[string]struct{}{"worker2": {}}
+
+	var jobsSpec string
+	gomock.InOrder(
+		kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any()),
+		kubectl.EXPECT().ApplyKubeSpecFromBytesForce(ctx, cluster, gomock.Any()).Do(
+			func(_ context.Context, _ *types.Cluster, data []byte) { jobsSpec = string(data) },
+		),
+	)
+
+	if err := provider.PostBootstrapSetupUpgrade(ctx, clusterConfig, cluster); err != nil {
+		t.Fatalf("failed PostBootstrapSetupUpgrade: %v", err)
+	}
+
+	for _, name := range []string{"bmc-worker1-netboot", "bmc-worker4-netboot"} {
+		if !strings.Contains(jobsSpec, name) {
+			t.Errorf("bmc jobs spec missing %s:\n%s", name, jobsSpec)
+		}
+	}
+	if strings.Contains(jobsSpec, "bmc-worker2-netboot") {
+		t.Errorf("bmc jobs spec includes provisioned hardware worker2:\n%s", jobsSpec)
+	}
+}
+
+func TestSetupAndValidateUpgradeClusterExtractsDisksFromClusterHardware(t *testing.T) {
+	clusterSpecManifest := "cluster_tinkerbell_stacked_etcd.yaml"
+	mockCtrl := gomock.NewController(t)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	managementCluster := &types.Cluster{Name: "test", KubeconfigFile: "test.kubeconfig"}
+	ctx := context.Background()
+	forceCleanup := false
+
+	clusterConfig, err := v1alpha1.GetClusterConfig(path.Join(testDataDir, clusterSpecManifest))
+	if err != nil {
+		t.Fatalf("unable to get cluster config from file: %v", err)
+	}
+	clusterSpec := &cluster.Spec{Config: &cluster.Config{Cluster: clusterConfig}}
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+
+	provider := newProvider(datacenterConfig, machineConfigs, clusterConfig, writer, docker, helm, kubectl, forceCleanup)
+	provider.hardwareCSVFile = ""
+
+	clusterHardware := []tinkv1alpha1.Hardware{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "cp1",
+				Labels: map[string]string{"type": "cp", hardware.OwnerNameLabel: "test-control-plane-abcde"},
+			},
+			Spec: tinkv1alpha1.HardwareSpec{Disks: []tinkv1alpha1.Disk{{Device: "/dev/nvme0n1"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "worker1",
+				Labels: map[string]string{"type": "worker"},
+			},
+			Spec: tinkv1alpha1.HardwareSpec{
+				BMCRef: &corev1.TypedLocalObjectReference{Name: "bmc-worker1"},
+				Disks:  []tinkv1alpha1.Disk{{Device: "/dev/sda"}},
+				Metadata: &tinkv1alpha1.HardwareMetadata{
+					Instance: &tinkv1alpha1.MetadataInstance{ID: "worker1"},
+				},
+			},
+		},
+	}
+	kubectl.EXPECT().GetAllTinkerbellHardware(ctx, managementCluster.KubeconfigFile, "eksa-system").Return(clusterHardware, nil)
+
+	if err := provider.SetupAndValidateUpgradeCluster(ctx, managementCluster, clusterSpec); err != nil {
+		t.Fatalf("failed SetupAndValidateUpgradeCluster: %v", err)
+	}
+
+	disk, err := provider.templateBuilder.diskExtractor.GetDisk(v1alpha1.HardwareSelector{"type": "cp"})
+	if err != nil {
+		t.Fatalf("failed getting control plane disk: %v", err)
+	}
+	if disk != "/dev/nvme0n1" {
+		t.Errorf("control plane disk = %s, want /dev/nvme0n1", disk)
+	}
+}
+
 func TestTinkerbellProviderGenerateDeploymentFileWithFullOIDC(t *testing.T) {
 	clusterSpecManifest := "cluster_tinkerbell_full_oidc.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -79,7 +79,8 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	// If we've been given a CSV with additional hardware for the cluster, validate it and
 	// write it to the catalogue so it can be used for further processing.
 	if p.hardareCSVIsProvided() {
-		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
+		// Combine disk extraction with catalogue writing so library templates can be rendered.
+		machineCatalogueWriter := hardware.MultiMachineWriter(hardware.NewMachineCatalogueWriter(p.catalogue), &p.diskExtractor)
 
 		machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile)
 		if err != nil {
@@ -98,27 +99,39 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 		}
 	}
 
-	// Retrieve all unprovisioned hardware from the existing cluster and populate the catalogue so
-	// it can be considered for the upgrade.
-	hardware, err := p.providerKubectlClient.GetUnprovisionedTinkerbellHardware(
+	// Retrieve all hardware from the existing cluster. The unprovisioned hardware populates the
+	// catalogue so it can be considered for the upgrade, while the provisioned one is recorded so
+	// it isn't netbooted if it's also in the hardware CSV.
+	clusterHardware, err := p.providerKubectlClient.GetAllTinkerbellHardware(
 		ctx,
 		cluster.KubeconfigFile,
 		constants.EksaSystemNamespace,
 	)
 	if err != nil {
-		return fmt.Errorf("retrieving unprovisioned hardware: %v", err)
+		return fmt.Errorf("retrieving hardware: %v", err)
 	}
-	for i := range hardware {
-		if err := p.catalogue.InsertHardware(&hardware[i]); err != nil {
+	p.provisionedHardware = map[string]struct{}{}
+	for i := range clusterHardware {
+		// Both provisioned and unprovisioned hardware provide the disk and labels library templates are rendered with.
+		if err := p.diskExtractor.Write(hardware.MachineFromHardware(&clusterHardware[i], nil)); err != nil {
+			return err
+		}
+		if _, ok := clusterHardware[i].Labels[hardware.OwnerNameLabel]; ok {
+			p.provisionedHardware[clusterHardware[i].Name] = struct{}{}
+			continue
+		}
+		if err := p.catalogue.InsertHardware(&clusterHardware[i]); err != nil {
 			return err
 		}
 	}
 
 	// Construct a spec validator and apply assertions specific to upgrade. The validation
 	// must take place last so as to ensure the catalogue is populated with available hardware.
-	clusterSpecValidator := NewClusterSpecValidator()
+	clusterSpecValidator := NewClusterSpecValidator(
+		NewLibraryTemplatesRenderAssertion(p.templateBuilder),
+	)
 
-	// TODO(chrisdoherty4) Apply assertions specific to upgrade.
+	// TODO(chrisdoherty4) Apply more assertions specific to upgrade.
 
 	if err := clusterSpecValidator.Validate(tinkerbellClusterSpec); err != nil {
 		return err
@@ -141,7 +154,7 @@ func (p *Provider) PostBootstrapSetupUpgrade(ctx context.Context, clusterConfig
 	if err != nil {
 		return fmt.Errorf("applying hardware yaml: %v", err)
 	}
-	return nil
+	return p.netbootSelectedHardware(ctx, cluster)
 }
 
 func (p *Provider) RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpec *cluster.Spec, clusterSpec *cluster.Spec, workloadCluster *types.Cluster, managementCluster *types.Cluster) error {
@@ -161,7 +174,7 @@ func (p *Provider) RunPostControlPlaneUpgrade(ctx context.Context, oldClusterSpe
 	if err != nil {
 		return fmt.Errorf("failed updating the tinkerbell provider resource set post upgrade: %v", err)
 	} */
-	return nil
+	return p.reconcileKubeVipServicesManifest(ctx, workloadCluster, oldClusterSpec, clusterSpec)
 }
 
 func (p *Provider) UpgradeNeeded(_ context.Context, _, _ *cluster.Spec, _ *types.Cluster) (bool, error) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	rufiov1alpha1 "github.com/tinkerbell/rufio/api/v1alpha1"
 	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -201,6 +202,43 @@ func (ss *selectorSet) Add(selector v1alpha1.HardwareSelector) error {
 	return nil
 }
 
+// validateBMCsReachable ensures each BMC in bmcs can be contacted so Rufio can manage the power
+// and boot order of its machine. The check may be unreliable due to its implementation.
+func validateBMCsReachable(client networkutils.NetClient, bmcs []*rufiov1alpha1.BaseboardManagement) error {
+	for _, bmc := range bmcs {
+		if !networkutils.IsIPInUse(client, bmc.Spec.Connection.Host) {
+			return fmt.Errorf("bmc not reachable: name '%v'; ip '%v'", bmc.Name, bmc.Spec.Connection.Host)
+		}
+	}
+	return nil
+}
+
+// getBMCsForSelectedHardware retrieves the BMCs from catalogue that manage hardware satisfying
+// any of selectors. Hardware without a BMC, and hardware for which skip returns true, is ignored.
+// A nil skip ignores no hardware. catalogue must be indexed with hardware.WithBMCNameIndex.
+func getBMCsForSelectedHardware(
+	catalogue *hardware.Catalogue,
+	selectors selectorSet,
+	skip func(*tinkv1alpha1.Hardware) bool,
+) ([]*rufiov1alpha1.BaseboardManagement, error) {
+	var bmcs []*rufiov1alpha1.BaseboardManagement
+	for _, hw := range catalogue.AllHardware() {
+		if hw.Spec.BMCRef == nil || len(getMatchingHardwareSelectors(hw, selectors)) == 0 {
+			continue
+		}
+		if skip != nil && skip(hw) {
+			continue
+		}
+
+		matches, err := catalogue.LookupBMC(hardware.BMCNameIndex, hw.Spec.BMCRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		bmcs = append(bmcs, matches...)
+	}
+	return bmcs, nil
+}
+
 func getMatchingHardwareSelectors(
 	hw *tinkv1alpha1.Hardware,
 	selectors selectorSet,
@@ -15,3 +15,22 @@ func ValidateControlPlaneIpUniqueness(cluster *v1alpha1.Cluster, netClient netwo
 	}
 	return nil
 }
+
+// ValidateControlPlaneIpReachable checks that the control plane endpoint is already served, which is
+// expected when the endpoint is load balanced outside of EKS Anywhere.
+func ValidateControlPlaneIpReachable(cluster *v1alpha1.Cluster, netClient networkutils.NetClient) error {
+	ip := cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+	if !networkutils.IsIPInUse(netClient, ip) {
+		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is not reachable, the external load balancer must be serving it before creating the cluster", ip)
+	}
+	return nil
+}
+
+// ValidateControlPlaneEndpointIp runs the control plane endpoint ip check that applies to the endpoint load balancer.
+// External load balancers must already serve the ip, while the ones managed by EKS Anywhere require an unused ip.
+func ValidateControlPlaneEndpointIp(cluster *v1alpha1.Cluster, netClient networkutils.NetClient) error {
+	if cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.ExternalLoadBalancer {
+		return ValidateControlPlaneIpReachable(cluster, netClient)
+	}
+	return ValidateControlPlaneIpUniqueness(cluster, netClient)
+}
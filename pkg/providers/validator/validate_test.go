@@ -2,6 +2,7 @@ package validator_test
 
 import (
 	"errors"
+	"net"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -30,3 +31,42 @@ func TestValidateControlPlaneIpUniqueness(t *testing.T) {
 		Return(nil, errors.New("no connection"))
 	g.Expect(validator.ValidateControlPlaneIpUniqueness(cluster, client)).To(Succeed())
 }
+
+func TestValidateControlPlaneEndpointIpExternalReachable(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{
+					Host:         "1.2.3.4",
+					LoadBalancer: v1alpha1.ExternalLoadBalancer,
+				},
+			},
+		},
+	}
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockNetClient(ctrl)
+	conn, _ := net.Pipe()
+	client.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).Return(conn, nil)
+	g.Expect(validator.ValidateControlPlaneEndpointIp(cluster, client)).To(Succeed())
+}
+
+func TestValidateControlPlaneEndpointIpExternalNotReachable(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+				Endpoint: &v1alpha1.Endpoint{
+					Host:         "1.2.3.4",
+					LoadBalancer: v1alpha1.ExternalLoadBalancer,
+				},
+			},
+		},
+	}
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockNetClient(ctrl)
+	client.EXPECT().DialTimeout(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(5).
+		Return(nil, errors.New("no connection"))
+	g.Expect(validator.ValidateControlPlaneEndpointIp(cluster, client)).To(MatchError(ContainSubstring("is not reachable")))
+}
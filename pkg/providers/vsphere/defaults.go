@@ -111,7 +111,48 @@ func (d *Defaulter) setDefaultTemplateIfMissing(ctx context.Context, spec *Spec,
 	return nil
 }
 
-func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
+// previewDefaultsForMachineConfig sets the same defaults as setDefaultsForMachineConfig without importing
+// the default templates that don't exist yet. It returns the paths of those templates, which are set in the
+// machine configs with the path they will have once imported.
+func (d *Defaulter) previewDefaultsForMachineConfig(ctx context.Context, spec *Spec) (map[string]struct{}, error) {
+	templatesToImport := map[string]struct{}{}
+	setDefaultsForEtcdMachineConfig(spec.etcdMachineConfig())
+	for _, m := range spec.machineConfigs() {
+		setDefaultsForMachineConfig(m)
+		if m.Spec.Template == "" {
+			if _, err := setDefaultTemplatePath(spec, m); err != nil {
+				return nil, err
+			}
+
+			templateFullPath, err := d.govc.SearchTemplate(ctx, spec.datacenterConfig.Spec.Datacenter, m)
+			if err != nil {
+				return nil, fmt.Errorf("checking for template: %v", err)
+			}
+
+			if len(templateFullPath) <= 0 {
+				logger.Info("Default template is missing and will be imported", "template", m.Spec.Template)
+				templatesToImport[m.Spec.Template] = struct{}{}
+				// Templates imported by EKS Anywhere always have a snapshot
+				m.Spec.DiskGiB = 25
+				continue
+			}
+		}
+
+		if err := d.setTemplateFullPath(ctx, spec.datacenterConfig, m); err != nil {
+			return nil, err
+		}
+
+		if err := d.setDiskDefaults(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+
+	return templatesToImport, nil
+}
+
+// setDefaultTemplatePath sets the path of the default template for the machine config OS family
+// and returns the OVA the template is imported from.
+func setDefaultTemplatePath(spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) (releasev1.OSImage, error) {
 	osFamily := machineConfig.Spec.OSFamily
 	eksd := spec.VersionsBundle.EksD
 	var ova releasev1.OSImage
@@ -121,12 +162,21 @@ func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machin
 	case anywherev1.Ubuntu:
 		ova = eksd.Ova.Ubuntu
 	default:
-		return fmt.Errorf("can not import ova for osFamily: %s, please use a valid osFamily", osFamily)
+		return ova, fmt.Errorf("can not import ova for osFamily: %s, please use a valid osFamily", osFamily)
 	}
 
 	templateName := fmt.Sprintf("%s-%s-%s-%s-%s", osFamily, eksd.KubeVersion, eksd.Name, strings.Join(ova.Arch, "-"), ova.SHA256[:7])
 	machineConfig.Spec.Template = filepath.Join("/", spec.datacenterConfig.Spec.Datacenter, defaultTemplatesFolder, templateName)
 
+	return ova, nil
+}
+
+func (d *Defaulter) setupDefaultTemplate(ctx context.Context, spec *Spec, machineConfig *anywherev1.VSphereMachineConfig) error {
+	ova, err := setDefaultTemplatePath(spec, machineConfig)
+	if err != nil {
+		return err
+	}
+
 	tags := requiredTemplateTagsByCategory(spec.Spec, machineConfig)
 
 	// TODO: figure out if it's worth refactoring the factory to be able to reuse across machine configs.
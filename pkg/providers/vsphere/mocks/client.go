@@ -55,6 +55,20 @@ func (mr *MockProviderGovcClientMockRecorder) AddTag(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockProviderGovcClient)(nil).AddTag), arg0, arg1, arg2)
 }
 
+// CheckVCenterSetupMachineConfig mocks base method.
+func (m *MockProviderGovcClient) CheckVCenterSetupMachineConfig(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig, arg2 *v1alpha1.VSphereMachineConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckVCenterSetupMachineConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckVCenterSetupMachineConfig indicates an expected call of CheckVCenterSetupMachineConfig.
+func (mr *MockProviderGovcClientMockRecorder) CheckVCenterSetupMachineConfig(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckVCenterSetupMachineConfig", reflect.TypeOf((*MockProviderGovcClient)(nil).CheckVCenterSetupMachineConfig), arg0, arg1, arg2)
+}
+
 // ConfigureCertThumbprint mocks base method.
 func (m *MockProviderGovcClient) ConfigureCertThumbprint(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -467,6 +481,20 @@ func (mr *MockProviderKubectlClientMockRecorder) DeleteEksaMachineConfig(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEksaMachineConfig", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteEksaMachineConfig), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DeleteKubeSpecFromBytesIgnoreNotFound mocks base method.
+func (m *MockProviderKubectlClient) DeleteKubeSpecFromBytesIgnoreNotFound(arg0 context.Context, arg1 *types.Cluster, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKubeSpecFromBytesIgnoreNotFound", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKubeSpecFromBytesIgnoreNotFound indicates an expected call of DeleteKubeSpecFromBytesIgnoreNotFound.
+func (mr *MockProviderKubectlClientMockRecorder) DeleteKubeSpecFromBytesIgnoreNotFound(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKubeSpecFromBytesIgnoreNotFound", reflect.TypeOf((*MockProviderKubectlClient)(nil).DeleteKubeSpecFromBytesIgnoreNotFound), arg0, arg1, arg2)
+}
+
 // GetEksaCluster mocks base method.
 func (m *MockProviderKubectlClient) GetEksaCluster(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*v1alpha1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,148 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const (
+	datacenterConfigCheck      = "vSphere datacenter config is valid"
+	vCenterAccessCheck         = "vCenter is reachable and credentials are valid"
+	vCenterConfigCheck         = "vSphere datacenter and network exist"
+	machineConfigDefaultsCheck = "vSphere machine config defaults are set"
+	machineConfigsCheck        = "vSphere machine configs are valid"
+	datastoreUsageCheck        = "vSphere datastores have enough space"
+	existingConfigsCheck       = "vSphere configs don't exist in the management cluster"
+	machineConfigNamesCheck    = "vSphere machine config names are unique"
+	controlPlaneIPCheck        = "control plane IP is not in use"
+)
+
+// preflight holds the state shared by the checks of a single preflight run.
+type preflight struct {
+	*vsphereProvider
+	spec              *Spec
+	templatesToImport map[string]struct{}
+}
+
+// CreatePreflightChecks returns the create validations of SetupAndValidateCreateCluster as individual checks
+// that don't import the missing templates, create folders or generate ssh keys.
+func (p *vsphereProvider) CreatePreflightChecks(clusterSpec *cluster.Spec) []providers.PreflightCheck {
+	f := p.newPreflight(clusterSpec)
+	checks := f.commonChecks()
+	if clusterSpec.Cluster.IsManaged() {
+		checks = append(checks, providers.PreflightCheck{
+			Name: existingConfigsCheck,
+			Run: func(ctx context.Context) error {
+				return p.validateManagedConfigsDontExist(ctx, clusterSpec)
+			},
+		})
+	}
+
+	return append(checks, f.controlPlaneIPCheck())
+}
+
+// UpgradePreflightChecks returns the upgrade validations of SetupAndValidateUpgradeCluster as individual checks
+// that don't import the missing templates, create folders or generate ssh keys.
+func (p *vsphereProvider) UpgradePreflightChecks(cluster *types.Cluster, clusterSpec *cluster.Spec) []providers.PreflightCheck {
+	f := p.newPreflight(clusterSpec)
+	return append(f.commonChecks(), providers.PreflightCheck{
+		Name: machineConfigNamesCheck,
+		Run: func(ctx context.Context) error {
+			if err := p.validateMachineConfigsNameUniqueness(ctx, cluster, clusterSpec); err != nil {
+				return fmt.Errorf("failed validate machineconfig uniqueness: %v", err)
+			}
+			return nil
+		},
+	})
+}
+
+func (p *vsphereProvider) newPreflight(clusterSpec *cluster.Spec) *preflight {
+	return &preflight{
+		vsphereProvider: p,
+		spec:            NewSpec(clusterSpec, p.machineConfigs, p.datacenterConfig),
+	}
+}
+
+func (f *preflight) commonChecks() []providers.PreflightCheck {
+	return []providers.PreflightCheck{
+		{
+			Name: datacenterConfigCheck,
+			Run: func(ctx context.Context) error {
+				if err := SetupEnvVars(f.spec.datacenterConfig); err != nil {
+					return fmt.Errorf("failed setup and validations: %v", err)
+				}
+				if err := f.defaulter.SetDefaultsForDatacenterConfig(ctx, f.spec.datacenterConfig); err != nil {
+					return fmt.Errorf("failed setting default values for vsphere datacenter config: %v", err)
+				}
+				return f.spec.datacenterConfig.ValidateFields()
+			},
+		},
+		{
+			Name:      vCenterAccessCheck,
+			DependsOn: []string{datacenterConfigCheck},
+			Run: func(ctx context.Context) error {
+				return f.validator.validateVCenterAccess(ctx, f.spec.datacenterConfig.Spec.Server)
+			},
+		},
+		{
+			Name:      vCenterConfigCheck,
+			DependsOn: []string{vCenterAccessCheck},
+			Run: func(ctx context.Context) error {
+				datacenterConfig := f.spec.datacenterConfig
+				if err := f.validator.validateThumbprint(ctx, datacenterConfig); err != nil {
+					return err
+				}
+				if err := f.validator.validateDatacenter(ctx, datacenterConfig.Spec.Datacenter); err != nil {
+					return err
+				}
+				return f.validator.validateNetwork(ctx, datacenterConfig.Spec.Network)
+			},
+		},
+		{
+			Name:      machineConfigDefaultsCheck,
+			DependsOn: []string{vCenterConfigCheck},
+			Run: func(ctx context.Context) (err error) {
+				f.templatesToImport, err = f.defaulter.previewDefaultsForMachineConfig(ctx, f.spec)
+				if err != nil {
+					return fmt.Errorf("failed setting default values for vsphere machine configs: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      machineConfigsCheck,
+			DependsOn: []string{machineConfigDefaultsCheck},
+			Run: func(ctx context.Context) error {
+				return f.validator.validateClusterMachineConfigs(ctx, f.spec, machineConfigsValidation{
+					readOnly:          true,
+					templatesToImport: f.templatesToImport,
+				})
+			},
+		},
+		{
+			Name:      datastoreUsageCheck,
+			DependsOn: []string{machineConfigsCheck},
+			Run: func(ctx context.Context) error {
+				return f.validator.validateDatastoreUsage(ctx, f.spec, f.spec.controlPlaneMachineConfig(), f.spec.etcdMachineConfig())
+			},
+		},
+	}
+}
+
+func (f *preflight) controlPlaneIPCheck() providers.PreflightCheck {
+	return providers.PreflightCheck{
+		Name: controlPlaneIPCheck,
+		Run: func(_ context.Context) error {
+			if f.skipIpCheck {
+				logger.Info("Skipping check for whether control plane ip is in use")
+				return nil
+			}
+			return f.validator.validateControlPlaneEndpointIp(f.spec)
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+func runPreflightChecks(ctx context.Context, checks []providers.PreflightCheck) error {
+	for _, c := range checks {
+		if err := c.Run(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestCreatePreflightChecksMissingDefaultTemplate(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.VersionsBundle.EksD.Ova.Ubuntu.SHA256 = "63a8dce1683379cb8df7d15e9c5adf9462a2b9803a544dd79b16f19a4657967f"
+	tt.clusterSpec.VersionsBundle.EksD.Ova.Ubuntu.Arch = []string{"amd64"}
+	tt.clusterSpec.VersionsBundle.EksD.Name = eksd119Release
+	tt.clusterSpec.VersionsBundle.EksD.KubeVersion = "v1.19.8"
+	for _, m := range tt.machineConfigs {
+		m.Spec.Template = ""
+		m.Spec.DiskGiB = 40
+	}
+	wantTemplate := "/SDDC-Datacenter/vm/Templates/ubuntu-v1.19.8-kubernetes-1-19-eks-4-amd64-63a8dce"
+
+	tt.setExpectationForSetup()
+	tt.setExpectationForVCenterValidation()
+	for _, m := range tt.machineConfigs {
+		tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, m).Return("", nil)
+		tt.govc.EXPECT().CheckVCenterSetupMachineConfig(tt.ctx, tt.datacenterConfig, m).Return(nil)
+	}
+	tt.govc.EXPECT().GetWorkloadAvailableSpace(tt.ctx, gomock.Any()).Return(1000.0, nil).AnyTimes()
+
+	tt.Expect(runPreflightChecks(tt.ctx, tt.provider.CreatePreflightChecks(tt.clusterSpec))).To(Succeed())
+	for _, m := range tt.machineConfigs {
+		tt.Expect(m.Spec.Template).To(Equal(wantTemplate))
+		tt.Expect(m.Spec.DiskGiB).To(Equal(25))
+	}
+}
+
+func TestCreatePreflightChecksMissingFolder(t *testing.T) {
+	tt := newProviderTest(t)
+
+	tt.setExpectationForSetup()
+	tt.setExpectationForVCenterValidation()
+	tt.setExpectationsForDefaultDiskGovcCalls()
+	for _, m := range tt.machineConfigs {
+		tt.govc.EXPECT().SearchTemplate(tt.ctx, tt.datacenterConfig.Spec.Datacenter, m).Return(m.Spec.Template, nil)
+		tt.govc.EXPECT().CheckVCenterSetupMachineConfig(tt.ctx, tt.datacenterConfig, m).Return(errors.New("folder does not exist")).MaxTimes(1)
+	}
+
+	err := runPreflightChecks(tt.ctx, tt.provider.CreatePreflightChecks(tt.clusterSpec))
+	tt.Expect(err).To(MatchError(ContainSubstring("folder does not exist")))
+}
+
+func TestCreatePreflightChecksDependencies(t *testing.T) {
+	tt := newProviderTest(t)
+	names := map[string]struct{}{}
+	for _, c := range tt.provider.CreatePreflightChecks(tt.clusterSpec) {
+		for _, d := range c.DependsOn {
+			tt.Expect(names).To(HaveKey(d), "check %s depends on %s, which is not returned before it", c.Name, d)
+		}
+		names[c.Name] = struct{}{}
+	}
+	tt.Expect(names).To(HaveKey(datastoreUsageCheck))
+}
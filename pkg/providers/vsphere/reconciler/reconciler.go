@@ -4,24 +4,30 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	c "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	clustercontrollers "github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/metrics"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
 	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/kubevip"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
@@ -41,10 +47,24 @@ const (
 	controlPlaneReadyCondition            clusterv1.ConditionType = "ControlPlaneReady"
 )
 
+// Reasons of the events recorded by the vSphere reconcilers.
+const (
+	reasonSpecInvalid             = "SpecInvalid"
+	reasonDatacenterNotValid      = "DatacenterConfigNotValid"
+	reasonOIDCConfigChanged       = "OIDCConfigChanged"
+	reasonControlPlaneSpecApplied = "ControlPlaneSpecApplied"
+	reasonWorkerNodeSpecApplied   = "WorkerNodeSpecApplied"
+	reasonWaitingForEtcd          = "WaitingForEtcd"
+	reasonWaitingForControlPlane  = "WaitingForControlPlane"
+	reasonExtraObjectsApplied     = "ExtraObjectsApplied"
+	reasonCNISpecApplied          = "CNISpecApplied"
+)
+
 // Struct that holds common methods and properties
 type VSphereReconciler struct {
 	Client    client.Client
 	Log       logr.Logger
+	Recorder  record.EventRecorder
 	Validator *vsphere.Validator
 	Defaulter *vsphere.Defaulter
 	tracker   *remote.ClusterCacheTracker
@@ -55,11 +75,12 @@ type VSphereClusterReconciler struct {
 	*clustercontrollers.ProviderClusterReconciler
 }
 
-func NewVSphereReconciler(client client.Client, log logr.Logger, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) *VSphereClusterReconciler {
+func NewVSphereReconciler(client client.Client, log logr.Logger, recorder record.EventRecorder, validator *vsphere.Validator, defaulter *vsphere.Defaulter, tracker *remote.ClusterCacheTracker) *VSphereClusterReconciler {
 	return &VSphereClusterReconciler{
 		VSphereReconciler: VSphereReconciler{
 			Client:    client,
 			Log:       log,
+			Recorder:  recorder,
 			Validator: validator,
 			Defaulter: defaulter,
 			tracker:   tracker,
@@ -115,8 +136,16 @@ func (v *VSphereClusterReconciler) bundles(ctx context.Context, name, namespace
 	return clusterBundle, nil
 }
 
+func (v *VSphereClusterReconciler) oidcConfig(ctx context.Context, name, namespace string) (*anywherev1.OIDCConfig, error) {
+	oidcConfig := &anywherev1.OIDCConfig{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, oidcConfig); err != nil {
+		return nil, err
+	}
+	return oidcConfig, nil
+}
+
 func (v *VSphereClusterReconciler) FetchAppliedSpec(ctx context.Context, cs *anywherev1.Cluster) (*c.Spec, error) {
-	return c.BuildSpecForCluster(ctx, cs, v.bundles, v.GetEksdRelease, nil, nil, nil)
+	return c.BuildSpecForCluster(ctx, cs, v.bundles, v.GetEksdRelease, nil, nil, v.oidcConfig)
 }
 
 func (v *VSphereClusterReconciler) Reconcile(ctx context.Context, cluster *anywherev1.Cluster) (controller.Result, error) {
@@ -132,6 +161,7 @@ func (v *VSphereClusterReconciler) Reconcile(ctx context.Context, cluster *anywh
 	}
 	if !dataCenterConfig.Status.SpecValid {
 		v.Log.Info("Skipping cluster reconciliation because data center config is invalid", "data center", dataCenterConfig.Name)
+		v.Recorder.Eventf(cluster, apiv1.EventTypeWarning, reasonDatacenterNotValid, "Waiting for VSphereDatacenterConfig %s to be valid", dataCenterConfig.Name)
 		return controller.Result{
 			Result: &ctrl.Result{
 				Requeue:      true,
@@ -166,11 +196,20 @@ func (v *VSphereClusterReconciler) Reconcile(ctx context.Context, cluster *anywh
 		return controller.Result{}, err
 	}
 
-	specWithBundles, err := c.BuildSpecFromBundles(cluster, bundles, c.WithEksdRelease(eksd))
+	oidcConfig, err := c.GetOIDCForCluster(ctx, cluster, v.oidcConfig)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	specWithBundles, err := c.BuildSpecFromBundles(cluster, bundles, c.WithEksdRelease(eksd), c.WithOIDCConfig(oidcConfig))
+	if err != nil {
+		return controller.Result{}, err
+	}
 
 	vsphereClusterSpec := vsphere.NewSpec(specWithBundles, machineConfigMap, dataCenterConfig)
 
 	if err := v.Validator.ValidateClusterMachineConfigs(ctx, vsphereClusterSpec); err != nil {
+		v.Recorder.Eventf(cluster, apiv1.EventTypeWarning, reasonSpecInvalid, "Invalid machine configs: %v", err)
 		return controller.Result{}, err
 	}
 
@@ -229,6 +268,7 @@ func (v *VSphereClusterReconciler) Reconcile(ctx context.Context, cluster *anywh
 	if cluster.Spec.ExternalEtcdConfiguration != nil {
 		if !conditions.Has(capiCluster, managedEtcdReadyCondition) || conditions.IsFalse(capiCluster, managedEtcdReadyCondition) {
 			v.Log.Info("Waiting for etcd to be ready", "cluster", cluster.Name)
+			v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonWaitingForEtcd, "Waiting for etcd to be ready")
 			return controller.Result{Result: &ctrl.Result{
 				RequeueAfter: defaultRequeueTime,
 			}}, nil
@@ -237,6 +277,7 @@ func (v *VSphereClusterReconciler) Reconcile(ctx context.Context, cluster *anywh
 
 	if !conditions.IsTrue(capiCluster, controlPlaneReadyCondition) {
 		v.Log.Info("waiting for control plane to be ready", "cluster", capiCluster.Name, "kind", capiCluster.Kind)
+		v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonWaitingForControlPlane, "Waiting for control plane to be ready")
 		return controller.Result{Result: &ctrl.Result{
 			RequeueAfter: defaultRequeueTime,
 		}}, err
@@ -250,10 +291,41 @@ func (v *VSphereClusterReconciler) Reconcile(ctx context.Context, cluster *anywh
 		return result, err
 	}
 
+	if result, err := v.reconcileServiceLoadBalancer(ctx, cluster, capiCluster, specWithBundles); err != nil {
+		return result, err
+	}
+
+	return controller.Result{}, nil
+}
+
+// reconcileServiceLoadBalancer installs, updates or removes the kube-vip services load balancer in the workload cluster
+// following the cluster loadBalancerConfiguration.
+func (v *VSphereClusterReconciler) reconcileServiceLoadBalancer(ctx context.Context, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, specWithBundles *c.Spec) (controller.Result, error) {
+	key := client.ObjectKey{
+		Namespace: capiCluster.Namespace,
+		Name:      capiCluster.Name,
+	}
+	remoteClient, err := v.tracker.GetClient(ctx, key)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	bundle := specWithBundles.VersionsBundle.VSphere
+	if err := kubevip.ReconcileServicesLoadBalancer(ctx, remoteClient, cluster.Spec.LoadBalancerConfiguration, bundle.KubeVip.VersionedImage(), bundle.KubeVipCloudProvider.VersionedImage()); err != nil {
+		return controller.Result{}, err
+	}
+
 	return controller.Result{}, nil
 }
 
 func (v *VSphereClusterReconciler) reconcileCNI(ctx context.Context, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, specWithBundles *c.Spec) (controller.Result, error) {
+	defer metrics.ObservePhase(constants.VSphereProviderName, metrics.PhaseCNI, time.Now())
+
+	if cluster.Spec.ClusterNetwork.CNIConfig.IsUserManaged() {
+		v.Log.Info("Skipping CNI reconciliation, CNI is user managed", "cluster", cluster.Name)
+		return controller.Result{}, nil
+	}
+
 	if !conditions.Has(cluster, cniSpecAppliedCondition) || conditions.IsFalse(capiCluster, cniSpecAppliedCondition) {
 		v.Log.Info("Getting remote client", "client for cluster", capiCluster.Name)
 		key := client.ObjectKey{
@@ -281,11 +353,14 @@ func (v *VSphereClusterReconciler) reconcileCNI(ctx context.Context, cluster *an
 			return controller.Result{}, err
 		}
 		conditions.MarkTrue(cluster, cniSpecAppliedCondition)
+		v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonCNISpecApplied, "Applied CNI spec")
 	}
 	return controller.Result{}, nil
 }
 
 func (v *VSphereClusterReconciler) reconcileExtraObjects(ctx context.Context, cluster *anywherev1.Cluster, capiCluster *clusterv1.Cluster, specWithBundles *c.Spec) (controller.Result, error) {
+	defer metrics.ObservePhase(constants.VSphereProviderName, metrics.PhaseExtraObjects, time.Now())
+
 	if !conditions.IsTrue(capiCluster, extraObjectsSpecPlaneAppliedCondition) {
 		extraObjects := c.BuildExtraObjects(specWithBundles)
 
@@ -295,6 +370,7 @@ func (v *VSphereClusterReconciler) reconcileExtraObjects(ctx context.Context, cl
 			}
 		}
 		conditions.MarkTrue(cluster, extraObjectsSpecPlaneAppliedCondition)
+		v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonExtraObjectsApplied, "Applied extra objects spec")
 	}
 	return controller.Result{}, nil
 }
@@ -316,6 +392,8 @@ func (v *VSphereClusterReconciler) reconcileWorkerNodeSpec(
 	ctx context.Context, cluster *anywherev1.Cluster, templateBuilder providers.TemplateBuilder,
 	specWithBundles *c.Spec, workloadTemplateNames, kubeadmconfigTemplateNames map[string]string,
 ) (controller.Result, error) {
+	defer metrics.ObservePhase(constants.VSphereProviderName, metrics.PhaseWorkersSpec, time.Now())
+
 	if !conditions.IsTrue(cluster, workerNodeSpecPlaneAppliedCondition) {
 		workersSpec, err := templateBuilder.GenerateCAPISpecWorkers(specWithBundles, workloadTemplateNames, kubeadmconfigTemplateNames)
 		if err != nil {
@@ -327,11 +405,26 @@ func (v *VSphereClusterReconciler) reconcileWorkerNodeSpec(
 		}
 
 		conditions.MarkTrue(cluster, workerNodeSpecPlaneAppliedCondition)
+		v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonWorkerNodeSpecApplied, "Applied worker node spec")
 	}
 	return controller.Result{}, nil
 }
 
 func (v *VSphereClusterReconciler) reconcileControlPlaneSpec(ctx context.Context, cluster *anywherev1.Cluster, templateBuilder providers.TemplateBuilder, specWithBundles *c.Spec, cpOpt func(values map[string]interface{})) (controller.Result, error) {
+	defer metrics.ObservePhase(constants.VSphereProviderName, metrics.PhaseControlPlaneSpec, time.Now())
+
+	if conditions.IsTrue(cluster, controlSpecPlaneAppliedCondition) {
+		outdated, err := v.controlPlaneOIDCOutdated(ctx, cluster, specWithBundles)
+		if err != nil {
+			return controller.Result{}, err
+		}
+		if outdated {
+			v.Log.Info("OIDC configuration changed, rolling out control plane", "name", cluster.Name)
+			v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonOIDCConfigChanged, "OIDC configuration changed, rolling out control plane")
+			conditions.MarkFalse(cluster, controlSpecPlaneAppliedCondition, reasonOIDCConfigChanged, clusterv1.ConditionSeverityInfo, "")
+		}
+	}
+
 	if !conditions.IsTrue(cluster, controlSpecPlaneAppliedCondition) {
 		v.Log.Info("Applying control plane spec", "name", cluster.Name)
 		controlPlaneSpec, err := templateBuilder.GenerateCAPISpecControlPlane(specWithBundles, cpOpt)
@@ -344,6 +437,40 @@ func (v *VSphereClusterReconciler) reconcileControlPlaneSpec(ctx context.Context
 			}}, err
 		}
 		conditions.MarkTrue(cluster, controlSpecPlaneAppliedCondition)
+		v.Recorder.Event(cluster, apiv1.EventTypeNormal, reasonControlPlaneSpecApplied, "Applied control plane spec")
 	}
 	return controller.Result{}, nil
 }
+
+// controlPlaneOIDCOutdated checks if the OIDC configuration of the control plane API server differs
+// from the OIDCConfig referenced by the cluster.
+func (v *VSphereClusterReconciler) controlPlaneOIDCOutdated(ctx context.Context, cluster *anywherev1.Cluster, specWithBundles *c.Spec) (bool, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	kcpName := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: cluster.Name}
+	if err := v.Client.Get(ctx, kcpName, kcp); err != nil {
+		return false, err
+	}
+
+	var currentArgs map[string]string
+	if kcp.Spec.KubeadmConfigSpec.ClusterConfiguration != nil {
+		currentArgs = kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs
+	}
+
+	return oidcArgsOutdated(currentArgs, clusterapi.OIDCToExtraArgs(specWithBundles.OIDCConfig)), nil
+}
+
+func oidcArgsOutdated(current, desired map[string]string) bool {
+	for k, v := range desired {
+		if current[k] != v {
+			return true
+		}
+	}
+
+	for k := range current {
+		if _, ok := desired[k]; strings.HasPrefix(k, "oidc-") && !ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package reconciler
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestOIDCArgsOutdated(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		desired  map[string]string
+		outdated bool
+	}{
+		{
+			name: "unchanged",
+			current: map[string]string{
+				"oidc-client-id":  "id",
+				"oidc-issuer-url": "https://issuer",
+				"audit-log-path":  "/var/log/audit.log",
+			},
+			desired: map[string]string{
+				"oidc-client-id":  "id",
+				"oidc-issuer-url": "https://issuer",
+			},
+			outdated: false,
+		},
+		{
+			name: "client id changed",
+			current: map[string]string{
+				"oidc-client-id":  "id",
+				"oidc-issuer-url": "https://issuer",
+			},
+			desired: map[string]string{
+				"oidc-client-id":  "id2",
+				"oidc-issuer-url": "https://issuer",
+			},
+			outdated: true,
+		},
+		{
+			name: "claim removed",
+			current: map[string]string{
+				"oidc-client-id":      "id",
+				"oidc-issuer-url":     "https://issuer",
+				"oidc-username-claim": "email",
+			},
+			desired: map[string]string{
+				"oidc-client-id":  "id",
+				"oidc-issuer-url": "https://issuer",
+			},
+			outdated: true,
+		},
+		{
+			name:    "oidc added",
+			current: nil,
+			desired: map[string]string{
+				"oidc-client-id":  "id",
+				"oidc-issuer-url": "https://issuer",
+			},
+			outdated: true,
+		},
+		{
+			name: "oidc removed",
+			current: map[string]string{
+				"oidc-client-id":  "id",
+				"oidc-issuer-url": "https://issuer",
+			},
+			desired:  map[string]string{},
+			outdated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(oidcArgsOutdated(tt.current, tt.desired)).To(Equal(tt.outdated))
+		})
+	}
+}
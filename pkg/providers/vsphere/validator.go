@@ -9,6 +9,7 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
+	providerValidator "github.com/aws/eks-anywhere/pkg/providers/validator"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -62,6 +63,22 @@ func (v *Validator) ValidateVCenterConfig(ctx context.Context, datacenterConfig
 
 // TODO: dry out machine configs validations
 func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereClusterSpec *Spec) error {
+	if err := v.validateClusterMachineConfigs(ctx, vsphereClusterSpec, machineConfigsValidation{}); err != nil {
+		return err
+	}
+
+	return v.validateDatastoreUsage(ctx, vsphereClusterSpec, vsphereClusterSpec.controlPlaneMachineConfig(), vsphereClusterSpec.etcdMachineConfig())
+}
+
+// machineConfigsValidation customizes how validateClusterMachineConfigs checks the machine configs in vCenter.
+type machineConfigsValidation struct {
+	// readOnly fails when a machine config folder doesn't exist instead of creating it.
+	readOnly bool
+	// templatesToImport are default templates that will be imported, so their presence and tags are not validated.
+	templatesToImport map[string]struct{}
+}
+
+func (v *Validator) validateClusterMachineConfigs(ctx context.Context, vsphereClusterSpec *Spec, check machineConfigsValidation) error {
 	var etcdMachineConfig *anywherev1.VSphereMachineConfig
 
 	// TODO: move this to api Cluster validations
@@ -150,9 +167,18 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		return err
 	}
 
+	if err := v.validateControlPlaneIpFamily(vsphereClusterSpec); err != nil {
+		return err
+	}
+
 	for _, config := range vsphereClusterSpec.machineConfigsLookup {
-		var b bool                                                                                            // Temporary until we remove the need to pass a bool pointer
-		err := v.govc.ValidateVCenterSetupMachineConfig(ctx, vsphereClusterSpec.datacenterConfig, config, &b) // TODO: remove side effects from this implementation or directly move it to set defaults (pointer to bool is not needed)
+		var err error
+		if check.readOnly {
+			err = v.govc.CheckVCenterSetupMachineConfig(ctx, vsphereClusterSpec.datacenterConfig, config)
+		} else {
+			var b bool                                                                                           // Temporary until we remove the need to pass a bool pointer
+			err = v.govc.ValidateVCenterSetupMachineConfig(ctx, vsphereClusterSpec.datacenterConfig, config, &b) // TODO: remove side effects from this implementation or directly move it to set defaults (pointer to bool is not needed)
+		}
 		if err != nil {
 			return fmt.Errorf("validating vCenter setup for VSphereMachineConfig %v: %v", config.Name, err)
 		}
@@ -191,11 +217,13 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		return errors.New("VSphereDatacenterConfig and Cluster objects must have the same namespace specified")
 	}
 
-	if err := v.validateTemplate(ctx, vsphereClusterSpec, controlPlaneMachineConfig); err != nil {
-		logger.V(1).Info("Control plane template validation failed.")
-		return err
+	if _, ok := check.templatesToImport[controlPlaneMachineConfig.Spec.Template]; !ok {
+		if err := v.validateTemplate(ctx, vsphereClusterSpec, controlPlaneMachineConfig); err != nil {
+			logger.V(1).Info("Control plane template validation failed.")
+			return err
+		}
+		logger.MarkPass("Control plane and Workload templates validated")
 	}
-	logger.MarkPass("Control plane and Workload templates validated")
 
 	if etcdMachineConfig != nil {
 		if etcdMachineConfig.Spec.Template != controlPlaneMachineConfig.Spec.Template {
@@ -203,7 +231,7 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 
-	return v.validateDatastoreUsage(ctx, vsphereClusterSpec, controlPlaneMachineConfig, etcdMachineConfig)
+	return nil
 }
 
 func (v *Validator) validateControlPlaneIp(ip string) error {
@@ -215,6 +243,26 @@ func (v *Validator) validateControlPlaneIp(ip string) error {
 	return nil
 }
 
+// validateControlPlaneIpFamily checks that the control plane endpoint belongs to one of the cluster IP families.
+func (v *Validator) validateControlPlaneIpFamily(spec *Spec) error {
+	ip := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
+	ipFamily := anywherev1.IPv4Family
+	if networkutils.IsIPv6(ip) {
+		ipFamily = anywherev1.IPv6Family
+	}
+
+	clusterIPFamilies := spec.Cluster.Spec.ClusterNetwork.IPFamilies()
+	if len(clusterIPFamilies) == 0 {
+		return nil
+	}
+	for _, clusterIPFamily := range clusterIPFamilies {
+		if clusterIPFamily == ipFamily {
+			return nil
+		}
+	}
+	return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host %s is %s but the cluster network doesn't use that IP family", ip, ipFamily)
+}
+
 func (v *Validator) validateSSHUsername(machineConfig *anywherev1.VSphereMachineConfig) error {
 	if machineConfig.Spec.OSFamily == anywherev1.Bottlerocket && machineConfig.Spec.Users[0].Name != bottlerocketDefaultUser {
 		return fmt.Errorf("SSHUsername %s is invalid. Please use 'ec2-user' for Bottlerocket", machineConfig.Spec.Users[0].Name)
@@ -378,10 +426,6 @@ func (v *Validator) validateNetwork(ctx context.Context, network string) error {
 	return nil
 }
 
-func (v *Validator) validateControlPlaneIpUniqueness(spec *Spec) error {
-	ip := spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host
-	if networkutils.IsIPInUse(v.netClient, ip) {
-		return fmt.Errorf("cluster controlPlaneConfiguration.Endpoint.Host <%s> is already in use, please provide a unique IP", ip)
-	}
-	return nil
+func (v *Validator) validateControlPlaneEndpointIp(spec *Spec) error {
+	return providerValidator.ValidateControlPlaneEndpointIp(spec.Cluster, v.netClient)
 }
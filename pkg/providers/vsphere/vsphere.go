@@ -25,6 +25,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/kubevip"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -108,6 +109,7 @@ type ProviderGovcClient interface {
 	TemplateHasSnapshot(ctx context.Context, template string) (bool, error)
 	GetWorkloadAvailableSpace(ctx context.Context, datastore string) (float64, error)
 	ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, selfSigned *bool) error
+	CheckVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig) error
 	ValidateVCenterConnection(ctx context.Context, server string) error
 	ValidateVCenterAuthentication(ctx context.Context) error
 	IsCertSelfSigned(ctx context.Context) bool
@@ -128,6 +130,7 @@ type ProviderGovcClient interface {
 
 type ProviderKubectlClient interface {
 	ApplyKubeSpecFromBytes(ctx context.Context, cluster *types.Cluster, data []byte) error
+	DeleteKubeSpecFromBytesIgnoreNotFound(ctx context.Context, cluster *types.Cluster, data []byte) error
 	GetNamespace(ctx context.Context, kubeconfig string, namespace string) error
 	CreateNamespace(ctx context.Context, kubeconfig string, namespace string) error
 	LoadSecret(ctx context.Context, secretObject string, secretObjType string, secretObjectName string, kubeConfFile string) error
@@ -400,33 +403,14 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 		return fmt.Errorf("failed setup and validations: %v", err)
 	}
 
-	// TODO: move this to validator
 	if clusterSpec.Cluster.IsManaged() {
-		for _, mc := range p.MachineConfigs(clusterSpec) {
-			em, err := p.providerKubectlClient.SearchVsphereMachineConfig(ctx, mc.GetName(), clusterSpec.ManagementCluster.KubeconfigFile, mc.GetNamespace())
-			if err != nil {
-				return err
-			}
-			if len(em) > 0 {
-				return fmt.Errorf("VSphereMachineConfig %s already exists", mc.GetName())
-			}
-		}
-		existingDatacenter, err := p.providerKubectlClient.SearchVsphereDatacenterConfig(ctx, p.datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
-		if err != nil {
+		if err := p.validateManagedConfigsDontExist(ctx, clusterSpec); err != nil {
 			return err
 		}
-		if len(existingDatacenter) > 0 {
-			return fmt.Errorf("VSphereDatacenter %s already exists", p.datacenterConfig.Name)
-		}
-		for _, identityProviderRef := range clusterSpec.Cluster.Spec.IdentityProviderRefs {
-			if identityProviderRef.Kind == v1alpha1.OIDCConfigKind {
-				clusterSpec.OIDCConfig.SetManagedBy(p.clusterConfig.ManagedBy())
-			}
-		}
 	}
 
 	if !p.skipIpCheck {
-		if err := p.validator.validateControlPlaneIpUniqueness(vSphereClusterSpec); err != nil {
+		if err := p.validator.validateControlPlaneEndpointIp(vSphereClusterSpec); err != nil {
 			return err
 		}
 	} else {
@@ -436,6 +420,35 @@ func (p *vsphereProvider) SetupAndValidateCreateCluster(ctx context.Context, clu
 	return nil
 }
 
+// validateManagedConfigsDontExist checks the vSphere configs of a workload cluster don't exist
+// yet in its management cluster.
+// TODO: move this to validator
+func (p *vsphereProvider) validateManagedConfigsDontExist(ctx context.Context, clusterSpec *cluster.Spec) error {
+	for _, mc := range p.MachineConfigs(clusterSpec) {
+		em, err := p.providerKubectlClient.SearchVsphereMachineConfig(ctx, mc.GetName(), clusterSpec.ManagementCluster.KubeconfigFile, mc.GetNamespace())
+		if err != nil {
+			return err
+		}
+		if len(em) > 0 {
+			return fmt.Errorf("VSphereMachineConfig %s already exists", mc.GetName())
+		}
+	}
+	existingDatacenter, err := p.providerKubectlClient.SearchVsphereDatacenterConfig(ctx, p.datacenterConfig.Name, clusterSpec.ManagementCluster.KubeconfigFile, clusterSpec.Cluster.Namespace)
+	if err != nil {
+		return err
+	}
+	if len(existingDatacenter) > 0 {
+		return fmt.Errorf("VSphereDatacenter %s already exists", p.datacenterConfig.Name)
+	}
+	for _, identityProviderRef := range clusterSpec.Cluster.Spec.IdentityProviderRefs {
+		if identityProviderRef.Kind == v1alpha1.OIDCConfigKind {
+			clusterSpec.OIDCConfig.SetManagedBy(p.clusterConfig.ManagedBy())
+		}
+	}
+
+	return nil
+}
+
 func (p *vsphereProvider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if err := SetupEnvVars(p.datacenterConfig); err != nil {
 		return fmt.Errorf("failed setup and validations: %v", err)
@@ -689,7 +702,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 	sharedExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs()
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
-		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration))
+		Append(clusterapi.ControlPlaneNodeLabelsExtraArgs(clusterSpec.Cluster.Spec.ControlPlaneConfiguration)).
+		Append(clusterapi.NodeIPExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
 	apiServerExtraArgs := clusterapi.OIDCToExtraArgs(clusterSpec.OIDCConfig).
 		Append(clusterapi.AwsIamAuthExtraArgs(clusterSpec.AWSIamConfig)).
 		Append(clusterapi.PodIAMAuthExtraArgs(clusterSpec.Cluster.Spec.PodIAMConfig)).
@@ -719,6 +733,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 	values := map[string]interface{}{
 		"clusterName":                          clusterSpec.Cluster.Name,
 		"controlPlaneEndpointIp":               clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
+		"controlPlaneEndpointCidrMask":         controlPlaneEndpointCidrMask(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host),
 		"controlPlaneReplicas":                 clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
 		"kubernetesRepository":                 bundle.KubeDistro.Kubernetes.Repository,
 		"kubernetesVersion":                    bundle.KubeDistro.Kubernetes.Tag,
@@ -736,6 +751,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		"controlPlaneVsphereFolder":            controlPlaneMachineSpec.Folder,
 		"managerImage":                         bundle.VSphere.Manager.VersionedImage(),
 		"kubeVipImage":                         bundle.VSphere.KubeVip.VersionedImage(),
+		"kubeVip":                              clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.KubeVipLoadBalancer,
 		"driverImage":                          bundle.VSphere.Driver.VersionedImage(),
 		"syncerImage":                          bundle.VSphere.Syncer.VersionedImage(),
 		"insecure":                             datacenterSpec.Insecure,
@@ -770,6 +786,13 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 		"eksaCSIPassword":                      eksaCSIPassword,
 	}
 
+	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancerType() == v1alpha1.HAProxyStaticPodLoadBalancer {
+		values["haproxyStaticPod"] = true
+		values["haproxyImage"] = bundle.Haproxy.Image.VersionedImage()
+		values["haproxyConfig"] = common.GetHAProxyConfig("6443")
+		values["apiServerBindPort"] = common.HAProxyAPIServerBindPort
+	}
+
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		values["registryMirrorConfiguration"] = net.JoinHostPort(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Endpoint, clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Port)
 		if len(clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent) > 0 {
@@ -831,12 +854,21 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphe
 	return values
 }
 
+// controlPlaneEndpointCidrMask returns the prefix length used by kube-vip for the control plane VIP.
+func controlPlaneEndpointCidrMask(ip string) string {
+	if networkutils.IsIPv6(ip) {
+		return "128"
+	}
+	return "32"
+}
+
 func buildTemplateMapMD(clusterSpec *cluster.Spec, datacenterSpec v1alpha1.VSphereDatacenterConfigSpec, workerNodeGroupMachineSpec v1alpha1.VSphereMachineConfigSpec, workerNodeGroupConfiguration v1alpha1.WorkerNodeGroupConfiguration) map[string]interface{} {
 	bundle := clusterSpec.VersionsBundle
 	format := "cloud-config"
 	kubeletExtraArgs := clusterapi.SecureTlsCipherSuitesExtraArgs().
 		Append(clusterapi.WorkerNodeLabelsExtraArgs(workerNodeGroupConfiguration)).
-		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf))
+		Append(clusterapi.ResolvConfExtraArgs(clusterSpec.Cluster.Spec.ClusterNetwork.DNS.ResolvConf)).
+		Append(clusterapi.NodeIPExtraArgs(&clusterSpec.Cluster.Spec.ClusterNetwork))
 
 	values := map[string]interface{}{
 		"clusterName":                    clusterSpec.Cluster.Name,
@@ -1124,6 +1156,55 @@ func (p *vsphereProvider) PostBootstrapSetupUpgrade(ctx context.Context, cluster
 }
 
 func (p *vsphereProvider) PostWorkloadInit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	return p.applyKubeVipServicesManifest(ctx, cluster, clusterSpec)
+}
+
+// reconcileKubeVipServicesManifest applies the kube-vip services manifest of newSpec, or deletes the one of
+// currentSpec when the load balancer configuration was removed.
+func (p *vsphereProvider) reconcileKubeVipServicesManifest(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) error {
+	if newSpec.Cluster.Spec.LoadBalancerConfiguration != nil || currentSpec.Cluster.Spec.LoadBalancerConfiguration == nil {
+		return p.applyKubeVipServicesManifest(ctx, cluster, newSpec)
+	}
+
+	bundle := currentSpec.VersionsBundle.VSphere
+	manifest, err := kubevip.GenerateServicesManifest(currentSpec.Cluster.Spec.LoadBalancerConfiguration, bundle.KubeVip.VersionedImage(), bundle.KubeVipCloudProvider.VersionedImage())
+	if err != nil {
+		return err
+	}
+
+	logger.V(4).Info("Deleting kube-vip service load balancer manifest")
+	err = p.Retrier.Retry(
+		func() error {
+			return p.providerKubectlClient.DeleteKubeSpecFromBytesIgnoreNotFound(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("deleting kube-vip service load balancer manifest: %v", err)
+	}
+	return nil
+}
+
+func (p *vsphereProvider) applyKubeVipServicesManifest(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	lbConfig := clusterSpec.Cluster.Spec.LoadBalancerConfiguration
+	if lbConfig == nil {
+		return nil
+	}
+
+	bundle := clusterSpec.VersionsBundle.VSphere
+	manifest, err := kubevip.GenerateServicesManifest(lbConfig, bundle.KubeVip.VersionedImage(), bundle.KubeVipCloudProvider.VersionedImage())
+	if err != nil {
+		return err
+	}
+
+	logger.V(4).Info("Applying kube-vip service load balancer manifest")
+	err = p.Retrier.Retry(
+		func() error {
+			return p.providerKubectlClient.ApplyKubeSpecFromBytes(ctx, cluster, manifest)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("applying kube-vip service load balancer manifest: %v", err)
+	}
 	return nil
 }
 
@@ -1340,7 +1421,7 @@ func (p *vsphereProvider) RunPostControlPlaneUpgrade(ctx context.Context, oldClu
 	if err != nil {
 		return fmt.Errorf("failed updating the vsphere provider resource set post upgrade: %v", err)
 	}
-	return nil
+	return p.reconcileKubeVipServicesManifest(ctx, workloadCluster, oldClusterSpec, clusterSpec)
 }
 
 func resourceSetName(clusterSpec *cluster.Spec) string {
@@ -104,6 +104,10 @@ func (pc *DummyProviderGovcClient) ValidateVCenterSetupMachineConfig(ctx context
 	return nil
 }
 
+func (pc *DummyProviderGovcClient) CheckVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig) error {
+	return nil
+}
+
 func (pc *DummyProviderGovcClient) SearchTemplate(ctx context.Context, datacenter string, machineConfig *v1alpha1.VSphereMachineConfig) (string, error) {
 	return machineConfig.Spec.Template, nil
 }
@@ -1832,6 +1836,20 @@ func TestSetupAndValidateCreateClusterBogusIp(t *testing.T) {
 	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host is invalid: bogus", err)
 }
 
+func TestSetupAndValidateCreateClusterIpFamilyMismatch(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenEmptyClusterSpec()
+	fillClusterSpecWithClusterConfig(clusterSpec, givenClusterConfig(t, testClusterConfigMainFilename))
+	provider := givenProvider(t)
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "fd00::10"
+	var tctx testContext
+	tctx.SaveContext()
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+
+	thenErrorExpected(t, "cluster controlPlaneConfiguration.Endpoint.Host fd00::10 is IPv6 but the cluster network doesn't use that IP family", err)
+}
+
 func TestSetupAndValidateCreateClusterUsedIp(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenEmptyClusterSpec()
@@ -2784,6 +2802,40 @@ func TestVsphereProviderRunPostControlPlaneUpgrade(t *testing.T) {
 	tt.Expect(tt.provider.RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, tt.workloadCluster, tt.managementCluster)).To(Succeed())
 }
 
+func TestVsphereProviderRunPostControlPlaneUpgradeWithLoadBalancerConfiguration(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Cluster.Spec.LoadBalancerConfiguration = &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+	tt.clusterSpec.VersionsBundle.VSphere.KubeVipCloudProvider.URI = "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip-cloud-provider:v0.0.2"
+
+	tt.resourceSetManager.EXPECT().ForceUpdate(tt.ctx, "test-crs-0", "eksa-system", tt.managementCluster, tt.workloadCluster)
+	tt.kubectl.EXPECT().ApplyKubeSpecFromBytes(tt.ctx, tt.workloadCluster, gomock.Any())
+	tt.Expect(tt.provider.RunPostControlPlaneUpgrade(tt.ctx, tt.clusterSpec, tt.clusterSpec, tt.workloadCluster, tt.managementCluster)).To(Succeed())
+}
+
+func TestVsphereProviderRunPostControlPlaneUpgradeLoadBalancerConfigurationRemoved(t *testing.T) {
+	tt := newProviderTest(t)
+	oldClusterSpec := tt.clusterSpec.DeepCopy()
+	oldClusterSpec.Cluster.Spec.LoadBalancerConfiguration = &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+	oldClusterSpec.VersionsBundle.VSphere.KubeVipCloudProvider.URI = "public.ecr.aws/l0g8r8j6/kube-vip/kube-vip-cloud-provider:v0.0.2"
+
+	tt.resourceSetManager.EXPECT().ForceUpdate(tt.ctx, "test-crs-0", "eksa-system", tt.managementCluster, tt.workloadCluster)
+	tt.kubectl.EXPECT().DeleteKubeSpecFromBytesIgnoreNotFound(tt.ctx, tt.workloadCluster, gomock.Any())
+	tt.Expect(tt.provider.RunPostControlPlaneUpgrade(tt.ctx, oldClusterSpec, tt.clusterSpec, tt.workloadCluster, tt.managementCluster)).To(Succeed())
+}
+
+func TestVsphereProviderPostWorkloadInitWithLoadBalancerConfigurationMissingImage(t *testing.T) {
+	tt := newProviderTest(t)
+	tt.clusterSpec.Cluster.Spec.LoadBalancerConfiguration = &v1alpha1.LoadBalancerConfiguration{
+		IPPools: []v1alpha1.LoadBalancerIPPool{{CIDR: "10.0.2.0/28"}},
+	}
+
+	tt.Expect(tt.provider.PostWorkloadInit(tt.ctx, tt.workloadCluster, tt.clusterSpec)).To(MatchError(ContainSubstring("kube-vip-cloud-provider image")))
+}
+
 func TestProviderUpgradeNeeded(t *testing.T) {
 	testCases := []struct {
 		testName               string
@@ -2870,6 +2922,73 @@ func TestProviderGenerateCAPISpecForCreateWithPodIAMConfig(t *testing.T) {
 	test.AssertContentToFile(t, string(cp), "testdata/expected_results_pod_iam_config.yaml")
 }
 
+func TestProviderGenerateCAPISpecForCreateWithHAProxyStaticPodLoadBalancer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+	ctx := context.Background()
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancer = v1alpha1.HAProxyStaticPodLoadBalancer
+	clusterSpec.VersionsBundle.Haproxy.Image = releasev1alpha1.Image{URI: "public.ecr.aws/l0g8r8j6/kubernetes-sigs/kind/haproxy:v0.11.1-eks-a-v0.0.0-dev-build.1464"}
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_haproxy_static_pod_cp.yaml")
+}
+
+func TestProviderGenerateCAPISpecForCreateWithExternalLoadBalancer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	var tctx testContext
+	tctx.SaveContext()
+	defer tctx.RestoreContext()
+	ctx := context.Background()
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{
+		Name: "test",
+	}
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.LoadBalancer = v1alpha1.ExternalLoadBalancer
+
+	datacenterConfig := givenDatacenterConfig(t, testClusterConfigMainFilename)
+	machineConfigs := givenMachineConfigs(t, testClusterConfigMainFilename)
+	provider := newProviderWithKubectl(t, datacenterConfig, machineConfigs, clusterSpec.Cluster, kubectl)
+	if provider == nil {
+		t.Fatalf("provider object is nil")
+	}
+
+	provider.skipIpCheck = true
+
+	err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, _, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_external_load_balancer_cp.yaml")
+}
+
 func TestProviderGenerateCAPISpecForCreateWithCustomResolvConf(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	var tctx testContext
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/config/crd"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// crdSchemas returns the OpenAPI schema of the EKS-A API version for each kind in the CRDs.
+// They have the required fields and the enums set by the kubebuilder markers in the API types.
+func crdSchemas() (map[string]*apiextensionsv1.JSONSchemaProps, error) {
+	files, err := fs.Glob(crd.Bases, "bases/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make(map[string]*apiextensionsv1.JSONSchemaProps, len(files))
+	for _, f := range files {
+		content, err := crd.Bases.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		c := &apiextensionsv1.CustomResourceDefinition{}
+		if err = yaml.Unmarshal(content, c); err != nil {
+			return nil, fmt.Errorf("parsing CRD %s: %v", f, err)
+		}
+
+		for _, v := range c.Spec.Versions {
+			if v.Name == anywherev1.GroupVersion.Version && v.Schema != nil {
+				schemas[c.Spec.Names.Kind] = v.Schema.OpenAPIV3Schema
+			}
+		}
+	}
+
+	return schemas, nil
+}
+
+func crdProperty(crd *apiextensionsv1.JSONSchemaProps, name string) *apiextensionsv1.JSONSchemaProps {
+	if crd == nil {
+		return nil
+	}
+	p, ok := crd.Properties[name]
+	if !ok {
+		return nil
+	}
+	return &p
+}
+
+func crdItems(crd *apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	if crd == nil || crd.Items == nil {
+		return nil
+	}
+	return crd.Items.Schema
+}
+
+func crdAdditionalProperties(crd *apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	if crd == nil || crd.AdditionalProperties == nil {
+		return nil
+	}
+	return crd.AdditionalProperties.Schema
+}
+
+func crdRequired(crd *apiextensionsv1.JSONSchemaProps, name string) bool {
+	if crd == nil {
+		return false
+	}
+	for _, r := range crd.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// crdEnum returns the allowed values of a string field. The enums come from the markers on string
+// types, so values that aren't strings are skipped.
+func crdEnum(crd *apiextensionsv1.JSONSchemaProps) []string {
+	if crd == nil {
+		return nil
+	}
+
+	var values []string
+	for _, e := range crd.Enum {
+		var v string
+		if err := json.Unmarshal(e.Raw, &v); err == nil {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type setDefaulter interface {
+	SetDefaults()
+}
+
+type defaulter interface {
+	Default()
+}
+
+// defaultedValues holds an API object with all its optional structs set and the same object
+// after applying the API defaults, so the fields the defaults set can be told apart.
+type defaultedValues struct {
+	filled, defaulted reflect.Value
+}
+
+func newDefaultedValues(obj runtime.Object) defaultedValues {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	filled := reflect.New(t)
+	fill(filled.Elem(), map[reflect.Type]bool{})
+	defaulted := reflect.ValueOf(filled.Interface().(runtime.Object).DeepCopyObject())
+
+	switch d := defaulted.Interface().(type) {
+	case setDefaulter:
+		d.SetDefaults()
+	case defaulter:
+		d.Default()
+	}
+
+	return defaultedValues{filled: filled, defaulted: defaulted}
+}
+
+// fill sets every nil pointer to a struct to an empty struct and every list of structs to a single
+// empty element, so the defaults of the nested fields are applied too.
+func fill(v reflect.Value, visiting map[reflect.Type]bool) {
+	t := v.Type()
+	if t.Kind() != reflect.Struct || visiting[t] || t == objectMetaType {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+
+		switch f.Kind() {
+		case reflect.Ptr:
+			if f.Type().Elem().Kind() == reflect.Struct {
+				f.Set(reflect.New(f.Type().Elem()))
+				fill(f.Elem(), visiting)
+			}
+		case reflect.Slice:
+			if f.Type().Elem().Kind() == reflect.Struct {
+				f.Set(reflect.MakeSlice(f.Type(), 1, 1))
+				fill(f.Index(0), visiting)
+			}
+		case reflect.Struct:
+			fill(f, visiting)
+		}
+	}
+}
+
+// field returns the values of the struct field i.
+func (d defaultedValues) field(i int) defaultedValues {
+	if !d.valid() {
+		return defaultedValues{}
+	}
+	return defaultedValues{filled: d.filled.Field(i), defaulted: d.defaulted.Field(i)}
+}
+
+// elem returns the values pointed by the pointers or the first element of the lists.
+func (d defaultedValues) elem() defaultedValues {
+	if !d.valid() {
+		return defaultedValues{}
+	}
+
+	switch d.filled.Kind() {
+	case reflect.Ptr:
+		if d.filled.IsNil() || d.defaulted.IsNil() {
+			return defaultedValues{}
+		}
+		return defaultedValues{filled: d.filled.Elem(), defaulted: d.defaulted.Elem()}
+	case reflect.Slice:
+		if d.filled.Len() == 0 || d.defaulted.Len() == 0 {
+			return defaultedValues{}
+		}
+		return defaultedValues{filled: d.filled.Index(0), defaulted: d.defaulted.Index(0)}
+	default:
+		return defaultedValues{}
+	}
+}
+
+func (d defaultedValues) valid() bool {
+	return d.filled.IsValid() && d.defaulted.IsValid()
+}
+
+// isDefaulted returns true if the API defaults change the value.
+func (d defaultedValues) isDefaulted() bool {
+	return d.valid() && !reflect.DeepEqual(d.filled.Interface(), d.defaulted.Interface())
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type defaultsTestObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              defaultsTestSpec `json:"spec,omitempty"`
+}
+
+type defaultsTestSpec struct {
+	Host    string              `json:"host"`
+	Port    string              `json:"port"`
+	Enabled bool                `json:"enabled"`
+	Nested  *defaultsTestNested `json:"nested"`
+}
+
+type defaultsTestNested struct {
+	Timeout int `json:"timeout"`
+}
+
+func (o *defaultsTestObject) DeepCopyObject() runtime.Object {
+	c := *o
+	if o.Spec.Nested != nil {
+		n := *o.Spec.Nested
+		c.Spec.Nested = &n
+	}
+	return &c
+}
+
+func (o *defaultsTestObject) SetDefaults() {
+	if o.Spec.Port == "" {
+		o.Spec.Port = "443"
+	}
+	if o.Spec.Nested != nil && o.Spec.Nested.Timeout == 0 {
+		o.Spec.Nested.Timeout = 10
+	}
+}
+
+func TestForObjectRequiredSkipsDefaultedFields(t *testing.T) {
+	g := NewWithT(t)
+	crds := map[string]*apiextensionsv1.JSONSchemaProps{
+		"Test": {
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": {
+					Required: []string{"host", "port", "enabled", "nested"},
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"nested": {Required: []string{"timeout"}},
+					},
+				},
+			},
+		},
+	}
+
+	s, err := forObject("Test", &defaultsTestObject{}, crds)
+	g.Expect(err).NotTo(HaveOccurred())
+	spec := s.Properties["spec"]
+	g.Expect(spec.Required).To(Equal([]string{"host", "nested"}))
+	g.Expect(spec.Properties["nested"].Required).To(BeEmpty())
+}
@@ -0,0 +1,305 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// Draft is the JSON Schema version the generated schemas follow.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document. Only the subset of keywords needed
+// to describe the EKS-A API types is supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Const                string             `json:"const,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// requiredSpecFields are the spec fields of each kind the CLI validations require, even though the
+// CRD accepts objects without them.
+var requiredSpecFields = map[string][]string{
+	anywherev1.ClusterKind: {"clusterNetwork", "controlPlaneConfiguration", "datacenterRef", "kubernetesVersion"},
+}
+
+var (
+	objectMetaType = reflect.TypeOf(metav1.ObjectMeta{})
+	timeType       = reflect.TypeOf(metav1.Time{})
+	durationType   = reflect.TypeOf(metav1.Duration{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// ForKinds builds a JSON Schema that accepts any of the API objects in objs, indexed by kind.
+// Each kind is added as a definition and the document matches one of them by its kind.
+func ForKinds(objs map[string]runtime.Object) (*Schema, error) {
+	crds, err := crdSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make([]string, 0, len(objs))
+	for kind := range objs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	s := &Schema{
+		Schema:      Draft,
+		Title:       "EKS Anywhere cluster config",
+		Definitions: make(map[string]*Schema, len(objs)),
+	}
+	for _, kind := range kinds {
+		def, err := forObject(kind, objs[kind], crds)
+		if err != nil {
+			return nil, err
+		}
+		s.Definitions[kind] = def
+		s.OneOf = append(s.OneOf, &Schema{Ref: "#/definitions/" + kind})
+	}
+
+	return s, nil
+}
+
+// ForKind builds the JSON Schema for an API object of the given kind.
+func ForKind(kind string, obj runtime.Object) (*Schema, error) {
+	crds, err := crdSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := forObject(kind, obj, crds)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+
+	return s, nil
+}
+
+// forObject builds the schema of an API object. The status is left out since
+// it's not part of the user provided config.
+func forObject(kind string, obj runtime.Object, crds map[string]*apiextensionsv1.JSONSchemaProps) (*Schema, error) {
+	crd, ok := crds[kind]
+	if !ok {
+		return nil, fmt.Errorf("no CRD found for kind %s", kind)
+	}
+
+	s := newGenerator().forType(reflect.TypeOf(obj), crd, newDefaultedValues(obj))
+	s.Title = kind
+	delete(s.Properties, "status")
+	s.Properties["apiVersion"] = &Schema{Type: "string", Const: anywherev1.GroupVersion.String()}
+	s.Properties["kind"] = &Schema{Type: "string", Const: kind}
+	s.Required = []string{"apiVersion", "kind", "metadata"}
+
+	if spec, ok := s.Properties["spec"]; ok {
+		spec.Required = mergeRequired(spec.Required, requiredSpecFields[kind])
+		if len(spec.Required) > 0 {
+			s.Required = append(s.Required, "spec")
+		}
+	}
+
+	return s, nil
+}
+
+// mergeRequired returns the sorted union of both lists of required fields.
+func mergeRequired(required, extra []string) []string {
+	for _, name := range extra {
+		found := false
+		for _, r := range required {
+			if r == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+
+	return required
+}
+
+type generator struct {
+	// visiting holds the types being generated to break recursive types
+	visiting map[reflect.Type]bool
+}
+
+func newGenerator() *generator {
+	return &generator{visiting: map[reflect.Type]bool{}}
+}
+
+// forType builds the schema for t. The required fields and the enums are taken from crd, the
+// schema of the same field in the CRD, and values is used to leave out the fields set by the defaults.
+func (g *generator) forType(t reflect.Type, crd *apiextensionsv1.JSONSchemaProps, values defaultedValues) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		values = values.elem()
+	}
+
+	switch t {
+	case objectMetaType:
+		return objectMetaSchema()
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "string"}
+	}
+
+	if t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(marshalerType) {
+		// Custom serialization, the Go type doesn't describe the document
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string", Enum: crdEnum(crd)}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is serialized as a base64 string
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: g.forType(t.Elem(), crdItems(crd), values.elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.forType(t.Elem(), crdAdditionalProperties(crd), defaultedValues{})}
+	case reflect.Struct:
+		return g.forStruct(t, crd, values)
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *generator) forStruct(t reflect.Type, crd *apiextensionsv1.JSONSchemaProps, values defaultedValues) *Schema {
+	if g.visiting[t] {
+		return &Schema{Type: "object"}
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(s, t, crd, values)
+	sort.Strings(s.Required)
+
+	return s
+}
+
+func (g *generator) addFields(s *Schema, t reflect.Type, crd *apiextensionsv1.JSONSchemaProps, values defaultedValues) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		name, inline := fieldName(f)
+		if name == "-" {
+			continue
+		}
+
+		fieldValues := values.field(i)
+		if inline {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+				fieldValues = fieldValues.elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft, crd, fieldValues)
+				continue
+			}
+		}
+
+		s.Properties[name] = g.forType(f.Type, crdProperty(crd, name), fieldValues)
+		if isRequired(f, crdRequired(crd, name), fieldValues) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// isRequired returns true if a field required in the CRD has to be set in the user config.
+// Booleans are never required since a missing boolean is read as false, which is always accepted,
+// and neither are the fields the API defaults set. Defaults of structs are not taken into account,
+// since they only set some of the nested fields.
+func isRequired(f reflect.StructField, crdRequired bool, values defaultedValues) bool {
+	if !crdRequired {
+		return false
+	}
+
+	t := f.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return false
+	case reflect.Struct:
+		return true
+	default:
+		return !values.isDefaulted()
+	}
+}
+
+// fieldName returns the name a struct field is serialized with and whether it's inlined.
+func fieldName(f reflect.StructField) (name string, inline bool) {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return f.Name, f.Anonymous
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+
+	if name == "" {
+		if f.Anonymous {
+			inline = true
+		}
+		name = f.Name
+	}
+
+	return name, inline
+}
+
+func objectMetaSchema() *Schema {
+	stringMap := &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"name":        {Type: "string"},
+			"namespace":   {Type: "string"},
+			"labels":      stringMap,
+			"annotations": stringMap,
+		},
+		Required: []string{"name"},
+	}
+}
@@ -0,0 +1,103 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/schema"
+)
+
+func TestForKindEnums(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := schema.ForKind(anywherev1.SnowMachineConfigKind, &anywherev1.SnowMachineConfig{})
+	g.Expect(err).NotTo(HaveOccurred())
+	spec := s.Properties["spec"]
+	g.Expect(spec.Properties["instanceType"].Enum).To(ConsistOf("sbe-c.large", "sbe-c.xlarge", "sbe-c.2xlarge", "sbe-c.4xlarge"))
+	g.Expect(spec.Required).To(ContainElement("amiID"))
+
+	s, err = schema.ForKind(anywherev1.VSphereMachineConfigKind, &anywherev1.VSphereMachineConfig{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Properties["spec"].Properties["osFamily"].Enum).To(ConsistOf("ubuntu", "bottlerocket"))
+
+	s, err = schema.ForKind(anywherev1.ClusterKind, &anywherev1.Cluster{})
+	g.Expect(err).NotTo(HaveOccurred())
+	network := s.Properties["spec"].Properties["clusterNetwork"]
+	g.Expect(network.Properties["cni"].Enum).To(ConsistOf("cilium", "cilium-enterprise", "kindnetd"))
+}
+
+func TestForKindObject(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := schema.ForKind(anywherev1.ClusterKind, &anywherev1.Cluster{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Schema).To(Equal(schema.Draft))
+	g.Expect(s.Title).To(Equal(anywherev1.ClusterKind))
+	g.Expect(s.Required).To(ConsistOf("apiVersion", "kind", "metadata", "spec"))
+	g.Expect(s.Properties["spec"].Required).To(ConsistOf("clusterNetwork", "controlPlaneConfiguration", "datacenterRef", "kubernetesVersion"))
+	g.Expect(s.Properties["kind"].Const).To(Equal(anywherev1.ClusterKind))
+	g.Expect(s.Properties["apiVersion"].Const).To(Equal(anywherev1.GroupVersion.String()))
+	g.Expect(s.Properties["metadata"].Required).To(ConsistOf("name"))
+	g.Expect(s.Properties).NotTo(HaveKey("status"))
+
+	endpoint := s.Properties["spec"].Properties["controlPlaneConfiguration"].Properties["endpoint"]
+	g.Expect(endpoint.Required).To(ConsistOf("host"))
+	workers := s.Properties["spec"].Properties["workerNodeGroupConfigurations"]
+	g.Expect(workers.Type).To(Equal("array"))
+	g.Expect(workers.Items.Properties["count"].Type).To(Equal("integer"))
+	g.Expect(workers.Items.Properties["labels"].AdditionalProperties.Type).To(Equal("string"))
+}
+
+func TestForKindNestedTypes(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := schema.ForKind(anywherev1.TinkerbellTemplateConfigKind, &anywherev1.TinkerbellTemplateConfig{})
+	g.Expect(err).NotTo(HaveOccurred())
+	template := s.Properties["spec"].Properties["template"]
+	g.Expect(template.Required).To(ConsistOf("global_timeout", "id", "name", "tasks", "version"))
+	actions := template.Properties["tasks"].Items.Properties["actions"]
+	g.Expect(actions.Items.Properties["environment"].AdditionalProperties.Type).To(Equal("string"))
+}
+
+func TestForKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := schema.ForKinds(map[string]runtime.Object{
+		anywherev1.ClusterKind:          &anywherev1.Cluster{},
+		anywherev1.DockerDatacenterKind: &anywherev1.DockerDatacenterConfig{},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Schema).To(Equal(schema.Draft))
+	g.Expect(s.Definitions).To(HaveKey(anywherev1.ClusterKind))
+	g.Expect(s.Definitions).To(HaveKey(anywherev1.DockerDatacenterKind))
+	g.Expect(s.OneOf).To(Equal([]*schema.Schema{
+		{Ref: "#/definitions/Cluster"},
+		{Ref: "#/definitions/DockerDatacenterConfig"},
+	}))
+
+	_, err = json.Marshal(s)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestForKindRequiredFromCRD(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := schema.ForKind(anywherev1.VSphereMachineConfigKind, &anywherev1.VSphereMachineConfig{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Properties["spec"].Required).To(ConsistOf("datastore", "folder", "resourcePool"))
+
+	s, err = schema.ForKind(anywherev1.VSphereDatacenterKind, &anywherev1.VSphereDatacenterConfig{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.Properties["spec"].Required).To(ConsistOf("datacenter", "network", "server"))
+}
+
+func TestForKindNoCRD(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := schema.ForKind("Unknown", &anywherev1.Cluster{})
+	g.Expect(err).To(MatchError("no CRD found for kind Unknown"))
+}
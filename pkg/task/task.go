@@ -40,8 +40,22 @@ type CommandContext struct {
 	WorkloadCluster    *types.Cluster
 	Profiler           *Profiler
 	OriginalError      error
+	UpgradeScope       UpgradeScope
 }
 
+// UpgradeScope selects the components an upgrade changes.
+type UpgradeScope int
+
+const (
+	// UpgradeAll upgrades the components of the management cluster and the cluster.
+	UpgradeAll UpgradeScope = iota
+	// UpgradeManagementComponents only upgrades the components all the clusters of a management cluster share:
+	// the CAPI providers, the EKS-A and EKS-D components and Flux.
+	UpgradeManagementComponents
+	// UpgradeClusterOnly upgrades the cluster without the management components, which must be already upgraded.
+	UpgradeClusterOnly
+)
+
 func (c *CommandContext) SetError(err error) {
 	if c.OriginalError == nil {
 		c.OriginalError = err
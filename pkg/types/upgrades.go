@@ -34,3 +34,86 @@ func (c *ChangeDiff) Append(changeDiffs ...*ChangeDiff) {
 func (c *ChangeDiff) Changed() bool {
 	return len(c.ComponentReports) > 0
 }
+
+// ObjectChange is the operation an upgrade would perform on an object.
+type ObjectChange string
+
+const (
+	ObjectCreated   ObjectChange = "create"
+	ObjectUpdated   ObjectChange = "update"
+	ObjectUnchanged ObjectChange = "none"
+)
+
+// ObjectDiff describes how an upgrade would change a single object.
+type ObjectDiff struct {
+	Kind      string       `json:"kind"`
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace,omitempty"`
+	Change    ObjectChange `json:"change"`
+	// ChangedFields holds the paths of the fields that would change
+	ChangedFields []string `json:"changedFields,omitempty"`
+	// Rollout is true when the change triggers the replacement of machines
+	Rollout bool `json:"rollout"`
+}
+
+// UpgradeDiff describes the changes an upgrade would make to a cluster without applying them.
+type UpgradeDiff struct {
+	CAPIObjects []ObjectDiff `json:"capiObjects"`
+	EKSAObjects []ObjectDiff `json:"eksaObjects"`
+	// GitOpsFiles holds the paths in the GitOps repository that would be updated
+	GitOpsFiles []string `json:"gitOpsFiles,omitempty"`
+}
+
+// Rollout returns true if any of the changes triggers the replacement of machines.
+func (u *UpgradeDiff) Rollout() bool {
+	for _, d := range u.CAPIObjects {
+		if d.Rollout {
+			return true
+		}
+	}
+	return false
+}
+
+// EKSAChanged returns true if any of the EKS-A objects would be created or updated.
+func (u *UpgradeDiff) EKSAChanged() bool {
+	for _, d := range u.EKSAObjects {
+		if d.Change != ObjectUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkloadClusterUpgradeStatus is the outcome of the upgrade of a workload cluster.
+type WorkloadClusterUpgradeStatus string
+
+const (
+	WorkloadClusterUpgraded WorkloadClusterUpgradeStatus = "upgraded"
+	WorkloadClusterFailed   WorkloadClusterUpgradeStatus = "failed"
+	WorkloadClusterSkipped  WorkloadClusterUpgradeStatus = "skipped"
+)
+
+// WorkloadClusterUpgradeResult reports the upgrade of a single workload cluster.
+type WorkloadClusterUpgradeResult struct {
+	Name      string                       `json:"name"`
+	Namespace string                       `json:"namespace"`
+	Wave      int                          `json:"wave"`
+	Status    WorkloadClusterUpgradeStatus `json:"status"`
+	Error     string                       `json:"error,omitempty"`
+}
+
+// WorkloadClustersUpgradeReport reports the upgrade of all the workload clusters of a management cluster.
+type WorkloadClustersUpgradeReport struct {
+	Results []WorkloadClusterUpgradeResult `json:"results"`
+}
+
+// Count returns the number of clusters with the given status.
+func (r *WorkloadClustersUpgradeReport) Count(status WorkloadClusterUpgradeStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
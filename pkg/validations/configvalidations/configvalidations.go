@@ -0,0 +1,99 @@
+package configvalidations
+
+import (
+	"fmt"
+	"reflect"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+const (
+	parseValidationName    = "parse cluster config"
+	defaultsValidationName = "set cluster config defaults"
+)
+
+// RegisterOffline registers in runner the validations for the cluster config in fileName that don't need
+// access to any infrastructure: parsing, defaulting, the API objects validations and the checks
+// the webhooks run on the same objects. Each step only runs if the previous one succeeded.
+func RegisterOffline(runner *validations.Runner, fileName string) {
+	var c *cluster.Config
+
+	runner.RegisterWithDependencies(parseValidationName, func() *validations.ValidationResult {
+		var err error
+		if !validations.FileExists(fileName) {
+			err = fmt.Errorf("the cluster config file %s does not exist", fileName)
+		} else {
+			c, err = cluster.ParseConfigFromFile(fileName)
+		}
+		return &validations.ValidationResult{
+			Name:        parseValidationName,
+			Remediation: "ensure the file contains a valid Cluster object and its referenced objects",
+			Err:         err,
+		}
+	})
+
+	runner.RegisterWithDependencies(defaultsValidationName, func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: defaultsValidationName,
+			Err:  cluster.SetConfigDefaults(c),
+		}
+	}, parseValidationName)
+
+	runner.RegisterWithDependencies("validate cluster config objects", func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: "validate cluster config objects",
+			Err:  cluster.ValidateConfig(c),
+		}
+	}, defaultsValidationName)
+
+	runner.RegisterWithDependencies("validate webhook rules", func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:        "validate webhook rules",
+			Remediation: "fix the fields rejected by the cluster API webhooks",
+			Err:         validateWebhookRules(c),
+		}
+	}, defaultsValidationName)
+
+	runner.RegisterWithDependencies("validate kubernetes version 1.23 support", func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name:        "validate kubernetes version 1.23 support",
+			Remediation: fmt.Sprintf("ensure %v env variable is set", features.K8s123SupportEnvVar),
+			Err:         validations.ValidateK8s123Support(&cluster.Spec{Config: c}),
+		}
+	}, defaultsValidationName)
+}
+
+// validateWebhookRules runs the create validations of the webhooks on the objects the way the CLI
+// creates them, with the Cluster and its datacenter paused.
+func validateWebhookRules(c *cluster.Config) error {
+	c = c.DeepCopy()
+	c.Cluster.PauseReconcile()
+	for _, d := range []pausable{c.VSphereDatacenter, c.CloudStackDatacenter, c.DockerDatacenter, c.SnowDatacenter} {
+		if !reflect.ValueOf(d).IsNil() {
+			d.PauseReconcile()
+		}
+	}
+
+	var allErrs []error
+	for _, obj := range append([]kubernetes.Object{c.Cluster}, c.ChildObjects()...) {
+		validator, ok := obj.(webhook.Validator)
+		if !ok {
+			continue
+		}
+		if err := validator.ValidateCreate(); err != nil {
+			allErrs = append(allErrs, fmt.Errorf("%s %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err))
+		}
+	}
+
+	return utilerrors.NewAggregate(allErrs)
+}
+
+type pausable interface {
+	PauseReconcile()
+}
@@ -0,0 +1,80 @@
+package configvalidations_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/features"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/validations/configvalidations"
+)
+
+func TestRegisterOfflineSuccess(t *testing.T) {
+	g := NewWithT(t)
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, "testdata/cluster_docker.yaml")
+
+	g.Expect(runner.Run()).To(Succeed())
+	report := runner.Report()
+	g.Expect(report.Passed).To(BeTrue())
+	g.Expect(report.Results).To(HaveLen(5))
+}
+
+func TestRegisterOfflineMissingFile(t *testing.T) {
+	g := NewWithT(t)
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, "testdata/missing.yaml")
+
+	g.Expect(runner.Run()).NotTo(Succeed())
+	report := runner.Report()
+	g.Expect(report.Passed).To(BeFalse())
+	g.Expect(report.Results[0].Error).To(ContainSubstring("does not exist"))
+	for _, r := range report.Results[1:] {
+		g.Expect(r.Error).To(ContainSubstring("skipped because validation"))
+	}
+}
+
+func TestRegisterOfflineNoCluster(t *testing.T) {
+	g := NewWithT(t)
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, "testdata/no_cluster.yaml")
+
+	g.Expect(runner.Run()).NotTo(Succeed())
+	g.Expect(runner.Report().Results[0].Passed).To(BeFalse())
+}
+
+func TestRegisterOfflineInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, "testdata/cluster_docker_invalid_cni.yaml")
+
+	g.Expect(runner.Run()).NotTo(Succeed())
+	report := runner.Report()
+	g.Expect(report.Results[0].Passed).To(BeTrue())
+	g.Expect(report.Results[1].Passed).To(BeTrue())
+	g.Expect(report.Results[2].Passed).To(BeFalse())
+	g.Expect(report.Results[2].Error).To(ContainSubstring("cni"))
+}
+
+func TestRegisterOfflineWebhookRules(t *testing.T) {
+	g := NewWithT(t)
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, "testdata/cluster_cloudstack.yaml")
+
+	g.Expect(runner.Run()).NotTo(Succeed())
+	report := runner.Report()
+	g.Expect(report.Results[3].Name).To(Equal("validate webhook rules"))
+	g.Expect(report.Results[3].Error).To(ContainSubstring("CloudStackProvider feature is not active"))
+}
+
+func TestRegisterOfflineWebhookRulesCloudStackActive(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(features.CloudStackProviderEnvVar, "true")
+	features.ClearCache()
+	t.Cleanup(features.ClearCache)
+	runner := validations.NewRunner()
+	configvalidations.RegisterOffline(runner, "testdata/cluster_cloudstack.yaml")
+
+	g.Expect(runner.Run()).To(Succeed())
+}
@@ -10,7 +10,8 @@ import (
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
 
-func (u *CreateValidations) PreflightValidations(ctx context.Context) (err error) {
+// PreflightValidations returns the validations to run before creating a cluster. They are only evaluated when run.
+func (u *CreateValidations) PreflightValidations(ctx context.Context) []validations.Validation {
 	k := u.Opts.Kubectl
 
 	targetCluster := &types.Cluster{
@@ -18,45 +19,57 @@ func (u *CreateValidations) PreflightValidations(ctx context.Context) (err error
 		KubeconfigFile: u.Opts.ManagementCluster.KubeconfigFile,
 	}
 
-	createValidations := []validations.ValidationResult{
-		{
-			Name:        "validate certificate for registry mirror",
-			Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
-			Err:         validations.ValidateCertForRegistryMirror(u.Opts.Spec, u.Opts.TlsValidator),
+	createValidations := []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate certificate for registry mirror",
+				Remediation: fmt.Sprintf("provide a valid certificate for you registry endpoint using %s env var", anywherev1.RegistryMirrorCAKey),
+				Err:         validations.ValidateCertForRegistryMirror(u.Opts.Spec, u.Opts.TlsValidator),
+			}
 		},
-		{
-			Name:        "validate kubernetes version 1.23 support",
-			Remediation: fmt.Sprintf("ensure %v env variable is set", features.K8s123SupportEnvVar),
-			Err:         validations.ValidateK8s123Support(u.Opts.Spec),
-			Silent:      true,
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate kubernetes version 1.23 support",
+				Remediation: fmt.Sprintf("ensure %v env variable is set", features.K8s123SupportEnvVar),
+				Err:         validations.ValidateK8s123Support(u.Opts.Spec),
+				Silent:      true,
+			}
 		},
 	}
 
 	if u.Opts.Spec.Cluster.IsManaged() {
 		createValidations = append(
 			createValidations,
-			validations.ValidationResult{
-				Name:        "validate cluster name",
-				Remediation: "",
-				Err:         ValidateClusterNameIsUnique(ctx, k, targetCluster, u.Opts.Spec.Cluster.Name),
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate cluster name",
+					Remediation: "",
+					Err:         ValidateClusterNameIsUnique(ctx, k, targetCluster, u.Opts.Spec.Cluster.Name),
+				}
 			},
-			validations.ValidationResult{
-				Name:        "validate gitops",
-				Remediation: "",
-				Err:         ValidateGitOps(ctx, k, u.Opts.ManagementCluster, u.Opts.Spec, u.Opts.CliConfig),
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate gitops",
+					Remediation: "",
+					Err:         ValidateGitOps(ctx, k, u.Opts.ManagementCluster, u.Opts.Spec, u.Opts.CliConfig),
+				}
 			},
-			validations.ValidationResult{
-				Name:        "validate identity providers' name",
-				Remediation: "",
-				Err:         ValidateIdentityProviderNameIsUnique(ctx, k, targetCluster, u.Opts.Spec),
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate identity providers' name",
+					Remediation: "",
+					Err:         ValidateIdentityProviderNameIsUnique(ctx, k, targetCluster, u.Opts.Spec),
+				}
 			},
-			validations.ValidationResult{
-				Name:        "validate management cluster has eksa crds",
-				Remediation: "",
-				Err:         ValidateManagementCluster(ctx, k, targetCluster),
+			func() *validations.ValidationResult {
+				return &validations.ValidationResult{
+					Name:        "validate management cluster has eksa crds",
+					Remediation: "",
+					Err:         ValidateManagementCluster(ctx, k, targetCluster),
+				}
 			},
 		)
 	}
 
-	return validations.RunPreflightValidations(createValidations)
+	return createValidations
 }
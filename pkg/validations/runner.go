@@ -1,27 +1,132 @@
 package validations
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var errRunnerValidation = errors.New("validations failed")
 
+// DefaultMaxConcurrency is the default maximum number of validations a Runner runs at the same time.
+const DefaultMaxConcurrency = 5
+
 type Validation func() *ValidationResult
 
+type registeredValidation struct {
+	name       string
+	validation Validation
+	dependsOn  []string
+}
+
+// Runner runs validations concurrently with a bounded worker pool. Validations can declare
+// dependencies on validations registered before them, in which case they only run after those
+// pass and they are skipped if any of them fails. Results are always reported in registration order.
 type Runner struct {
-	validations []Validation
+	validations    []registeredValidation
+	maxConcurrency int
+	results        []*ValidationResult
+	durations      []time.Duration
+}
+
+// RunnerOpt allows to customize a Runner on construction.
+type RunnerOpt func(*Runner)
+
+// WithMaxConcurrency sets the maximum number of validations run at the same time.
+// A value lower than 1 runs the validations one after another.
+func WithMaxConcurrency(n int) RunnerOpt {
+	return func(r *Runner) {
+		if n < 1 {
+			n = 1
+		}
+		r.maxConcurrency = n
+	}
 }
 
-func NewRunner() *Runner {
-	return &Runner{validations: make([]Validation, 0)}
+func NewRunner(opts ...RunnerOpt) *Runner {
+	r := &Runner{
+		validations:    make([]registeredValidation, 0),
+		maxConcurrency: DefaultMaxConcurrency,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
+// Register adds validations without dependencies to the runner.
 func (r *Runner) Register(validations ...Validation) {
-	r.validations = append(r.validations, validations...)
+	for _, v := range validations {
+		r.validations = append(r.validations, registeredValidation{validation: v})
+	}
+}
+
+// RegisterWithDependencies adds a validation identified by name to the runner that only runs
+// once all the validations in dependsOn have passed. Dependencies need to be registered before.
+func (r *Runner) RegisterWithDependencies(name string, validation Validation, dependsOn ...string) {
+	r.validations = append(r.validations, registeredValidation{
+		name:       name,
+		validation: validation,
+		dependsOn:  dependsOn,
+	})
 }
 
+// Run runs all the registered validations, reports their results in registration order
+// and returns an error if any of them failed.
 func (r *Runner) Run() error {
+	if err := r.validateDependencies(); err != nil {
+		return err
+	}
+
+	r.results = make([]*ValidationResult, len(r.validations))
+	r.durations = make([]time.Duration, len(r.validations))
+	done := make([]chan struct{}, len(r.validations))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	indexes := make(map[string]int, len(r.validations))
+	for i, v := range r.validations {
+		if v.name != "" {
+			indexes[v.name] = i
+		}
+	}
+
+	workers := make(chan struct{}, r.maxConcurrency)
+	for i := range r.validations {
+		go func(i int) {
+			defer close(done[i])
+			v := r.validations[i]
+
+			for _, dep := range v.dependsOn {
+				d := indexes[dep]
+				<-done[d]
+				if r.results[d].Err != nil {
+					r.results[i] = &ValidationResult{
+						Name: v.name,
+						Err:  fmt.Errorf("skipped because validation %s failed", dep),
+					}
+					return
+				}
+			}
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			start := time.Now()
+			r.results[i] = v.validation()
+			r.durations[i] = time.Since(start)
+			if r.results[i] == nil {
+				r.results[i] = &ValidationResult{Name: v.name}
+			}
+		}(i)
+	}
+
 	failed := false
-	for _, v := range r.validations {
-		result := v()
+	for i := range r.validations {
+		<-done[i]
+		result := r.results[i]
 		result.Report()
 		if result.Err != nil {
 			failed = true
@@ -34,3 +139,61 @@ func (r *Runner) Run() error {
 
 	return nil
 }
+
+func (r *Runner) validateDependencies() error {
+	registered := map[string]struct{}{}
+	for _, v := range r.validations {
+		for _, dep := range v.dependsOn {
+			if _, ok := registered[dep]; !ok {
+				return fmt.Errorf("validation %s depends on %s, which is not registered before it", v.name, dep)
+			}
+		}
+		if v.name != "" {
+			if _, ok := registered[v.name]; ok {
+				return fmt.Errorf("validation %s is registered more than once", v.name)
+			}
+			registered[v.name] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// Report returns the results of the last Run in registration order.
+func (r *Runner) Report() *Report {
+	report := &Report{
+		Passed:  true,
+		Results: make([]ReportResult, 0, len(r.results)),
+	}
+
+	for i, result := range r.results {
+		reportResult := ReportResult{
+			Name:        result.Name,
+			Passed:      result.Err == nil,
+			Remediation: result.Remediation,
+			Duration:    r.durations[i].Round(time.Millisecond).String(),
+		}
+		if result.Err != nil {
+			report.Passed = false
+			reportResult.Error = result.Err.Error()
+		}
+		report.Results = append(report.Results, reportResult)
+	}
+
+	return report
+}
+
+// Report is the outcome of running the validations of a Runner.
+type Report struct {
+	Passed  bool           `json:"passed"`
+	Results []ReportResult `json:"results"`
+}
+
+// ReportResult is the outcome of a single validation.
+type ReportResult struct {
+	Name        string `json:"name"`
+	Passed      bool   `json:"passed"`
+	Error       string `json:"error,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+	Duration    string `json:"duration"`
+}
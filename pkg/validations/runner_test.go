@@ -2,7 +2,10 @@ package validations_test
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -44,3 +47,134 @@ func TestRunnerRunSuccess(t *testing.T) {
 
 	g.Expect(r.Run()).To(Succeed())
 }
+
+func TestRunnerRunConcurrently(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithMaxConcurrency(2))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		r.Register(func() *validations.ValidationResult {
+			started <- struct{}{}
+			<-release
+			return &validations.ValidationResult{}
+		})
+	}
+
+	errCh := make(chan error)
+	go func() { errCh <- r.Run() }()
+
+	// Both validations need to be running at the same time for this not to block
+	<-started
+	<-started
+	close(release)
+
+	g.Expect(<-errCh).To(Succeed())
+}
+
+func TestRunnerRunMaxConcurrency(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner(validations.WithMaxConcurrency(2))
+	var running, maxRunning int32
+	for i := 0; i < 10; i++ {
+		r.Register(func() *validations.ValidationResult {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return &validations.ValidationResult{}
+		})
+	}
+
+	g.Expect(r.Run()).To(Succeed())
+	g.Expect(atomic.LoadInt32(&maxRunning)).To(BeNumerically("<=", 2))
+}
+
+func TestRunnerRunDependencies(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	var authDone int32
+	r.RegisterWithDependencies("auth", func() *validations.ValidationResult {
+		time.Sleep(5 * time.Millisecond)
+		atomic.StoreInt32(&authDone, 1)
+		return &validations.ValidationResult{Name: "auth"}
+	})
+	r.RegisterWithDependencies("datastore", func() *validations.ValidationResult {
+		if atomic.LoadInt32(&authDone) == 0 {
+			return &validations.ValidationResult{Name: "datastore", Err: errors.New("ran before auth")}
+		}
+		return &validations.ValidationResult{Name: "datastore"}
+	}, "auth")
+
+	g.Expect(r.Run()).To(Succeed())
+}
+
+func TestRunnerRunSkipsFailedDependencies(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	ran := false
+	r.RegisterWithDependencies("auth", func() *validations.ValidationResult {
+		return &validations.ValidationResult{Name: "auth", Err: errors.New("invalid credentials")}
+	})
+	r.RegisterWithDependencies("datastore", func() *validations.ValidationResult {
+		ran = true
+		return &validations.ValidationResult{Name: "datastore"}
+	}, "auth")
+
+	g.Expect(r.Run()).To(MatchError("validations failed"))
+	g.Expect(ran).To(BeFalse())
+
+	report := r.Report()
+	g.Expect(report.Passed).To(BeFalse())
+	g.Expect(report.Results).To(HaveLen(2))
+	g.Expect(report.Results[1].Name).To(Equal("datastore"))
+	g.Expect(report.Results[1].Error).To(Equal("skipped because validation auth failed"))
+}
+
+func TestRunnerRunUnregisteredDependency(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	r.RegisterWithDependencies("datastore", func() *validations.ValidationResult {
+		return &validations.ValidationResult{Name: "datastore"}
+	}, "auth")
+	r.RegisterWithDependencies("auth", func() *validations.ValidationResult {
+		return &validations.ValidationResult{Name: "auth"}
+	})
+
+	g.Expect(r.Run()).To(MatchError("validation datastore depends on auth, which is not registered before it"))
+}
+
+func TestRunnerReportOrder(t *testing.T) {
+	g := NewWithT(t)
+	r := validations.NewRunner()
+	for i := 0; i < 5; i++ {
+		i := i
+		r.Register(func() *validations.ValidationResult {
+			// Later validations finish first
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			result := &validations.ValidationResult{Name: fmt.Sprintf("validation %d", i)}
+			if i == 3 {
+				result.Err = errors.New("failed")
+				result.Remediation = "fix it"
+			}
+			return result
+		})
+	}
+
+	g.Expect(r.Run()).NotTo(Succeed())
+
+	report := r.Report()
+	g.Expect(report.Passed).To(BeFalse())
+	g.Expect(report.Results).To(HaveLen(5))
+	for i, result := range report.Results {
+		g.Expect(result.Name).To(Equal(fmt.Sprintf("validation %d", i)))
+		g.Expect(result.Passed).To(Equal(i != 3))
+	}
+	g.Expect(report.Results[3].Error).To(Equal("failed"))
+	g.Expect(report.Results[3].Remediation).To(Equal("fix it"))
+}
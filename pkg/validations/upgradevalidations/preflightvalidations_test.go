@@ -736,7 +736,7 @@ func TestPreflightValidations(t *testing.T) {
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
 			upgradeValidations := upgradevalidations.New(opts)
-			err := upgradeValidations.PreflightValidations(ctx)
+			err := runValidations(upgradeValidations.PreflightValidations(ctx))
 			if !reflect.DeepEqual(err, tc.wantErr) {
 				t.Errorf("%s want err=%v\n got err=%v\n", tc.name, tc.wantErr, err)
 			}
@@ -744,6 +744,15 @@ func TestPreflightValidations(t *testing.T) {
 	}
 }
 
+func runValidations(vs []validations.Validation) error {
+	results := make([]validations.ValidationResult, 0, len(vs))
+	for _, v := range vs {
+		results = append(results, *v())
+	}
+
+	return validations.RunPreflightValidations(results)
+}
+
 func composeError(msgs ...string) *validations.ValidationError {
 	var errs []string
 	errs = append(errs, msgs...)
@@ -964,7 +973,7 @@ func TestPreFlightValidationsGit(t *testing.T) {
 			k.EXPECT().GetEksaAWSIamConfig(ctx, clusterSpec.Cluster.Spec.IdentityProviderRefs[1].Name, gomock.Any(), gomock.Any()).Return(existingClusterSpec.AWSIamConfig, nil).MaxTimes(1)
 			k.EXPECT().Version(ctx, workloadCluster).Return(versionResponse, nil)
 			upgradeValidations := upgradevalidations.New(opts)
-			err := upgradeValidations.PreflightValidations(ctx)
+			err := runValidations(upgradeValidations.PreflightValidations(ctx))
 			if !reflect.DeepEqual(err, tc.wantErr) {
 				t.Errorf("%s want err=%v\n got err=%v\n", tc.name, tc.wantErr, err)
 			}
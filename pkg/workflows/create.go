@@ -2,10 +2,10 @@ package workflows
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -71,6 +71,33 @@ func (c *Create) Run(ctx context.Context, clusterSpec *cluster.Spec, validator i
 	return err
 }
 
+// RunPreflights only runs the setup and validations of the create workflow, without creating
+// anything, and returns the report of all the validations.
+func (c *Create) RunPreflights(ctx context.Context, clusterSpec *cluster.Spec, validator interfaces.Validator) (*validations.Report, error) {
+	commandContext := &task.CommandContext{
+		Provider:     c.provider,
+		AddonManager: c.addonManager,
+		ClusterSpec:  clusterSpec,
+		Validations:  validator,
+	}
+
+	var providerChecks []providers.PreflightCheck
+	if checker, ok := c.provider.(providers.PreflightChecker); ok {
+		providerChecks = checker.CreatePreflightChecks(clusterSpec)
+	} else {
+		// Providers that can't validate the spec without changing the infrastructure run their full setup
+		providerChecks = providerSetupCheck(c.provider, func(ctx context.Context) error {
+			return c.provider.SetupAndValidateCreateCluster(ctx, clusterSpec)
+		})
+	}
+
+	logger.Info("Performing setup and validations")
+	runner := (&SetAndValidateTask{}).runner(ctx, commandContext, providerChecks)
+	err := runner.Run()
+
+	return runner.Report(), err
+}
+
 // task related entities
 
 type CreateBootStrapClusterTask struct{}
@@ -161,12 +188,10 @@ func (s *CreateBootStrapClusterTask) Checkpoint() *task.CompletedTask {
 
 func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
-	runner.Register(s.providerValidation(ctx, commandContext)...)
-	runner.Register(commandContext.AddonManager.Validations(ctx, commandContext.ClusterSpec)...)
-	runner.Register(s.validations(ctx, commandContext)...)
-
-	err := runner.Run()
+	providerSetup := providerSetupCheck(commandContext.Provider, func(ctx context.Context) error {
+		return commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec)
+	})
+	err := s.runner(ctx, commandContext, providerSetup).Run()
 	if err != nil {
 		commandContext.SetError(err)
 		return nil
@@ -174,26 +199,15 @@ func (s *SetAndValidateTask) Run(ctx context.Context, commandContext *task.Comma
 	return &CreateBootStrapClusterTask{}
 }
 
-func (s *SetAndValidateTask) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
-	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: "create preflight validations pass",
-				Err:  commandContext.Validations.PreflightValidations(ctx),
-			}
-		},
-	}
-}
-
-func (s *SetAndValidateTask) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
-	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateCreateCluster(ctx, commandContext.ClusterSpec),
-			}
-		},
-	}
+// runner builds a validations runner with the provider checks and the addon and preflight validations.
+// The provider checks set defaults in the cluster spec, so the addon and preflight validations,
+// which read it, only run once all of them have passed. They run concurrently with each other.
+func (s *SetAndValidateTask) runner(ctx context.Context, commandContext *task.CommandContext, providerChecks []providers.PreflightCheck) *validations.Runner {
+	runner := validations.NewRunner()
+	providerCheckNames := registerProviderChecks(ctx, runner, providerChecks)
+	registerValidations(runner, "addon validation", commandContext.AddonManager.Validations(ctx, commandContext.ClusterSpec), providerCheckNames...)
+	registerValidations(runner, "create preflight validation", commandContext.Validations.PreflightValidations(ctx), providerCheckNames...)
+	return runner
 }
 
 func (s *SetAndValidateTask) Name() string {
@@ -260,6 +274,15 @@ func (s *CreateWorkloadClusterTask) Run(ctx context.Context, commandContext *tas
 			commandContext.SetError(err)
 			return &CollectDiagnosticsTask{}
 		}
+	} else if commandContext.Provider.Name() == constants.CloudStackProviderName {
+		// The existing management cluster reconciles the workload cluster with the CloudStack
+		// credentials stored in secrets, so it needs the secrets for the workload cluster too.
+		logger.Info("Installing EKS-A secrets on management cluster")
+		err := commandContext.Provider.UpdateSecrets(ctx, commandContext.BootstrapCluster)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
 	}
 
 	logger.V(4).Info("Installing machine health checks on bootstrap cluster")
@@ -6,16 +6,19 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
 )
@@ -147,6 +150,16 @@ func (c *createTestSetup) expectCreateWorkloadSkipCAPI() {
 	c.provider.EXPECT().UpdateSecrets(c.ctx, c.workloadCluster).Times(0)
 }
 
+func (c *createTestSetup) expectSkipInstallSecretsOnManagement() {
+	c.provider.EXPECT().Name().Return("vsphere")
+	c.provider.EXPECT().UpdateSecrets(c.ctx, c.bootstrapCluster).Times(0)
+}
+
+func (c *createTestSetup) expectInstallSecretsOnManagement() {
+	c.provider.EXPECT().Name().Return(constants.CloudStackProviderName)
+	c.provider.EXPECT().UpdateSecrets(c.ctx, c.bootstrapCluster)
+}
+
 func (c *createTestSetup) expectMoveManagement() {
 	c.clusterManager.EXPECT().MoveCAPI(
 		c.ctx, c.bootstrapCluster, c.workloadCluster, c.workloadCluster.Name, c.clusterSpec, gomock.Any(),
@@ -304,6 +317,37 @@ func TestCreateWorkloadClusterRunSuccess(t *testing.T) {
 
 	test.expectSetup()
 	test.expectCreateWorkloadSkipCAPI()
+	test.expectSkipInstallSecretsOnManagement()
+	test.skipMoveManagement()
+	test.skipInstallEksaComponents()
+	test.expectInstallAddonManager()
+	test.expectWriteClusterConfig()
+	test.expectNotDeleteBootstrap()
+	test.expectInstallMHC()
+	test.expectPreflightValidationsToPass()
+
+	if err := test.run(); err != nil {
+		t.Fatalf("Create.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestCreateWorkloadClusterRunCloudStackSuccess(t *testing.T) {
+	managementKubeconfig := "test.kubeconfig"
+	test := newCreateTest(t)
+
+	test.bootstrapCluster.ExistingManagement = true
+	test.bootstrapCluster.KubeconfigFile = managementKubeconfig
+	test.bootstrapCluster.Name = "cluster-name"
+
+	test.clusterSpec.ManagementCluster = &types.Cluster{
+		Name:               test.bootstrapCluster.Name,
+		KubeconfigFile:     managementKubeconfig,
+		ExistingManagement: true,
+	}
+
+	test.expectSetup()
+	test.expectCreateWorkloadSkipCAPI()
+	test.expectInstallSecretsOnManagement()
 	test.skipMoveManagement()
 	test.skipInstallEksaComponents()
 	test.expectInstallAddonManager()
@@ -371,3 +415,122 @@ func TestCreateWorkloadClusterTaskRunPostCreateWorkloadClusterFailure(t *testing
 		t.Fatalf("expected error from task")
 	}
 }
+
+// preflightCheckerProvider is a provider that validates the cluster spec with individual checks.
+type preflightCheckerProvider struct {
+	*providermocks.MockProvider
+	checks []providers.PreflightCheck
+}
+
+func (p *preflightCheckerProvider) CreatePreflightChecks(_ *cluster.Spec) []providers.PreflightCheck {
+	return p.checks
+}
+
+func (p *preflightCheckerProvider) UpgradePreflightChecks(_ *types.Cluster, _ *cluster.Spec) []providers.PreflightCheck {
+	return p.checks
+}
+
+// providerChecks returns the checks of a provider where the second check depends on the first one,
+// which returns firstErr, and records the checks that run.
+func providerChecks(firstErr error, ran *[]string) []providers.PreflightCheck {
+	return []providers.PreflightCheck{
+		{
+			Name: "vcenter access",
+			Run: func(_ context.Context) error {
+				*ran = append(*ran, "vcenter access")
+				return firstErr
+			},
+		},
+		{
+			Name:      "datastores",
+			DependsOn: []string{"vcenter access"},
+			Run: func(_ context.Context) error {
+				*ran = append(*ran, "datastores")
+				return nil
+			},
+		},
+	}
+}
+
+func passingValidation(name string) validations.Validation {
+	return func() *validations.ValidationResult {
+		return &validations.ValidationResult{Name: name}
+	}
+}
+
+func TestCreateRunPreflightsSuccess(t *testing.T) {
+	g := NewWithT(t)
+	test := newCreateTest(t)
+	test.expectSetup()
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{
+		passingValidation("cluster name"), passingValidation("gitops"),
+	})
+
+	report, err := test.workflow.RunPreflights(test.ctx, test.clusterSpec, test.validator)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Passed).To(BeTrue())
+	g.Expect(report.Results).To(HaveLen(3))
+	g.Expect(report.Results[1].Name).To(Equal("cluster name"))
+	g.Expect(report.Results[2].Name).To(Equal("gitops"))
+}
+
+func TestCreateRunPreflightsFailure(t *testing.T) {
+	g := NewWithT(t)
+	test := newCreateTest(t)
+	test.provider.EXPECT().SetupAndValidateCreateCluster(test.ctx, test.clusterSpec).Return(errors.New("invalid vCenter config"))
+	test.provider.EXPECT().Name().Return("vsphere")
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{
+		func() *validations.ValidationResult {
+			t.Fatal("preflight validation should not run when the provider setup fails")
+			return nil
+		},
+	})
+
+	report, err := test.workflow.RunPreflights(test.ctx, test.clusterSpec, test.validator)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(report.Passed).To(BeFalse())
+	g.Expect(report.Results).To(HaveLen(2))
+	g.Expect(report.Results[0].Name).To(Equal("vsphere Provider setup is valid"))
+	g.Expect(report.Results[0].Error).To(Equal("invalid vCenter config"))
+	g.Expect(report.Results[1].Passed).To(BeFalse())
+	g.Expect(report.Results[1].Error).To(Equal("skipped because validation vsphere Provider setup is valid failed"))
+}
+
+func TestCreateRunPreflightsProviderChecks(t *testing.T) {
+	g := NewWithT(t)
+	test := newCreateTest(t)
+	var ran []string
+	provider := &preflightCheckerProvider{MockProvider: test.provider, checks: providerChecks(nil, &ran)}
+	workflow := workflows.NewCreate(test.bootstrapper, provider, test.clusterManager, test.addonManager, test.writer, test.eksd, nil)
+	test.provider.EXPECT().SetupAndValidateCreateCluster(gomock.Any(), gomock.Any()).Times(0)
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec).Return([]validations.Validation{passingValidation("flux")})
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{
+		passingValidation("cluster name"), passingValidation("gitops"),
+	})
+
+	report, err := workflow.RunPreflights(test.ctx, test.clusterSpec, test.validator)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ran).To(Equal([]string{"vcenter access", "datastores"}))
+	g.Expect(report.Results).To(HaveLen(5))
+	g.Expect(report.Results[0].Name).To(Equal("vcenter access"))
+	g.Expect(report.Results[1].Name).To(Equal("datastores"))
+}
+
+func TestCreateRunPreflightsProviderCheckFailure(t *testing.T) {
+	g := NewWithT(t)
+	test := newCreateTest(t)
+	var ran []string
+	provider := &preflightCheckerProvider{MockProvider: test.provider, checks: providerChecks(errors.New("invalid credentials"), &ran)}
+	workflow := workflows.NewCreate(test.bootstrapper, provider, test.clusterManager, test.addonManager, test.writer, test.eksd, nil)
+	test.addonManager.EXPECT().Validations(test.ctx, test.clusterSpec)
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{passingValidation("cluster name")})
+
+	report, err := workflow.RunPreflights(test.ctx, test.clusterSpec, test.validator)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(ran).To(Equal([]string{"vcenter access"}))
+	g.Expect(report.Results).To(HaveLen(3))
+	g.Expect(report.Results[0].Error).To(Equal("invalid credentials"))
+	g.Expect(report.Results[1].Error).To(Equal("skipped because validation vcenter access failed"))
+	g.Expect(report.Results[2].Error).To(Equal("skipped because validation vcenter access failed"))
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -20,6 +21,8 @@ type ClusterManager interface {
 	CreateWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) (*types.Cluster, error)
 	RunPostCreateWorkloadCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	UpgradeCluster(ctx context.Context, managementCluster, workloadCluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
+	DiffClusterUpgrade(ctx context.Context, managementCluster, workloadCluster *types.Cluster, newClusterSpec *cluster.Spec, provider providers.Provider) (*types.UpgradeDiff, error)
+	ScaleWorkerNodeGroups(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
 	DeleteCluster(ctx context.Context, managementCluster, clusterToDelete *types.Cluster, provider providers.Provider, clusterSpec *cluster.Spec) error
 	InstallCAPI(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster, provider providers.Provider) error
 	InstallNetworking(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
@@ -32,7 +35,13 @@ type ClusterManager interface {
 	ApplyBundles(ctx context.Context, clusterSpec *cluster.Spec, cluster *types.Cluster) error
 	PauseEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	ResumeEKSAControllerReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
+	PauseCAPIClusterReconcile(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
+	ResumeCAPIClusterReconcile(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
+	EKSAControllerReconcilePaused(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error)
+	CAPIClusterReconcilePaused(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error)
+	ReconcilePausedClusters(ctx context.Context, managementCluster *types.Cluster) ([]string, error)
 	EKSAClusterSpecChanged(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error)
+	OnlyWorkerNodeGroupCountsChanged(ctx context.Context, managementCluster *types.Cluster, currentSpec, newSpec *cluster.Spec, provider providers.Provider) (bool, error)
 	InstallMachineHealthChecks(ctx context.Context, workloadCluster *types.Cluster, provider providers.Provider) error
 	GetCurrentClusterSpec(ctx context.Context, cluster *types.Cluster, clusterName string) (*cluster.Spec, error)
 	Upgrade(ctx context.Context, cluster *types.Cluster, currentSpec, newSpec *cluster.Spec) (*types.ChangeDiff, error)
@@ -44,7 +53,9 @@ type AddonManager interface {
 	InstallGitOps(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
 	PauseGitOpsKustomization(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	ResumeGitOpsKustomization(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	GitOpsKustomizationPaused(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error)
 	UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
+	DiffGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) ([]string, error)
 	ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
 	Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation
 	CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) error
@@ -53,7 +64,7 @@ type AddonManager interface {
 }
 
 type Validator interface {
-	PreflightValidations(ctx context.Context) error
+	PreflightValidations(ctx context.Context) []validations.Validation
 }
 
 type CAPIManager interface {
@@ -73,3 +84,12 @@ type EksdUpgrader interface {
 type PackageInstaller interface {
 	InstallCuratedPackages(ctx context.Context) error
 }
+
+type WorkloadClusterUpgrader interface {
+	UpgradeManagementComponents(ctx context.Context, managementCluster *types.Cluster, cluster *v1alpha1.Cluster) error
+	UpgradeWorkloadCluster(ctx context.Context, managementCluster *types.Cluster, cluster *v1alpha1.Cluster) error
+}
+
+type ClusterHealthChecker interface {
+	CheckClusterHealth(ctx context.Context, managementCluster *types.Cluster, clusterName string) error
+}
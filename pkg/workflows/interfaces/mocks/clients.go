@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,AddonManager,Validator,CAPIManager,EksdInstaller,EksdUpgrader,PackageInstaller)
+// Source: github.com/aws/eks-anywhere/pkg/workflows/interfaces (interfaces: Bootstrapper,ClusterManager,AddonManager,Validator,CAPIManager,EksdInstaller,EksdUpgrader,PackageInstaller,WorkloadClusterUpgrader,ClusterHealthChecker)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	bootstrapper "github.com/aws/eks-anywhere/pkg/bootstrapper"
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
 	providers "github.com/aws/eks-anywhere/pkg/providers"
@@ -110,6 +111,21 @@ func (mr *MockClusterManagerMockRecorder) ApplyBundles(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBundles", reflect.TypeOf((*MockClusterManager)(nil).ApplyBundles), arg0, arg1, arg2)
 }
 
+// CAPIClusterReconcilePaused mocks base method.
+func (m *MockClusterManager) CAPIClusterReconcilePaused(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CAPIClusterReconcilePaused", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CAPIClusterReconcilePaused indicates an expected call of CAPIClusterReconcilePaused.
+func (mr *MockClusterManagerMockRecorder) CAPIClusterReconcilePaused(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CAPIClusterReconcilePaused", reflect.TypeOf((*MockClusterManager)(nil).CAPIClusterReconcilePaused), arg0, arg1, arg2)
+}
+
 // CreateAwsIamAuthCaSecret mocks base method.
 func (m *MockClusterManager) CreateAwsIamAuthCaSecret(arg0 context.Context, arg1 *types.Cluster) error {
 	m.ctrl.T.Helper()
@@ -167,6 +183,21 @@ func (mr *MockClusterManagerMockRecorder) DeleteCluster(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCluster", reflect.TypeOf((*MockClusterManager)(nil).DeleteCluster), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DiffClusterUpgrade mocks base method.
+func (m *MockClusterManager) DiffClusterUpgrade(arg0 context.Context, arg1, arg2 *types.Cluster, arg3 *cluster.Spec, arg4 providers.Provider) (*types.UpgradeDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffClusterUpgrade", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*types.UpgradeDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffClusterUpgrade indicates an expected call of DiffClusterUpgrade.
+func (mr *MockClusterManagerMockRecorder) DiffClusterUpgrade(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffClusterUpgrade", reflect.TypeOf((*MockClusterManager)(nil).DiffClusterUpgrade), arg0, arg1, arg2, arg3, arg4)
+}
+
 // EKSAClusterSpecChanged mocks base method.
 func (m *MockClusterManager) EKSAClusterSpecChanged(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) (bool, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +213,21 @@ func (mr *MockClusterManagerMockRecorder) EKSAClusterSpecChanged(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EKSAClusterSpecChanged", reflect.TypeOf((*MockClusterManager)(nil).EKSAClusterSpecChanged), arg0, arg1, arg2)
 }
 
+// EKSAControllerReconcilePaused mocks base method.
+func (m *MockClusterManager) EKSAControllerReconcilePaused(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EKSAControllerReconcilePaused", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EKSAControllerReconcilePaused indicates an expected call of EKSAControllerReconcilePaused.
+func (mr *MockClusterManagerMockRecorder) EKSAControllerReconcilePaused(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EKSAControllerReconcilePaused", reflect.TypeOf((*MockClusterManager)(nil).EKSAControllerReconcilePaused), arg0, arg1, arg2)
+}
+
 // GetCurrentClusterSpec mocks base method.
 func (m *MockClusterManager) GetCurrentClusterSpec(arg0 context.Context, arg1 *types.Cluster, arg2 string) (*cluster.Spec, error) {
 	m.ctrl.T.Helper()
@@ -300,6 +346,35 @@ func (mr *MockClusterManagerMockRecorder) MoveCAPI(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCAPI", reflect.TypeOf((*MockClusterManager)(nil).MoveCAPI), varargs...)
 }
 
+// OnlyWorkerNodeGroupCountsChanged mocks base method.
+func (m *MockClusterManager) OnlyWorkerNodeGroupCountsChanged(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec, arg4 providers.Provider) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnlyWorkerNodeGroupCountsChanged", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OnlyWorkerNodeGroupCountsChanged indicates an expected call of OnlyWorkerNodeGroupCountsChanged.
+func (mr *MockClusterManagerMockRecorder) OnlyWorkerNodeGroupCountsChanged(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnlyWorkerNodeGroupCountsChanged", reflect.TypeOf((*MockClusterManager)(nil).OnlyWorkerNodeGroupCountsChanged), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PauseCAPIClusterReconcile mocks base method.
+func (m *MockClusterManager) PauseCAPIClusterReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseCAPIClusterReconcile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseCAPIClusterReconcile indicates an expected call of PauseCAPIClusterReconcile.
+func (mr *MockClusterManagerMockRecorder) PauseCAPIClusterReconcile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseCAPIClusterReconcile", reflect.TypeOf((*MockClusterManager)(nil).PauseCAPIClusterReconcile), arg0, arg1, arg2)
+}
+
 // PauseEKSAControllerReconcile mocks base method.
 func (m *MockClusterManager) PauseEKSAControllerReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -314,6 +389,35 @@ func (mr *MockClusterManagerMockRecorder) PauseEKSAControllerReconcile(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseEKSAControllerReconcile", reflect.TypeOf((*MockClusterManager)(nil).PauseEKSAControllerReconcile), arg0, arg1, arg2, arg3)
 }
 
+// ReconcilePausedClusters mocks base method.
+func (m *MockClusterManager) ReconcilePausedClusters(arg0 context.Context, arg1 *types.Cluster) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcilePausedClusters", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcilePausedClusters indicates an expected call of ReconcilePausedClusters.
+func (mr *MockClusterManagerMockRecorder) ReconcilePausedClusters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcilePausedClusters", reflect.TypeOf((*MockClusterManager)(nil).ReconcilePausedClusters), arg0, arg1)
+}
+
+// ResumeCAPIClusterReconcile mocks base method.
+func (m *MockClusterManager) ResumeCAPIClusterReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeCAPIClusterReconcile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeCAPIClusterReconcile indicates an expected call of ResumeCAPIClusterReconcile.
+func (mr *MockClusterManagerMockRecorder) ResumeCAPIClusterReconcile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeCAPIClusterReconcile", reflect.TypeOf((*MockClusterManager)(nil).ResumeCAPIClusterReconcile), arg0, arg1, arg2)
+}
+
 // ResumeEKSAControllerReconcile mocks base method.
 func (m *MockClusterManager) ResumeEKSAControllerReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
@@ -370,6 +474,20 @@ func (mr *MockClusterManagerMockRecorder) SaveLogsWorkloadCluster(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLogsWorkloadCluster", reflect.TypeOf((*MockClusterManager)(nil).SaveLogsWorkloadCluster), arg0, arg1, arg2, arg3)
 }
 
+// ScaleWorkerNodeGroups mocks base method.
+func (m *MockClusterManager) ScaleWorkerNodeGroups(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScaleWorkerNodeGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScaleWorkerNodeGroups indicates an expected call of ScaleWorkerNodeGroups.
+func (mr *MockClusterManagerMockRecorder) ScaleWorkerNodeGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleWorkerNodeGroups", reflect.TypeOf((*MockClusterManager)(nil).ScaleWorkerNodeGroups), arg0, arg1, arg2)
+}
+
 // Upgrade mocks base method.
 func (m *MockClusterManager) Upgrade(arg0 context.Context, arg1 *types.Cluster, arg2, arg3 *cluster.Spec) (*types.ChangeDiff, error) {
 	m.ctrl.T.Helper()
@@ -451,6 +569,21 @@ func (mr *MockAddonManagerMockRecorder) CleanupGitRepo(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupGitRepo", reflect.TypeOf((*MockAddonManager)(nil).CleanupGitRepo), arg0, arg1)
 }
 
+// DiffGitEksaSpec mocks base method.
+func (m *MockAddonManager) DiffGitEksaSpec(arg0 context.Context, arg1 *cluster.Spec, arg2 providers.DatacenterConfig, arg3 []providers.MachineConfig) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffGitEksaSpec", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffGitEksaSpec indicates an expected call of DiffGitEksaSpec.
+func (mr *MockAddonManagerMockRecorder) DiffGitEksaSpec(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffGitEksaSpec", reflect.TypeOf((*MockAddonManager)(nil).DiffGitEksaSpec), arg0, arg1, arg2, arg3)
+}
+
 // ForceReconcileGitRepo mocks base method.
 func (m *MockAddonManager) ForceReconcileGitRepo(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -465,6 +598,21 @@ func (mr *MockAddonManagerMockRecorder) ForceReconcileGitRepo(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceReconcileGitRepo", reflect.TypeOf((*MockAddonManager)(nil).ForceReconcileGitRepo), arg0, arg1, arg2)
 }
 
+// GitOpsKustomizationPaused mocks base method.
+func (m *MockAddonManager) GitOpsKustomizationPaused(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GitOpsKustomizationPaused", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GitOpsKustomizationPaused indicates an expected call of GitOpsKustomizationPaused.
+func (mr *MockAddonManagerMockRecorder) GitOpsKustomizationPaused(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GitOpsKustomizationPaused", reflect.TypeOf((*MockAddonManager)(nil).GitOpsKustomizationPaused), arg0, arg1, arg2)
+}
+
 // InstallGitOps mocks base method.
 func (m *MockAddonManager) InstallGitOps(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.DatacenterConfig, arg4 []providers.MachineConfig) error {
 	m.ctrl.T.Helper()
@@ -588,10 +736,10 @@ func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
 }
 
 // PreflightValidations mocks base method.
-func (m *MockValidator) PreflightValidations(arg0 context.Context) error {
+func (m *MockValidator) PreflightValidations(arg0 context.Context) []validations.Validation {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PreflightValidations", arg0)
-	ret0, _ := ret[0].(error)
+	ret0, _ := ret[0].([]validations.Validation)
 	return ret0
 }
 
@@ -778,3 +926,91 @@ func (mr *MockPackageInstallerMockRecorder) InstallCuratedPackages(arg0 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallCuratedPackages", reflect.TypeOf((*MockPackageInstaller)(nil).InstallCuratedPackages), arg0)
 }
+
+// MockWorkloadClusterUpgrader is a mock of WorkloadClusterUpgrader interface.
+type MockWorkloadClusterUpgrader struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkloadClusterUpgraderMockRecorder
+}
+
+// MockWorkloadClusterUpgraderMockRecorder is the mock recorder for MockWorkloadClusterUpgrader.
+type MockWorkloadClusterUpgraderMockRecorder struct {
+	mock *MockWorkloadClusterUpgrader
+}
+
+// NewMockWorkloadClusterUpgrader creates a new mock instance.
+func NewMockWorkloadClusterUpgrader(ctrl *gomock.Controller) *MockWorkloadClusterUpgrader {
+	mock := &MockWorkloadClusterUpgrader{ctrl: ctrl}
+	mock.recorder = &MockWorkloadClusterUpgraderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkloadClusterUpgrader) EXPECT() *MockWorkloadClusterUpgraderMockRecorder {
+	return m.recorder
+}
+
+// UpgradeManagementComponents mocks base method.
+func (m *MockWorkloadClusterUpgrader) UpgradeManagementComponents(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeManagementComponents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeManagementComponents indicates an expected call of UpgradeManagementComponents.
+func (mr *MockWorkloadClusterUpgraderMockRecorder) UpgradeManagementComponents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeManagementComponents", reflect.TypeOf((*MockWorkloadClusterUpgrader)(nil).UpgradeManagementComponents), arg0, arg1, arg2)
+}
+
+// UpgradeWorkloadCluster mocks base method.
+func (m *MockWorkloadClusterUpgrader) UpgradeWorkloadCluster(arg0 context.Context, arg1 *types.Cluster, arg2 *v1alpha1.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeWorkloadCluster", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeWorkloadCluster indicates an expected call of UpgradeWorkloadCluster.
+func (mr *MockWorkloadClusterUpgraderMockRecorder) UpgradeWorkloadCluster(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeWorkloadCluster", reflect.TypeOf((*MockWorkloadClusterUpgrader)(nil).UpgradeWorkloadCluster), arg0, arg1, arg2)
+}
+
+// MockClusterHealthChecker is a mock of ClusterHealthChecker interface.
+type MockClusterHealthChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterHealthCheckerMockRecorder
+}
+
+// MockClusterHealthCheckerMockRecorder is the mock recorder for MockClusterHealthChecker.
+type MockClusterHealthCheckerMockRecorder struct {
+	mock *MockClusterHealthChecker
+}
+
+// NewMockClusterHealthChecker creates a new mock instance.
+func NewMockClusterHealthChecker(ctrl *gomock.Controller) *MockClusterHealthChecker {
+	mock := &MockClusterHealthChecker{ctrl: ctrl}
+	mock.recorder = &MockClusterHealthCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClusterHealthChecker) EXPECT() *MockClusterHealthCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckClusterHealth mocks base method.
+func (m *MockClusterHealthChecker) CheckClusterHealth(arg0 context.Context, arg1 *types.Cluster, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckClusterHealth", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckClusterHealth indicates an expected call of CheckClusterHealth.
+func (mr *MockClusterHealthCheckerMockRecorder) CheckClusterHealth(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckClusterHealth", reflect.TypeOf((*MockClusterHealthChecker)(nil).CheckClusterHealth), arg0, arg1, arg2)
+}
@@ -0,0 +1,169 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+// PauseCluster pauses and resumes the reconciliation of a cluster by the Flux, EKS-A and CAPI controllers.
+type PauseCluster struct {
+	provider       providers.Provider
+	clusterManager interfaces.ClusterManager
+	addonManager   interfaces.AddonManager
+}
+
+func NewPauseCluster(provider providers.Provider, clusterManager interfaces.ClusterManager, addonManager interfaces.AddonManager) *PauseCluster {
+	return &PauseCluster{
+		provider:       provider,
+		clusterManager: clusterManager,
+		addonManager:   addonManager,
+	}
+}
+
+type reconcilePauser struct {
+	name   string
+	paused func(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) (bool, error)
+	pause  func(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
+	resume func(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error
+	// shared is true if the controller reconciles all the clusters of the management cluster,
+	// so it's only resumed once none of them is paused.
+	shared bool
+}
+
+// pausers returns the controllers in the order they are paused. Flux goes first so it doesn't
+// revert the EKS-A objects, and EKS-A goes before CAPI so it doesn't unpause the CAPI cluster.
+func (p *PauseCluster) pausers() []reconcilePauser {
+	return []reconcilePauser{
+		{
+			name:   "Flux kustomization",
+			paused: p.addonManager.GitOpsKustomizationPaused,
+			pause:  p.addonManager.PauseGitOpsKustomization,
+			resume: p.addonManager.ResumeGitOpsKustomization,
+			shared: true,
+		},
+		{
+			name:   "EKS-A cluster controller",
+			paused: p.clusterManager.EKSAControllerReconcilePaused,
+			pause: func(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+				return p.clusterManager.PauseEKSAControllerReconcile(ctx, managementCluster, clusterSpec, p.provider)
+			},
+			resume: func(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+				return p.clusterManager.ResumeEKSAControllerReconcile(ctx, managementCluster, clusterSpec, p.provider)
+			},
+		},
+		{
+			name:   "CAPI cluster",
+			paused: p.clusterManager.CAPIClusterReconcilePaused,
+			pause:  p.clusterManager.PauseCAPIClusterReconcile,
+			resume: p.clusterManager.ResumeCAPIClusterReconcile,
+		},
+	}
+}
+
+// Pause pauses the reconciliation of the cluster by all its controllers. The controllers already paused are
+// left as they are, so pausing a paused cluster is a no-op. If a controller can't be paused, only the ones
+// this call paused are resumed, so the cluster is left as it was.
+func (p *PauseCluster) Pause(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	var changed []reconcilePauser
+	rollback := func() {
+		for i := len(changed) - 1; i >= 0; i-- {
+			if err := changed[i].resume(ctx, managementCluster, clusterSpec); err != nil {
+				logger.MarkFail("Failed resuming reconciliation after pause failed", "controller", changed[i].name, "error", err)
+			}
+		}
+	}
+
+	for _, pauser := range p.pausers() {
+		paused, err := pauser.paused(ctx, managementCluster, clusterSpec)
+		if err != nil {
+			rollback()
+			return fmt.Errorf("checking %s reconciliation: %v", pauser.name, err)
+		}
+		if paused {
+			logger.Info("Reconciliation already paused", "controller", pauser.name)
+			continue
+		}
+
+		logger.Info("Pausing reconciliation", "controller", pauser.name)
+		if err := pauser.pause(ctx, managementCluster, clusterSpec); err != nil {
+			rollback()
+			return fmt.Errorf("pausing %s reconciliation: %v", pauser.name, err)
+		}
+		changed = append(changed, pauser)
+	}
+
+	logger.MarkSuccess("Cluster reconciliation paused", "cluster", clusterSpec.Cluster.Name)
+	return nil
+}
+
+// Resume resumes the reconciliation of the cluster by all its controllers, in the reverse order they were paused.
+// The controllers that aren't paused are left as they are, so resuming a cluster that isn't paused is a no-op.
+// The controllers shared with other clusters stay paused while any of those clusters is paused. If a controller
+// can't be resumed, only the ones this call resumed are paused again, so the cluster is left as it was.
+func (p *PauseCluster) Resume(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	var changed []reconcilePauser
+	rollback := func() {
+		for i := len(changed) - 1; i >= 0; i-- {
+			if err := changed[i].pause(ctx, managementCluster, clusterSpec); err != nil {
+				logger.MarkFail("Failed pausing reconciliation after resume failed", "controller", changed[i].name, "error", err)
+			}
+		}
+	}
+
+	pausers := p.pausers()
+	for i := len(pausers) - 1; i >= 0; i-- {
+		pauser := pausers[i]
+		paused, err := pauser.paused(ctx, managementCluster, clusterSpec)
+		if err != nil {
+			rollback()
+			return fmt.Errorf("checking %s reconciliation: %v", pauser.name, err)
+		}
+		if !paused {
+			logger.Info("Reconciliation not paused", "controller", pauser.name)
+			continue
+		}
+
+		if pauser.shared {
+			others, err := p.otherPausedClusters(ctx, managementCluster, clusterSpec)
+			if err != nil {
+				rollback()
+				return fmt.Errorf("checking %s reconciliation: %v", pauser.name, err)
+			}
+			if len(others) > 0 {
+				logger.Info("Leaving reconciliation paused for other paused clusters", "controller", pauser.name, "clusters", others)
+				continue
+			}
+		}
+
+		logger.Info("Resuming reconciliation", "controller", pauser.name)
+		if err := pauser.resume(ctx, managementCluster, clusterSpec); err != nil {
+			rollback()
+			return fmt.Errorf("resuming %s reconciliation: %v", pauser.name, err)
+		}
+		changed = append(changed, pauser)
+	}
+
+	logger.MarkSuccess("Cluster reconciliation resumed", "cluster", clusterSpec.Cluster.Name)
+	return nil
+}
+
+func (p *PauseCluster) otherPausedClusters(ctx context.Context, managementCluster *types.Cluster, clusterSpec *cluster.Spec) ([]string, error) {
+	paused, err := p.clusterManager.ReconcilePausedClusters(ctx, managementCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	others := make([]string, 0, len(paused))
+	for _, name := range paused {
+		if name != clusterSpec.Cluster.Name {
+			others = append(others, name)
+		}
+	}
+	return others, nil
+}
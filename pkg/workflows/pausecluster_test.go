@@ -0,0 +1,159 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type pauseClusterTest struct {
+	*WithT
+	ctx               context.Context
+	clusterManager    *mocks.MockClusterManager
+	addonManager      *mocks.MockAddonManager
+	provider          *providermocks.MockProvider
+	workflow          *workflows.PauseCluster
+	clusterSpec       *cluster.Spec
+	managementCluster *types.Cluster
+}
+
+func newPauseClusterTest(t *testing.T) *pauseClusterTest {
+	ctrl := gomock.NewController(t)
+	clusterManager := mocks.NewMockClusterManager(ctrl)
+	addonManager := mocks.NewMockAddonManager(ctrl)
+	provider := providermocks.NewMockProvider(ctrl)
+
+	return &pauseClusterTest{
+		WithT:             NewWithT(t),
+		ctx:               context.Background(),
+		clusterManager:    clusterManager,
+		addonManager:      addonManager,
+		provider:          provider,
+		workflow:          workflows.NewPauseCluster(provider, clusterManager, addonManager),
+		clusterSpec:       test.NewClusterSpec(func(s *cluster.Spec) { s.Cluster.Name = "w01" }),
+		managementCluster: &types.Cluster{Name: "mgmt", KubeconfigFile: "mgmt.kubeconfig"},
+	}
+}
+
+func (tt *pauseClusterTest) expectPaused(flux, eksa, capi bool) {
+	tt.addonManager.EXPECT().GitOpsKustomizationPaused(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(flux, nil).AnyTimes()
+	tt.clusterManager.EXPECT().EKSAControllerReconcilePaused(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(eksa, nil).AnyTimes()
+	tt.clusterManager.EXPECT().CAPIClusterReconcilePaused(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(capi, nil).AnyTimes()
+}
+
+func TestPauseClusterPauseSuccess(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(false, false, false)
+	gomock.InOrder(
+		tt.addonManager.EXPECT().PauseGitOpsKustomization(tt.ctx, tt.managementCluster, tt.clusterSpec),
+		tt.clusterManager.EXPECT().PauseEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.clusterManager.EXPECT().PauseCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec),
+	)
+
+	tt.Expect(tt.workflow.Pause(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPauseClusterPauseSkipsPausedControllers(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(true, true, false)
+	tt.clusterManager.EXPECT().PauseCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec)
+
+	tt.Expect(tt.workflow.Pause(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPauseClusterPauseRollsBackOnError(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(false, false, false)
+	gomock.InOrder(
+		tt.addonManager.EXPECT().PauseGitOpsKustomization(tt.ctx, tt.managementCluster, tt.clusterSpec),
+		tt.clusterManager.EXPECT().PauseEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.clusterManager.EXPECT().PauseCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(errors.New("capi cluster not found")),
+		tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.addonManager.EXPECT().ResumeGitOpsKustomization(tt.ctx, tt.managementCluster, tt.clusterSpec),
+	)
+
+	tt.Expect(tt.workflow.Pause(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(
+		MatchError("pausing CAPI cluster reconciliation: capi cluster not found"),
+	)
+}
+
+func TestPauseClusterPauseRollsBackOnlyChangedControllers(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(true, false, false)
+	gomock.InOrder(
+		tt.clusterManager.EXPECT().PauseEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.clusterManager.EXPECT().PauseCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(errors.New("capi cluster not found")),
+		tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+	)
+
+	tt.Expect(tt.workflow.Pause(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(HaveOccurred())
+}
+
+func TestPauseClusterPauseStateError(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.addonManager.EXPECT().GitOpsKustomizationPaused(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(false, nil)
+	tt.addonManager.EXPECT().PauseGitOpsKustomization(tt.ctx, tt.managementCluster, tt.clusterSpec)
+	tt.clusterManager.EXPECT().EKSAControllerReconcilePaused(tt.ctx, tt.managementCluster, tt.clusterSpec).Return(false, errors.New("connection refused"))
+	tt.addonManager.EXPECT().ResumeGitOpsKustomization(tt.ctx, tt.managementCluster, tt.clusterSpec)
+
+	tt.Expect(tt.workflow.Pause(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(
+		MatchError("checking EKS-A cluster controller reconciliation: connection refused"),
+	)
+}
+
+func TestPauseClusterResumeSuccess(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(true, true, true)
+	gomock.InOrder(
+		tt.clusterManager.EXPECT().ResumeCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec),
+		tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.clusterManager.EXPECT().ReconcilePausedClusters(tt.ctx, tt.managementCluster).Return([]string{"w01"}, nil),
+		tt.addonManager.EXPECT().ResumeGitOpsKustomization(tt.ctx, tt.managementCluster, tt.clusterSpec),
+	)
+
+	tt.Expect(tt.workflow.Resume(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPauseClusterResumeKeepsFluxPausedForOtherClusters(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(true, true, true)
+	gomock.InOrder(
+		tt.clusterManager.EXPECT().ResumeCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec),
+		tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider),
+		tt.clusterManager.EXPECT().ReconcilePausedClusters(tt.ctx, tt.managementCluster).Return([]string{"w02"}, nil),
+	)
+	tt.addonManager.EXPECT().ResumeGitOpsKustomization(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	tt.Expect(tt.workflow.Resume(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPauseClusterResumeSkipsControllersNotPaused(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(false, false, false)
+
+	tt.Expect(tt.workflow.Resume(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(Succeed())
+}
+
+func TestPauseClusterResumeRollsBackOnError(t *testing.T) {
+	tt := newPauseClusterTest(t)
+	tt.expectPaused(true, true, true)
+	gomock.InOrder(
+		tt.clusterManager.EXPECT().ResumeCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec),
+		tt.clusterManager.EXPECT().ResumeEKSAControllerReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec, tt.provider).Return(errors.New("cluster not found")),
+		tt.clusterManager.EXPECT().PauseCAPIClusterReconcile(tt.ctx, tt.managementCluster, tt.clusterSpec),
+	)
+
+	tt.Expect(tt.workflow.Resume(tt.ctx, tt.managementCluster, tt.clusterSpec)).To(
+		MatchError("resuming EKS-A cluster controller reconciliation: cluster not found"),
+	)
+}
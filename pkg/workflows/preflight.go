@@ -0,0 +1,56 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// providerSetupCheck wraps a provider SetupAndValidate method, which can create the resources the cluster
+// needs in the infrastructure, as a single check.
+func providerSetupCheck(provider providers.Provider, setup func(ctx context.Context) error) []providers.PreflightCheck {
+	return []providers.PreflightCheck{{
+		Name: fmt.Sprintf("%s Provider setup is valid", provider.Name()),
+		Run:  setup,
+	}}
+}
+
+// registerProviderChecks registers the provider checks in the runner with their dependencies and
+// returns their names.
+func registerProviderChecks(ctx context.Context, runner *validations.Runner, checks []providers.PreflightCheck) []string {
+	names := make([]string, 0, len(checks))
+	for _, c := range checks {
+		c := c
+		runner.RegisterWithDependencies(c.Name, func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name: c.Name,
+				Err:  c.Run(ctx),
+			}
+		}, c.DependsOn...)
+		names = append(names, c.Name)
+	}
+
+	return names
+}
+
+// registerValidations registers the validations in the runner, each one identified by kind and
+// position, so they all run concurrently once the validations in dependsOn have passed.
+func registerValidations(runner *validations.Runner, kind string, vs []validations.Validation, dependsOn ...string) {
+	for i, v := range vs {
+		runner.RegisterWithDependencies(fmt.Sprintf("%s %d", kind, i+1), v, dependsOn...)
+	}
+}
+
+// runProviderChecks runs the provider checks one after another and stops at the first one that fails.
+// Checks only depend on checks returned before them, so running them in order respects their dependencies.
+func runProviderChecks(ctx context.Context, checks []providers.PreflightCheck) error {
+	for _, c := range checks {
+		if err := c.Run(ctx); err != nil {
+			return fmt.Errorf("%s: %v", c.Name, err)
+		}
+	}
+
+	return nil
+}
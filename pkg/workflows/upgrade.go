@@ -25,6 +25,7 @@ type Upgrade struct {
 	eksdInstaller     interfaces.EksdInstaller
 	eksdUpgrader      interfaces.EksdUpgrader
 	upgradeChangeDiff *types.ChangeDiff
+	scope             task.UpgradeScope
 }
 
 func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provider,
@@ -45,6 +46,13 @@ func NewUpgrade(bootstrapper interfaces.Bootstrapper, provider providers.Provide
 	}
 }
 
+// WithScope limits the components Run upgrades. By default, it upgrades the management components
+// and the cluster.
+func (c *Upgrade) WithScope(scope task.UpgradeScope) *Upgrade {
+	c.scope = scope
+	return c
+}
+
 func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, workloadCluster *types.Cluster, validator interfaces.Validator, forceCleanup bool) error {
 	if forceCleanup {
 		if err := c.bootstrapper.DeleteBootstrapCluster(ctx, &types.Cluster{
@@ -68,11 +76,66 @@ func (c *Upgrade) Run(ctx context.Context, clusterSpec *cluster.Spec, management
 		EksdInstaller:     c.eksdInstaller,
 		EksdUpgrader:      c.eksdUpgrader,
 		UpgradeChangeDiff: c.upgradeChangeDiff,
+		UpgradeScope:      c.scope,
 	}
 
 	return task.NewTaskRunner(&setupAndValidateTasks{}, c.writer).RunTask(ctx, commandContext)
 }
 
+// RunPreflights only runs the setup and validations of the upgrade workflow, without upgrading
+// anything, and returns the report of all the validations.
+func (c *Upgrade) RunPreflights(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, workloadCluster *types.Cluster, validator interfaces.Validator) (*validations.Report, error) {
+	commandContext := &task.CommandContext{
+		Provider:          c.provider,
+		ManagementCluster: managementCluster,
+		WorkloadCluster:   workloadCluster,
+		ClusterSpec:       clusterSpec,
+		Validations:       validator,
+	}
+
+	var providerChecks []providers.PreflightCheck
+	if checker, ok := c.provider.(providers.PreflightChecker); ok {
+		providerChecks = checker.UpgradePreflightChecks(managementCluster, clusterSpec)
+	} else {
+		// Providers that can't validate the spec without changing the infrastructure run their full setup
+		providerChecks = providerSetupCheck(c.provider, func(ctx context.Context) error {
+			return c.provider.SetupAndValidateUpgradeCluster(ctx, managementCluster, clusterSpec)
+		})
+	}
+
+	logger.Info("Performing setup and validations")
+	runner := (&setupAndValidateTasks{}).runner(ctx, commandContext, providerChecks)
+	err := runner.Run()
+
+	return runner.Report(), err
+}
+
+// DryRun computes the changes the upgrade would apply to the CAPI and EKS-A objects and
+// to the GitOps repository, without changing anything.
+func (c *Upgrade) DryRun(ctx context.Context, clusterSpec *cluster.Spec, managementCluster *types.Cluster, workloadCluster *types.Cluster) (*types.UpgradeDiff, error) {
+	logger.Info("Performing provider setup and validations")
+	if checker, ok := c.provider.(providers.PreflightChecker); ok {
+		if err := runProviderChecks(ctx, checker.UpgradePreflightChecks(managementCluster, clusterSpec)); err != nil {
+			return nil, err
+		}
+	} else if err := c.provider.SetupAndValidateUpgradeCluster(ctx, managementCluster, clusterSpec); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Computing upgrade changes")
+	diff, err := c.clusterManager.DiffClusterUpgrade(ctx, managementCluster, workloadCluster, clusterSpec, c.provider)
+	if err != nil {
+		return nil, fmt.Errorf("computing upgrade changes: %v", err)
+	}
+
+	diff.GitOpsFiles, err = c.addonManager.DiffGitEksaSpec(ctx, clusterSpec, c.provider.DatacenterConfig(clusterSpec), c.provider.MachineConfigs(clusterSpec))
+	if err != nil {
+		return nil, fmt.Errorf("computing gitops repository changes: %v", err)
+	}
+
+	return diff, nil
+}
+
 type setupAndValidateTasks struct{}
 
 type updateSecrets struct{}
@@ -83,7 +146,13 @@ type upgradeCoreComponents struct{}
 
 type upgradeNeeded struct{}
 
-type pauseEksaAndFluxReconcile struct{}
+type applyBundlesTask struct{}
+
+type pauseEksaAndFluxReconcile struct {
+	scaleOnly bool
+}
+
+type scaleWorkerNodeGroupsTask struct{}
 
 type createBootstrapClusterTask struct{}
 
@@ -111,10 +180,10 @@ type writeClusterConfigTask struct{}
 
 func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Performing setup and validations")
-	runner := validations.NewRunner()
-	runner.Register(s.validations(ctx, commandContext)...)
-
-	err := runner.Run()
+	providerSetup := providerSetupCheck(commandContext.Provider, func(ctx context.Context) error {
+		return commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec)
+	})
+	err := s.runner(ctx, commandContext, providerSetup).Run()
 	if err != nil {
 		commandContext.SetError(err)
 		return nil
@@ -123,21 +192,14 @@ func (s *setupAndValidateTasks) Run(ctx context.Context, commandContext *task.Co
 	return &updateSecrets{}
 }
 
-func (s *setupAndValidateTasks) validations(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
-	return []validations.Validation{
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: fmt.Sprintf("%s Provider setup is valid", commandContext.Provider.Name()),
-				Err:  commandContext.Provider.SetupAndValidateUpgradeCluster(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec),
-			}
-		},
-		func() *validations.ValidationResult {
-			return &validations.ValidationResult{
-				Name: "upgrade preflight validations pass",
-				Err:  commandContext.Validations.PreflightValidations(ctx),
-			}
-		},
-	}
+// runner builds a validations runner with the provider checks and the preflight validations. The
+// preflight validations compare the new spec with the existing cluster, so they need the provider
+// defaults set by the provider checks first. They run concurrently with each other.
+func (s *setupAndValidateTasks) runner(ctx context.Context, commandContext *task.CommandContext, providerChecks []providers.PreflightCheck) *validations.Runner {
+	runner := validations.NewRunner()
+	providerCheckNames := registerProviderChecks(ctx, runner, providerChecks)
+	registerValidations(runner, "upgrade preflight validation", commandContext.Validations.PreflightValidations(ctx), providerCheckNames...)
+	return runner
 }
 
 func (s *setupAndValidateTasks) Name() string {
@@ -181,6 +243,9 @@ func (s *ensureEtcdCAPIComponentsExistTask) Run(ctx context.Context, commandCont
 		return &CollectDiagnosticsTask{}
 	}
 	commandContext.CurrentClusterSpec = currentSpec
+	if commandContext.UpgradeScope == task.UpgradeClusterOnly {
+		return &upgradeCoreComponents{}
+	}
 	if err := commandContext.CAPIManager.EnsureEtcdProvidersInstallation(ctx, commandContext.ManagementCluster, commandContext.Provider, currentSpec); err != nil {
 		commandContext.SetError(err)
 		return nil
@@ -203,27 +268,42 @@ func (s *ensureEtcdCAPIComponentsExistTask) Restore(ctx context.Context, command
 func (s *upgradeCoreComponents) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	logger.Info("Upgrading core components")
 
-	changeDiff, err := commandContext.ClusterManager.UpgradeNetworking(ctx, commandContext.WorkloadCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec, commandContext.Provider)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
+	// UpgradeClusterOnly skips the components shared by all the clusters of the management cluster,
+	// UpgradeManagementComponents skips the ones that belong to the cluster being upgraded.
+	upgradeCluster := commandContext.UpgradeScope != task.UpgradeManagementComponents
+	upgradeManagement := commandContext.UpgradeScope != task.UpgradeClusterOnly
+
+	if upgradeCluster {
+		changeDiff, err := commandContext.ClusterManager.UpgradeNetworking(ctx, commandContext.WorkloadCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec, commandContext.Provider)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+		commandContext.UpgradeChangeDiff.Append(changeDiff)
 	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
 
-	changeDiff, err = commandContext.CAPIManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.Provider, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
+	if upgradeManagement {
+		changeDiff, err := commandContext.CAPIManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.Provider, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+		commandContext.UpgradeChangeDiff.Append(changeDiff)
 	}
-	commandContext.UpgradeChangeDiff.Append(changeDiff)
 
-	err = commandContext.AddonManager.UpdateLegacyFileStructure(ctx, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
-	if err != nil {
-		commandContext.SetError(err)
-		return &CollectDiagnosticsTask{}
+	if upgradeCluster {
+		err := commandContext.AddonManager.UpdateLegacyFileStructure(ctx, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+	}
+
+	if !upgradeManagement {
+		return &upgradeNeeded{}
 	}
 
-	changeDiff, err = commandContext.AddonManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
+	changeDiff, err := commandContext.AddonManager.Upgrade(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
@@ -244,6 +324,10 @@ func (s *upgradeCoreComponents) Run(ctx context.Context, commandContext *task.Co
 	}
 	commandContext.UpgradeChangeDiff.Append(changeDiff)
 
+	if !upgradeCluster {
+		return &applyBundlesTask{}
+	}
+
 	return &upgradeNeeded{}
 }
 
@@ -259,6 +343,27 @@ func (s *upgradeCoreComponents) Restore(ctx context.Context, commandContext *tas
 	return &upgradeNeeded{}, nil
 }
 
+func (s *applyBundlesTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Applying Bundles to management cluster")
+	if err := commandContext.ClusterManager.ApplyBundles(ctx, commandContext.ClusterSpec, commandContext.ManagementCluster); err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	return nil
+}
+
+func (s *applyBundlesTask) Name() string {
+	return "apply-bundles"
+}
+
+func (s *applyBundlesTask) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+func (s *applyBundlesTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return nil, nil
+}
+
 func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	newSpec := commandContext.ClusterSpec
 
@@ -280,6 +385,18 @@ func (s *upgradeNeeded) Run(ctx context.Context, commandContext *task.CommandCon
 		return nil
 	}
 
+	if !commandContext.UpgradeChangeDiff.Changed() {
+		scaleOnly, err := commandContext.ClusterManager.OnlyWorkerNodeGroupCountsChanged(ctx, commandContext.ManagementCluster, commandContext.CurrentClusterSpec, newSpec, commandContext.Provider)
+		if err != nil {
+			commandContext.SetError(err)
+			return &CollectDiagnosticsTask{}
+		}
+		if scaleOnly {
+			logger.V(3).Info("Only worker node group counts changed, scaling in place")
+			return &pauseEksaAndFluxReconcile{scaleOnly: true}
+		}
+	}
+
 	return &pauseEksaAndFluxReconcile{}
 }
 
@@ -309,6 +426,10 @@ func (s *pauseEksaAndFluxReconcile) Run(ctx context.Context, commandContext *tas
 		commandContext.SetError(err)
 		return &CollectDiagnosticsTask{}
 	}
+
+	if s.scaleOnly {
+		return &scaleWorkerNodeGroupsTask{}
+	}
 	return &createBootstrapClusterTask{}
 }
 
@@ -324,6 +445,28 @@ func (s *pauseEksaAndFluxReconcile) Restore(ctx context.Context, commandContext
 	return &createBootstrapClusterTask{}, nil
 }
 
+func (s *scaleWorkerNodeGroupsTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	logger.Info("Scaling worker node groups")
+	err := commandContext.ClusterManager.ScaleWorkerNodeGroups(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec)
+	if err != nil {
+		commandContext.SetError(err)
+		return &CollectDiagnosticsTask{}
+	}
+	return &updateClusterAndGitResources{}
+}
+
+func (s *scaleWorkerNodeGroupsTask) Name() string {
+	return "scale-worker-node-groups"
+}
+
+func (s *scaleWorkerNodeGroupsTask) Checkpoint() *task.CompletedTask {
+	return nil
+}
+
+func (s *scaleWorkerNodeGroupsTask) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &updateClusterAndGitResources{}, nil
+}
+
 func (s *createBootstrapClusterTask) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
 	if commandContext.ManagementCluster != nil && commandContext.ManagementCluster.ExistingManagement {
 		return &upgradeWorkloadClusterTask{}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -15,7 +16,9 @@ import (
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
 )
@@ -170,6 +173,55 @@ func (c *upgradeTestSetup) expectUpgradeCoreComponents(managementCluster *types.
 	)
 }
 
+func (c *upgradeTestSetup) expectUpgradeCoreComponentsNoChanges(managementCluster *types.Cluster, workloadCluster *types.Cluster) {
+	currentSpec := c.currentClusterSpec
+	gomock.InOrder(
+		c.clusterManager.EXPECT().UpgradeNetworking(c.ctx, workloadCluster, currentSpec, c.newClusterSpec, c.provider).Return(nil, nil),
+		c.capiManager.EXPECT().Upgrade(c.ctx, managementCluster, c.provider, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.addonManager.EXPECT().UpdateLegacyFileStructure(c.ctx, currentSpec, c.newClusterSpec),
+		c.addonManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.clusterManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.eksdUpgrader.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil, nil),
+	)
+}
+
+func (c *upgradeTestSetup) expectUpgradeManagementComponents(managementCluster *types.Cluster) {
+	currentSpec := c.currentClusterSpec
+	gomock.InOrder(
+		c.clusterManager.EXPECT().GetCurrentClusterSpec(c.ctx, managementCluster, c.newClusterSpec.Cluster.Name).Return(currentSpec, nil),
+		c.capiManager.EXPECT().EnsureEtcdProvidersInstallation(c.ctx, managementCluster, c.provider, currentSpec),
+		c.capiManager.EXPECT().Upgrade(c.ctx, managementCluster, c.provider, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.addonManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.clusterManager.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.eksdUpgrader.EXPECT().Upgrade(c.ctx, managementCluster, currentSpec, c.newClusterSpec).Return(nil, nil),
+		c.clusterManager.EXPECT().ApplyBundles(c.ctx, c.newClusterSpec, managementCluster),
+	)
+}
+
+func (c *upgradeTestSetup) expectUpgradeClusterComponentsOnly(managementCluster *types.Cluster, workloadCluster *types.Cluster) {
+	currentSpec := c.currentClusterSpec
+	gomock.InOrder(
+		c.clusterManager.EXPECT().GetCurrentClusterSpec(c.ctx, managementCluster, c.newClusterSpec.Cluster.Name).Return(currentSpec, nil),
+		c.clusterManager.EXPECT().UpgradeNetworking(c.ctx, workloadCluster, currentSpec, c.newClusterSpec, c.provider).Return(types.NewChangeDiff(&types.ComponentChangeDiff{
+			ComponentName: "cilium",
+			OldVersion:    "v0.0.1",
+			NewVersion:    "v0.0.2",
+		}), nil),
+		c.addonManager.EXPECT().UpdateLegacyFileStructure(c.ctx, currentSpec, c.newClusterSpec),
+	)
+	c.capiManager.EXPECT().EnsureEtcdProvidersInstallation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	c.capiManager.EXPECT().Upgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	c.addonManager.EXPECT().Upgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	c.clusterManager.EXPECT().Upgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	c.eksdUpgrader.EXPECT().Upgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+}
+
+func (c *upgradeTestSetup) expectScaleWorkerNodeGroups(expectedCluster *types.Cluster) {
+	gomock.InOrder(
+		c.clusterManager.EXPECT().ScaleWorkerNodeGroups(c.ctx, expectedCluster, c.newClusterSpec),
+	)
+}
+
 func (c *upgradeTestSetup) expectCreateBootstrap() {
 	opts := []bootstrapper.BootstrapClusterOption{
 		bootstrapper.WithDefaultCNIDisabled(), bootstrapper.WithExtraDockerMounts(),
@@ -339,6 +391,10 @@ func (c *upgradeTestSetup) expectVerifyClusterSpecChanged(expectedCluster *types
 	)
 }
 
+func (c *upgradeTestSetup) expectOnlyWorkerNodeGroupCountsChanged(expectedCluster *types.Cluster) {
+	c.clusterManager.EXPECT().OnlyWorkerNodeGroupCountsChanged(c.ctx, expectedCluster, c.currentClusterSpec, c.newClusterSpec, c.provider).Return(true, nil)
+}
+
 func (c *upgradeTestSetup) expectSaveLogs(expectedWorkloadCluster *types.Cluster) {
 	gomock.InOrder(
 		c.clusterManager.EXPECT().SaveLogsManagementCluster(c.ctx, c.newClusterSpec, c.bootstrapCluster).Return(nil),
@@ -464,6 +520,40 @@ func TestUpgradeRunProviderNeedsUpgradeSuccess(t *testing.T) {
 	}
 }
 
+func TestUpgradeRunOnlyWorkerNodeGroupCountsChangedSuccess(t *testing.T) {
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.currentClusterSpec = test.newClusterSpec.DeepCopy()
+	test.newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count++
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.workloadCluster)
+	test.expectEnsureEtcdCAPIComponentsExistTask(test.workloadCluster)
+	test.expectUpgradeCoreComponentsNoChanges(test.workloadCluster, test.workloadCluster)
+	test.expectProviderNoUpgradeNeeded(test.workloadCluster)
+	test.expectVerifyClusterSpecChanged(test.workloadCluster)
+	test.expectOnlyWorkerNodeGroupCountsChanged(test.workloadCluster)
+	test.expectPauseEKSAControllerReconcile(test.workloadCluster)
+	test.expectPauseGitOpsKustomization(test.workloadCluster)
+	test.expectCreateBootstrapNotToBeCalled()
+	test.expectNotToMoveManagementToBootstrap()
+	test.expectScaleWorkerNodeGroups(test.workloadCluster)
+	test.expectNotToMoveManagementToWorkload()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.workloadCluster)
+	test.expectInstallEksdManifest(test.workloadCluster)
+	test.expectResumeEKSAControllerReconcile(test.workloadCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.workloadCluster)
+	test.expectResumeGitOpsKustomization(test.workloadCluster)
+	test.expectWriteClusterConfig()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestUpgradeRunFailedUpgrade(t *testing.T) {
 	test := newUpgradeSelfManagedClusterTest(t)
 	test.expectSetup()
@@ -518,3 +608,184 @@ func TestUpgradeWorkloadRunSuccess(t *testing.T) {
 		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
 	}
 }
+
+func TestUpgradeManagementComponentsRunSuccess(t *testing.T) {
+	test := newUpgradeManagedClusterTest(t)
+	test.workflow.WithScope(task.UpgradeManagementComponents)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.managementCluster)
+	test.expectUpgradeManagementComponents(test.managementCluster)
+	test.clusterManager.EXPECT().UpgradeNetworking(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	test.expectPauseEKSAControllerReconcileNotToBeCalled()
+	test.expectPauseGitOpsKustomizationNotToBeCalled()
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeClusterOnlyRunSuccess(t *testing.T) {
+	test := newUpgradeManagedClusterTest(t)
+	test.workflow.WithScope(task.UpgradeClusterOnly)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(test.managementCluster)
+	test.expectUpgradeClusterComponentsOnly(test.managementCluster, test.workloadCluster)
+	test.expectProviderNoUpgradeNeeded(test.managementCluster)
+	test.expectVerifyClusterSpecChanged(test.managementCluster)
+	test.expectPauseEKSAControllerReconcile(test.managementCluster)
+	test.expectPauseGitOpsKustomization(test.managementCluster)
+	test.expectNotToCreateBootstrap()
+	test.expectNotToMoveManagementToBootstrap()
+	test.expectNotToMoveManagementToWorkload()
+	test.expectWriteClusterConfig()
+	test.expectNotToDeleteBootstrap()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectCreateEKSAResources(test.managementCluster)
+	test.expectInstallEksdManifest(test.managementCluster)
+	test.expectResumeEKSAControllerReconcile(test.managementCluster)
+	test.expectUpdateGitEksaSpec()
+	test.expectForceReconcileGitRepo(test.managementCluster)
+	test.expectResumeGitOpsKustomization(test.managementCluster)
+	test.expectUpgradeWorkload(test.managementCluster, test.workloadCluster)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("Upgrade.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeRunPreflightsSuccess(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.expectSetup()
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{
+		passingValidation("control plane ready"), passingValidation("nodes ready"),
+	})
+
+	report, err := test.workflow.RunPreflights(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster, test.validator)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(report.Passed).To(BeTrue())
+	g.Expect(report.Results).To(HaveLen(3))
+}
+
+func TestUpgradeRunPreflightsSkipsPreflightsWhenProviderSetupFails(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(test.ctx, gomock.Any(), test.newClusterSpec).Return(errors.New("invalid vCenter config"))
+	test.provider.EXPECT().Name().Return("vsphere")
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{passingValidation("control plane ready")})
+
+	report, err := test.workflow.RunPreflights(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster, test.validator)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(report.Passed).To(BeFalse())
+	g.Expect(report.Results).To(HaveLen(2))
+	g.Expect(report.Results[0].Error).To(Equal("invalid vCenter config"))
+	g.Expect(report.Results[1].Error).To(Equal("skipped because validation vsphere Provider setup is valid failed"))
+}
+
+func TestUpgradeRunPreflightsProviderChecks(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	var ran []string
+	provider := &preflightCheckerProvider{MockProvider: test.provider, checks: providerChecks(nil, &ran)}
+	workflow := workflows.NewUpgrade(test.bootstrapper, provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, test.eksdUpgrader, test.eksdInstaller)
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	test.validator.EXPECT().PreflightValidations(test.ctx).Return([]validations.Validation{passingValidation("control plane ready")})
+
+	report, err := workflow.RunPreflights(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster, test.validator)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ran).To(Equal([]string{"vcenter access", "datastores"}))
+	g.Expect(report.Results).To(HaveLen(3))
+}
+
+func TestUpgradeDryRunWithEKSAChanges(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	diff := &types.UpgradeDiff{
+		CAPIObjects: []types.ObjectDiff{{Kind: "KubeadmControlPlane", Name: "cluster-name", Change: types.ObjectUpdated, Rollout: true}},
+		EKSAObjects: []types.ObjectDiff{{Kind: "Cluster", Name: "cluster-name", Change: types.ObjectUpdated}},
+	}
+	gitOpsFiles := []string{"clusters/cluster-name/eksa-system/eksa-cluster.yaml"}
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(test.ctx, test.managementCluster, test.newClusterSpec)
+	test.clusterManager.EXPECT().DiffClusterUpgrade(test.ctx, test.managementCluster, test.workloadCluster, test.newClusterSpec, test.provider).Return(diff, nil)
+	test.provider.EXPECT().DatacenterConfig(test.newClusterSpec).Return(test.datacenterConfig)
+	test.provider.EXPECT().MachineConfigs(test.newClusterSpec).Return(test.machineConfigs)
+	test.addonManager.EXPECT().DiffGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).Return(gitOpsFiles, nil)
+
+	got, err := test.workflow.DryRun(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.GitOpsFiles).To(Equal(gitOpsFiles))
+	g.Expect(got.Rollout()).To(BeTrue())
+}
+
+func TestUpgradeDryRunWithoutGitOpsChanges(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	diff := &types.UpgradeDiff{
+		EKSAObjects: []types.ObjectDiff{{Kind: "Cluster", Name: "cluster-name", Change: types.ObjectUnchanged}},
+	}
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(test.ctx, test.managementCluster, test.newClusterSpec)
+	test.clusterManager.EXPECT().DiffClusterUpgrade(test.ctx, test.managementCluster, test.workloadCluster, test.newClusterSpec, test.provider).Return(diff, nil)
+	test.provider.EXPECT().DatacenterConfig(test.newClusterSpec).Return(test.datacenterConfig)
+	test.provider.EXPECT().MachineConfigs(test.newClusterSpec).Return(test.machineConfigs)
+	test.addonManager.EXPECT().DiffGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).Return(nil, nil)
+
+	got, err := test.workflow.DryRun(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.GitOpsFiles).To(BeEmpty())
+}
+
+func TestUpgradeDryRunGitOpsDiffError(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(test.ctx, test.managementCluster, test.newClusterSpec)
+	test.clusterManager.EXPECT().DiffClusterUpgrade(test.ctx, test.managementCluster, test.workloadCluster, test.newClusterSpec, test.provider).Return(&types.UpgradeDiff{}, nil)
+	test.provider.EXPECT().DatacenterConfig(test.newClusterSpec).Return(test.datacenterConfig)
+	test.provider.EXPECT().MachineConfigs(test.newClusterSpec).Return(test.machineConfigs)
+	test.addonManager.EXPECT().DiffGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).Return(nil, errors.New("failed to clone"))
+
+	_, err := test.workflow.DryRun(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster)
+	g.Expect(err).To(MatchError(ContainSubstring("failed to clone")))
+}
+
+func TestUpgradeDryRunProviderSetupError(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(test.ctx, test.managementCluster, test.newClusterSpec).Return(errors.New("invalid vCenter config"))
+
+	_, err := test.workflow.DryRun(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid vCenter config")))
+}
+
+func TestUpgradeDryRunProviderChecks(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	var ran []string
+	provider := &preflightCheckerProvider{MockProvider: test.provider, checks: providerChecks(nil, &ran)}
+	workflow := workflows.NewUpgrade(test.bootstrapper, provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, test.eksdUpgrader, test.eksdInstaller)
+	test.provider.EXPECT().SetupAndValidateUpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	test.clusterManager.EXPECT().DiffClusterUpgrade(test.ctx, test.managementCluster, test.workloadCluster, test.newClusterSpec, provider).Return(&types.UpgradeDiff{}, nil)
+	test.provider.EXPECT().DatacenterConfig(test.newClusterSpec).Return(test.datacenterConfig)
+	test.provider.EXPECT().MachineConfigs(test.newClusterSpec).Return(test.machineConfigs)
+	test.addonManager.EXPECT().DiffGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).Return(nil, nil)
+
+	_, err := workflow.DryRun(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ran).To(Equal([]string{"vcenter access", "datastores"}))
+}
+
+func TestUpgradeDryRunProviderCheckError(t *testing.T) {
+	g := NewWithT(t)
+	test := newUpgradeSelfManagedClusterTest(t)
+	var ran []string
+	provider := &preflightCheckerProvider{MockProvider: test.provider, checks: providerChecks(errors.New("invalid credentials"), &ran)}
+	workflow := workflows.NewUpgrade(test.bootstrapper, provider, test.capiManager, test.clusterManager, test.addonManager, test.writer, test.eksdUpgrader, test.eksdInstaller)
+
+	_, err := workflow.DryRun(test.ctx, test.newClusterSpec, test.managementCluster, test.workloadCluster)
+	g.Expect(err).To(MatchError("vcenter access: invalid credentials"))
+	g.Expect(ran).To(Equal([]string{"vcenter access"}))
+}
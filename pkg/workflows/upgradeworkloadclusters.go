@@ -0,0 +1,192 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
+)
+
+// UpgradeWorkloadClusters upgrades all the workload clusters of a management cluster in waves.
+type UpgradeWorkloadClusters struct {
+	upgrader      interfaces.WorkloadClusterUpgrader
+	healthChecker interfaces.ClusterHealthChecker
+	concurrency   int
+	maxFailures   int
+}
+
+func NewUpgradeWorkloadClusters(upgrader interfaces.WorkloadClusterUpgrader, healthChecker interfaces.ClusterHealthChecker, concurrency, maxFailures int) *UpgradeWorkloadClusters {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &UpgradeWorkloadClusters{
+		upgrader:      upgrader,
+		healthChecker: healthChecker,
+		concurrency:   concurrency,
+		maxFailures:   maxFailures,
+	}
+}
+
+// Run upgrades the components the clusters share in the management cluster once per infrastructure provider,
+// with the config of the first cluster of each provider, and then upgrades the clusters in waves of at most
+// concurrency clusters, in the given order. If the management components fail to upgrade, all the clusters are
+// reported as skipped. After each wave it checks the health of the management cluster and of the clusters upgraded in the wave.
+// Once more than maxFailures clusters have failed, or if the management cluster is not healthy, it stops
+// and the clusters left are reported as skipped. It returns an error if any cluster was not upgraded.
+// Clusters managed with GitOps share the Flux kustomization and the git repository, so they can only be upgraded one at a time.
+func (u *UpgradeWorkloadClusters) Run(ctx context.Context, managementCluster *types.Cluster, clusters []v1alpha1.Cluster) (*types.WorkloadClustersUpgradeReport, error) {
+	if u.concurrency > 1 {
+		for _, c := range clusters {
+			if c.Spec.GitOpsRef != nil {
+				return nil, fmt.Errorf("cluster %s is managed with GitOps, workload clusters with GitOps can only be upgraded with a concurrency of 1", c.Name)
+			}
+		}
+	}
+
+	report := &types.WorkloadClustersUpgradeReport{}
+	failures := 0
+	var stopReason string
+
+	for _, c := range firstClusterPerProvider(clusters) {
+		logger.Info("Upgrading management components", "cluster", managementCluster.Name, "provider", c.Spec.DatacenterRef.Kind)
+		if err := u.upgrader.UpgradeManagementComponents(ctx, managementCluster, c); err != nil {
+			logger.MarkFail("Management components upgrade failed", "provider", c.Spec.DatacenterRef.Kind, "error", err)
+			stopReason = fmt.Sprintf("the management components failed to upgrade: %v", err)
+			break
+		}
+	}
+
+	for i, wave := range splitInWaves(clusters, u.concurrency) {
+		waveNumber := i + 1
+		if stopReason != "" {
+			report.Results = append(report.Results, skippedResults(wave, waveNumber, stopReason)...)
+			continue
+		}
+
+		logger.Info("Upgrading workload clusters", "wave", waveNumber, "clusters", clusterNames(wave))
+		results := u.upgradeWave(ctx, managementCluster, wave, waveNumber)
+
+		logger.Info("Checking clusters health", "wave", waveNumber)
+		u.checkWaveHealth(ctx, managementCluster, results)
+
+		for _, r := range results {
+			if r.Status == types.WorkloadClusterFailed {
+				failures++
+			}
+		}
+		report.Results = append(report.Results, results...)
+
+		if err := u.healthChecker.CheckClusterHealth(ctx, managementCluster, managementCluster.Name); err != nil {
+			stopReason = fmt.Sprintf("management cluster %s is not healthy: %v", managementCluster.Name, err)
+		} else if failures > u.maxFailures {
+			stopReason = fmt.Sprintf("the maximum number of failures (%d) was exceeded", u.maxFailures)
+		}
+		if stopReason != "" {
+			logger.MarkFail("Stopping the upgrade of workload clusters", "reason", stopReason)
+		}
+	}
+
+	failed := report.Count(types.WorkloadClusterFailed)
+	skipped := report.Count(types.WorkloadClusterSkipped)
+	if failed > 0 || skipped > 0 {
+		return report, fmt.Errorf("%d workload clusters failed to upgrade and %d were skipped", failed, skipped)
+	}
+
+	return report, nil
+}
+
+func (u *UpgradeWorkloadClusters) upgradeWave(ctx context.Context, managementCluster *types.Cluster, wave []v1alpha1.Cluster, waveNumber int) []types.WorkloadClusterUpgradeResult {
+	results := make([]types.WorkloadClusterUpgradeResult, len(wave))
+	var wg sync.WaitGroup
+	for i := range wave {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := &wave[i]
+			results[i] = types.WorkloadClusterUpgradeResult{
+				Name:      c.Name,
+				Namespace: c.Namespace,
+				Wave:      waveNumber,
+				Status:    types.WorkloadClusterUpgraded,
+			}
+			if err := u.upgrader.UpgradeWorkloadCluster(ctx, managementCluster, c); err != nil {
+				logger.MarkFail("Workload cluster upgrade failed", "cluster", c.Name, "error", err)
+				results[i].Status = types.WorkloadClusterFailed
+				results[i].Error = err.Error()
+				return
+			}
+			logger.MarkSuccess("Workload cluster upgraded", "cluster", c.Name)
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// checkWaveHealth marks as failed the clusters upgraded in the wave that are not healthy.
+func (u *UpgradeWorkloadClusters) checkWaveHealth(ctx context.Context, managementCluster *types.Cluster, results []types.WorkloadClusterUpgradeResult) {
+	for i := range results {
+		if results[i].Status != types.WorkloadClusterUpgraded {
+			continue
+		}
+		if err := u.healthChecker.CheckClusterHealth(ctx, managementCluster, results[i].Name); err != nil {
+			logger.MarkFail("Workload cluster is not healthy after upgrade", "cluster", results[i].Name, "error", err)
+			results[i].Status = types.WorkloadClusterFailed
+			results[i].Error = fmt.Sprintf("health check after upgrade: %v", err)
+		}
+	}
+}
+
+// firstClusterPerProvider returns the first cluster of each infrastructure provider, so the CAPI provider
+// of every provider in use is upgraded.
+func firstClusterPerProvider(clusters []v1alpha1.Cluster) []*v1alpha1.Cluster {
+	var firsts []*v1alpha1.Cluster
+	seen := map[string]bool{}
+	for i := range clusters {
+		kind := clusters[i].Spec.DatacenterRef.Kind
+		if seen[kind] {
+			continue
+		}
+		seen[kind] = true
+		firsts = append(firsts, &clusters[i])
+	}
+	return firsts
+}
+
+func splitInWaves(clusters []v1alpha1.Cluster, size int) [][]v1alpha1.Cluster {
+	var waves [][]v1alpha1.Cluster
+	for start := 0; start < len(clusters); start += size {
+		end := start + size
+		if end > len(clusters) {
+			end = len(clusters)
+		}
+		waves = append(waves, clusters[start:end])
+	}
+	return waves
+}
+
+func skippedResults(wave []v1alpha1.Cluster, waveNumber int, reason string) []types.WorkloadClusterUpgradeResult {
+	results := make([]types.WorkloadClusterUpgradeResult, 0, len(wave))
+	for _, c := range wave {
+		results = append(results, types.WorkloadClusterUpgradeResult{
+			Name:      c.Name,
+			Namespace: c.Namespace,
+			Wave:      waveNumber,
+			Status:    types.WorkloadClusterSkipped,
+			Error:     "skipped because " + reason,
+		})
+	}
+	return results
+}
+
+func clusterNames(clusters []v1alpha1.Cluster) []string {
+	names := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,212 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/workflows"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces/mocks"
+)
+
+type upgradeWorkloadClustersTest struct {
+	*WithT
+	ctx               context.Context
+	upgrader          *mocks.MockWorkloadClusterUpgrader
+	healthChecker     *mocks.MockClusterHealthChecker
+	managementCluster *types.Cluster
+	clusters          []v1alpha1.Cluster
+}
+
+func newUpgradeWorkloadClustersTest(t *testing.T, clusterNames ...string) *upgradeWorkloadClustersTest {
+	ctrl := gomock.NewController(t)
+	tt := &upgradeWorkloadClustersTest{
+		WithT:         NewWithT(t),
+		ctx:           context.Background(),
+		upgrader:      mocks.NewMockWorkloadClusterUpgrader(ctrl),
+		healthChecker: mocks.NewMockClusterHealthChecker(ctrl),
+		managementCluster: &types.Cluster{
+			Name:           "mgmt",
+			KubeconfigFile: "mgmt/mgmt-eks-a-cluster.kubeconfig",
+		},
+	}
+	for _, name := range clusterNames {
+		tt.clusters = append(tt.clusters, v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.ClusterSpec{
+				DatacenterRef: v1alpha1.Ref{Kind: v1alpha1.VSphereDatacenterKind, Name: name},
+			},
+		})
+	}
+
+	return tt
+}
+
+// clusterNamed matches an EKS-A Cluster by name, since the clusters in a wave are upgraded concurrently.
+type clusterNamed string
+
+func (m clusterNamed) Matches(x interface{}) bool {
+	c, ok := x.(*v1alpha1.Cluster)
+	return ok && c.Name == string(m)
+}
+
+func (m clusterNamed) String() string {
+	return "is cluster " + string(m)
+}
+
+func (tt *upgradeWorkloadClustersTest) expectUpgradeManagementComponents(err error) {
+	tt.upgrader.EXPECT().UpgradeManagementComponents(tt.ctx, tt.managementCluster, clusterNamed(tt.clusters[0].Name)).Return(err)
+}
+
+func (tt *upgradeWorkloadClustersTest) expectUpgrade(name string, err error) {
+	tt.upgrader.EXPECT().UpgradeWorkloadCluster(tt.ctx, tt.managementCluster, clusterNamed(name)).Return(err)
+}
+
+func (tt *upgradeWorkloadClustersTest) expectHealthCheck(name string, err error) {
+	tt.healthChecker.EXPECT().CheckClusterHealth(tt.ctx, tt.managementCluster, name).Return(err)
+}
+
+func (tt *upgradeWorkloadClustersTest) statuses(report *types.WorkloadClustersUpgradeReport) map[string]types.WorkloadClusterUpgradeStatus {
+	statuses := map[string]types.WorkloadClusterUpgradeStatus{}
+	for _, r := range report.Results {
+		statuses[r.Name] = r.Status
+	}
+	return statuses
+}
+
+func TestUpgradeWorkloadClustersRunSuccess(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2", "w3")
+	tt.expectUpgradeManagementComponents(nil)
+	for _, c := range tt.clusters {
+		tt.expectUpgrade(c.Name, nil)
+		tt.expectHealthCheck(c.Name, nil)
+	}
+	tt.expectHealthCheck("mgmt", nil)
+	tt.expectHealthCheck("mgmt", nil)
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 2, 0).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Results).To(Equal([]types.WorkloadClusterUpgradeResult{
+		{Name: "w1", Namespace: "default", Wave: 1, Status: types.WorkloadClusterUpgraded},
+		{Name: "w2", Namespace: "default", Wave: 1, Status: types.WorkloadClusterUpgraded},
+		{Name: "w3", Namespace: "default", Wave: 2, Status: types.WorkloadClusterUpgraded},
+	}))
+}
+
+func TestUpgradeWorkloadClustersRunStopsAfterMaxFailures(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2", "w3")
+	tt.expectUpgradeManagementComponents(nil)
+	tt.expectUpgrade("w1", errors.New("timed out waiting for control plane"))
+	tt.expectHealthCheck("mgmt", nil)
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 1, 0).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).To(MatchError("1 workload clusters failed to upgrade and 2 were skipped"))
+	tt.Expect(tt.statuses(report)).To(Equal(map[string]types.WorkloadClusterUpgradeStatus{
+		"w1": types.WorkloadClusterFailed,
+		"w2": types.WorkloadClusterSkipped,
+		"w3": types.WorkloadClusterSkipped,
+	}))
+	tt.Expect(report.Results[0].Error).To(Equal("timed out waiting for control plane"))
+	tt.Expect(report.Results[1].Error).To(Equal("skipped because the maximum number of failures (0) was exceeded"))
+}
+
+func TestUpgradeWorkloadClustersRunToleratesFailures(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2")
+	tt.expectUpgradeManagementComponents(nil)
+	tt.expectUpgrade("w1", nil)
+	tt.expectHealthCheck("w1", errors.New("control plane is not ready"))
+	tt.expectHealthCheck("mgmt", nil)
+	tt.expectUpgrade("w2", nil)
+	tt.expectHealthCheck("w2", nil)
+	tt.expectHealthCheck("mgmt", nil)
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 1, 1).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).To(HaveOccurred())
+	tt.Expect(tt.statuses(report)).To(Equal(map[string]types.WorkloadClusterUpgradeStatus{
+		"w1": types.WorkloadClusterFailed,
+		"w2": types.WorkloadClusterUpgraded,
+	}))
+	tt.Expect(report.Results[0].Error).To(Equal("health check after upgrade: control plane is not ready"))
+}
+
+func TestUpgradeWorkloadClustersRunStopsWhenManagementClusterUnhealthy(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2")
+	tt.expectUpgradeManagementComponents(nil)
+	tt.expectUpgrade("w1", nil)
+	tt.expectHealthCheck("w1", nil)
+	tt.expectHealthCheck("mgmt", errors.New("control plane is not ready"))
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 1, 5).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).To(HaveOccurred())
+	tt.Expect(report.Count(types.WorkloadClusterUpgraded)).To(Equal(1))
+	tt.Expect(report.Results[1].Status).To(Equal(types.WorkloadClusterSkipped))
+	tt.Expect(report.Results[1].Error).To(ContainSubstring("management cluster mgmt is not healthy"))
+}
+
+func TestUpgradeWorkloadClustersRunManagementComponentsFailure(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2")
+	tt.expectUpgradeManagementComponents(errors.New("upgrading capi providers"))
+	tt.upgrader.EXPECT().UpgradeWorkloadCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 2, 5).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).To(MatchError("0 workload clusters failed to upgrade and 2 were skipped"))
+	tt.Expect(tt.statuses(report)).To(Equal(map[string]types.WorkloadClusterUpgradeStatus{
+		"w1": types.WorkloadClusterSkipped,
+		"w2": types.WorkloadClusterSkipped,
+	}))
+	tt.Expect(report.Results[0].Error).To(Equal("skipped because the management components failed to upgrade: upgrading capi providers"))
+}
+
+func TestUpgradeWorkloadClustersRunManagementComponentsPerProvider(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2", "w3")
+	tt.clusters[1].Spec.DatacenterRef.Kind = v1alpha1.CloudStackDatacenterKind
+	gomock.InOrder(
+		tt.upgrader.EXPECT().UpgradeManagementComponents(tt.ctx, tt.managementCluster, clusterNamed("w1")),
+		tt.upgrader.EXPECT().UpgradeManagementComponents(tt.ctx, tt.managementCluster, clusterNamed("w2")),
+	)
+	for _, c := range tt.clusters {
+		tt.expectUpgrade(c.Name, nil)
+		tt.expectHealthCheck(c.Name, nil)
+	}
+	tt.healthChecker.EXPECT().CheckClusterHealth(tt.ctx, tt.managementCluster, "mgmt").Times(3)
+
+	_, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 1, 0).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestUpgradeWorkloadClustersRunGitOpsConcurrencyError(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1", "w2")
+	tt.clusters[1].Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: "flux"}
+	tt.upgrader.EXPECT().UpgradeManagementComponents(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	tt.upgrader.EXPECT().UpgradeWorkloadCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 2, 0).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).To(MatchError(ContainSubstring("cluster w2 is managed with GitOps")))
+	tt.Expect(report).To(BeNil())
+}
+
+func TestUpgradeWorkloadClustersRunGitOpsNoConcurrency(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t, "w1")
+	tt.clusters[0].Spec.GitOpsRef = &v1alpha1.Ref{Kind: v1alpha1.FluxConfigKind, Name: "flux"}
+	tt.expectUpgradeManagementComponents(nil)
+	tt.expectUpgrade("w1", nil)
+	tt.expectHealthCheck("w1", nil)
+	tt.expectHealthCheck("mgmt", nil)
+
+	_, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 1, 0).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestUpgradeWorkloadClustersRunNoClusters(t *testing.T) {
+	tt := newUpgradeWorkloadClustersTest(t)
+
+	report, err := workflows.NewUpgradeWorkloadClusters(tt.upgrader, tt.healthChecker, 0, 0).Run(tt.ctx, tt.managementCluster, tt.clusters)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(report.Results).To(BeEmpty())
+}
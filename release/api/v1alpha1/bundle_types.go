@@ -188,6 +188,7 @@ type VSphereBundle struct {
 	KubeProxy            Image    `json:"kubeProxy"`
 	Manager              Image    `json:"manager"`
 	KubeVip              Image    `json:"kubeVip"`
+	KubeVipCloudProvider Image    `json:"kubeVipCloudProvider,omitempty"`
 	Driver               Image    `json:"driver"`
 	Syncer               Image    `json:"syncer"`
 	Components           Manifest `json:"components"`
@@ -272,6 +273,8 @@ type TinkerbellStackBundle struct {
 	Hook           HookBundle    `json:"hook"`
 	Rufio          Image         `json:"rufio"`
 	Tink           TinkBundle    `json:"tink"`
+	// TemplateLibrary is the library of Tinkerbell workflow templates that can be referenced by TinkerbellTemplateConfigs.
+	TemplateLibrary Manifest `json:"templateLibrary,omitempty"`
 }
 
 // Tinkerbell Template Actions
@@ -308,6 +311,7 @@ type TinkerbellBundle struct {
 	Version              string                `json:"version"`
 	ClusterAPIController Image                 `json:"clusterAPIController"`
 	KubeVip              Image                 `json:"kubeVip"`
+	KubeVipCloudProvider Image                 `json:"kubeVipCloudProvider,omitempty"`
 	Components           Manifest              `json:"components"`
 	Metadata             Manifest              `json:"metadata"`
 	ClusterTemplate      Manifest              `json:"clusterTemplate"`
@@ -769,6 +769,7 @@ func (in *TinkerbellBundle) DeepCopyInto(out *TinkerbellBundle) {
 	*out = *in
 	in.ClusterAPIController.DeepCopyInto(&out.ClusterAPIController)
 	in.KubeVip.DeepCopyInto(&out.KubeVip)
+	in.KubeVipCloudProvider.DeepCopyInto(&out.KubeVipCloudProvider)
 	out.Components = in.Components
 	out.Metadata = in.Metadata
 	out.ClusterTemplate = in.ClusterTemplate
@@ -815,6 +816,7 @@ func (in *VSphereBundle) DeepCopyInto(out *VSphereBundle) {
 	in.KubeProxy.DeepCopyInto(&out.KubeProxy)
 	in.Manager.DeepCopyInto(&out.Manager)
 	in.KubeVip.DeepCopyInto(&out.KubeVip)
+	in.KubeVipCloudProvider.DeepCopyInto(&out.KubeVipCloudProvider)
 	in.Driver.DeepCopyInto(&out.Driver)
 	in.Syncer.DeepCopyInto(&out.Syncer)
 	out.Components = in.Components
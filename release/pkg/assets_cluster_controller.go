@@ -24,7 +24,13 @@ import (
 	"github.com/aws/eks-anywhere/release/pkg/git"
 )
 
-const eksAnywhereProjectPath = "projects/aws/eks-anywhere"
+const (
+	eksAnywhereProjectPath = "projects/aws/eks-anywhere"
+
+	// tinkerbellTemplateLibraryManifest is the library of Tinkerbell workflow templates
+	// referenced by TinkerbellTemplateConfigs.
+	tinkerbellTemplateLibraryManifest = "template-library.yaml"
+)
 
 // GetClusterControllerAssets returns the artifacts for eks-a cluster controller
 func (r *ReleaseConfig) GetClusterControllerAssets() ([]Artifact, error) {
@@ -103,7 +109,9 @@ func (r *ReleaseConfig) GetClusterControllerAssets() ([]Artifact, error) {
 	}
 	imageTagOverrides = append(imageTagOverrides, imageTagOverride, kubeRbacProxyImageTagOverride)
 
-	manifest := "eksa-components.yaml"
+	// The Tinkerbell template library is published with the controller manifests as both are
+	// built from this repo.
+	manifests := []string{"eksa-components.yaml", tinkerbellTemplateLibraryManifest}
 
 	var sourceS3Prefix string
 	var releaseS3Path string
@@ -121,23 +129,27 @@ func (r *ReleaseConfig) GetClusterControllerAssets() ([]Artifact, error) {
 		releaseS3Path = fmt.Sprintf("releases/bundles/%d/artifacts/eks-anywhere-cluster-controller/manifests/%s", r.BundleNumber, gitTag)
 	}
 
-	cdnURI, err := r.GetURI(filepath.Join(releaseS3Path, manifest))
-	if err != nil {
-		return nil, errors.Cause(err)
-	}
+	for _, manifest := range manifests {
+		cdnURI, err := r.GetURI(filepath.Join(releaseS3Path, manifest))
+		if err != nil {
+			return nil, errors.Cause(err)
+		}
 
-	manifestArtifact := &ManifestArtifact{
-		SourceS3Key:       manifest,
-		SourceS3Prefix:    sourceS3Prefix,
-		ArtifactPath:      filepath.Join(r.ArtifactDir, "cluster-controller-manifests", r.BuildRepoHead),
-		ReleaseName:       manifest,
-		ReleaseS3Path:     releaseS3Path,
-		ReleaseCdnURI:     cdnURI,
-		ImageTagOverrides: imageTagOverrides,
-		GitTag:            gitTag,
-		ProjectPath:       eksAnywhereProjectPath,
+		manifestArtifact := &ManifestArtifact{
+			SourceS3Key:    manifest,
+			SourceS3Prefix: sourceS3Prefix,
+			ArtifactPath:   filepath.Join(r.ArtifactDir, "cluster-controller-manifests", r.BuildRepoHead),
+			ReleaseName:    manifest,
+			ReleaseS3Path:  releaseS3Path,
+			ReleaseCdnURI:  cdnURI,
+			GitTag:         gitTag,
+			ProjectPath:    eksAnywhereProjectPath,
+		}
+		if manifest != tinkerbellTemplateLibraryManifest {
+			manifestArtifact.ImageTagOverrides = imageTagOverrides
+		}
+		artifacts = append(artifacts, Artifact{Manifest: manifestArtifact})
 	}
-	artifacts = append(artifacts, Artifact{Manifest: manifestArtifact})
 
 	return artifacts, nil
 }
@@ -25,7 +25,9 @@ import (
 func (r *ReleaseConfig) GetTinkerbellBundle(imageDigests map[string]string) (anywherev1alpha1.TinkerbellBundle, error) {
 	tinkerbellBundleArtifacts := map[string][]Artifact{
 		"cluster-api-provider-tinkerbell": r.BundleArtifactsTable["cluster-api-provider-tinkerbell"],
+		"cluster-controller":              r.BundleArtifactsTable["cluster-controller"],
 		"kube-vip":                        r.BundleArtifactsTable["kube-vip"],
+		"kube-vip-cloud-provider":         r.BundleArtifactsTable["kube-vip-cloud-provider"],
 		"tink":                            r.BundleArtifactsTable["tink"],
 		"hegel":                           r.BundleArtifactsTable["hegel"],
 		"cfssl":                           r.BundleArtifactsTable["cfssl"],
@@ -47,6 +49,11 @@ func (r *ReleaseConfig) GetTinkerbellBundle(imageDigests map[string]string) (any
 
 	for _, componentName := range sortedComponentNames {
 		for _, artifact := range tinkerbellBundleArtifacts[componentName] {
+			// Only the template library is taken from the cluster controller artifacts.
+			if componentName == "cluster-controller" && (artifact.Manifest == nil || artifact.Manifest.ReleaseName != tinkerbellTemplateLibraryManifest) {
+				continue
+			}
+
 			if artifact.Image != nil {
 				imageArtifact := artifact.Image
 				if componentName == "cluster-api-provider-tinkerbell" {
@@ -113,6 +120,7 @@ func (r *ReleaseConfig) GetTinkerbellBundle(imageDigests map[string]string) (any
 		Version:              version,
 		ClusterAPIController: bundleImageArtifacts["cluster-api-provider-tinkerbell"],
 		KubeVip:              bundleImageArtifacts["kube-vip"],
+		KubeVipCloudProvider: bundleImageArtifacts["kube-vip-cloud-provider"],
 		Components:           bundleManifestArtifacts["infrastructure-components.yaml"],
 		Metadata:             bundleManifestArtifacts["metadata.yaml"],
 		ClusterTemplate:      bundleManifestArtifacts["cluster-template.yaml"],
@@ -141,7 +149,8 @@ func (r *ReleaseConfig) GetTinkerbellBundle(imageDigests map[string]string) (any
 					Amd: bundleArchiveArtifacts["vmlinuz-x86_64"],
 				},
 			},
-			Rufio: bundleImageArtifacts["rufio"],
+			Rufio:           bundleImageArtifacts["rufio"],
+			TemplateLibrary: bundleManifestArtifacts[tinkerbellTemplateLibraryManifest],
 			Tink: anywherev1alpha1.TinkBundle{
 				TinkController: bundleImageArtifacts["tink-controller"],
 				TinkServer:     bundleImageArtifacts["tink-server"],
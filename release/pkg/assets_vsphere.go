@@ -27,6 +27,7 @@ func (r *ReleaseConfig) GetVsphereBundle(eksDReleaseChannel string, imageDigests
 		"cluster-api-provider-vsphere": r.BundleArtifactsTable["cluster-api-provider-vsphere"],
 		"kube-rbac-proxy":              r.BundleArtifactsTable["kube-rbac-proxy"],
 		"kube-vip":                     r.BundleArtifactsTable["kube-vip"],
+		"kube-vip-cloud-provider":      r.BundleArtifactsTable["kube-vip-cloud-provider"],
 		"vsphere-csi-driver":           r.BundleArtifactsTable["vsphere-csi-driver"],
 	}
 	sortedComponentNames := sortArtifactsMap(vsphereBundleArtifacts)
@@ -109,6 +110,7 @@ func (r *ReleaseConfig) GetVsphereBundle(eksDReleaseChannel string, imageDigests
 		KubeProxy:            bundleImageArtifacts["kube-rbac-proxy"],
 		Manager:              bundleImageArtifacts["cloud-provider-vsphere"],
 		KubeVip:              bundleImageArtifacts["kube-vip"],
+		KubeVipCloudProvider: bundleImageArtifacts["kube-vip-cloud-provider"],
 		Driver:               bundleImageArtifacts["vsphere-csi-driver"],
 		Syncer:               bundleImageArtifacts["vsphere-csi-syncer"],
 		Components:           bundleManifestArtifacts["infrastructure-components.yaml"],
@@ -314,6 +314,10 @@ func (r *ReleaseConfig) GenerateBundleArtifactsTable() (map[string][]Artifact, e
 		eksAArtifactsFuncs["tinkerbell-chart"] = r.GetTinkerbellChartAssets
 	}
 
+	if r.BuildRepoBranchName == "main" || branchMinorVersion > 10 {
+		eksAArtifactsFuncs["kube-vip-cloud-provider"] = r.GetKubeVipCloudProviderAssets
+	}
+
 	if r.DevRelease && (r.BuildRepoBranchName == "main" || r.BuildRepoBranchName == "cloudstack") {
 		eksAArtifactsFuncs["cluster-api-provider-aws-snow"] = r.GetCapasAssets
 	}